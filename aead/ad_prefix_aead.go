@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"encoding/binary"
+
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// adPrefixAEAD is a [tink.AEAD] that binds a fixed prefix to the associated
+// data of every ciphertext.
+type adPrefixAEAD struct {
+	aead tink.AEAD
+	// encodedPrefix is the 4-byte big-endian length of the prefix followed by
+	// the prefix.
+	encodedPrefix []byte
+}
+
+var _ tink.AEAD = (*adPrefixAEAD)(nil)
+
+// NewAEADWithADPrefix returns an AEAD that encrypts and decrypts with a,
+// using as associated data the given prefix followed by the associated data
+// of the caller. This binds every ciphertext to prefix, for example a
+// "table:column" name, so that a ciphertext cannot be moved to a place with a
+// different prefix, without having to pass the prefix on every call.
+//
+// The prefix is preceded by its 4-byte big-endian length, so that different
+// pairs of prefix and associated data never give the same associated data for
+// a. For example, prefix "a" with associated data "bc" is not the same as
+// prefix "ab" with associated data "c". As a consequence, ciphertexts can only
+// be decrypted by an AEAD with the same prefix, and an empty prefix is not the
+// same as using a directly.
+//
+// As with a, nil and empty associated data are the same.
+func NewAEADWithADPrefix(a tink.AEAD, prefix []byte) tink.AEAD {
+	encodedPrefix := make([]byte, 4, 4+len(prefix))
+	binary.BigEndian.PutUint32(encodedPrefix, uint32(len(prefix)))
+	encodedPrefix = append(encodedPrefix, prefix...)
+	return &adPrefixAEAD{aead: a, encodedPrefix: encodedPrefix}
+}
+
+func (p *adPrefixAEAD) associatedData(associatedData []byte) []byte {
+	ad := make([]byte, 0, len(p.encodedPrefix)+len(associatedData))
+	ad = append(ad, p.encodedPrefix...)
+	return append(ad, associatedData...)
+}
+
+// Encrypt encrypts plaintext with the prefix followed by associatedData as
+// associated data.
+func (p *adPrefixAEAD) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	return p.aead.Encrypt(plaintext, p.associatedData(associatedData))
+}
+
+// Decrypt decrypts ciphertext with the prefix followed by associatedData as
+// associated data.
+func (p *adPrefixAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	return p.aead.Decrypt(ciphertext, p.associatedData(associatedData))
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+func mustNewAEAD(t *testing.T) tink.AEAD {
+	t.Helper()
+	handle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	a, err := aead.New(handle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	return a
+}
+
+func TestAEADWithADPrefixEncryptDecrypt(t *testing.T) {
+	a := mustNewAEAD(t)
+	plaintext := []byte("plaintext")
+	for _, tc := range []struct {
+		name           string
+		prefix         []byte
+		associatedData []byte
+	}{
+		{"prefix and associated data", []byte("table:column"), []byte("row 1")},
+		{"nil associated data", []byte("table:column"), nil},
+		{"empty associated data", []byte("table:column"), []byte{}},
+		{"nil prefix", nil, []byte("row 1")},
+		{"empty prefix", []byte{}, []byte("row 1")},
+		{"empty prefix and nil associated data", []byte{}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := aead.NewAEADWithADPrefix(a, tc.prefix)
+			ciphertext, err := p.Encrypt(plaintext, tc.associatedData)
+			if err != nil {
+				t.Fatalf("p.Encrypt() err = %v, want nil", err)
+			}
+			got, err := p.Decrypt(ciphertext, tc.associatedData)
+			if err != nil {
+				t.Fatalf("p.Decrypt() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("p.Decrypt() = %q, want %q", got, plaintext)
+			}
+			if _, err := p.Decrypt(ciphertext, []byte("other associated data")); err == nil {
+				t.Error("p.Decrypt() with other associated data err = nil, want error")
+			}
+			other := aead.NewAEADWithADPrefix(a, []byte("other:column"))
+			if _, err := other.Decrypt(ciphertext, tc.associatedData); err == nil {
+				t.Error("other.Decrypt() err = nil, want error")
+			}
+			if _, err := a.Decrypt(ciphertext, tc.associatedData); err == nil {
+				t.Error("a.Decrypt() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestAEADWithADPrefixNilAndEmptyAreEquivalent(t *testing.T) {
+	a := mustNewAEAD(t)
+	plaintext := []byte("plaintext")
+
+	// nil and empty associated data are the same.
+	p := aead.NewAEADWithADPrefix(a, []byte("table:column"))
+	ciphertext, err := p.Encrypt(plaintext, nil)
+	if err != nil {
+		t.Fatalf("p.Encrypt() err = %v, want nil", err)
+	}
+	if got, err := p.Decrypt(ciphertext, []byte{}); err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("p.Decrypt(ciphertext, []byte{}) = %q, %v, want %q, nil", got, err, plaintext)
+	}
+
+	// nil and empty prefixes are the same.
+	ciphertext, err = aead.NewAEADWithADPrefix(a, nil).Encrypt(plaintext, []byte("ad"))
+	if err != nil {
+		t.Fatalf("Encrypt() err = %v, want nil", err)
+	}
+	if got, err := aead.NewAEADWithADPrefix(a, []byte{}).Decrypt(ciphertext, []byte("ad")); err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("Decrypt() = %q, %v, want %q, nil", got, err, plaintext)
+	}
+}
+
+func TestAEADWithADPrefixIsUnambiguous(t *testing.T) {
+	a := mustNewAEAD(t)
+	ciphertext, err := aead.NewAEADWithADPrefix(a, []byte("a")).Encrypt([]byte("plaintext"), []byte("bc"))
+	if err != nil {
+		t.Fatalf("Encrypt() err = %v, want nil", err)
+	}
+	if _, err := aead.NewAEADWithADPrefix(a, []byte("ab")).Decrypt(ciphertext, []byte("c")); err == nil {
+		t.Error("Decrypt() with prefix \"ab\" and associated data \"c\" err = nil, want error")
+	}
+	if _, err := aead.NewAEADWithADPrefix(a, nil).Decrypt(ciphertext, []byte("abc")); err == nil {
+		t.Error("Decrypt() with empty prefix and associated data \"abc\" err = nil, want error")
+	}
+}
+
+func TestAEADWithADPrefixCopiesPrefix(t *testing.T) {
+	a := mustNewAEAD(t)
+	prefix := []byte("table:column")
+	p := aead.NewAEADWithADPrefix(a, prefix)
+	ciphertext, err := p.Encrypt([]byte("plaintext"), nil)
+	if err != nil {
+		t.Fatalf("p.Encrypt() err = %v, want nil", err)
+	}
+	prefix[0] = 'x'
+	if _, err := p.Decrypt(ciphertext, nil); err != nil {
+		t.Errorf("p.Decrypt() after modifying prefix err = %v, want nil", err)
+	}
+}
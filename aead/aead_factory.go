@@ -15,9 +15,13 @@
 package aead
 
 import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"slices"
 
+	"github.com/tink-crypto/tink-go/v2/aead/aesgcm"
 	"github.com/tink-crypto/tink-go/v2/core/cryptofmt"
 	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
 	"github.com/tink-crypto/tink-go/v2/internal/internalregistry"
@@ -29,6 +33,8 @@ import (
 )
 
 // New returns an AEAD primitive from the given keyset handle.
+//
+// The returned primitive also implements [KeyIDDecrypter].
 func New(handle *keyset.Handle) (tink.AEAD, error) {
 	ps, err := keyset.Primitives[tink.AEAD](handle, internalapi.Token{})
 	if err != nil {
@@ -37,6 +43,51 @@ func New(handle *keyset.Handle) (tink.AEAD, error) {
 	return newWrappedAead(ps)
 }
 
+// Option configures the AEAD primitive returned by [NewWithOptions].
+type Option func(*wrapperOptions)
+
+type wrapperOptions struct {
+	uniformFailureTiming bool
+}
+
+// UniformFailureTiming makes decryption attempt every enabled key in the
+// keyset, rather than only the keys whose output prefix matches the
+// ciphertext, and never return early.
+//
+// This reduces how much the time taken by a failed decryption reveals about
+// why it failed, for example whether the ciphertext prefix matched a key or
+// the authentication tag was invalid. The cost is that every decryption takes
+// time proportional to the number of enabled keys in the keyset.
+func UniformFailureTiming() Option {
+	return func(o *wrapperOptions) {
+		o.uniformFailureTiming = true
+	}
+}
+
+// NewWithOptions returns an AEAD primitive from the given keyset handle,
+// configured with the given options.
+func NewWithOptions(handle *keyset.Handle, opts ...Option) (tink.AEAD, error) {
+	ps, err := keyset.Primitives[tink.AEAD](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("aead_factory: cannot obtain primitive set: %s", err)
+	}
+	a, err := newWrappedAead(ps)
+	if err != nil {
+		return nil, err
+	}
+	o := new(wrapperOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.uniformFailureTiming {
+		a.allPrimitives, err = allPrimitivesInKeysetOrder(ps)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
 // NewWithConfig creates an AEAD primitive from the given [keyset.Handle] using
 // the provided [Config].
 func NewWithConfig(handle *keyset.Handle, config keyset.Config) (tink.AEAD, error) {
@@ -47,16 +98,69 @@ func NewWithConfig(handle *keyset.Handle, config keyset.Config) (tink.AEAD, erro
 	return newWrappedAead(ps)
 }
 
+// KeyPrimitive is the AEAD primitive of a single enabled key in a keyset,
+// together with the output prefix of that key.
+type KeyPrimitive struct {
+	// AEAD encrypts and decrypts using a single key. Ciphertexts it produces
+	// start with Prefix, so they can be decrypted by the primitive returned by
+	// [New] on the same keyset.
+	AEAD tink.AEAD
+	// Prefix is the output prefix of the key; it is empty for RAW keys.
+	Prefix []byte
+}
+
+// PrimitivesByKeyID returns the AEAD primitive of every enabled key in the
+// given keyset handle, indexed by key ID.
+//
+// This allows callers to spread encryption across several keys, for example to
+// shard load. Unlike the primitive returned by [New], which always encrypts
+// with the primary key, encryption happens with whatever key the caller picks.
+// Managing nonce uniqueness for each primitive, and staying within the usage
+// limits of each key, is the caller's responsibility.
+func PrimitivesByKeyID(handle *keyset.Handle) (map[uint32]KeyPrimitive, error) {
+	ps, err := keyset.Primitives[tink.AEAD](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("aead_factory: cannot obtain primitive set: %s", err)
+	}
+	primitives := make(map[uint32]KeyPrimitive, len(ps.EntriesInKeysetOrder))
+	for _, entry := range ps.EntriesInKeysetOrder {
+		p, err := extractFullAEAD(entry)
+		if err != nil {
+			return nil, err
+		}
+		primitives[entry.KeyID] = KeyPrimitive{
+			AEAD:   p.primitive,
+			Prefix: []byte(entry.Prefix),
+		}
+	}
+	return primitives, nil
+}
+
+// KeyIDDecrypter is implemented by the AEAD primitive returned by [New]. It
+// decrypts like [tink.AEAD.Decrypt], and additionally reports which key of the
+// keyset decrypted the ciphertext, for example to find ciphertexts that are not
+// encrypted with the primary key during key rotation.
+type KeyIDDecrypter interface {
+	// DecryptAndKeyID decrypts ciphertext with associatedData, and returns the
+	// ID of the key that decrypted it and the plaintext.
+	DecryptAndKeyID(ciphertext, associatedData []byte) (uint32, []byte, error)
+}
+
 // wrappedAead is an AEAD implementation that uses the underlying primitive set for encryption
 // and decryption.
 type wrappedAead struct {
 	primary    aeadAndKeyID
 	primitives map[string][]aeadAndKeyID
+	// allPrimitives is only set when decryption must try every key; see
+	// [UniformFailureTiming].
+	allPrimitives []prefixedAEAD
 
 	encLogger monitoring.Logger
 	decLogger monitoring.Logger
 }
 
+var _ KeyIDDecrypter = (*wrappedAead)(nil)
+
 type aeadAndKeyID struct {
 	primitive tink.AEAD
 	keyID     uint32
@@ -70,6 +174,28 @@ func (a *aeadAndKeyID) Decrypt(ciphertext, associatedData []byte) ([]byte, error
 	return a.primitive.Decrypt(ciphertext, associatedData)
 }
 
+type prefixedAEAD struct {
+	aeadAndKeyID
+	prefix string
+}
+
+func allPrimitivesInKeysetOrder(ps *primitiveset.PrimitiveSet[tink.AEAD]) ([]prefixedAEAD, error) {
+	var nonRaw, raw []prefixedAEAD
+	for _, entry := range ps.EntriesInKeysetOrder {
+		p, err := extractFullAEAD(entry)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Prefix == cryptofmt.RawPrefix {
+			raw = append(raw, prefixedAEAD{aeadAndKeyID: *p, prefix: entry.Prefix})
+		} else {
+			nonRaw = append(nonRaw, prefixedAEAD{aeadAndKeyID: *p, prefix: entry.Prefix})
+		}
+	}
+	// Keep the order of the default decryption path: non-raw keys first.
+	return append(nonRaw, raw...), nil
+}
+
 // aeadPrimitiveAdapter is an adapter that turns a non-full [tink.AEAD]
 // primitive into a full [tink.AEAD] primitive.
 type fullAEADPrimitiveAdapter struct {
@@ -86,6 +212,9 @@ func (a *fullAEADPrimitiveAdapter) Encrypt(plaintext, associatedData []byte) ([]
 }
 
 func (a *fullAEADPrimitiveAdapter) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	if len(ciphertext) < len(a.prefix) || !bytes.HasPrefix(ciphertext, a.prefix) {
+		return nil, fmt.Errorf("aead_factory: ciphertext does not start with the key's output prefix")
+	}
 	return a.primitive.Decrypt(ciphertext[len(a.prefix):], associatedData)
 }
 
@@ -129,7 +258,7 @@ func newWrappedAead(ps *primitiveset.PrimitiveSet[tink.AEAD]) (*wrappedAead, err
 }
 
 func createLoggers(ps *primitiveset.PrimitiveSet[tink.AEAD]) (monitoring.Logger, monitoring.Logger, error) {
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, &monitoringutil.DoNothingLogger{}, nil
 	}
 	client := internalregistry.GetMonitoringClient()
@@ -171,7 +300,26 @@ func (a *wrappedAead) Encrypt(plaintext, associatedData []byte) ([]byte, error)
 // Decrypt decrypts the given ciphertext and authenticates it with the given
 // associatedData. It returns the corresponding plaintext if the
 // ciphertext is authenticated.
+//
+// If every key that could decrypt the ciphertext is an AES-GCM key that
+// rejected it as too short, the returned error wraps
+// [aesgcm.ErrCiphertextTooShort].
 func (a *wrappedAead) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	_, pt, err := a.DecryptAndKeyID(ciphertext, associatedData)
+	return pt, err
+}
+
+// DecryptAndKeyID decrypts like Decrypt, and also returns the ID of the key
+// that decrypted the ciphertext.
+//
+// The candidate keys are tried exactly as in Decrypt, so this does not reveal
+// more through timing than Decrypt does.
+func (a *wrappedAead) DecryptAndKeyID(ciphertext, associatedData []byte) (uint32, []byte, error) {
+	if a.allPrimitives != nil {
+		return a.decryptWithAllKeys(ciphertext, associatedData)
+	}
+	// Whether all keys that were tried rejected the ciphertext as too short.
+	tried, allTooShort := false, true
 	// Try non-raw keys.
 	prefixSize := cryptofmt.NonRawPrefixSize
 	if len(ciphertext) > prefixSize {
@@ -183,8 +331,10 @@ func (a *wrappedAead) Decrypt(ciphertext, associatedData []byte) ([]byte, error)
 				if err == nil {
 					numBytes := len(ciphertext[prefixSize:])
 					a.decLogger.Log(primitive.keyID, numBytes)
-					return pt, nil
+					return primitive.keyID, pt, nil
 				}
+				tried = true
+				allTooShort = allTooShort && errors.Is(err, aesgcm.ErrCiphertextTooShort)
 			}
 		}
 	}
@@ -195,11 +345,50 @@ func (a *wrappedAead) Decrypt(ciphertext, associatedData []byte) ([]byte, error)
 			pt, err := primitive.Decrypt(ciphertext, associatedData)
 			if err == nil {
 				a.decLogger.Log(primitive.keyID, len(ciphertext))
-				return pt, nil
+				return primitive.keyID, pt, nil
 			}
+			tried = true
+			allTooShort = allTooShort && errors.Is(err, aesgcm.ErrCiphertextTooShort)
 		}
 	}
 	// Nothing worked.
 	a.decLogger.LogFailure()
-	return nil, fmt.Errorf("aead_factory: decryption failed")
+	if tried && allTooShort {
+		return 0, nil, fmt.Errorf("aead_factory: decryption failed: %w", aesgcm.ErrCiphertextTooShort)
+	}
+	return 0, nil, fmt.Errorf("aead_factory: decryption failed")
+}
+
+// decryptWithAllKeys decrypts ciphertext with every key in the keyset. Keys
+// whose prefix doesn't match the ciphertext are tried with their own prefix
+// substituted, so that they do the same amount of work, but their result is
+// discarded.
+func (a *wrappedAead) decryptWithAllKeys(ciphertext, associatedData []byte) (uint32, []byte, error) {
+	prefixSize := cryptofmt.NonRawPrefixSize
+	payload := ciphertext[min(len(ciphertext), prefixSize):]
+	var (
+		plaintext []byte
+		keyID     uint32
+		numBytes  int
+		found     bool
+	)
+	for _, p := range a.allPrimitives {
+		ct := ciphertext
+		matches := true
+		if p.prefix != cryptofmt.RawPrefix {
+			ct = slices.Concat([]byte(p.prefix), payload)
+			matches = len(ciphertext) > prefixSize && subtle.ConstantTimeCompare([]byte(p.prefix), ciphertext[:prefixSize]) == 1
+		}
+		pt, err := p.Decrypt(ct, associatedData)
+		if err == nil && matches && !found {
+			plaintext, keyID, found = pt, p.keyID, true
+			numBytes = len(ct) - len(p.prefix)
+		}
+	}
+	if !found {
+		a.decLogger.LogFailure()
+		return 0, nil, fmt.Errorf("aead_factory: decryption failed")
+	}
+	a.decLogger.Log(keyID, numBytes)
+	return keyID, plaintext, nil
 }
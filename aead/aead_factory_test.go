@@ -16,6 +16,7 @@ package aead_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"slices"
@@ -866,3 +867,269 @@ func TestPrimitiveFactoryEncryptDecryptWithoutAnnotationsDoesNothing(t *testing.
 		t.Errorf("len(client.Failures()) = %d, want 0", failures)
 	}
 }
+
+func TestPrimitivesByKeyID(t *testing.T) {
+	km := keyset.NewManager()
+	tinkKeyID, err := km.Add(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("km.Add() err = %v, want nil", err)
+	}
+	if err := km.SetPrimary(tinkKeyID); err != nil {
+		t.Fatalf("km.SetPrimary() err = %v, want nil", err)
+	}
+	rawKeyID, err := km.Add(aead.AES256GCMNoPrefixKeyTemplate())
+	if err != nil {
+		t.Fatalf("km.Add() err = %v, want nil", err)
+	}
+	disabledKeyID, err := km.Add(aead.ChaCha20Poly1305KeyTemplate())
+	if err != nil {
+		t.Fatalf("km.Add() err = %v, want nil", err)
+	}
+	if err := km.Disable(disabledKeyID); err != nil {
+		t.Fatalf("km.Disable() err = %v, want nil", err)
+	}
+	kh, err := km.Handle()
+	if err != nil {
+		t.Fatalf("km.Handle() err = %v, want nil", err)
+	}
+
+	primitives, err := aead.PrimitivesByKeyID(kh)
+	if err != nil {
+		t.Fatalf("aead.PrimitivesByKeyID() err = %v, want nil", err)
+	}
+	if got, want := len(primitives), 2; got != want {
+		t.Fatalf("len(aead.PrimitivesByKeyID()) = %d, want %d", got, want)
+	}
+	if _, ok := primitives[disabledKeyID]; ok {
+		t.Errorf("aead.PrimitivesByKeyID() contains disabled key %d", disabledKeyID)
+	}
+	wantPrefixes := map[uint32][]byte{
+		tinkKeyID: binary.BigEndian.AppendUint32([]byte{cryptofmt.TinkStartByte}, tinkKeyID),
+		rawKeyID:  []byte{},
+	}
+	a, err := aead.New(kh)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	plaintext := []byte("plaintext")
+	associatedData := []byte("associatedData")
+	for keyID, wantPrefix := range wantPrefixes {
+		p, ok := primitives[keyID]
+		if !ok {
+			t.Fatalf("aead.PrimitivesByKeyID() does not contain key %d", keyID)
+		}
+		if !bytes.Equal(p.Prefix, wantPrefix) {
+			t.Errorf("primitives[%d].Prefix = %x, want %x", keyID, p.Prefix, wantPrefix)
+		}
+		ct, err := p.AEAD.Encrypt(plaintext, associatedData)
+		if err != nil {
+			t.Fatalf("primitives[%d].AEAD.Encrypt() err = %v, want nil", keyID, err)
+		}
+		if !bytes.HasPrefix(ct, wantPrefix) {
+			t.Errorf("primitives[%d].AEAD.Encrypt() = %x, want prefix %x", keyID, ct, wantPrefix)
+		}
+		got, err := a.Decrypt(ct, associatedData)
+		if err != nil {
+			t.Fatalf("a.Decrypt() err = %v, want nil", err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("a.Decrypt() = %q, want %q", got, plaintext)
+		}
+	}
+}
+
+func TestPrimitivesByKeyIDWithLegacyPrimitiveRejectsInvalidPrefix(t *testing.T) {
+	typeURL := "TestPrimitivesByKeyIDWithLegacyPrimitiveRejectsInvalidPrefix"
+	km := &stubkeymanager.StubKeyManager{
+		URL:  typeURL,
+		Key:  &agpb.AesGcmKey{},
+		Prim: &testutil.DummyAEAD{Name: typeURL},
+		KeyData: &tinkpb.KeyData{
+			TypeUrl:         typeURL,
+			KeyMaterialType: tinkpb.KeyData_SYMMETRIC,
+			Value:           []byte("serialized_key"),
+		},
+	}
+	if err := registry.RegisterKeyManager(km); err != nil {
+		t.Fatalf("registry.RegisterKeyManager() err = %v, want nil", err)
+	}
+	kh, err := keyset.NewHandle(&tinkpb.KeyTemplate{
+		TypeUrl:          typeURL,
+		OutputPrefixType: tinkpb.OutputPrefixType_TINK,
+	})
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	primitives, err := aead.PrimitivesByKeyID(kh)
+	if err != nil {
+		t.Fatalf("aead.PrimitivesByKeyID() err = %v, want nil", err)
+	}
+	p, ok := primitives[kh.KeysetInfo().GetPrimaryKeyId()]
+	if !ok {
+		t.Fatalf("aead.PrimitivesByKeyID() does not contain the primary key")
+	}
+	ct, err := p.AEAD.Encrypt([]byte("plaintext"), nil)
+	if err != nil {
+		t.Fatalf("p.AEAD.Encrypt() err = %v, want nil", err)
+	}
+	if _, err := p.AEAD.Decrypt(ct, nil); err != nil {
+		t.Fatalf("p.AEAD.Decrypt() err = %v, want nil", err)
+	}
+	wrongPrefix := slices.Clone(ct)
+	wrongPrefix[1] ^= 1
+	for _, tc := range []struct {
+		name       string
+		ciphertext []byte
+	}{
+		{"empty", nil},
+		{"shorter than prefix", ct[:cryptofmt.NonRawPrefixSize-1]},
+		{"wrong prefix", wrongPrefix},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := p.AEAD.Decrypt(tc.ciphertext, nil); err == nil {
+				t.Errorf("p.AEAD.Decrypt() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewWithOptionsUniformFailureTiming(t *testing.T) {
+	km := keyset.NewManager()
+	var keyIDs []uint32
+	for _, template := range []*tinkpb.KeyTemplate{
+		aead.AES128GCMKeyTemplate(),
+		aead.AES256GCMNoPrefixKeyTemplate(),
+		aead.ChaCha20Poly1305KeyTemplate(),
+	} {
+		keyID, err := km.Add(template)
+		if err != nil {
+			t.Fatalf("km.Add() err = %v, want nil", err)
+		}
+		keyIDs = append(keyIDs, keyID)
+	}
+	plaintext := []byte("plaintext")
+	associatedData := []byte("associatedData")
+	for _, keyID := range keyIDs {
+		if err := km.SetPrimary(keyID); err != nil {
+			t.Fatalf("km.SetPrimary() err = %v, want nil", err)
+		}
+		kh, err := km.Handle()
+		if err != nil {
+			t.Fatalf("km.Handle() err = %v, want nil", err)
+		}
+		a, err := aead.NewWithOptions(kh, aead.UniformFailureTiming())
+		if err != nil {
+			t.Fatalf("aead.NewWithOptions() err = %v, want nil", err)
+		}
+		defaultAEAD, err := aead.New(kh)
+		if err != nil {
+			t.Fatalf("aead.New() err = %v, want nil", err)
+		}
+		ct, err := a.Encrypt(plaintext, associatedData)
+		if err != nil {
+			t.Fatalf("a.Encrypt() err = %v, want nil", err)
+		}
+		for _, d := range []tink.AEAD{a, defaultAEAD} {
+			got, err := d.Decrypt(ct, associatedData)
+			if err != nil {
+				t.Fatalf("Decrypt() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("Decrypt() = %q, want %q", got, plaintext)
+			}
+		}
+		if _, err := a.Decrypt(ct, []byte("wrong")); err == nil {
+			t.Errorf("a.Decrypt() with wrong associated data err = nil, want error")
+		}
+		modified := bytes.Clone(ct)
+		modified[len(modified)-1] ^= 1
+		if _, err := a.Decrypt(modified, associatedData); err == nil {
+			t.Errorf("a.Decrypt() with modified ciphertext err = nil, want error")
+		}
+		for _, short := range [][]byte{nil, ct[:cryptofmt.NonRawPrefixSize]} {
+			if _, err := a.Decrypt(short, associatedData); err == nil {
+				t.Errorf("a.Decrypt(%x) err = nil, want error", short)
+			}
+		}
+	}
+}
+
+func TestNewWithOptionsUniformFailureTimingRejectsWrongPrefix(t *testing.T) {
+	kh, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	a, err := aead.NewWithOptions(kh, aead.UniformFailureTiming())
+	if err != nil {
+		t.Fatalf("aead.NewWithOptions() err = %v, want nil", err)
+	}
+	ct, err := a.Encrypt([]byte("plaintext"), nil)
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	// A ciphertext that is valid apart from its prefix must be rejected.
+	ct[1] ^= 1
+	if _, err := a.Decrypt(ct, nil); err == nil {
+		t.Errorf("a.Decrypt() err = nil, want error")
+	}
+}
+
+func TestFactoryDecryptReturnsErrCiphertextTooShort(t *testing.T) {
+	manager := keyset.NewManager()
+	tinkKeyID, err := manager.Add(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(tinkKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	if _, err := manager.Add(aead.AES256GCMNoPrefixKeyTemplate()); err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	a, err := aead.New(handle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	ct, err := a.Encrypt([]byte("plaintext"), nil)
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	// Shorter than the IV and tag of the raw key, and so also than the prefix,
+	// IV and tag of the primary key.
+	if _, err := a.Decrypt(ct[:12+15], nil); !errors.Is(err, aesgcm.ErrCiphertextTooShort) {
+		t.Errorf("a.Decrypt() with short ciphertext err = %v, want %v", err, aesgcm.ErrCiphertextTooShort)
+	}
+	// Long enough for the raw key, so it fails authentication.
+	_, err = a.Decrypt(ct[:12+16], nil)
+	if err == nil {
+		t.Fatalf("a.Decrypt() err = nil, want error")
+	}
+	if errors.Is(err, aesgcm.ErrCiphertextTooShort) {
+		t.Errorf("a.Decrypt() err = %v, want an error other than %v", err, aesgcm.ErrCiphertextTooShort)
+	}
+
+	// Other key types do not report that the ciphertext is too short.
+	chachaKeyID, err := manager.Add(aead.ChaCha20Poly1305KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	handle, err = manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	a, err = aead.New(handle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	_, err = a.Decrypt(append(binary.BigEndian.AppendUint32([]byte{0x01}, chachaKeyID), 0x00), nil)
+	if err == nil {
+		t.Fatalf("a.Decrypt() err = nil, want error")
+	}
+	if errors.Is(err, aesgcm.ErrCiphertextTooShort) {
+		t.Errorf("a.Decrypt() with a ChaCha20Poly1305 key err = %v, want an error other than %v", err, aesgcm.ErrCiphertextTooShort)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"errors"
 	"fmt"
 
 	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
@@ -34,6 +35,10 @@ const (
 	tagSize = 16
 )
 
+// ErrCiphertextTooShort is returned by Decrypt if the ciphertext is too short
+// to contain the output prefix, the IV and the tag.
+var ErrCiphertextTooShort = errors.New("aesgcm: ciphertext too short")
+
 // fullAEAD is an implementation of the [tink.AEAD] interface with AES-GCM.
 //
 // It implements RFC 5116 Section 5.1 and 5.2 and adds a prefix to the
@@ -73,9 +78,12 @@ func (a *fullAEAD) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
 // where prefix is the key's output prefix, iv is the 12-byte IV, ciphertext is
 // the encrypted plaintext, and tag is the 16-byte tag.
 // prefix must match the key's output prefix. The prefix may be empty.
+//
+// If ciphertext is too short to be of this form, the returned error wraps
+// [ErrCiphertextTooShort].
 func (a *fullAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
 	if len(ciphertext) < len(a.prefix)+ivSize+tagSize {
-		return nil, fmt.Errorf("ciphertext with size %d is too short", len(ciphertext))
+		return nil, fmt.Errorf("%w: got %d bytes, want at least %d", ErrCiphertextTooShort, len(ciphertext), len(a.prefix)+ivSize+tagSize)
 	}
 	prefix := ciphertext[:len(a.prefix)]
 	if !bytes.Equal(prefix, a.prefix) {
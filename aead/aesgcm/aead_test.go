@@ -17,6 +17,7 @@ package aesgcm_test
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"slices"
 	"testing"
@@ -304,6 +305,34 @@ func TestAEADDecryptFailsIfCiphertextIsCorruptedOrTruncated(t *testing.T) {
 	}
 }
 
+func TestAEADDecryptReturnsErrCiphertextTooShort(t *testing.T) {
+	a, err := aesgcm.NewAEAD(mustCreateKey(t, random.GetRandomBytes(16), 0x11223344, aesgcm.ParametersOpts{
+		KeySizeInBytes: 16,
+		IVSizeInBytes:  12,
+		TagSizeInBytes: 16,
+		Variant:        aesgcm.VariantTink,
+	}))
+	if err != nil {
+		t.Fatalf("aesgcm.NewAEAD() err = %v, want nil", err)
+	}
+	prefix := []byte{0x01, 0x11, 0x22, 0x33, 0x44}
+	for _, size := range []int{0, len(prefix), len(prefix) + ivSize, len(prefix) + ivSize + tagSize - 1} {
+		ct := append(slices.Clone(prefix), make([]byte, size)...)[:size]
+		if _, err := a.Decrypt(ct, nil); !errors.Is(err, aesgcm.ErrCiphertextTooShort) {
+			t.Errorf("a.Decrypt() with %d-byte ciphertext err = %v, want %v", size, err, aesgcm.ErrCiphertextTooShort)
+		}
+	}
+	// A ciphertext that is long enough but invalid fails with another error.
+	ct := append(slices.Clone(prefix), make([]byte, ivSize+tagSize)...)
+	_, err = a.Decrypt(ct, nil)
+	if err == nil {
+		t.Fatalf("a.Decrypt() err = nil, want error")
+	}
+	if errors.Is(err, aesgcm.ErrCiphertextTooShort) {
+		t.Errorf("a.Decrypt() err = %v, want an error other than %v", err, aesgcm.ErrCiphertextTooShort)
+	}
+}
+
 // Checks that the nonce is random by making sure that the multiple ciphertexts
 // of the same message are distinct.
 func TestAEADEncryptUsesRandomNonce(t *testing.T) {
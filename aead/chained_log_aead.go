@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// chainIDSize is the size in bytes of the random ID of a chain.
+const chainIDSize = 16
+
+// ChainedLogAEAD encrypts the entries of an append-only log such that the
+// entries are chained together. Every chain has a random 16-byte ID, and each
+// entry is
+//
+//	chainID || ciphertext
+//
+// where ciphertext is encrypted with chainID || prevHash as associated data,
+// prevHash being the SHA-256 hash of the previous entry, or all zeros for the
+// first entry.
+//
+// This protects the structure of the log in addition to the individual
+// entries: [ChainedLogAEAD.VerifyChain] fails if an entry was modified,
+// removed, inserted or reordered, or comes from another chain. It cannot
+// detect entries removed from the end of the log, so the expected number of
+// entries or the last entry should be stored separately if that matters.
+//
+// ChainedLogAEAD is stateful: every call to Encrypt appends an entry to the
+// chain, so the ciphertexts must be stored in the order in which they were
+// returned, and none of them may be discarded. [NewChainedLogAEAD] starts a
+// new chain and [ResumeChainedLogAEAD] continues an existing one. It is safe
+// for concurrent use; concurrent calls to Encrypt are chained in an
+// unspecified order.
+type ChainedLogAEAD struct {
+	aead tink.AEAD
+
+	mu       sync.Mutex
+	chainID  []byte
+	prevHash [sha256.Size]byte
+}
+
+// NewChainedLogAEAD returns a ChainedLogAEAD that starts a new chain with a
+// random ID and encrypts log entries with the primitive obtained from the
+// given keyset handle.
+func NewChainedLogAEAD(handle *keyset.Handle) (*ChainedLogAEAD, error) {
+	a, err := New(handle)
+	if err != nil {
+		return nil, err
+	}
+	return &ChainedLogAEAD{aead: a, chainID: random.GetRandomBytes(chainIDSize)}, nil
+}
+
+// ResumeChainedLogAEAD returns a ChainedLogAEAD that appends entries to the
+// chain whose last entry is lastEntry, for example after a restart. The
+// entries it encrypts follow lastEntry in the chain.
+//
+// ResumeChainedLogAEAD only checks that lastEntry is well formed; the chain
+// it ends should be checked with [ChainedLogAEAD.VerifyChain] before it is
+// resumed.
+func ResumeChainedLogAEAD(handle *keyset.Handle, lastEntry []byte) (*ChainedLogAEAD, error) {
+	if len(lastEntry) < chainIDSize {
+		return nil, errors.New("aead.ResumeChainedLogAEAD: log entry too short")
+	}
+	a, err := New(handle)
+	if err != nil {
+		return nil, err
+	}
+	return &ChainedLogAEAD{
+		aead:     a,
+		chainID:  append([]byte{}, lastEntry[:chainIDSize]...),
+		prevHash: sha256.Sum256(lastEntry),
+	}, nil
+}
+
+// associatedData returns the associated data of the entry of chain chainID
+// that follows the entry with hash prevHash.
+func associatedData(chainID []byte, prevHash [sha256.Size]byte) []byte {
+	ad := make([]byte, 0, len(chainID)+len(prevHash))
+	ad = append(ad, chainID...)
+	return append(ad, prevHash[:]...)
+}
+
+// Encrypt encrypts plaintext as the next entry of the log and returns its
+// ciphertext.
+func (c *ChainedLogAEAD) Encrypt(plaintext []byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ciphertext, err := c.aead.Encrypt(plaintext, associatedData(c.chainID, c.prevHash))
+	if err != nil {
+		return nil, err
+	}
+	entry := make([]byte, 0, len(c.chainID)+len(ciphertext))
+	entry = append(entry, c.chainID...)
+	entry = append(entry, ciphertext...)
+	c.prevHash = sha256.Sum256(entry)
+	return entry, nil
+}
+
+// DecryptChain decrypts all entries of a log, given in order from the first
+// one, and returns their plaintexts. It fails if the chain is broken.
+//
+// DecryptChain does not depend on or change the state of Encrypt, and can
+// decrypt chains started by any ChainedLogAEAD with the same keys.
+func (c *ChainedLogAEAD) DecryptChain(entries [][]byte) ([][]byte, error) {
+	var chainID []byte
+	var prevHash [sha256.Size]byte
+	plaintexts := make([][]byte, 0, len(entries))
+	for i, entry := range entries {
+		if len(entry) < chainIDSize {
+			return nil, fmt.Errorf("aead: log entry %d: too short", i)
+		}
+		if i == 0 {
+			chainID = entry[:chainIDSize]
+		} else if !bytes.Equal(entry[:chainIDSize], chainID) {
+			return nil, fmt.Errorf("aead: log entry %d: from another chain", i)
+		}
+		plaintext, err := c.aead.Decrypt(entry[chainIDSize:], associatedData(chainID, prevHash))
+		if err != nil {
+			return nil, fmt.Errorf("aead: log entry %d: %v", i, err)
+		}
+		plaintexts = append(plaintexts, plaintext)
+		prevHash = sha256.Sum256(entry)
+	}
+	return plaintexts, nil
+}
+
+// VerifyChain checks that entries, given in order from the first one, form an
+// intact chain of log entries.
+//
+// VerifyChain does not depend on or change the state of Encrypt.
+func (c *ChainedLogAEAD) VerifyChain(entries [][]byte) error {
+	_, err := c.DecryptChain(entries)
+	return err
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+func newChainedLog(t *testing.T, handle *keyset.Handle, numEntries int) (*aead.ChainedLogAEAD, [][]byte, [][]byte) {
+	t.Helper()
+	c, err := aead.NewChainedLogAEAD(handle)
+	if err != nil {
+		t.Fatalf("aead.NewChainedLogAEAD() err = %v, want nil", err)
+	}
+	var plaintexts, entries [][]byte
+	for i := 0; i < numEntries; i++ {
+		plaintext := []byte(fmt.Sprintf("entry %d", i))
+		entry, err := c.Encrypt(plaintext)
+		if err != nil {
+			t.Fatalf("c.Encrypt() err = %v, want nil", err)
+		}
+		plaintexts = append(plaintexts, plaintext)
+		entries = append(entries, entry)
+	}
+	return c, plaintexts, entries
+}
+
+func TestChainedLogAEAD(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	c, plaintexts, entries := newChainedLog(t, handle, 5)
+	if err := c.VerifyChain(entries); err != nil {
+		t.Errorf("c.VerifyChain() err = %v, want nil", err)
+	}
+	got, err := c.DecryptChain(entries)
+	if err != nil {
+		t.Fatalf("c.DecryptChain() err = %v, want nil", err)
+	}
+	if len(got) != len(plaintexts) {
+		t.Fatalf("len(c.DecryptChain()) = %d, want %d", len(got), len(plaintexts))
+	}
+	for i := range got {
+		if !bytes.Equal(got[i], plaintexts[i]) {
+			t.Errorf("c.DecryptChain()[%d] = %q, want %q", i, got[i], plaintexts[i])
+		}
+	}
+
+	// A new ChainedLogAEAD can verify the chain, and verifying does not
+	// interfere with appending entries.
+	other, err := aead.NewChainedLogAEAD(handle)
+	if err != nil {
+		t.Fatalf("aead.NewChainedLogAEAD() err = %v, want nil", err)
+	}
+	if err := other.VerifyChain(entries); err != nil {
+		t.Errorf("other.VerifyChain() err = %v, want nil", err)
+	}
+	entry, err := c.Encrypt([]byte("entry 5"))
+	if err != nil {
+		t.Fatalf("c.Encrypt() err = %v, want nil", err)
+	}
+	if err := other.VerifyChain(append(entries, entry)); err != nil {
+		t.Errorf("other.VerifyChain() after Encrypt err = %v, want nil", err)
+	}
+}
+
+func TestChainedLogAEADVerifyChainFails(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	c, _, entries := newChainedLog(t, handle, 4)
+	_, _, otherEntries := newChainedLog(t, handle, 4)
+	modified := slices.Clone(entries[1])
+	modified[len(modified)-1] ^= 1
+	modifiedChainID := slices.Clone(entries[3])
+	modifiedChainID[0] ^= 1
+	for _, tc := range []struct {
+		name    string
+		entries [][]byte
+	}{
+		{
+			name:    "first entry removed",
+			entries: entries[1:],
+		},
+		{
+			name:    "middle entry removed",
+			entries: [][]byte{entries[0], entries[1], entries[3]},
+		},
+		{
+			name:    "entries reordered",
+			entries: [][]byte{entries[0], entries[2], entries[1], entries[3]},
+		},
+		{
+			name:    "entry duplicated",
+			entries: [][]byte{entries[0], entries[1], entries[1], entries[2], entries[3]},
+		},
+		{
+			name:    "entry modified",
+			entries: [][]byte{entries[0], modified, entries[2], entries[3]},
+		},
+		{
+			name:    "entry from another chain",
+			entries: [][]byte{entries[0], otherEntries[1], entries[2], entries[3]},
+		},
+		{
+			name:    "first entry from another chain",
+			entries: [][]byte{otherEntries[0], entries[1], entries[2], entries[3]},
+		},
+		{
+			name:    "chain ID of last entry modified",
+			entries: [][]byte{entries[0], entries[1], entries[2], modifiedChainID},
+		},
+		{
+			name:    "truncated entry",
+			entries: [][]byte{entries[0], entries[1][:10]},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := c.VerifyChain(tc.entries); err == nil {
+				t.Errorf("c.VerifyChain() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestResumeChainedLogAEAD(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	c, plaintexts, entries := newChainedLog(t, handle, 3)
+	resumed, err := aead.ResumeChainedLogAEAD(handle, entries[len(entries)-1])
+	if err != nil {
+		t.Fatalf("aead.ResumeChainedLogAEAD() err = %v, want nil", err)
+	}
+	for i := 3; i < 5; i++ {
+		plaintext := []byte(fmt.Sprintf("entry %d", i))
+		entry, err := resumed.Encrypt(plaintext)
+		if err != nil {
+			t.Fatalf("resumed.Encrypt() err = %v, want nil", err)
+		}
+		plaintexts = append(plaintexts, plaintext)
+		entries = append(entries, entry)
+	}
+	got, err := c.DecryptChain(entries)
+	if err != nil {
+		t.Fatalf("c.DecryptChain() err = %v, want nil", err)
+	}
+	if len(got) != len(plaintexts) {
+		t.Fatalf("len(c.DecryptChain()) = %d, want %d", len(got), len(plaintexts))
+	}
+	for i := range got {
+		if !bytes.Equal(got[i], plaintexts[i]) {
+			t.Errorf("c.DecryptChain()[%d] = %q, want %q", i, got[i], plaintexts[i])
+		}
+	}
+
+	// Entries appended after resuming from an earlier entry do not extend the
+	// chain.
+	fork, err := aead.ResumeChainedLogAEAD(handle, entries[1])
+	if err != nil {
+		t.Fatalf("aead.ResumeChainedLogAEAD() err = %v, want nil", err)
+	}
+	forked, err := fork.Encrypt([]byte("forked entry"))
+	if err != nil {
+		t.Fatalf("fork.Encrypt() err = %v, want nil", err)
+	}
+	if err := c.VerifyChain(append(slices.Clone(entries), forked)); err == nil {
+		t.Errorf("c.VerifyChain() with forked entry err = nil, want error")
+	}
+}
+
+func TestResumeChainedLogAEADFails(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	if _, err := aead.ResumeChainedLogAEAD(handle, []byte("short")); err == nil {
+		t.Errorf("aead.ResumeChainedLogAEAD() with short entry err = nil, want error")
+	}
+	_, _, entries := newChainedLog(t, handle, 1)
+	if _, err := aead.ResumeChainedLogAEAD(nil, entries[0]); err == nil {
+		t.Errorf("aead.ResumeChainedLogAEAD(nil) err = nil, want error")
+	}
+}
+
+func TestNewChainedLogAEADFailsWithInvalidHandle(t *testing.T) {
+	if _, err := aead.NewChainedLogAEAD(nil); err == nil {
+		t.Errorf("aead.NewChainedLogAEAD(nil) err = nil, want error")
+	}
+}
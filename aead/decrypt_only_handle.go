@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/aead/aesgcm"
+	"github.com/tink-crypto/tink-go/v2/insecurecleartextkeyset"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+	"github.com/tink-crypto/tink-go/v2/secretdata"
+)
+
+// RawKey is a raw AES-GCM key with a 12-byte IV and a 16-byte tag, as
+// accepted by [NewDecryptOnlyHandle].
+type RawKey struct {
+	// KeyBytes is the AES key; it must be 16 or 32 bytes long.
+	KeyBytes []byte
+	// ID is the key ID. Ciphertexts of keys with variant
+	// [aesgcm.VariantTink] or [aesgcm.VariantCrunchy] are prefixed with it.
+	ID uint32
+	// Variant determines the ciphertext prefix of the key.
+	Variant aesgcm.Variant
+	// Primary marks the primary key of the keyset. Exactly one key must be
+	// primary.
+	Primary bool
+}
+
+// NewDecryptOnlyHandle returns a keyset handle containing the given raw
+// AES-GCM keys, all enabled, with the given IDs and ciphertext prefixes.
+//
+// It is meant for assembling keysets of known keys, for example to check that
+// ciphertexts produced before a key rotation still decrypt under a handle that
+// contains the old key. Key material should normally be generated by Tink
+// instead.
+func NewDecryptOnlyHandle(keys []RawKey) (*keyset.Handle, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("aead: no keys")
+	}
+	ks := &tinkpb.Keyset{}
+	seenIDs := make(map[uint32]bool)
+	numPrimary := 0
+	for _, k := range keys {
+		if seenIDs[k.ID] {
+			return nil, fmt.Errorf("aead: duplicate key ID %d", k.ID)
+		}
+		seenIDs[k.ID] = true
+		keySize := len(k.KeyBytes)
+		if keySize != 16 && keySize != 32 {
+			return nil, fmt.Errorf("aead: key %d has invalid size %d, want 16 or 32", k.ID, keySize)
+		}
+		params, err := aesgcm.NewParameters(aesgcm.ParametersOpts{
+			KeySizeInBytes: keySize,
+			IVSizeInBytes:  12,
+			TagSizeInBytes: 16,
+			Variant:        k.Variant,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("aead: key %d: %v", k.ID, err)
+		}
+		idRequirement := k.ID
+		if !params.HasIDRequirement() {
+			idRequirement = 0
+		}
+		key, err := aesgcm.NewKey(secretdata.NewBytesFromData(k.KeyBytes, insecuresecretdataaccess.Token{}), idRequirement, params)
+		if err != nil {
+			return nil, fmt.Errorf("aead: key %d: %v", k.ID, err)
+		}
+		keySerialization, err := protoserialization.SerializeKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("aead: key %d: %v", k.ID, err)
+		}
+		if k.Primary {
+			numPrimary++
+			ks.PrimaryKeyId = k.ID
+		}
+		ks.Key = append(ks.Key, &tinkpb.Keyset_Key{
+			KeyId:            k.ID,
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			OutputPrefixType: keySerialization.OutputPrefixType(),
+			KeyData:          keySerialization.KeyData(),
+		})
+	}
+	if numPrimary != 1 {
+		return nil, fmt.Errorf("aead: got %d primary keys, want exactly 1", numPrimary)
+	}
+	return insecurecleartextkeyset.Read(&keyset.MemReaderWriter{Keyset: ks})
+}
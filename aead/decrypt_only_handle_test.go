@@ -0,0 +1,204 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/aead/aesgcm"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/secretdata"
+)
+
+func mustEncryptWithRawKey(t *testing.T, k aead.RawKey, plaintext, associatedData []byte) []byte {
+	t.Helper()
+	params, err := aesgcm.NewParameters(aesgcm.ParametersOpts{
+		KeySizeInBytes: len(k.KeyBytes),
+		IVSizeInBytes:  12,
+		TagSizeInBytes: 16,
+		Variant:        k.Variant,
+	})
+	if err != nil {
+		t.Fatalf("aesgcm.NewParameters() err = %v, want nil", err)
+	}
+	idRequirement := k.ID
+	if !params.HasIDRequirement() {
+		idRequirement = 0
+	}
+	key, err := aesgcm.NewKey(secretdata.NewBytesFromData(k.KeyBytes, insecuresecretdataaccess.Token{}), idRequirement, params)
+	if err != nil {
+		t.Fatalf("aesgcm.NewKey() err = %v, want nil", err)
+	}
+	a, err := aesgcm.NewAEAD(key)
+	if err != nil {
+		t.Fatalf("aesgcm.NewAEAD() err = %v, want nil", err)
+	}
+	ciphertext, err := a.Encrypt(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	return ciphertext
+}
+
+func TestNewDecryptOnlyHandle(t *testing.T) {
+	oldRawKey := aead.RawKey{
+		KeyBytes: bytes.Repeat([]byte{0x01}, 16),
+		ID:       0x01020304,
+		Variant:  aesgcm.VariantNoPrefix,
+	}
+	oldTinkKey := aead.RawKey{
+		KeyBytes: bytes.Repeat([]byte{0x02}, 32),
+		ID:       0x05060708,
+		Variant:  aesgcm.VariantTink,
+	}
+	oldCrunchyKey := aead.RawKey{
+		KeyBytes: bytes.Repeat([]byte{0x03}, 32),
+		ID:       0x090a0b0c,
+		Variant:  aesgcm.VariantCrunchy,
+	}
+	primaryKey := aead.RawKey{
+		KeyBytes: bytes.Repeat([]byte{0x04}, 32),
+		ID:       0x0d0e0f10,
+		Variant:  aesgcm.VariantTink,
+		Primary:  true,
+	}
+	handle, err := aead.NewDecryptOnlyHandle([]aead.RawKey{oldRawKey, oldTinkKey, oldCrunchyKey, primaryKey})
+	if err != nil {
+		t.Fatalf("aead.NewDecryptOnlyHandle() err = %v, want nil", err)
+	}
+	if got, want := handle.Len(), 4; got != want {
+		t.Errorf("handle.Len() = %d, want %d", got, want)
+	}
+	primary, err := handle.Primary()
+	if err != nil {
+		t.Fatalf("handle.Primary() err = %v, want nil", err)
+	}
+	if got, want := primary.KeyID(), primaryKey.ID; got != want {
+		t.Errorf("primary.KeyID() = %d, want %d", got, want)
+	}
+	for i := 0; i < handle.Len(); i++ {
+		entry, err := handle.Entry(i)
+		if err != nil {
+			t.Fatalf("handle.Entry(%d) err = %v, want nil", i, err)
+		}
+		if entry.KeyStatus() != keyset.Enabled {
+			t.Errorf("entry.KeyStatus() = %v, want %v", entry.KeyStatus(), keyset.Enabled)
+		}
+	}
+
+	a, err := aead.New(handle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	plaintext := []byte("plaintext")
+	associatedData := []byte("associatedData")
+	for _, k := range []aead.RawKey{oldRawKey, oldTinkKey, oldCrunchyKey, primaryKey} {
+		ciphertext := mustEncryptWithRawKey(t, k, plaintext, associatedData)
+		got, err := a.Decrypt(ciphertext, associatedData)
+		if err != nil {
+			t.Fatalf("a.Decrypt() with key %d err = %v, want nil", k.ID, err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("a.Decrypt() with key %d = %q, want %q", k.ID, got, plaintext)
+		}
+	}
+
+	// New ciphertexts use the primary key.
+	ciphertext, err := a.Encrypt(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	if want := []byte{0x01, 0x0d, 0x0e, 0x0f, 0x10}; !bytes.HasPrefix(ciphertext, want) {
+		t.Errorf("a.Encrypt() = %x, want prefix %x", ciphertext, want)
+	}
+}
+
+func TestNewDecryptOnlyHandleFails(t *testing.T) {
+	validKey := aead.RawKey{
+		KeyBytes: bytes.Repeat([]byte{0x01}, 16),
+		ID:       1,
+		Variant:  aesgcm.VariantTink,
+		Primary:  true,
+	}
+	for _, tc := range []struct {
+		name string
+		keys []aead.RawKey
+	}{
+		{
+			name: "no keys",
+			keys: nil,
+		},
+		{
+			name: "invalid key size",
+			keys: []aead.RawKey{{
+				KeyBytes: bytes.Repeat([]byte{0x01}, 24),
+				ID:       1,
+				Variant:  aesgcm.VariantTink,
+				Primary:  true,
+			}},
+		},
+		{
+			name: "empty key",
+			keys: []aead.RawKey{{
+				ID:      1,
+				Variant: aesgcm.VariantTink,
+				Primary: true,
+			}},
+		},
+		{
+			name: "unknown variant",
+			keys: []aead.RawKey{{
+				KeyBytes: bytes.Repeat([]byte{0x01}, 16),
+				ID:       1,
+				Variant:  aesgcm.VariantUnknown,
+				Primary:  true,
+			}},
+		},
+		{
+			name: "no primary",
+			keys: []aead.RawKey{{
+				KeyBytes: bytes.Repeat([]byte{0x01}, 16),
+				ID:       1,
+				Variant:  aesgcm.VariantTink,
+			}},
+		},
+		{
+			name: "two primaries",
+			keys: []aead.RawKey{validKey, {
+				KeyBytes: bytes.Repeat([]byte{0x02}, 16),
+				ID:       2,
+				Variant:  aesgcm.VariantTink,
+				Primary:  true,
+			}},
+		},
+		{
+			name: "duplicate ID",
+			keys: []aead.RawKey{validKey, {
+				KeyBytes: bytes.Repeat([]byte{0x02}, 16),
+				ID:       1,
+				Variant:  aesgcm.VariantNoPrefix,
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := aead.NewDecryptOnlyHandle(tc.keys); err == nil {
+				t.Errorf("aead.NewDecryptOnlyHandle() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/aead/aesgcm"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/prf"
+	"github.com/tink-crypto/tink-go/v2/secretdata"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// derivedAEADInputPrefix precedes the label in the PRF input, to separate the
+// keys derived by NewDerivedAEAD from other uses of the master keyset.
+const derivedAEADInputPrefix = "tink-go aead.NewDerivedAEAD:"
+
+const derivedAEADKeySize = 32
+
+// NewDerivedAEAD returns an AES256-GCM AEAD whose key is derived from the
+// primary key of master and label, so that many purpose-specific AEADs can be
+// used without storing a key for each.
+//
+// master must be a PRF keyset, such as one created with
+// [prf.HKDFSHA256PRFKeyTemplate]. The AES key is the output of the PRF of the
+// primary key on a fixed prefix followed by label. The same master and label
+// always give the same key, and keys for different labels are independent, as
+// long as the PRF is secure. PRFs with an output shorter than 32 bytes, such
+// as AES-CMAC PRF, are not supported.
+//
+// The derived key depends only on the primary key of master: after rotating
+// master, the AEADs derived from the new primary cannot decrypt the
+// ciphertexts of the AEADs derived from the old one. Ciphertexts have no
+// output prefix.
+func NewDerivedAEAD(master *keyset.Handle, label string) (tink.AEAD, error) {
+	prfSet, err := prf.NewPRFSet(master)
+	if err != nil {
+		return nil, fmt.Errorf("aead.NewDerivedAEAD: %v", err)
+	}
+	input := make([]byte, 0, len(derivedAEADInputPrefix)+len(label))
+	input = append(input, derivedAEADInputPrefix...)
+	input = append(input, label...)
+	keyBytes, err := prfSet.ComputePrimaryPRF(input, derivedAEADKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("aead.NewDerivedAEAD: %v", err)
+	}
+	params, err := aesgcm.NewParameters(aesgcm.ParametersOpts{
+		KeySizeInBytes: derivedAEADKeySize,
+		IVSizeInBytes:  12,
+		TagSizeInBytes: 16,
+		Variant:        aesgcm.VariantNoPrefix,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("aead.NewDerivedAEAD: %v", err)
+	}
+	key, err := aesgcm.NewKey(secretdata.NewBytesFromData(keyBytes, insecuresecretdataaccess.Token{}), 0, params)
+	if err != nil {
+		return nil, fmt.Errorf("aead.NewDerivedAEAD: %v", err)
+	}
+	a, err := aesgcm.NewAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("aead.NewDerivedAEAD: %v", err)
+	}
+	return a, nil
+}
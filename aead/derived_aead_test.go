@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/aead/subtle"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/prf"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestNewDerivedAEAD(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"HKDF-SHA256", prf.HKDFSHA256PRFKeyTemplate()},
+		{"HMAC-SHA256", prf.HMACSHA256PRFKeyTemplate()},
+		{"HMAC-SHA512", prf.HMACSHA512PRFKeyTemplate()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			master, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			a, err := aead.NewDerivedAEAD(master, "purpose-1")
+			if err != nil {
+				t.Fatalf("aead.NewDerivedAEAD() err = %v, want nil", err)
+			}
+			plaintext := []byte("plaintext")
+			associatedData := []byte("associated data")
+			ciphertext, err := a.Encrypt(plaintext, associatedData)
+			if err != nil {
+				t.Fatalf("a.Encrypt() err = %v, want nil", err)
+			}
+
+			// The same label gives the same key.
+			same, err := aead.NewDerivedAEAD(master, "purpose-1")
+			if err != nil {
+				t.Fatalf("aead.NewDerivedAEAD() err = %v, want nil", err)
+			}
+			got, err := same.Decrypt(ciphertext, associatedData)
+			if err != nil {
+				t.Fatalf("same.Decrypt() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("same.Decrypt() = %q, want %q", got, plaintext)
+			}
+
+			// Other labels and masters give other keys.
+			for _, label := range []string{"purpose-2", "", "purpose-1 "} {
+				other, err := aead.NewDerivedAEAD(master, label)
+				if err != nil {
+					t.Fatalf("aead.NewDerivedAEAD(%q) err = %v, want nil", label, err)
+				}
+				if _, err := other.Decrypt(ciphertext, associatedData); err == nil {
+					t.Errorf("Decrypt() with label %q err = nil, want error", label)
+				}
+			}
+			otherMaster, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			other, err := aead.NewDerivedAEAD(otherMaster, "purpose-1")
+			if err != nil {
+				t.Fatalf("aead.NewDerivedAEAD() err = %v, want nil", err)
+			}
+			if _, err := other.Decrypt(ciphertext, associatedData); err == nil {
+				t.Error("Decrypt() with other master err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewDerivedAEADKeyIsPRFOutput(t *testing.T) {
+	master, err := keyset.NewHandle(prf.HKDFSHA256PRFKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	a, err := aead.NewDerivedAEAD(master, "label")
+	if err != nil {
+		t.Fatalf("aead.NewDerivedAEAD() err = %v, want nil", err)
+	}
+	prfSet, err := prf.NewPRFSet(master)
+	if err != nil {
+		t.Fatalf("prf.NewPRFSet() err = %v, want nil", err)
+	}
+	key, err := prfSet.ComputePrimaryPRF([]byte("tink-go aead.NewDerivedAEAD:label"), 32)
+	if err != nil {
+		t.Fatalf("prfSet.ComputePrimaryPRF() err = %v, want nil", err)
+	}
+	want, err := subtle.NewAESGCM(key)
+	if err != nil {
+		t.Fatalf("subtle.NewAESGCM() err = %v, want nil", err)
+	}
+	ciphertext, err := a.Encrypt([]byte("plaintext"), nil)
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	if _, err := want.Decrypt(ciphertext, nil); err != nil {
+		t.Errorf("want.Decrypt() err = %v, want nil", err)
+	}
+}
+
+func TestNewDerivedAEADFails(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"AES-CMAC PRF", prf.AESCMACPRFKeyTemplate()},
+		{"not a PRF", aead.AES256GCMKeyTemplate()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			master, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			if _, err := aead.NewDerivedAEAD(master, "label"); err == nil {
+				t.Error("aead.NewDerivedAEAD() err = nil, want error")
+			}
+		})
+	}
+	if _, err := aead.NewDerivedAEAD(nil, "label"); err == nil {
+		t.Error("aead.NewDerivedAEAD(nil) err = nil, want error")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"golang.org/x/sys/cpu"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// hasAESGCMHardwareSupport reports whether the CPU has the instructions used
+// by the Go standard library for constant-time, accelerated AES-GCM.
+var hasAESGCMHardwareSupport = (cpu.X86.HasAES && cpu.X86.HasPCLMULQDQ) ||
+	(cpu.ARM64.HasAES && cpu.ARM64.HasPMULL) ||
+	(cpu.S390X.HasAES && cpu.S390X.HasAESCTR && cpu.S390X.HasGHASH)
+
+// HasAESGCMHardwareSupport reports whether the current CPU has AES and
+// carry-less multiplication instructions (AES-NI and PCLMULQDQ on x86, the
+// AES and PMULL extensions on arm64, CPACF on s390x), which make AES-GCM fast
+// and constant-time.
+func HasAESGCMHardwareSupport() bool {
+	return hasAESGCMHardwareSupport
+}
+
+// FastestTemplate returns the AEAD key template expected to be the fastest on
+// the current CPU. It is FastestTemplateForCPU(HasAESGCMHardwareSupport()).
+//
+// The decision is based on CPU feature detection rather than on a benchmark,
+// so it is cheap and deterministic. To override it for a deployment, for
+// example from a configuration flag, call [FastestTemplateForCPU] instead.
+//
+// Both templates produce keys with output prefix type TINK. Since the choice
+// may differ between machines, a keyset generated on one machine can be used
+// on any other one; only the speed differs.
+func FastestTemplate() *tinkpb.KeyTemplate {
+	return FastestTemplateForCPU(hasAESGCMHardwareSupport)
+}
+
+// FastestTemplateForCPU returns the AEAD key template expected to be the
+// fastest on a CPU that has AES-GCM hardware support or not:
+//   - [AES256GCMKeyTemplate] if hasAESGCMHardwareSupport is true;
+//   - [ChaCha20Poly1305KeyTemplate] otherwise, since software AES-GCM is both
+//     much slower than ChaCha20-Poly1305 and harder to make constant-time.
+func FastestTemplateForCPU(hasAESGCMHardwareSupport bool) *tinkpb.KeyTemplate {
+	if hasAESGCMHardwareSupport {
+		return AES256GCMKeyTemplate()
+	}
+	return ChaCha20Poly1305KeyTemplate()
+}
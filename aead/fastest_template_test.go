@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestFastestTemplate(t *testing.T) {
+	template := aead.FastestTemplate()
+	if !proto.Equal(template, aead.AES256GCMKeyTemplate()) && !proto.Equal(template, aead.ChaCha20Poly1305KeyTemplate()) {
+		t.Fatalf("aead.FastestTemplate() = %v, want AES256GCMKeyTemplate() or ChaCha20Poly1305KeyTemplate()", template)
+	}
+	if want := aead.FastestTemplateForCPU(aead.HasAESGCMHardwareSupport()); !proto.Equal(template, want) {
+		t.Errorf("aead.FastestTemplate() = %v, want %v", template, want)
+	}
+	handle, err := keyset.NewHandle(template)
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	a, err := aead.New(handle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	ciphertext, err := a.Encrypt([]byte("plaintext"), []byte("associated data"))
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	if _, err := a.Decrypt(ciphertext, []byte("associated data")); err != nil {
+		t.Errorf("a.Decrypt() err = %v, want nil", err)
+	}
+}
+
+func TestFastestTemplateForCPU(t *testing.T) {
+	for _, tc := range []struct {
+		hasAESGCMHardwareSupport bool
+		want                     *tinkpb.KeyTemplate
+	}{
+		{true, aead.AES256GCMKeyTemplate()},
+		{false, aead.ChaCha20Poly1305KeyTemplate()},
+	} {
+		if got := aead.FastestTemplateForCPU(tc.hasAESGCMHardwareSupport); !proto.Equal(got, tc.want) {
+			t.Errorf("aead.FastestTemplateForCPU(%v) = %v, want %v", tc.hasAESGCMHardwareSupport, got, tc.want)
+		}
+	}
+}
@@ -140,11 +140,15 @@ func encryptDataAndSerializeEnvelope(dekTypeURL string, dek, encryptedDEK []byte
 			"kms_envelope_aead: length of encrypted DEK too large; got %d, want at most %d",
 			len(encryptedDEK), maxLengthEncryptedDEK)
 	}
+	return serializeEnvelope(encryptedDEK, payload), nil
+}
+
+func serializeEnvelope(encryptedDEK, payload []byte) []byte {
 	res := make([]byte, 0, lenDEK+len(encryptedDEK)+len(payload))
 	res = binary.BigEndian.AppendUint32(res, uint32(len(encryptedDEK)))
 	res = append(res, encryptedDEK...)
 	res = append(res, payload...)
-	return res, nil
+	return res
 }
 
 // Encrypt implements the tink.AEAD interface for encryption.
@@ -215,6 +219,45 @@ func (a *KMSEnvelopeAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, er
 	return decryptDataWithDEK(a.dekTemplate.GetTypeUrl(), dek, payload, associatedData)
 }
 
+// RewrapDEK re-encrypts the DEK of ciphertext under newKEK, and returns the
+// resulting ciphertext. The DEK is decrypted with the KEK of a and encrypted
+// again with newKEK; the encrypted payload is copied unchanged, so the data
+// does not need to be re-encrypted when rotating the KEK.
+//
+// The DEK is only held in memory for the duration of the call, and is never
+// returned. The returned ciphertext can only be decrypted by a
+// [KMSEnvelopeAEAD] that uses newKEK and the same DEK template as a.
+func (a *KMSEnvelopeAEAD) RewrapDEK(ciphertext []byte, newKEK tink.AEAD) ([]byte, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if newKEK == nil {
+		return nil, errors.New("kms_envelope_aead: newKEK is nil")
+	}
+	encryptedDEK, payload, err := parseEnvelope(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	dek, err := a.kekAEAD.Decrypt(encryptedDEK, []byte{})
+	if err != nil {
+		return nil, err
+	}
+	defer clear(dek)
+	newEncryptedDEK, err := newKEK.Encrypt(dek, []byte{})
+	if err != nil {
+		return nil, err
+	}
+	if len(newEncryptedDEK) == 0 {
+		return nil, errors.New("kms_envelope_aead: encrypted dek is empty")
+	}
+	if len(newEncryptedDEK) > maxLengthEncryptedDEK {
+		return nil, fmt.Errorf(
+			"kms_envelope_aead: length of encrypted DEK too large; got %d, want at most %d",
+			len(newEncryptedDEK), maxLengthEncryptedDEK)
+	}
+	return serializeEnvelope(newEncryptedDEK, payload), nil
+}
+
 // EncryptWithContext implements the [tink.AEADWithContext] interface for encryption.
 func (a *KMSEnvelopeAEADWithContext) EncryptWithContext(ctx context.Context, plaintext, associatedData []byte) ([]byte, error) {
 	dek, err := newDEK(a.dekTemplate)
@@ -17,6 +17,7 @@ package aead_test
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"testing"
 
@@ -285,3 +286,60 @@ func TestKMSEnvelopeEncryptWithInvalidAEADFails(t *testing.T) {
 		t.Error("envAEADWithInvalidKEK.Encrypt(plaintext, associatedData) err = nil, want error")
 	}
 }
+
+func TestKMSEnvelopeRewrapDEK(t *testing.T) {
+	oldKeyURI, err := fakekms.NewKeyURI()
+	if err != nil {
+		t.Fatalf("fakekms.NewKeyURI() err = %q, want nil", err)
+	}
+	oldKEK, err := fakekms.NewAEAD(oldKeyURI)
+	if err != nil {
+		t.Fatalf("fakekms.NewAEAD(oldKeyURI) err = %q, want nil", err)
+	}
+	newKeyURI, err := fakekms.NewKeyURI()
+	if err != nil {
+		t.Fatalf("fakekms.NewKeyURI() err = %q, want nil", err)
+	}
+	newKEK, err := fakekms.NewAEAD(newKeyURI)
+	if err != nil {
+		t.Fatalf("fakekms.NewAEAD(newKeyURI) err = %q, want nil", err)
+	}
+	oldEnvelope := aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), oldKEK)
+	newEnvelope := aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), newKEK)
+
+	plaintext := []byte("plaintext")
+	associatedData := []byte("associatedData")
+	ciphertext, err := oldEnvelope.Encrypt(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("oldEnvelope.Encrypt() err = %q, want nil", err)
+	}
+	rewrapped, err := oldEnvelope.RewrapDEK(ciphertext, newKEK)
+	if err != nil {
+		t.Fatalf("oldEnvelope.RewrapDEK() err = %q, want nil", err)
+	}
+
+	// The payload is unchanged.
+	oldDEKLen := 4 + int(binary.BigEndian.Uint32(ciphertext))
+	newDEKLen := 4 + int(binary.BigEndian.Uint32(rewrapped))
+	if !bytes.Equal(rewrapped[newDEKLen:], ciphertext[oldDEKLen:]) {
+		t.Errorf("payload of rewrapped ciphertext = %x, want %x", rewrapped[newDEKLen:], ciphertext[oldDEKLen:])
+	}
+
+	got, err := newEnvelope.Decrypt(rewrapped, associatedData)
+	if err != nil {
+		t.Fatalf("newEnvelope.Decrypt() err = %q, want nil", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("newEnvelope.Decrypt() = %q, want %q", got, plaintext)
+	}
+	if _, err := oldEnvelope.Decrypt(rewrapped, associatedData); err == nil {
+		t.Error("oldEnvelope.Decrypt(rewrapped) err = nil, want error")
+	}
+	// Rewrapping with the wrong current KEK fails.
+	if _, err := newEnvelope.RewrapDEK(ciphertext, newKEK); err == nil {
+		t.Error("newEnvelope.RewrapDEK(ciphertext) err = nil, want error")
+	}
+	if _, err := oldEnvelope.RewrapDEK([]byte{0, 0}, newKEK); err == nil {
+		t.Error("oldEnvelope.RewrapDEK() with short ciphertext err = nil, want error")
+	}
+}
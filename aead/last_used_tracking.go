@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/monitoring"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// TrackingAEAD is an AEAD primitive that records which key was used by the
+// most recent successful operation.
+type TrackingAEAD struct {
+	aead *wrappedAead
+	last atomic.Pointer[lastUse]
+}
+
+var _ tink.AEAD = (*TrackingAEAD)(nil)
+
+type lastUse struct {
+	keyID uint32
+	at    time.Time
+}
+
+// lastUseLogger records every successful operation in last before passing it
+// on to the underlying logger.
+type lastUseLogger struct {
+	monitoring.Logger
+	last *atomic.Pointer[lastUse]
+}
+
+func (l *lastUseLogger) Log(keyID uint32, numBytes int) {
+	l.last.Store(&lastUse{keyID: keyID, at: time.Now()})
+	l.Logger.Log(keyID, numBytes)
+}
+
+// NewWithLastUsedTracking returns an AEAD primitive from the given keyset
+// handle that behaves like the one returned by [New], and additionally
+// records the key ID used by the most recent successful encryption or
+// decryption.
+//
+// This can serve as a cheap health signal, for example to confirm that
+// encryption goes through the expected primary key after a key rotation.
+func NewWithLastUsedTracking(handle *keyset.Handle) (*TrackingAEAD, error) {
+	ps, err := keyset.Primitives[tink.AEAD](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("aead_factory: cannot obtain primitive set: %s", err)
+	}
+	a, err := newWrappedAead(ps)
+	if err != nil {
+		return nil, err
+	}
+	t := &TrackingAEAD{aead: a}
+	a.encLogger = &lastUseLogger{Logger: a.encLogger, last: &t.last}
+	a.decLogger = &lastUseLogger{Logger: a.decLogger, last: &t.last}
+	return t, nil
+}
+
+// Encrypt encrypts plaintext with associatedData using the primary key.
+func (t *TrackingAEAD) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	return t.aead.Encrypt(plaintext, associatedData)
+}
+
+// Decrypt decrypts ciphertext with associatedData.
+func (t *TrackingAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	return t.aead.Decrypt(ciphertext, associatedData)
+}
+
+// LastUsedKeyID returns the ID of the key used by the most recent successful
+// Encrypt or Decrypt call, and the time at which that call completed. It
+// returns 0 and the zero time if no call has succeeded yet.
+func (t *TrackingAEAD) LastUsedKeyID() (uint32, time.Time) {
+	last := t.last.Load()
+	if last == nil {
+		return 0, time.Time{}
+	}
+	return last.keyID, last.at
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+func TestNewWithLastUsedTrackingRecordsKeyID(t *testing.T) {
+	manager := keyset.NewManager()
+	oldKeyID, err := manager.Add(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	newKeyID, err := manager.Add(aead.AES256GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(oldKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary(%d) err = %v, want nil", oldKeyID, err)
+	}
+	oldHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(newKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary(%d) err = %v, want nil", newKeyID, err)
+	}
+	newHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+
+	oldAEAD, err := aead.New(oldHandle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	plaintext := []byte("plaintext")
+	associatedData := []byte("associatedData")
+	oldCiphertext, err := oldAEAD.Encrypt(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("oldAEAD.Encrypt() err = %v, want nil", err)
+	}
+
+	a, err := aead.NewWithLastUsedTracking(newHandle)
+	if err != nil {
+		t.Fatalf("aead.NewWithLastUsedTracking() err = %v, want nil", err)
+	}
+	if keyID, at := a.LastUsedKeyID(); keyID != 0 || !at.IsZero() {
+		t.Errorf("a.LastUsedKeyID() = %d, %v, want 0, zero time", keyID, at)
+	}
+
+	before := time.Now()
+	if _, err := a.Decrypt(oldCiphertext, associatedData); err != nil {
+		t.Fatalf("a.Decrypt() err = %v, want nil", err)
+	}
+	keyID, decryptedAt := a.LastUsedKeyID()
+	if keyID != oldKeyID {
+		t.Errorf("a.LastUsedKeyID() after Decrypt = %d, want %d", keyID, oldKeyID)
+	}
+	if decryptedAt.Before(before) || decryptedAt.After(time.Now()) {
+		t.Errorf("a.LastUsedKeyID() after Decrypt time = %v, want between %v and now", decryptedAt, before)
+	}
+
+	ciphertext, err := a.Encrypt(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	keyID, encryptedAt := a.LastUsedKeyID()
+	if keyID != newKeyID {
+		t.Errorf("a.LastUsedKeyID() after Encrypt = %d, want %d", keyID, newKeyID)
+	}
+	if encryptedAt.Before(decryptedAt) {
+		t.Errorf("a.LastUsedKeyID() after Encrypt time = %v, want not before %v", encryptedAt, decryptedAt)
+	}
+
+	// Failed operations leave the last use unchanged.
+	if _, err := a.Decrypt(ciphertext, []byte("wrong associatedData")); err == nil {
+		t.Fatalf("a.Decrypt() err = nil, want error")
+	}
+	if keyID, at := a.LastUsedKeyID(); keyID != newKeyID || !at.Equal(encryptedAt) {
+		t.Errorf("a.LastUsedKeyID() after failed Decrypt = %d, %v, want %d, %v", keyID, at, newKeyID, encryptedAt)
+	}
+}
+
+func TestNewWithLastUsedTrackingFailsWithNilHandle(t *testing.T) {
+	if _, err := aead.NewWithLastUsedTracking(nil); err == nil {
+		t.Errorf("aead.NewWithLastUsedTracking(nil) err = nil, want error")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// paddedLengthSize is the size of the big-endian plaintext length that
+// precedes the plaintext in a padded plaintext.
+const paddedLengthSize = 4
+
+// paddedAEAD is a [tink.AEAD] that pads plaintexts to a multiple of a block
+// size before encrypting them.
+type paddedAEAD struct {
+	aead          tink.AEAD
+	blockSize     int
+	maxPaddedSize int
+}
+
+var _ tink.AEAD = (*paddedAEAD)(nil)
+
+// NewPaddedAEAD returns an AEAD primitive from the given keyset handle that
+// pads each plaintext before encrypting it, so that all plaintexts whose
+// lengths round up to the same multiple of blockSize give ciphertexts of the
+// same length.
+//
+// The padded plaintext is the 4-byte big-endian length of the plaintext,
+// followed by the plaintext and by zero bytes up to the next multiple of
+// blockSize. It is encrypted with the primitive returned by [New], and
+// Decrypt removes the padding after decrypting.
+//
+// maxPaddedSize is the largest padded plaintext, and must be a multiple of
+// blockSize. Encrypt fails for plaintexts that don't fit in it, that is
+// plaintexts longer than maxPaddedSize-4 bytes, and Decrypt rejects longer
+// padded plaintexts. Setting maxPaddedSize to blockSize makes all ciphertexts
+// the same length.
+//
+// Otherwise, this hides the length of the plaintext only up to the
+// granularity of blockSize: ciphertexts still reveal the number of blocks of
+// the plaintext.
+func NewPaddedAEAD(handle *keyset.Handle, blockSize, maxPaddedSize int) (tink.AEAD, error) {
+	if blockSize <= 0 {
+		return nil, fmt.Errorf("aead.NewPaddedAEAD: block size must be positive, got %d", blockSize)
+	}
+	if maxPaddedSize < blockSize || maxPaddedSize%blockSize != 0 {
+		return nil, fmt.Errorf("aead.NewPaddedAEAD: maximum padded size must be a positive multiple of the block size %d, got %d", blockSize, maxPaddedSize)
+	}
+	a, err := New(handle)
+	if err != nil {
+		return nil, fmt.Errorf("aead.NewPaddedAEAD: %v", err)
+	}
+	return &paddedAEAD{aead: a, blockSize: blockSize, maxPaddedSize: maxPaddedSize}, nil
+}
+
+// Encrypt pads plaintext and encrypts it with associatedData using the primary
+// key.
+func (p *paddedAEAD) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	if len(plaintext) > p.maxPaddedSize-paddedLengthSize || uint64(len(plaintext)) > math.MaxUint32 {
+		return nil, fmt.Errorf("aead.paddedAEAD: plaintext too long")
+	}
+	// This does not exceed maxPaddedSize, which is a multiple of blockSize.
+	size := paddedLengthSize + len(plaintext)
+	if r := size % p.blockSize; r != 0 {
+		size += p.blockSize - r
+	}
+	padded := make([]byte, size)
+	binary.BigEndian.PutUint32(padded, uint32(len(plaintext)))
+	copy(padded[paddedLengthSize:], plaintext)
+	return p.aead.Encrypt(padded, associatedData)
+}
+
+// Decrypt decrypts ciphertext with associatedData and removes the padding.
+func (p *paddedAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	padded, err := p.aead.Decrypt(ciphertext, associatedData)
+	if err != nil {
+		return nil, err
+	}
+	if len(padded) < paddedLengthSize || len(padded) > p.maxPaddedSize || len(padded)%p.blockSize != 0 {
+		return nil, fmt.Errorf("aead.paddedAEAD: invalid padded plaintext length")
+	}
+	n := binary.BigEndian.Uint32(padded)
+	if uint64(n) > uint64(len(padded)-paddedLengthSize) {
+		return nil, fmt.Errorf("aead.paddedAEAD: invalid plaintext length")
+	}
+	plaintext := padded[paddedLengthSize : paddedLengthSize+int(n)]
+	for _, b := range padded[paddedLengthSize+int(n):] {
+		if b != 0 {
+			return nil, fmt.Errorf("aead.paddedAEAD: invalid padding")
+		}
+	}
+	return plaintext, nil
+}
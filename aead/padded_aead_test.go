@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+func TestPaddedAEADEncryptDecrypt(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	const blockSize = 64
+	a, err := aead.NewPaddedAEAD(handle, blockSize, 4*blockSize)
+	if err != nil {
+		t.Fatalf("aead.NewPaddedAEAD() err = %v, want nil", err)
+	}
+	associatedData := []byte("associated data")
+	// The 4-byte length and up to 60 bytes of plaintext fit in one block.
+	ciphertextLen := func(plaintextLen int) int {
+		t.Helper()
+		ct, err := a.Encrypt(make([]byte, plaintextLen), associatedData)
+		if err != nil {
+			t.Fatalf("a.Encrypt() err = %v, want nil", err)
+		}
+		return len(ct)
+	}
+	oneBlock := ciphertextLen(0)
+	for _, n := range []int{1, 59, 60} {
+		if got := ciphertextLen(n); got != oneBlock {
+			t.Errorf("len(a.Encrypt(%d bytes)) = %d, want %d", n, got, oneBlock)
+		}
+	}
+	for _, n := range []int{61, 124} {
+		if got, want := ciphertextLen(n), oneBlock+blockSize; got != want {
+			t.Errorf("len(a.Encrypt(%d bytes)) = %d, want %d", n, got, want)
+		}
+	}
+
+	for _, n := range []int{0, 1, 59, 60, 61, 200, 4*blockSize - 4} {
+		plaintext := bytes.Repeat([]byte{0xaa}, n)
+		ciphertext, err := a.Encrypt(plaintext, associatedData)
+		if err != nil {
+			t.Fatalf("a.Encrypt() err = %v, want nil", err)
+		}
+		got, err := a.Decrypt(ciphertext, associatedData)
+		if err != nil {
+			t.Fatalf("a.Decrypt() err = %v, want nil", err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("a.Decrypt() = %x, want %x", got, plaintext)
+		}
+		if _, err := a.Decrypt(ciphertext, []byte("other associated data")); err == nil {
+			t.Error("a.Decrypt() with other associated data err = nil, want error")
+		}
+	}
+	if _, err := a.Encrypt(make([]byte, 4*blockSize-3), associatedData); err == nil {
+		t.Error("a.Encrypt() of a plaintext longer than the maximum err = nil, want error")
+	}
+}
+
+func TestPaddedAEADDecryptRejectsInvalidPadding(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	a, err := aead.NewPaddedAEAD(handle, 8, 16)
+	if err != nil {
+		t.Fatalf("aead.NewPaddedAEAD() err = %v, want nil", err)
+	}
+	unpadded, err := aead.New(handle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		padded []byte
+	}{
+		{"empty", []byte{}},
+		{"not a multiple of the block size", []byte{0, 0, 0, 1, 'a', 0, 0}},
+		{"length too large", []byte{0, 0, 0, 5, 'a', 'b', 'c', 'd'}},
+		{"non-zero padding", []byte{0, 0, 0, 1, 'a', 0, 1, 0}},
+		{"longer than the maximum", make([]byte, 24)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ciphertext, err := unpadded.Encrypt(tc.padded, nil)
+			if err != nil {
+				t.Fatalf("unpadded.Encrypt() err = %v, want nil", err)
+			}
+			if _, err := a.Decrypt(ciphertext, nil); err == nil {
+				t.Error("a.Decrypt() err = nil, want error")
+			}
+		})
+	}
+	ciphertext, err := unpadded.Encrypt([]byte{0, 0, 0, 1, 'a', 0, 0, 0}, nil)
+	if err != nil {
+		t.Fatalf("unpadded.Encrypt() err = %v, want nil", err)
+	}
+	if got, err := a.Decrypt(ciphertext, nil); err != nil || !bytes.Equal(got, []byte("a")) {
+		t.Errorf("a.Decrypt() = %q, %v, want %q, nil", got, err, "a")
+	}
+}
+
+func TestNewPaddedAEADFails(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		blockSize     int
+		maxPaddedSize int
+	}{
+		{0, 16},
+		{-1, 16},
+		{16, 0},
+		{16, 8},
+		{16, 24},
+	} {
+		if _, err := aead.NewPaddedAEAD(handle, tc.blockSize, tc.maxPaddedSize); err == nil {
+			t.Errorf("aead.NewPaddedAEAD(handle, %d, %d) err = nil, want error", tc.blockSize, tc.maxPaddedSize)
+		}
+	}
+	if _, err := aead.NewPaddedAEAD(nil, 16, 16); err == nil {
+		t.Error("aead.NewPaddedAEAD(nil, 16, 16) err = nil, want error")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// RotatingAEAD is an AEAD primitive that helps migrating ciphertexts to the
+// primary key of a keyset after a key rotation, as they are read.
+type RotatingAEAD struct {
+	aead *wrappedAead
+}
+
+var _ tink.AEAD = (*RotatingAEAD)(nil)
+
+// NewRotatingAEAD returns an AEAD primitive from the given keyset handle that
+// behaves like the one returned by [New], and can additionally re-encrypt
+// ciphertexts that were not encrypted with the primary key.
+func NewRotatingAEAD(handle *keyset.Handle) (*RotatingAEAD, error) {
+	ps, err := keyset.Primitives[tink.AEAD](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("aead_factory: cannot obtain primitive set: %s", err)
+	}
+	a, err := newWrappedAead(ps)
+	if err != nil {
+		return nil, err
+	}
+	return &RotatingAEAD{aead: a}, nil
+}
+
+// Encrypt encrypts plaintext with associatedData using the primary key.
+func (r *RotatingAEAD) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	return r.aead.Encrypt(plaintext, associatedData)
+}
+
+// Decrypt decrypts ciphertext with associatedData using any enabled key.
+func (r *RotatingAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	return r.aead.Decrypt(ciphertext, associatedData)
+}
+
+// DecryptAndReencrypt decrypts ciphertext with associatedData. If the key that
+// decrypted it is not the primary key, it also encrypts the plaintext again
+// with the primary key and associatedData, and returns the new ciphertext as
+// reencrypted; otherwise reencrypted is nil.
+//
+// Callers can store reencrypted in place of ciphertext to lazily migrate data
+// to the primary key during normal reads.
+func (r *RotatingAEAD) DecryptAndReencrypt(ciphertext, associatedData []byte) (plaintext, reencrypted []byte, err error) {
+	keyID, plaintext, err := r.aead.DecryptAndKeyID(ciphertext, associatedData)
+	if err != nil {
+		return nil, nil, err
+	}
+	if keyID == r.aead.primary.keyID {
+		return plaintext, nil, nil
+	}
+	reencrypted, err = r.aead.Encrypt(plaintext, associatedData)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plaintext, reencrypted, nil
+}
+
+// PrimaryKeyID returns the ID of the primary key, which encrypts.
+func (r *RotatingAEAD) PrimaryKeyID() uint32 {
+	return r.aead.primary.keyID
+}
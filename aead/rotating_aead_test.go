@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// rotatedHandles returns a keyset handle before and after rotating its primary
+// from oldKeyID to newKeyID.
+func rotatedHandles(t *testing.T, newTemplate *tinkpb.KeyTemplate) (oldHandle, newHandle *keyset.Handle, oldKeyID, newKeyID uint32) {
+	t.Helper()
+	manager := keyset.NewManager()
+	oldKeyID, err := manager.Add(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	newKeyID, err = manager.Add(newTemplate)
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(oldKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary(%d) err = %v, want nil", oldKeyID, err)
+	}
+	oldHandle, err = manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(newKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary(%d) err = %v, want nil", newKeyID, err)
+	}
+	newHandle, err = manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	return oldHandle, newHandle, oldKeyID, newKeyID
+}
+
+func TestDecryptAndKeyID(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"TINK", aead.AES256GCMKeyTemplate()},
+		{"RAW", aead.AES256GCMNoPrefixKeyTemplate()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oldHandle, newHandle, oldKeyID, newKeyID := rotatedHandles(t, tc.template)
+			oldAEAD, err := aead.New(oldHandle)
+			if err != nil {
+				t.Fatalf("aead.New() err = %v, want nil", err)
+			}
+			newAEAD, err := aead.New(newHandle)
+			if err != nil {
+				t.Fatalf("aead.New() err = %v, want nil", err)
+			}
+			decrypter, ok := newAEAD.(aead.KeyIDDecrypter)
+			if !ok {
+				t.Fatalf("aead.New() = %T, want aead.KeyIDDecrypter", newAEAD)
+			}
+			plaintext := []byte("plaintext")
+			associatedData := []byte("associatedData")
+			for _, p := range []struct {
+				name      string
+				encrypter interface {
+					Encrypt(plaintext, associatedData []byte) ([]byte, error)
+				}
+				wantKeyID uint32
+			}{
+				{"old primary", oldAEAD, oldKeyID},
+				{"new primary", newAEAD, newKeyID},
+			} {
+				ciphertext, err := p.encrypter.Encrypt(plaintext, associatedData)
+				if err != nil {
+					t.Fatalf("%s: Encrypt() err = %v, want nil", p.name, err)
+				}
+				keyID, got, err := decrypter.DecryptAndKeyID(ciphertext, associatedData)
+				if err != nil {
+					t.Fatalf("%s: decrypter.DecryptAndKeyID() err = %v, want nil", p.name, err)
+				}
+				if !bytes.Equal(got, plaintext) {
+					t.Errorf("%s: decrypter.DecryptAndKeyID() plaintext = %q, want %q", p.name, got, plaintext)
+				}
+				if keyID != p.wantKeyID {
+					t.Errorf("%s: decrypter.DecryptAndKeyID() keyID = %d, want %d", p.name, keyID, p.wantKeyID)
+				}
+			}
+			if _, _, err := decrypter.DecryptAndKeyID([]byte("invalid ciphertext"), associatedData); err == nil {
+				t.Error("decrypter.DecryptAndKeyID() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestRotatingAEADDecryptAndReencrypt(t *testing.T) {
+	oldHandle, newHandle, _, newKeyID := rotatedHandles(t, aead.AES256GCMKeyTemplate())
+	oldAEAD, err := aead.New(oldHandle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	r, err := aead.NewRotatingAEAD(newHandle)
+	if err != nil {
+		t.Fatalf("aead.NewRotatingAEAD() err = %v, want nil", err)
+	}
+	if got := r.PrimaryKeyID(); got != newKeyID {
+		t.Errorf("r.PrimaryKeyID() = %d, want %d", got, newKeyID)
+	}
+	plaintext := []byte("plaintext")
+	associatedData := []byte("associatedData")
+	oldCiphertext, err := oldAEAD.Encrypt(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("oldAEAD.Encrypt() err = %v, want nil", err)
+	}
+
+	got, reencrypted, err := r.DecryptAndReencrypt(oldCiphertext, associatedData)
+	if err != nil {
+		t.Fatalf("r.DecryptAndReencrypt() err = %v, want nil", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("r.DecryptAndReencrypt() plaintext = %q, want %q", got, plaintext)
+	}
+	if reencrypted == nil {
+		t.Fatal("r.DecryptAndReencrypt() reencrypted = nil, want ciphertext under the primary key")
+	}
+	got, again, err := r.DecryptAndReencrypt(reencrypted, associatedData)
+	if err != nil {
+		t.Fatalf("r.DecryptAndReencrypt() err = %v, want nil", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("r.DecryptAndReencrypt() plaintext = %q, want %q", got, plaintext)
+	}
+	if again != nil {
+		t.Errorf("r.DecryptAndReencrypt() of a ciphertext under the primary key reencrypted = %x, want nil", again)
+	}
+
+	if _, _, err := r.DecryptAndReencrypt(oldCiphertext, []byte("wrong associatedData")); err == nil {
+		t.Error("r.DecryptAndReencrypt() err = nil, want error")
+	}
+}
+
+func TestNewRotatingAEADFailsWithNilHandle(t *testing.T) {
+	if _, err := aead.NewRotatingAEAD(nil); err == nil {
+		t.Errorf("aead.NewRotatingAEAD(nil) err = nil, want error")
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	// Placeholder for internal crypto/cipher allowlist, please ignore.
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/subtle"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+const (
+	seekableDEKSize          = 32
+	seekableChunkTagSize     = 16
+	seekableHeaderLengthSize = 4
+	seekableChunkKeyInfo     = "tink seekable aead chunk key"
+
+	// MaxSeekableChunkSize is the largest chunk size accepted by
+	// [NewSeekableAEAD].
+	MaxSeekableChunkSize = 1 << 30
+)
+
+// SeekableAEAD encrypts data as a sequence of independently authenticated
+// chunks, so that any range of the plaintext can be decrypted without
+// processing the whole ciphertext.
+//
+// Every ciphertext has the following layout, where integers are big-endian:
+//
+//	header_length (4 bytes) || header || chunk_0 || chunk_1 || ... || chunk_n
+//
+// The header is a random 32-byte data encryption key (DEK) encrypted with the
+// primary key of the keyset and the associated data of the ciphertext. Chunk i
+// is the AES-256-GCM encryption, with an all-zero nonce, of the i-th
+// chunkSize-byte block of the plaintext, followed by the 16-byte GCM tag. Its
+// key is HKDF-SHA256(DEK, info = "tink seekable aead chunk key" || uint64(i)),
+// so every chunk key is used exactly once. Its associated data is
+// uint64(i) || last, where last is 1 for the final chunk and 0 otherwise. Only
+// the final chunk may be shorter than chunkSize; it is empty if and only if
+// the plaintext is empty.
+//
+// Because each chunk is bound to its index and the final chunk is marked,
+// reordering, duplicating, modifying, or truncating chunks is detected when
+// the affected range is read. Chunks of different ciphertexts cannot be mixed,
+// since every ciphertext uses a fresh DEK.
+type SeekableAEAD struct {
+	aead      tink.AEAD
+	chunkSize int
+}
+
+// NewSeekableAEAD returns a [SeekableAEAD] that uses the AEAD primitive of
+// handle to protect per-ciphertext keys, and splits plaintexts into chunks of
+// chunkSize bytes.
+//
+// chunkSize must be positive and at most [MaxSeekableChunkSize]. The same
+// chunkSize must be used for encryption and decryption.
+func NewSeekableAEAD(handle *keyset.Handle, chunkSize int) (*SeekableAEAD, error) {
+	if chunkSize <= 0 || chunkSize > MaxSeekableChunkSize {
+		return nil, fmt.Errorf("aead.NewSeekableAEAD: invalid chunk size %d", chunkSize)
+	}
+	a, err := New(handle)
+	if err != nil {
+		return nil, fmt.Errorf("aead.NewSeekableAEAD: %v", err)
+	}
+	return &SeekableAEAD{aead: a, chunkSize: chunkSize}, nil
+}
+
+// chunkCipher returns the AES-GCM instance for chunk index of the ciphertext
+// with the given DEK.
+func chunkCipher(dek []byte, index uint64) (cipher.AEAD, error) {
+	info := binary.BigEndian.AppendUint64([]byte(seekableChunkKeyInfo), index)
+	key, err := subtle.ComputeHKDF("SHA256", dek, nil, info, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkAssociatedData(index uint64, last bool) []byte {
+	ad := binary.BigEndian.AppendUint64(make([]byte, 0, 9), index)
+	if last {
+		return append(ad, 1)
+	}
+	return append(ad, 0)
+}
+
+var zeroChunkNonce = make([]byte, 12)
+
+// NewEncryptingWriter returns a writer that encrypts data written to it and
+// writes the ciphertext to w. The header is written to w immediately.
+//
+// Close must be called to write the final chunk; the ciphertext is invalid
+// otherwise. Close does not close w.
+func (s *SeekableAEAD) NewEncryptingWriter(w io.Writer, associatedData []byte) (io.WriteCloser, error) {
+	dek := random.GetRandomBytes(seekableDEKSize)
+	header, err := s.aead.Encrypt(dek, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("aead.SeekableAEAD: %v", err)
+	}
+	if uint64(len(header)) > uint64(^uint32(0)) {
+		return nil, errors.New("aead.SeekableAEAD: header too long")
+	}
+	out := binary.BigEndian.AppendUint32(make([]byte, 0, seekableHeaderLengthSize+len(header)), uint32(len(header)))
+	out = append(out, header...)
+	if _, err := w.Write(out); err != nil {
+		return nil, err
+	}
+	return &seekableWriter{
+		w:         w,
+		dek:       dek,
+		chunkSize: s.chunkSize,
+		buf:       make([]byte, 0, s.chunkSize),
+	}, nil
+}
+
+type seekableWriter struct {
+	w         io.Writer
+	dek       []byte
+	chunkSize int
+	buf       []byte
+	index     uint64
+	closed    bool
+}
+
+// Write encrypts p. A full chunk is only written once more data arrives, since
+// the final chunk must be marked as such.
+func (sw *seekableWriter) Write(p []byte) (int, error) {
+	if sw.closed {
+		return 0, errors.New("aead.SeekableAEAD: write on closed writer")
+	}
+	n := 0
+	for len(p) > 0 {
+		if len(sw.buf) == sw.chunkSize {
+			if err := sw.writeChunk(false); err != nil {
+				return n, err
+			}
+		}
+		m := min(len(p), sw.chunkSize-len(sw.buf))
+		sw.buf = append(sw.buf, p[:m]...)
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+func (sw *seekableWriter) writeChunk(last bool) error {
+	c, err := chunkCipher(sw.dek, sw.index)
+	if err != nil {
+		return fmt.Errorf("aead.SeekableAEAD: %v", err)
+	}
+	ct := c.Seal(nil, zeroChunkNonce, sw.buf, chunkAssociatedData(sw.index, last))
+	if _, err := sw.w.Write(ct); err != nil {
+		return err
+	}
+	sw.index++
+	sw.buf = sw.buf[:0]
+	return nil
+}
+
+// Close writes the final chunk.
+func (sw *seekableWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	return sw.writeChunk(true)
+}
+
+// SeekableReaderAt decrypts arbitrary ranges of a ciphertext produced by
+// [SeekableAEAD]. It is safe for concurrent use if the underlying
+// [io.ReaderAt] is.
+type SeekableReaderAt struct {
+	r         io.ReaderAt
+	dek       []byte
+	chunkSize int64
+	// bodyOffset is the offset of the first chunk in the ciphertext.
+	bodyOffset int64
+	numChunks  int64
+	size       int64
+}
+
+var _ io.ReaderAt = (*SeekableReaderAt)(nil)
+
+// NewDecryptingReaderAt returns a [SeekableReaderAt] that decrypts the
+// ciphertext of ciphertextSize bytes in r. The header is decrypted
+// immediately; chunks are decrypted when they are read.
+func (s *SeekableAEAD) NewDecryptingReaderAt(r io.ReaderAt, ciphertextSize int64, associatedData []byte) (*SeekableReaderAt, error) {
+	var lengthBytes [seekableHeaderLengthSize]byte
+	if _, err := r.ReadAt(lengthBytes[:], 0); err != nil {
+		return nil, fmt.Errorf("aead.SeekableAEAD: cannot read header length: %v", err)
+	}
+	headerLength := int64(binary.BigEndian.Uint32(lengthBytes[:]))
+	bodyOffset := seekableHeaderLengthSize + headerLength
+	if bodyOffset > ciphertextSize {
+		return nil, errors.New("aead.SeekableAEAD: ciphertext too short")
+	}
+	header := make([]byte, headerLength)
+	if _, err := r.ReadAt(header, seekableHeaderLengthSize); err != nil {
+		return nil, fmt.Errorf("aead.SeekableAEAD: cannot read header: %v", err)
+	}
+	dek, err := s.aead.Decrypt(header, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("aead.SeekableAEAD: cannot decrypt header: %v", err)
+	}
+	if len(dek) != seekableDEKSize {
+		return nil, errors.New("aead.SeekableAEAD: invalid header")
+	}
+
+	bodySize := ciphertextSize - bodyOffset
+	chunkSize := int64(s.chunkSize)
+	encryptedChunkSize := chunkSize + seekableChunkTagSize
+	numChunks := (bodySize + encryptedChunkSize - 1) / encryptedChunkSize
+	if numChunks == 0 {
+		return nil, errors.New("aead.SeekableAEAD: ciphertext has no chunks")
+	}
+	lastChunkSize := bodySize - (numChunks-1)*encryptedChunkSize - seekableChunkTagSize
+	if lastChunkSize < 0 || (lastChunkSize == 0 && numChunks > 1) {
+		return nil, errors.New("aead.SeekableAEAD: invalid ciphertext size")
+	}
+	return &SeekableReaderAt{
+		r:          r,
+		dek:        dek,
+		chunkSize:  chunkSize,
+		bodyOffset: bodyOffset,
+		numChunks:  numChunks,
+		size:       (numChunks-1)*chunkSize + lastChunkSize,
+	}, nil
+}
+
+// Size returns the size of the plaintext.
+func (sr *SeekableReaderAt) Size() int64 { return sr.size }
+
+// decryptChunk reads and decrypts chunk index.
+func (sr *SeekableReaderAt) decryptChunk(index int64) ([]byte, error) {
+	last := index == sr.numChunks-1
+	plaintextSize := sr.chunkSize
+	if last {
+		plaintextSize = sr.size - index*sr.chunkSize
+	}
+	ct := make([]byte, plaintextSize+seekableChunkTagSize)
+	if _, err := sr.r.ReadAt(ct, sr.bodyOffset+index*(sr.chunkSize+seekableChunkTagSize)); err != nil {
+		return nil, fmt.Errorf("aead.SeekableAEAD: cannot read chunk %d: %v", index, err)
+	}
+	c, err := chunkCipher(sr.dek, uint64(index))
+	if err != nil {
+		return nil, fmt.Errorf("aead.SeekableAEAD: %v", err)
+	}
+	pt, err := c.Open(ct[:0], zeroChunkNonce, ct, chunkAssociatedData(uint64(index), last))
+	if err != nil {
+		return nil, fmt.Errorf("aead.SeekableAEAD: chunk %d is invalid", index)
+	}
+	return pt, nil
+}
+
+// ReadAt decrypts len(p) bytes of plaintext starting at offset off into p.
+// Only the chunks overlapping the requested range are read and
+// authenticated.
+//
+// As required by [io.ReaderAt], it returns a non-nil error whenever it reads
+// fewer than len(p) bytes; the error is [io.EOF] if the end of the plaintext
+// was reached.
+func (sr *SeekableReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("aead.SeekableAEAD: negative offset")
+	}
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= sr.size {
+			return n, io.EOF
+		}
+		index := pos / sr.chunkSize
+		pt, err := sr.decryptChunk(index)
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:], pt[pos-index*sr.chunkSize:])
+	}
+	return n, nil
+}
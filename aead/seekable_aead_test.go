@@ -0,0 +1,196 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aead_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+)
+
+func newSeekableAEAD(t *testing.T, chunkSize int) *aead.SeekableAEAD {
+	t.Helper()
+	handle, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	s, err := aead.NewSeekableAEAD(handle, chunkSize)
+	if err != nil {
+		t.Fatalf("aead.NewSeekableAEAD() err = %v, want nil", err)
+	}
+	return s
+}
+
+func seekableEncrypt(t *testing.T, s *aead.SeekableAEAD, plaintext, associatedData []byte) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w, err := s.NewEncryptingWriter(buf, associatedData)
+	if err != nil {
+		t.Fatalf("s.NewEncryptingWriter() err = %v, want nil", err)
+	}
+	// Write in uneven pieces to exercise buffering.
+	for len(plaintext) > 0 {
+		n := min(len(plaintext), 7)
+		if _, err := w.Write(plaintext[:n]); err != nil {
+			t.Fatalf("w.Write() err = %v, want nil", err)
+		}
+		plaintext = plaintext[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("w.Close() err = %v, want nil", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSeekableAEADEncryptDecrypt(t *testing.T) {
+	associatedData := []byte("associated data")
+	for _, chunkSize := range []int{1, 16, 100} {
+		for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize, 5*chunkSize + 3} {
+			t.Run(fmt.Sprintf("chunk_%d_size_%d", chunkSize, size), func(t *testing.T) {
+				s := newSeekableAEAD(t, chunkSize)
+				plaintext := random.GetRandomBytes(uint32(size))
+				ct := seekableEncrypt(t, s, plaintext, associatedData)
+				r, err := s.NewDecryptingReaderAt(bytes.NewReader(ct), int64(len(ct)), associatedData)
+				if err != nil {
+					t.Fatalf("s.NewDecryptingReaderAt() err = %v, want nil", err)
+				}
+				if got, want := r.Size(), int64(size); got != want {
+					t.Errorf("r.Size() = %d, want %d", got, want)
+				}
+				got, err := io.ReadAll(io.NewSectionReader(r, 0, r.Size()))
+				if err != nil {
+					t.Fatalf("io.ReadAll() err = %v, want nil", err)
+				}
+				if !bytes.Equal(got, plaintext) {
+					t.Errorf("decrypted = %x, want %x", got, plaintext)
+				}
+				// Every range.
+				for off := 0; off <= size; off++ {
+					for end := off; end <= size; end++ {
+						p := make([]byte, end-off)
+						n, err := r.ReadAt(p, int64(off))
+						if err != nil || n != len(p) {
+							t.Fatalf("r.ReadAt(len=%d, off=%d) = %d, %v, want %d, nil", len(p), off, n, err, len(p))
+						}
+						if !bytes.Equal(p, plaintext[off:end]) {
+							t.Fatalf("r.ReadAt(len=%d, off=%d) = %x, want %x", len(p), off, p, plaintext[off:end])
+						}
+					}
+				}
+				// Reading past the end.
+				p := make([]byte, 2)
+				n, err := r.ReadAt(p, int64(size)-1)
+				if size > 0 && (n != 1 || err != io.EOF) {
+					t.Errorf("r.ReadAt() past end = %d, %v, want 1, io.EOF", n, err)
+				}
+			})
+		}
+	}
+}
+
+func TestSeekableAEADDetectsTampering(t *testing.T) {
+	const chunkSize = 16
+	const encryptedChunkSize = chunkSize + 16
+	associatedData := []byte("associated data")
+	s := newSeekableAEAD(t, chunkSize)
+	plaintext := random.GetRandomBytes(4*chunkSize + 5)
+	ct := seekableEncrypt(t, s, plaintext, associatedData)
+	bodyOffset := 4 + int(binary.BigEndian.Uint32(ct))
+	chunk := func(i int) []byte {
+		return ct[bodyOffset+i*encryptedChunkSize : min(len(ct), bodyOffset+(i+1)*encryptedChunkSize)]
+	}
+
+	swapped := bytes.Clone(ct[:bodyOffset])
+	swapped = append(swapped, chunk(1)...)
+	swapped = append(swapped, chunk(0)...)
+	swapped = append(swapped, ct[bodyOffset+2*encryptedChunkSize:]...)
+
+	modified := bytes.Clone(ct)
+	modified[bodyOffset+2*encryptedChunkSize+3] ^= 1
+
+	// Truncation at a chunk boundary leaves a well-formed sequence of chunks,
+	// but the new last chunk is not marked as final.
+	truncated := bytes.Clone(ct[:bodyOffset+3*encryptedChunkSize])
+
+	otherCT := seekableEncrypt(t, s, plaintext, associatedData)
+	mixed := bytes.Clone(ct[:bodyOffset+encryptedChunkSize])
+	mixed = append(mixed, otherCT[bodyOffset+encryptedChunkSize:]...)
+
+	for _, tc := range []struct {
+		name string
+		ct   []byte
+	}{
+		{"swapped chunks", swapped},
+		{"modified chunk", modified},
+		{"truncated at chunk boundary", truncated},
+		{"chunks from another ciphertext", mixed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := s.NewDecryptingReaderAt(bytes.NewReader(tc.ct), int64(len(tc.ct)), associatedData)
+			if err != nil {
+				return
+			}
+			if _, err := io.ReadAll(io.NewSectionReader(r, 0, r.Size())); err == nil {
+				t.Error("reading tampered ciphertext err = nil, want error")
+			}
+		})
+	}
+
+	// Tampering only affects the chunks that are read.
+	r, err := s.NewDecryptingReaderAt(bytes.NewReader(modified), int64(len(modified)), associatedData)
+	if err != nil {
+		t.Fatalf("s.NewDecryptingReaderAt() err = %v, want nil", err)
+	}
+	p := make([]byte, chunkSize)
+	if _, err := r.ReadAt(p, 0); err != nil {
+		t.Errorf("r.ReadAt() of untouched chunk err = %v, want nil", err)
+	}
+	if _, err := r.ReadAt(p, 2*chunkSize); err == nil {
+		t.Error("r.ReadAt() of modified chunk err = nil, want error")
+	}
+}
+
+func TestSeekableAEADFailsWithWrongAssociatedDataOrKey(t *testing.T) {
+	s := newSeekableAEAD(t, 32)
+	ct := seekableEncrypt(t, s, []byte("some plaintext"), []byte("associated data"))
+	if _, err := s.NewDecryptingReaderAt(bytes.NewReader(ct), int64(len(ct)), []byte("other data")); err == nil {
+		t.Error("s.NewDecryptingReaderAt() with wrong associated data err = nil, want error")
+	}
+	other := newSeekableAEAD(t, 32)
+	if _, err := other.NewDecryptingReaderAt(bytes.NewReader(ct), int64(len(ct)), []byte("associated data")); err == nil {
+		t.Error("other.NewDecryptingReaderAt() err = nil, want error")
+	}
+	if _, err := s.NewDecryptingReaderAt(bytes.NewReader(ct[:3]), 3, []byte("associated data")); err == nil {
+		t.Error("s.NewDecryptingReaderAt() with short ciphertext err = nil, want error")
+	}
+}
+
+func TestNewSeekableAEADInvalidChunkSize(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	for _, chunkSize := range []int{-1, 0, aead.MaxSeekableChunkSize + 1} {
+		if _, err := aead.NewSeekableAEAD(handle, chunkSize); err == nil {
+			t.Errorf("aead.NewSeekableAEAD(chunkSize = %d) err = nil, want error", chunkSize)
+		}
+	}
+}
@@ -15,6 +15,8 @@
 package subtle
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"fmt"
 
 	"github.com/tink-crypto/tink-go/v2/aead/aesgcm"
@@ -32,11 +34,17 @@ const (
 	maxIntPlaintextSize = maxInt - AESGCMIVSize - AESGCMTagSize
 )
 
+// ErrCiphertextTooShort is returned by [AESGCM.Decrypt] if the ciphertext is
+// shorter than [AESGCMIVSize] + [AESGCMTagSize] bytes, and so cannot contain
+// a valid IV and tag. It is the same error as [aesgcm.ErrCiphertextTooShort].
+var ErrCiphertextTooShort = aesgcm.ErrCiphertextTooShort
+
 // AESGCM is an implementation of the [tink.AEAD] interface.
 //
 // This primitive adds no prefix to the ciphertext.
 type AESGCM struct {
 	aeadImpl tink.AEAD
+	gcm      cipher.AEAD
 }
 
 // NewAESGCM returns an [*AESGCM] value from the given key.
@@ -62,7 +70,15 @@ func NewAESGCM(key []byte) (*AESGCM, error) {
 	if err != nil {
 		return nil, fmt.Errorf("subtle.NewAESGCM: %v", err)
 	}
-	return &AESGCM{aeadImpl: aead}, nil
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("subtle.NewAESGCM: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("subtle.NewAESGCM: %v", err)
+	}
+	return &AESGCM{aeadImpl: aead, gcm: gcm}, nil
 }
 
 // Encrypt encrypts the plaintext with the associated data.
@@ -71,6 +87,58 @@ func (a *AESGCM) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
 }
 
 // Decrypt decrypts the ciphertext with the associated data.
+//
+// If the ciphertext is too short to contain an IV and a tag, the returned
+// error wraps [ErrCiphertextTooShort].
 func (a *AESGCM) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	if len(ciphertext) < AESGCMIVSize+AESGCMTagSize {
+		return nil, fmt.Errorf("AESGCM: %w: got %d bytes, want at least %d", ErrCiphertextTooShort, len(ciphertext), AESGCMIVSize+AESGCMTagSize)
+	}
 	return a.aeadImpl.Decrypt(ciphertext, associatedData)
 }
+
+// EncryptDetached encrypts the plaintext with the associated data using the
+// given nonce, and returns the ciphertext and the authentication tag
+// separately. Unlike [AESGCM.Encrypt], the nonce is not included in the output.
+//
+// The nonce must be [AESGCMIVSize] bytes long and is managed by the caller:
+// it must never be reused with the same key, otherwise both confidentiality
+// and authenticity are lost. Only use this for protocols that transmit the
+// nonce and tag separately from the ciphertext.
+func (a *AESGCM) EncryptDetached(nonce, plaintext, associatedData []byte) (ciphertext, tag []byte, err error) {
+	if len(nonce) != AESGCMIVSize {
+		return nil, nil, fmt.Errorf("AESGCM: invalid nonce length: got %d, want %d", len(nonce), AESGCMIVSize)
+	}
+	if len(plaintext) > maxIntPlaintextSize {
+		return nil, nil, fmt.Errorf("AESGCM: plaintext too long")
+	}
+	out := a.gcm.Seal(nil, nonce, plaintext, associatedData)
+	ciphertextSize := len(out) - AESGCMTagSize
+	return out[:ciphertextSize:ciphertextSize], out[ciphertextSize:], nil
+}
+
+// DecryptDetached decrypts the ciphertext with the associated data using the
+// given nonce, and verifies it against the given authentication tag. It is
+// the inverse of [AESGCM.EncryptDetached].
+//
+// The nonce must be [AESGCMIVSize] bytes long and the tag must be
+// [AESGCMTagSize] bytes long.
+func (a *AESGCM) DecryptDetached(nonce, ciphertext, tag, associatedData []byte) ([]byte, error) {
+	if len(nonce) != AESGCMIVSize {
+		return nil, fmt.Errorf("AESGCM: invalid nonce length: got %d, want %d", len(nonce), AESGCMIVSize)
+	}
+	if len(tag) != AESGCMTagSize {
+		return nil, fmt.Errorf("AESGCM: invalid tag length: got %d, want %d", len(tag), AESGCMTagSize)
+	}
+	if len(ciphertext) > maxIntPlaintextSize {
+		return nil, fmt.Errorf("AESGCM: ciphertext too long")
+	}
+	sealed := make([]byte, 0, len(ciphertext)+AESGCMTagSize)
+	sealed = append(sealed, ciphertext...)
+	sealed = append(sealed, tag...)
+	plaintext, err := a.gcm.Open(nil, nonce, sealed, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("AESGCM: decryption failed")
+	}
+	return plaintext, nil
+}
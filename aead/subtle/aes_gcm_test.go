@@ -17,6 +17,7 @@ package subtle_test
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -166,6 +167,25 @@ func TestAESGCMModifyCiphertext(t *testing.T) {
 	}
 }
 
+func TestAESGCMDecryptReturnsErrCiphertextTooShort(t *testing.T) {
+	a, err := subtle.NewAESGCM(random.GetRandomBytes(16))
+	if err != nil {
+		t.Fatalf("subtle.NewAESGCM() err = %v, want nil", err)
+	}
+	for _, size := range []int{0, subtle.AESGCMIVSize, subtle.AESGCMIVSize + subtle.AESGCMTagSize - 1} {
+		if _, err := a.Decrypt(make([]byte, size), nil); !errors.Is(err, subtle.ErrCiphertextTooShort) {
+			t.Errorf("a.Decrypt() with %d-byte ciphertext err = %v, want %v", size, err, subtle.ErrCiphertextTooShort)
+		}
+	}
+	_, err = a.Decrypt(make([]byte, subtle.AESGCMIVSize+subtle.AESGCMTagSize), nil)
+	if err == nil {
+		t.Fatalf("a.Decrypt() err = nil, want error")
+	}
+	if errors.Is(err, subtle.ErrCiphertextTooShort) {
+		t.Errorf("a.Decrypt() err = %v, want an error other than %v", err, subtle.ErrCiphertextTooShort)
+	}
+}
+
 /**
  * This is a very simple test for the randomness of the nonce.
  * The test simply checks that the multiple ciphertexts of the same
@@ -239,3 +259,108 @@ func runAESGCMWycheproofCase(t *testing.T, tc *aead.WycheproofCase) {
 		}
 	}
 }
+
+func TestAESGCMDetachedEncryptDecrypt(t *testing.T) {
+	for _, keySize := range aesKeySizes {
+		a, err := subtle.NewAESGCM(random.GetRandomBytes(keySize))
+		if err != nil {
+			t.Fatalf("subtle.NewAESGCM() err = %q, want nil", err)
+		}
+		ad := random.GetRandomBytes(5)
+		for ptSize := 0; ptSize < 75; ptSize++ {
+			pt := random.GetRandomBytes(uint32(ptSize))
+			nonce := random.GetRandomBytes(subtle.AESGCMIVSize)
+			ct, tag, err := a.EncryptDetached(nonce, pt, ad)
+			if err != nil {
+				t.Fatalf("a.EncryptDetached() err = %q, want nil", err)
+			}
+			if len(ct) != len(pt) {
+				t.Errorf("len(ct) = %d, want %d", len(ct), len(pt))
+			}
+			if len(tag) != subtle.AESGCMTagSize {
+				t.Errorf("len(tag) = %d, want %d", len(tag), subtle.AESGCMTagSize)
+			}
+			decrypted, err := a.DecryptDetached(nonce, ct, tag, ad)
+			if err != nil {
+				t.Fatalf("a.DecryptDetached() err = %q, want nil", err)
+			}
+			if !bytes.Equal(pt, decrypted) {
+				t.Errorf("a.DecryptDetached() = %x, want %x", decrypted, pt)
+			}
+			// The detached output is the attached output without the nonce.
+			attached, err := a.Decrypt(append(append(append([]byte{}, nonce...), ct...), tag...), ad)
+			if err != nil {
+				t.Fatalf("a.Decrypt() err = %q, want nil", err)
+			}
+			if !bytes.Equal(pt, attached) {
+				t.Errorf("a.Decrypt() = %x, want %x", attached, pt)
+			}
+		}
+	}
+}
+
+func TestAESGCMDetachedKnownAnswer(t *testing.T) {
+	// Test Case 3 from "The Galois/Counter Mode of Operation (GCM)", McGrew and
+	// Viega.
+	key, _ := hex.DecodeString("feffe9928665731c6d6a8f9467308308")
+	nonce, _ := hex.DecodeString("cafebabefacedbaddecaf888")
+	pt, _ := hex.DecodeString("d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a721c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b391aafd255")
+	wantCT, _ := hex.DecodeString("42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091473f5985")
+	wantTag, _ := hex.DecodeString("4d5c2af327cd64a62cf35abd2ba6fab4")
+	a, err := subtle.NewAESGCM(key)
+	if err != nil {
+		t.Fatalf("subtle.NewAESGCM() err = %q, want nil", err)
+	}
+	ct, tag, err := a.EncryptDetached(nonce, pt, nil)
+	if err != nil {
+		t.Fatalf("a.EncryptDetached() err = %q, want nil", err)
+	}
+	if !bytes.Equal(ct, wantCT) {
+		t.Errorf("ct = %x, want %x", ct, wantCT)
+	}
+	if !bytes.Equal(tag, wantTag) {
+		t.Errorf("tag = %x, want %x", tag, wantTag)
+	}
+}
+
+func TestAESGCMDetachedDecryptFailures(t *testing.T) {
+	a, err := subtle.NewAESGCM(random.GetRandomBytes(16))
+	if err != nil {
+		t.Fatalf("subtle.NewAESGCM() err = %q, want nil", err)
+	}
+	ad := []byte("associated data")
+	nonce := random.GetRandomBytes(subtle.AESGCMIVSize)
+	ct, tag, err := a.EncryptDetached(nonce, []byte("plaintext"), ad)
+	if err != nil {
+		t.Fatalf("a.EncryptDetached() err = %q, want nil", err)
+	}
+	modifiedTag := bytes.Clone(tag)
+	modifiedTag[0] ^= 1
+	modifiedCT := bytes.Clone(ct)
+	modifiedCT[0] ^= 1
+	for _, tc := range []struct {
+		name  string
+		nonce []byte
+		ct    []byte
+		tag   []byte
+		ad    []byte
+	}{
+		{"short nonce", nonce[:subtle.AESGCMIVSize-1], ct, tag, ad},
+		{"long nonce", append(bytes.Clone(nonce), 0), ct, tag, ad},
+		{"truncated tag", nonce, ct, tag[:subtle.AESGCMTagSize-4], ad},
+		{"long tag", nonce, ct, append(bytes.Clone(tag), 0), ad},
+		{"empty tag", nonce, ct, nil, ad},
+		{"modified tag", nonce, ct, modifiedTag, ad},
+		{"modified ciphertext", nonce, modifiedCT, tag, ad},
+		{"wrong associated data", nonce, ct, tag, []byte("other data")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := a.DecryptDetached(tc.nonce, tc.ct, tc.tag, tc.ad); err == nil {
+				t.Error("a.DecryptDetached() err = nil, want error")
+			}
+		})
+	}
+	if _, _, err := a.EncryptDetached(nonce[:8], []byte("plaintext"), ad); err == nil {
+		t.Error("a.EncryptDetached() with short nonce err = nil, want error")
+	}
+}
@@ -23,9 +23,14 @@ import (
 	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
+// errDecryptFailed is the only error returned by Decrypt of an
+// XChaCha20Poly1305 created with NewXChaCha20Poly1305Opaque.
+var errDecryptFailed = errors.New("xchacha20poly1305: decryption failed")
+
 // XChaCha20Poly1305 is an implementation of AEAD interface.
 type XChaCha20Poly1305 struct {
-	key []byte
+	key    []byte
+	opaque bool
 }
 
 // Assert that XChaCha20Poly1305 implements the AEAD interface.
@@ -41,12 +46,39 @@ func NewXChaCha20Poly1305(key []byte) (*XChaCha20Poly1305, error) {
 	return &XChaCha20Poly1305{key: key}, nil
 }
 
+// NewXChaCha20Poly1305Opaque returns an XChaCha20Poly1305 instance whose
+// Decrypt returns the same error whatever the reason of the failure, e.g. a
+// ciphertext that is too short or that fails authentication.
+// The key argument should be a 32-bytes key.
+func NewXChaCha20Poly1305Opaque(key []byte) (*XChaCha20Poly1305, error) {
+	x, err := NewXChaCha20Poly1305(key)
+	if err != nil {
+		return nil, err
+	}
+	x.opaque = true
+	return x, nil
+}
+
 // Encrypt encrypts plaintext with associatedData.
 //
 // The resulting ciphertext consists of two parts:
 //  1. the nonce used for encryption
 //  2. the actual ciphertext
 func (x *XChaCha20Poly1305) Encrypt(plaintext []byte, associatedData []byte) ([]byte, error) {
+	return x.EncryptWithNonce(plaintext, associatedData, random.GetRandomBytes(chacha20poly1305.NonceSizeX))
+}
+
+// EncryptWithNonce encrypts plaintext with associatedData using the given
+// nonce, which must be chacha20poly1305.NonceSizeX bytes long. The output has
+// the same format as that of Encrypt.
+//
+// The caller is responsible for never reusing a nonce with the same key.
+// Unless the protocol manages its own nonces, or to produce test vectors, use
+// Encrypt instead.
+func (x *XChaCha20Poly1305) EncryptWithNonce(plaintext, associatedData, nonce []byte) ([]byte, error) {
+	if len(nonce) != chacha20poly1305.NonceSizeX {
+		return nil, fmt.Errorf("xchacha20poly1305: bad nonce length %d, want %d", len(nonce), chacha20poly1305.NonceSizeX)
+	}
 	if len(plaintext) > maxInt-chacha20poly1305.NonceSizeX-chacha20poly1305.Overhead {
 		return nil, fmt.Errorf("xchacha20poly1305: plaintext too long")
 	}
@@ -55,12 +87,11 @@ func (x *XChaCha20Poly1305) Encrypt(plaintext []byte, associatedData []byte) ([]
 		return nil, err
 	}
 
-	nounce := random.GetRandomBytes(chacha20poly1305.NonceSizeX)
-	// Make the capacity of dst large enough so that both the nounce and the ciphertext fit inside.
+	// Make the capacity of dst large enough so that both the nonce and the ciphertext fit inside.
 	dst := make([]byte, 0, chacha20poly1305.NonceSizeX+len(plaintext)+c.Overhead())
-	dst = append(dst, nounce...)
-	// Seal appends the ciphertext to dst. So the final output is: nounce || ciphertext.
-	return c.Seal(dst, nounce, plaintext, associatedData), nil
+	dst = append(dst, nonce...)
+	// Seal appends the ciphertext to dst. So the final output is: nonce || ciphertext.
+	return c.Seal(dst, nonce, plaintext, associatedData), nil
 }
 
 // Decrypt decrypts ciphertext with associatedData.
@@ -69,6 +100,14 @@ func (x *XChaCha20Poly1305) Encrypt(plaintext []byte, associatedData []byte) ([]
 //  1. the nonce used for encryption
 //  2. the actual ciphertext
 func (x *XChaCha20Poly1305) Decrypt(ciphertext []byte, associatedData []byte) ([]byte, error) {
+	pt, err := x.decrypt(ciphertext, associatedData)
+	if err != nil && x.opaque {
+		return nil, errDecryptFailed
+	}
+	return pt, err
+}
+
+func (x *XChaCha20Poly1305) decrypt(ciphertext []byte, associatedData []byte) ([]byte, error) {
 	if len(ciphertext) < chacha20poly1305.NonceSizeX+chacha20poly1305.Overhead {
 		return nil, fmt.Errorf("xchacha20poly1305: ciphertext too short")
 	}
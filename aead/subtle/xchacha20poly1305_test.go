@@ -261,6 +261,16 @@ func runXChaCha20Poly1305WycheproofCase(t *testing.T, tc *aead.WycheproofCase) {
 		t.Fatalf("unexpected encryption error: %s", err)
 	}
 
+	if tc.Result == "valid" {
+		ct, err := ca.EncryptWithNonce(tc.Msg, tc.Aad, tc.Iv)
+		if err != nil {
+			t.Fatalf("ca.EncryptWithNonce() err = %v, want nil", err)
+		}
+		if !bytes.Equal(ct, combinedCt) {
+			t.Errorf("ca.EncryptWithNonce() = %x, want %x", ct, combinedCt)
+		}
+	}
+
 	decrypted, err := ca.Decrypt(combinedCt, tc.Aad)
 	if err != nil {
 		if tc.Result == "valid" {
@@ -297,3 +307,107 @@ func TestPreallocatedCiphertextMemoryInXChaCha20Poly1305IsExact(t *testing.T) {
 		t.Errorf("want len(ciphertext) == cap(ciphertext), got %d != %d", len(ciphertext), cap(ciphertext))
 	}
 }
+
+func TestXChaCha20Poly1305EncryptWithNonce(t *testing.T) {
+	key := random.GetRandomBytes(chacha20poly1305.KeySize)
+	ca, err := subtle.NewXChaCha20Poly1305(key)
+	if err != nil {
+		t.Fatalf("subtle.NewXChaCha20Poly1305() err = %v, want nil", err)
+	}
+	plaintext := random.GetRandomBytes(32)
+	associatedData := random.GetRandomBytes(16)
+	nonce := random.GetRandomBytes(chacha20poly1305.NonceSizeX)
+
+	ct1, err := ca.EncryptWithNonce(plaintext, associatedData, nonce)
+	if err != nil {
+		t.Fatalf("ca.EncryptWithNonce() err = %v, want nil", err)
+	}
+	ct2, err := ca.EncryptWithNonce(plaintext, associatedData, nonce)
+	if err != nil {
+		t.Fatalf("ca.EncryptWithNonce() err = %v, want nil", err)
+	}
+	if !bytes.Equal(ct1, ct2) {
+		t.Errorf("ca.EncryptWithNonce() is not deterministic: %x != %x", ct1, ct2)
+	}
+	if !bytes.HasPrefix(ct1, nonce) {
+		t.Errorf("ca.EncryptWithNonce() = %x, want prefix %x", ct1, nonce)
+	}
+	pt, err := ca.Decrypt(ct1, associatedData)
+	if err != nil {
+		t.Fatalf("ca.Decrypt() err = %v, want nil", err)
+	}
+	if !bytes.Equal(pt, plaintext) {
+		t.Errorf("ca.Decrypt() = %x, want %x", pt, plaintext)
+	}
+
+	for _, nonceSize := range []int{0, chacha20poly1305.NonceSize, chacha20poly1305.NonceSizeX - 1, chacha20poly1305.NonceSizeX + 1} {
+		if _, err := ca.EncryptWithNonce(plaintext, associatedData, make([]byte, nonceSize)); err == nil {
+			t.Errorf("ca.EncryptWithNonce() with %d-byte nonce err = nil, want error", nonceSize)
+		}
+	}
+}
+
+func TestXChaCha20Poly1305OpaqueDecryptErrors(t *testing.T) {
+	key := random.GetRandomBytes(chacha20poly1305.KeySize)
+	ca, err := subtle.NewXChaCha20Poly1305Opaque(key)
+	if err != nil {
+		t.Fatalf("subtle.NewXChaCha20Poly1305Opaque() err = %v, want nil", err)
+	}
+	plaintext := random.GetRandomBytes(32)
+	associatedData := random.GetRandomBytes(16)
+	ct, err := ca.Encrypt(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("ca.Encrypt() err = %v, want nil", err)
+	}
+	pt, err := ca.Decrypt(ct, associatedData)
+	if err != nil {
+		t.Fatalf("ca.Decrypt() err = %v, want nil", err)
+	}
+	if !bytes.Equal(pt, plaintext) {
+		t.Errorf("ca.Decrypt() = %x, want %x", pt, plaintext)
+	}
+
+	modified := append([]byte{}, ct...)
+	modified[len(modified)-1] ^= 1
+	_, tooShortErr := ca.Decrypt(ct[:chacha20poly1305.NonceSizeX+chacha20poly1305.Overhead-1], associatedData)
+	if tooShortErr == nil {
+		t.Fatalf("ca.Decrypt() with short ciphertext err = nil, want error")
+	}
+	for _, tc := range []struct {
+		name           string
+		ciphertext     []byte
+		associatedData []byte
+	}{
+		{
+			name:           "empty ciphertext",
+			ciphertext:     nil,
+			associatedData: associatedData,
+		},
+		{
+			name:           "modified ciphertext",
+			ciphertext:     modified,
+			associatedData: associatedData,
+		},
+		{
+			name:           "wrong associated data",
+			ciphertext:     ct,
+			associatedData: []byte("wrong"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ca.Decrypt(tc.ciphertext, tc.associatedData)
+			if err == nil {
+				t.Fatalf("ca.Decrypt() err = nil, want error")
+			}
+			if err != tooShortErr {
+				t.Errorf("ca.Decrypt() err = %v, want %v", err, tooShortErr)
+			}
+		})
+	}
+}
+
+func TestNewXChaCha20Poly1305OpaqueInvalidKey(t *testing.T) {
+	if _, err := subtle.NewXChaCha20Poly1305Opaque(make([]byte, 16)); err == nil {
+		t.Errorf("subtle.NewXChaCha20Poly1305Opaque() err = nil, want error")
+	}
+}
@@ -70,28 +70,30 @@ func (km *keyManager) Primitive(serializedKey []byte) (any, error) {
 	return ret, nil
 }
 
-// NewKey generates a new [aspb.AesSivKey]. serializedKeyFormat is optional
-// because there is only one valid key format.
+// NewKey generates a new [aspb.AesSivKey]. serializedKeyFormat is optional;
+// if it is nil, a key of size [subtle.AESSIVKeySize] is generated.
 func (km *keyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	keySize := uint32(subtle.AESSIVKeySize)
 	// A nil serializedKeyFormat is acceptable. If specified, validate.
 	if serializedKeyFormat != nil {
 		keyFormat := new(aspb.AesSivKeyFormat)
 		if err := proto.Unmarshal(serializedKeyFormat, keyFormat); err != nil {
 			return nil, fmt.Errorf("aes_siv_key_manager: %v", err)
 		}
-		if keyFormat.KeySize != subtle.AESSIVKeySize {
-			return nil, fmt.Errorf("aes_siv_key_manager: key size != %d", subtle.AESSIVKeySize)
+		if err := subtle.ValidateAESSIVKeySize(keyFormat.GetKeySize()); err != nil {
+			return nil, fmt.Errorf("aes_siv_key_manager: %v", err)
 		}
+		keySize = keyFormat.GetKeySize()
 	}
 	return &aspb.AesSivKey{
 		Version:  keyVersion,
-		KeyValue: random.GetRandomBytes(subtle.AESSIVKeySize),
+		KeyValue: random.GetRandomBytes(keySize),
 	}, nil
 }
 
-// NewKeyData generates a new KeyData. serializedKeyFormat is optional because
-// there is only one valid key format. This should be used solely by the key
-// management API.
+// NewKeyData generates a new KeyData. serializedKeyFormat is optional; if it
+// is nil, a key of size [subtle.AESSIVKeySize] is generated. This should be
+// used solely by the key management API.
 func (km *keyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
 	key, err := km.NewKey(serializedKeyFormat)
 	if err != nil {
@@ -130,14 +132,14 @@ func (km *keyManager) DeriveKey(serializedKeyFormat []byte, pseudorandomness io.
 	if err := proto.Unmarshal(serializedKeyFormat, keyFormat); err != nil {
 		return nil, fmt.Errorf("aes_siv_key_manager: %v", err)
 	}
-	if keyFormat.GetKeySize() != subtle.AESSIVKeySize {
-		return nil, fmt.Errorf("aes_siv_key_manager: key size != %d", subtle.AESSIVKeySize)
+	if err := subtle.ValidateAESSIVKeySize(keyFormat.GetKeySize()); err != nil {
+		return nil, fmt.Errorf("aes_siv_key_manager: %v", err)
 	}
 	if err := keyset.ValidateKeyVersion(keyFormat.GetVersion(), keyVersion); err != nil {
 		return nil, fmt.Errorf("aes_siv_key_manager: invalid key version: %s", err)
 	}
 
-	keyValue := make([]byte, subtle.AESSIVKeySize)
+	keyValue := make([]byte, keyFormat.GetKeySize())
 	if _, err := io.ReadFull(pseudorandomness, keyValue); err != nil {
 		return nil, fmt.Errorf("aes_siv_key_manager: not enough pseudorandomness given")
 	}
@@ -153,9 +155,8 @@ func (km *keyManager) validateKey(key *aspb.AesSivKey) error {
 	if err != nil {
 		return fmt.Errorf("aes_siv_key_manager: %v", err)
 	}
-	keySize := uint32(len(key.KeyValue))
-	if keySize != subtle.AESSIVKeySize {
-		return fmt.Errorf("aes_siv_key_manager: key size != %d", subtle.AESSIVKeySize)
+	if err := subtle.ValidateAESSIVKeySize(uint32(len(key.KeyValue))); err != nil {
+		return fmt.Errorf("aes_siv_key_manager: %v", err)
 	}
 	return nil
 }
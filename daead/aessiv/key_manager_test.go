@@ -84,7 +84,11 @@ func TestKeyManagerPrimitiveWithInvalidKeys(t *testing.T) {
 		},
 		&aspb.AesSivKey{
 			Version:  testutil.AESSIVKeyVersion,
-			KeyValue: random.GetRandomBytes(32),
+			KeyValue: random.GetRandomBytes(31),
+		},
+		&aspb.AesSivKey{
+			Version:  testutil.AESSIVKeyVersion,
+			KeyValue: random.GetRandomBytes(40),
 		},
 		&aspb.AesSivKey{
 			Version:  testutil.AESSIVKeyVersion,
@@ -129,6 +133,50 @@ func TestKeyManagerNewKey(t *testing.T) {
 	}
 }
 
+func TestKeyManagerNewKeyWithKeySize(t *testing.T) {
+	km, err := registry.GetKeyManager(testutil.AESSIVTypeURL)
+	if err != nil {
+		t.Fatalf("cannot obtain AESSIV key manager: %s", err)
+	}
+	for _, keySize := range []uint32{32, 48, 64} {
+		t.Run(fmt.Sprintf("%d", keySize), func(t *testing.T) {
+			keyFormat, err := proto.Marshal(&aspb.AesSivKeyFormat{
+				KeySize: keySize,
+				Version: testutil.AESSIVKeyVersion,
+			})
+			if err != nil {
+				t.Fatalf("proto.Marshal() err = %v, want nil", err)
+			}
+			m, err := km.NewKey(keyFormat)
+			if err != nil {
+				t.Fatalf("km.NewKey() err = %v, want nil", err)
+			}
+			key, ok := m.(*aspb.AesSivKey)
+			if !ok {
+				t.Fatalf("m is not *aspb.AesSivKey")
+			}
+			if got := uint32(len(key.GetKeyValue())); got != keySize {
+				t.Errorf("len(key.GetKeyValue()) = %d, want %d", got, keySize)
+			}
+			serializedKey, err := proto.Marshal(key)
+			if err != nil {
+				t.Fatalf("proto.Marshal() err = %v, want nil", err)
+			}
+			p, err := km.Primitive(serializedKey)
+			if err != nil {
+				t.Fatalf("km.Primitive() err = %v, want nil", err)
+			}
+			primitive, ok := p.(tink.DeterministicAEAD)
+			if !ok {
+				t.Fatalf("Primitive() = %T, want tink.DeterministicAEAD", p)
+			}
+			if err := encryptDecrypt(primitive, primitive); err != nil {
+				t.Errorf("encryptDecrypt() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
 func TestKeyManagerNewKeyData(t *testing.T) {
 	km, err := registry.GetKeyManager(testutil.AESSIVTypeURL)
 	if err != nil {
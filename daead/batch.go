@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daead
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchInput is a plaintext and its associated data, to be encrypted by
+// [BatchEncrypter.BatchEncryptDeterministically].
+type BatchInput struct {
+	Plaintext      []byte
+	AssociatedData []byte
+}
+
+// BatchEncrypter is implemented by the primitive returned by [New]. It
+// encrypts many plaintexts with the primary key in parallel.
+type BatchEncrypter interface {
+	// BatchEncryptDeterministically encrypts each input like
+	// EncryptDeterministically and returns the ciphertexts in the order of
+	// the inputs. The work is spread over up to GOMAXPROCS goroutines.
+	//
+	// If any encryption fails, it returns an error and no ciphertexts.
+	BatchEncryptDeterministically(inputs []BatchInput) ([][]byte, error)
+}
+
+var _ BatchEncrypter = (*wrappedDAEAD)(nil)
+
+// BatchEncryptDeterministically encrypts each input with the primary key and
+// returns the ciphertexts in the order of the inputs.
+func (d *wrappedDAEAD) BatchEncryptDeterministically(inputs []BatchInput) ([][]byte, error) {
+	ciphertexts := make([][]byte, len(inputs))
+	errs := make([]error, len(inputs))
+	// Workers take the next input index from next, so that the work is
+	// balanced when the inputs have different sizes.
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := min(runtime.GOMAXPROCS(0), len(inputs)); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(inputs) {
+					return
+				}
+				ciphertexts[i], errs[i] = d.EncryptDeterministically(inputs[i].Plaintext, inputs[i].AssociatedData)
+			}
+		}()
+	}
+	wg.Wait()
+	// Report the error of the first failed input, so that the result does
+	// not depend on scheduling.
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("daead_factory: input %d: %v", i, err)
+		}
+	}
+	return ciphertexts, nil
+}
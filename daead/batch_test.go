@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daead_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tink-crypto/tink-go/v2/daead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+func TestBatchEncryptDeterministically(t *testing.T) {
+	handle, err := keyset.NewHandle(daead.AESSIVKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	p, err := daead.New(handle)
+	if err != nil {
+		t.Fatalf("daead.New() err = %v, want nil", err)
+	}
+	batcher, ok := p.(daead.BatchEncrypter)
+	if !ok {
+		t.Fatal("daead.New() does not implement daead.BatchEncrypter")
+	}
+	for _, n := range []int{0, 1, 1000} {
+		t.Run(fmt.Sprintf("%d inputs", n), func(t *testing.T) {
+			inputs := make([]daead.BatchInput, n)
+			want := make([][]byte, n)
+			for i := range inputs {
+				// Use duplicate records, as when deduplicating.
+				inputs[i] = daead.BatchInput{
+					Plaintext:      []byte(fmt.Sprintf("record %d", i%100)),
+					AssociatedData: []byte("associated data"),
+				}
+				want[i], err = p.EncryptDeterministically(inputs[i].Plaintext, inputs[i].AssociatedData)
+				if err != nil {
+					t.Fatalf("p.EncryptDeterministically() err = %v, want nil", err)
+				}
+			}
+			got, err := batcher.BatchEncryptDeterministically(inputs)
+			if err != nil {
+				t.Fatalf("batcher.BatchEncryptDeterministically() err = %v, want nil", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("batcher.BatchEncryptDeterministically() diff (-want +got):\n%s", diff)
+			}
+			for i, ct := range got {
+				pt, err := p.DecryptDeterministically(ct, inputs[i].AssociatedData)
+				if err != nil {
+					t.Fatalf("p.DecryptDeterministically() err = %v, want nil", err)
+				}
+				if string(pt) != string(inputs[i].Plaintext) {
+					t.Errorf("p.DecryptDeterministically() = %q, want %q", pt, inputs[i].Plaintext)
+				}
+			}
+		})
+	}
+}
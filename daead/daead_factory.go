@@ -128,7 +128,7 @@ func newWrappedDeterministicAEAD(ps *primitiveset.PrimitiveSet[tink.Deterministi
 }
 
 func createLoggers(ps *primitiveset.PrimitiveSet[tink.DeterministicAEAD]) (monitoring.Logger, monitoring.Logger, error) {
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, &monitoringutil.DoNothingLogger{}, nil
 	}
 	client := internalregistry.GetMonitoringClient()
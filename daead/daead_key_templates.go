@@ -23,10 +23,35 @@ import (
 	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
 )
 
-// AESSIVKeyTemplate is a KeyTemplate that generates a AES-SIV key.
+// AESSIVKeyTemplate is a KeyTemplate that generates a 64-byte AES-SIV key,
+// which uses AES-256 (AEAD_AES_SIV_CMAC_512 in RFC 5297): the first 32 bytes
+// of the key are the AES-256 key of the S2V CMAC, and the last 32 bytes the
+// AES-256 key of the CTR encryption.
 func AESSIVKeyTemplate() *tinkpb.KeyTemplate {
+	return createAESSIVKeyTemplate(64)
+}
+
+// AES128SIVKeyTemplate is a KeyTemplate that generates a 32-byte AES-SIV key,
+// which uses AES-128 (AEAD_AES_SIV_CMAC_256 in RFC 5297).
+//
+// Prefer [AESSIVKeyTemplate] unless AES-128-SIV is required for
+// interoperability.
+func AES128SIVKeyTemplate() *tinkpb.KeyTemplate {
+	return createAESSIVKeyTemplate(32)
+}
+
+// AES192SIVKeyTemplate is a KeyTemplate that generates a 48-byte AES-SIV key,
+// which uses AES-192 (AEAD_AES_SIV_CMAC_384 in RFC 5297).
+//
+// Prefer [AESSIVKeyTemplate] unless AES-192-SIV is required for
+// interoperability.
+func AES192SIVKeyTemplate() *tinkpb.KeyTemplate {
+	return createAESSIVKeyTemplate(48)
+}
+
+func createAESSIVKeyTemplate(keySize uint32) *tinkpb.KeyTemplate {
 	format := &aspb.AesSivKeyFormat{
-		KeySize: 64,
+		KeySize: keySize,
 	}
 	serializedFormat, err := proto.Marshal(format)
 	if err != nil {
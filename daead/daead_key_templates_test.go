@@ -16,11 +16,15 @@ package daead_test
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
 	"github.com/tink-crypto/tink-go/v2/daead"
+	"github.com/tink-crypto/tink-go/v2/daead/aessiv"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
 	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/secretdata"
 
 	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
 )
@@ -32,6 +36,10 @@ func TestKeyTemplates(t *testing.T) {
 	}{
 		{name: "AES256_SIV",
 			template: daead.AESSIVKeyTemplate()},
+		{name: "AES128_SIV",
+			template: daead.AES128SIVKeyTemplate()},
+		{name: "AES192_SIV",
+			template: daead.AES192SIVKeyTemplate()},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -42,6 +50,70 @@ func TestKeyTemplates(t *testing.T) {
 	}
 }
 
+func TestAESSIVKeyTemplateUsesAES256(t *testing.T) {
+	handle, err := keyset.NewHandle(daead.AESSIVKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	entry, err := handle.Primary()
+	if err != nil {
+		t.Fatalf("handle.Primary() err = %v, want nil", err)
+	}
+	params, ok := entry.Key().Parameters().(*aessiv.Parameters)
+	if !ok {
+		t.Fatalf("entry.Key().Parameters() = %T, want *aessiv.Parameters", entry.Key().Parameters())
+	}
+	if got, want := params.KeySizeInBytes(), 64; got != want {
+		t.Errorf("params.KeySizeInBytes() = %d, want %d", got, want)
+	}
+
+	// AES-256-SIV test case from
+	// https://github.com/C2SP/wycheproof/blob/cd27d6419bedd83cbd24611ec54b6d4bfdb0cdca/testvectors/aes_siv_cmac_test.json#L2865.
+	keyBytes, err := hex.DecodeString("c25cafc6018b98dfbb79a40ec89c575a4f88c4116489bba27707479800c0130235334a45dbe8d8dae3da8dcb45bbe5dce031b0f68ded544fda7eca30d6749442")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() err = %v, want nil", err)
+	}
+	ad, err := hex.DecodeString("deeb0ccf3aef47a296ed1ca8f4ae5907")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() err = %v, want nil", err)
+	}
+	plaintext, err := hex.DecodeString("beec61030fa3d670337196beade6aeaa")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() err = %v, want nil", err)
+	}
+	wantCiphertext, err := hex.DecodeString("5865208eab9163db85cab9f96d846234a2626aae22f5c17c9aad4b501f4416e4")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() err = %v, want nil", err)
+	}
+	key, err := aessiv.NewKey(secretdata.NewBytesFromData(keyBytes, insecuresecretdataaccess.Token{}), entry.KeyID(), params)
+	if err != nil {
+		t.Fatalf("aessiv.NewKey() err = %v, want nil", err)
+	}
+	manager := keyset.NewManager()
+	keyID, err := manager.AddKey(key)
+	if err != nil {
+		t.Fatalf("manager.AddKey() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(keyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	vectorHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	primitive, err := daead.New(vectorHandle)
+	if err != nil {
+		t.Fatalf("daead.New() err = %v, want nil", err)
+	}
+	ciphertext, err := primitive.EncryptDeterministically(plaintext, ad)
+	if err != nil {
+		t.Fatalf("primitive.EncryptDeterministically() err = %v, want nil", err)
+	}
+	if got, want := ciphertext[len(key.OutputPrefix()):], wantCiphertext; !bytes.Equal(got, want) {
+		t.Errorf("primitive.EncryptDeterministically() = %x, want %x after the output prefix", got, want)
+	}
+}
+
 func testEncryptDecrypt(template *tinkpb.KeyTemplate) error {
 	handle, err := keyset.NewHandle(template)
 	if err != nil {
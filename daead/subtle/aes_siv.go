@@ -34,8 +34,9 @@ import (
 // https://tools.ietf.org/html/rfc5297.
 //
 // AESSIV implements a deterministic encryption with associated data (i.e. the
-// DeterministicAEAD interface). Hence the implementation below is restricted
-// to one AD component.
+// DeterministicAEAD interface), which has one AD component. Vectors of AD
+// components are supported by [AESSIV.EncryptDeterministicallyVector] and
+// [AESSIV.DecryptDeterministicallyVector].
 //
 // Security Note:
 //
@@ -48,30 +49,48 @@ import (
 // then it is possible  to find one of the MAC keys in time 2^b / k
 // where b is the size of the MAC key. A consequence of this attack
 // is that 128-bit MAC keys give unsufficient security.
-// Since 192-bit AES keys are not supported by tink for voodoo reasons
-// and RFC 5297 only supports same size encryption and MAC keys this
-// implies that keys must be 64 bytes (2*256 bits) long.
+// Since RFC 5297 only supports same size encryption and MAC keys this
+// implies that keys should be 64 bytes (2*256 bits) long. 32 and 48 byte
+// keys (AES-128 and AES-192) are supported for interoperability.
 type AESSIV struct {
 	k1, k2 []byte
 	cmac   *aescmac.CMAC
 }
 
 const (
-	// AESSIVKeySize is the key size in bytes.
+	// AESSIVKeySize is the recommended key size in bytes, for AES-256-SIV.
 	AESSIVKeySize = 64
 
+	// MaxAssociatedDataComponents is the maximum number of AD components
+	// accepted by EncryptDeterministicallyVector. S2V accepts at most 127
+	// inputs, the last of which is the plaintext.
+	MaxAssociatedDataComponents = 126
+
 	intSize = 32 << (^uint(0) >> 63) // 32 or 64
 	maxInt  = 1<<(intSize-1) - 1
 )
 
-// NewAESSIV returns an AESSIV instance.
+// ValidateAESSIVKeySize checks that sizeInBytes is a supported AES-SIV key
+// size: 32, 48 or 64 bytes, which use AES-128, AES-192 and AES-256
+// respectively.
+func ValidateAESSIVKeySize(sizeInBytes uint32) error {
+	switch sizeInBytes {
+	case 32, 48, AESSIVKeySize:
+		return nil
+	default:
+		return fmt.Errorf("invalid AES-SIV key size; want 32, 48, or 64, got %d", sizeInBytes)
+	}
+}
+
+// NewAESSIV returns an AESSIV instance. The key must be 32, 48 or 64 bytes
+// long; see [ValidateAESSIVKeySize].
 func NewAESSIV(key []byte) (*AESSIV, error) {
-	if len(key) != AESSIVKeySize {
-		return nil, fmt.Errorf("aes_siv: invalid key size %d", len(key))
+	if err := ValidateAESSIVKeySize(uint32(len(key))); err != nil {
+		return nil, fmt.Errorf("aes_siv: %v", err)
 	}
 
-	k1 := key[:32]
-	k2 := key[32:]
+	k1 := key[:len(key)/2]
+	k2 := key[len(key)/2:]
 
 	cmac, err := aescmac.New(k1)
 	if err != nil {
@@ -95,6 +114,24 @@ func multiplyByX(block []byte) {
 
 // EncryptDeterministically deterministically encrypts plaintext with associatedData.
 func (asc *AESSIV) EncryptDeterministically(plaintext, associatedData []byte) ([]byte, error) {
+	return asc.encrypt(plaintext, [][]byte{associatedData})
+}
+
+// EncryptDeterministicallyVector deterministically encrypts plaintext with a
+// vector of AD components, each of which is a separate S2V input as in
+// Section 2.6 of RFC 5297. This binds several fields without the ambiguity of
+// concatenating them.
+//
+// A vector with a single component is equivalent to EncryptDeterministically.
+// An empty vector is allowed, and differs from a single empty component.
+func (asc *AESSIV) EncryptDeterministicallyVector(plaintext []byte, associatedData [][]byte) ([]byte, error) {
+	if len(associatedData) > MaxAssociatedDataComponents {
+		return nil, fmt.Errorf("aes_siv: too many associated data components: got %d, want at most %d", len(associatedData), MaxAssociatedDataComponents)
+	}
+	return asc.encrypt(plaintext, associatedData)
+}
+
+func (asc *AESSIV) encrypt(plaintext []byte, associatedData [][]byte) ([]byte, error) {
 	if len(plaintext) > maxInt-aes.BlockSize {
 		return nil, fmt.Errorf("aes_siv: plaintext too long")
 	}
@@ -110,6 +147,19 @@ func (asc *AESSIV) EncryptDeterministically(plaintext, associatedData []byte) ([
 
 // DecryptDeterministically deterministically decrypts ciphertext with associatedData.
 func (asc *AESSIV) DecryptDeterministically(ciphertext, associatedData []byte) ([]byte, error) {
+	return asc.decrypt(ciphertext, [][]byte{associatedData})
+}
+
+// DecryptDeterministicallyVector deterministically decrypts ciphertext with a
+// vector of AD components, as encrypted by EncryptDeterministicallyVector.
+func (asc *AESSIV) DecryptDeterministicallyVector(ciphertext []byte, associatedData [][]byte) ([]byte, error) {
+	if len(associatedData) > MaxAssociatedDataComponents {
+		return nil, fmt.Errorf("aes_siv: too many associated data components: got %d, want at most %d", len(associatedData), MaxAssociatedDataComponents)
+	}
+	return asc.decrypt(ciphertext, associatedData)
+}
+
+func (asc *AESSIV) decrypt(ciphertext []byte, associatedData [][]byte) ([]byte, error) {
 	if len(ciphertext) < aes.BlockSize {
 		return nil, errors.New("aes_siv: ciphertext is too short")
 	}
@@ -151,14 +201,16 @@ func (asc *AESSIV) ctrCrypt(siv, in, out []byte) error {
 var zeroBlock [aes.BlockSize]byte
 
 // s2v is a Pseudo-Random Function (PRF) construction as defined in
-// Section 2.4 of RFC 5297.
-func (asc *AESSIV) s2v(msg, ad []byte) []byte {
+// Section 2.4 of RFC 5297, over the AD components ads followed by msg.
+func (asc *AESSIV) s2v(msg []byte, ads [][]byte) []byte {
 	block := asc.cmac.Compute(zeroBlock[:])
-	multiplyByX(block)
 
-	// block := CMAC(AD) XOR block
-	adMac := asc.cmac.Compute(ad)
-	subtle.XORBytes(block, block, adMac)
+	for _, ad := range ads {
+		// block := MultiplyByX(block) XOR CMAC(AD)
+		multiplyByX(block)
+		adMac := asc.cmac.Compute(ad)
+		subtle.XORBytes(block, block, adMac)
+	}
 	if len(msg) >= aes.BlockSize {
 		// v := CMAC(msg XOREND block)
 		res, err := asc.cmac.XOREndAndCompute(msg, block)
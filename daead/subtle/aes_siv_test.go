@@ -154,10 +154,11 @@ func TestAESSIV_KeySizes(t *testing.T) {
 	}
 	for i := 0; i < len(key); i++ {
 		_, err := subtle.NewAESSIV(key[:i])
-		if i == subtle.AESSIVKeySize && err != nil {
+		valid := i == 32 || i == 48 || i == subtle.AESSIVKeySize
+		if valid && err != nil {
 			t.Errorf("Rejected valid key size: %v, %v", i, err)
 		}
-		if i != subtle.AESSIVKeySize && err == nil {
+		if !valid && err == nil {
 			t.Errorf("Allowed invalid key size: %v", i)
 		}
 	}
@@ -274,7 +275,7 @@ func TestAESSIV_WycheproofVectors(t *testing.T) {
 	}
 
 	for _, g := range suite.TestGroups {
-		if g.KeySize/8 != subtle.AESSIVKeySize {
+		if err := subtle.ValidateAESSIVKeySize(g.KeySize / 8); err != nil {
 			continue
 		}
 
@@ -330,3 +331,100 @@ func TestAESSIV_WycheproofVectors(t *testing.T) {
 		}
 	}
 }
+
+func mustHexDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) err = %v, want nil", s, err)
+	}
+	return b
+}
+
+func TestAESSIV_VectorRFC5297(t *testing.T) {
+	// Test vector from Appendix A.2 of RFC 5297, with three AD components.
+	key := mustHexDecode(t, "7f7e7d7c7b7a79787776757473727170404142434445464748494a4b4c4d4e4f")
+	associatedData := [][]byte{
+		mustHexDecode(t, "00112233445566778899aabbccddeeffdeaddadadeaddadaffeeddccbbaa99887766554433221100"),
+		mustHexDecode(t, "102030405060708090a0"),
+		mustHexDecode(t, "09f911029d74e35bd84156c5635688c0"),
+	}
+	plaintext := mustHexDecode(t, "7468697320697320736f6d6520706c61696e7465787420746f20656e6372797074207573696e67205349562d414553")
+	want := mustHexDecode(t, "7bdb6e3b432667eb06f4d14bff2fbd0fcb900f2fddbe404326601965c889bf17dba77ceb094fa663b7a3f748ba8af829ea64ad544a272e9c485b62a3fd5c0d")
+
+	a, err := subtle.NewAESSIV(key)
+	if err != nil {
+		t.Fatalf("subtle.NewAESSIV() err = %v, want nil", err)
+	}
+	ct, err := a.EncryptDeterministicallyVector(plaintext, associatedData)
+	if err != nil {
+		t.Fatalf("a.EncryptDeterministicallyVector() err = %v, want nil", err)
+	}
+	if !bytes.Equal(ct, want) {
+		t.Errorf("a.EncryptDeterministicallyVector() = %x, want %x", ct, want)
+	}
+	pt, err := a.DecryptDeterministicallyVector(ct, associatedData)
+	if err != nil {
+		t.Fatalf("a.DecryptDeterministicallyVector() err = %v, want nil", err)
+	}
+	if !bytes.Equal(pt, plaintext) {
+		t.Errorf("a.DecryptDeterministicallyVector() = %x, want %x", pt, plaintext)
+	}
+	// Swapping two components must change the result.
+	swapped := [][]byte{associatedData[1], associatedData[0], associatedData[2]}
+	if _, err := a.DecryptDeterministicallyVector(ct, swapped); err == nil {
+		t.Error("a.DecryptDeterministicallyVector() with swapped components err = nil, want error")
+	}
+}
+
+func TestAESSIV_VectorWithOneComponentMatchesEncryptDeterministically(t *testing.T) {
+	a, err := subtle.NewAESSIV(random.GetRandomBytes(subtle.AESSIVKeySize))
+	if err != nil {
+		t.Fatalf("subtle.NewAESSIV() err = %v, want nil", err)
+	}
+	for _, plaintext := range [][]byte{nil, []byte("short"), []byte("a plaintext longer than one block")} {
+		associatedData := []byte("associated data")
+		want, err := a.EncryptDeterministically(plaintext, associatedData)
+		if err != nil {
+			t.Fatalf("a.EncryptDeterministically() err = %v, want nil", err)
+		}
+		got, err := a.EncryptDeterministicallyVector(plaintext, [][]byte{associatedData})
+		if err != nil {
+			t.Fatalf("a.EncryptDeterministicallyVector() err = %v, want nil", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("a.EncryptDeterministicallyVector() = %x, want %x", got, want)
+		}
+		// An empty vector is not the same as a single empty component.
+		noAD, err := a.EncryptDeterministicallyVector(plaintext, nil)
+		if err != nil {
+			t.Fatalf("a.EncryptDeterministicallyVector() err = %v, want nil", err)
+		}
+		emptyAD, err := a.EncryptDeterministically(plaintext, nil)
+		if err != nil {
+			t.Fatalf("a.EncryptDeterministically() err = %v, want nil", err)
+		}
+		if bytes.Equal(noAD, emptyAD) {
+			t.Error("encrypting with no AD components and with one empty component gave the same ciphertext")
+		}
+	}
+}
+
+func TestAESSIV_VectorTooManyComponents(t *testing.T) {
+	a, err := subtle.NewAESSIV(random.GetRandomBytes(subtle.AESSIVKeySize))
+	if err != nil {
+		t.Fatalf("subtle.NewAESSIV() err = %v, want nil", err)
+	}
+	associatedData := make([][]byte, subtle.MaxAssociatedDataComponents)
+	ct, err := a.EncryptDeterministicallyVector([]byte("plaintext"), associatedData)
+	if err != nil {
+		t.Fatalf("a.EncryptDeterministicallyVector() err = %v, want nil", err)
+	}
+	associatedData = append(associatedData, nil)
+	if _, err := a.EncryptDeterministicallyVector([]byte("plaintext"), associatedData); err == nil {
+		t.Error("a.EncryptDeterministicallyVector() err = nil, want error")
+	}
+	if _, err := a.DecryptDeterministicallyVector(ct, associatedData); err == nil {
+		t.Error("a.DecryptDeterministicallyVector() err = nil, want error")
+	}
+}
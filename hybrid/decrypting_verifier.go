@@ -0,0 +1,159 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tink-crypto/tink-go/v2/key"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/signature/ecdsa"
+	"github.com/tink-crypto/tink-go/v2/signature/ed25519"
+	"github.com/tink-crypto/tink-go/v2/signature/rsassapkcs1"
+	"github.com/tink-crypto/tink-go/v2/signature/rsassapss"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// DecryptingVerifier opens ciphertexts produced by a sign-then-encrypt
+// scheme: a payload is signed, and the signed payload is then encrypted with
+// a hybrid encryption primitive.
+//
+// The plaintext of the hybrid ciphertext is framed as
+//
+//	payload || signature
+//
+// where signature is the signature of payload, including the output prefix
+// of the signing key, if any. The payload is not length-prefixed: the length
+// of the signature is determined by the verification key, so only keys whose
+// signatures have a fixed length are supported, that is Ed25519, ECDSA with
+// IEEE P1363 encoding, RSA-SSA-PKCS1 and RSA-SSA-PSS keys.
+//
+// The signature covers the payload only, not the recipient or contextInfo,
+// so a recipient can decrypt a signed payload and encrypt it to a third party
+// as if the signer had sent it there. Protocols that need to prevent this
+// must include the recipient in the signed payload.
+type DecryptingVerifier struct {
+	decrypter tink.HybridDecrypt
+	verifier  tink.Verifier
+	// signatureLengths are the distinct signature lengths of the enabled keys
+	// of the verification keyset, in increasing order.
+	signatureLengths []int
+}
+
+// NewDecryptingVerifier returns a DecryptingVerifier that decrypts with the
+// private keys in decryptHandle and verifies signatures with the public keys
+// in verifyHandle.
+//
+// It returns an error if an enabled key in verifyHandle does not produce
+// signatures of a fixed length.
+func NewDecryptingVerifier(decryptHandle, verifyHandle *keyset.Handle) (*DecryptingVerifier, error) {
+	decrypter, err := NewHybridDecrypt(decryptHandle)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.NewDecryptingVerifier: %v", err)
+	}
+	verifier, err := signature.NewVerifier(verifyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.NewDecryptingVerifier: %v", err)
+	}
+	var signatureLengths []int
+	for i := 0; i < verifyHandle.Len(); i++ {
+		entry, err := verifyHandle.Entry(i)
+		if err != nil {
+			return nil, fmt.Errorf("hybrid.NewDecryptingVerifier: %v", err)
+		}
+		if entry.KeyStatus() != keyset.Enabled {
+			continue
+		}
+		length, err := signatureLength(entry.Key())
+		if err != nil {
+			return nil, fmt.Errorf("hybrid.NewDecryptingVerifier: key %d: %v", entry.KeyID(), err)
+		}
+		if !slices.Contains(signatureLengths, length) {
+			signatureLengths = append(signatureLengths, length)
+		}
+	}
+	slices.Sort(signatureLengths)
+	return &DecryptingVerifier{
+		decrypter:        decrypter,
+		verifier:         verifier,
+		signatureLengths: signatureLengths,
+	}, nil
+}
+
+// signatureLength returns the length of the signatures of publicKey,
+// including its output prefix.
+func signatureLength(publicKey key.Key) (int, error) {
+	switch k := publicKey.(type) {
+	case *ed25519.PublicKey:
+		return len(k.OutputPrefix()) + 64, nil
+	case *ecdsa.PublicKey:
+		params, ok := k.Parameters().(*ecdsa.Parameters)
+		if !ok {
+			return 0, fmt.Errorf("unexpected parameters type %T", k.Parameters())
+		}
+		if params.SignatureEncoding() != ecdsa.IEEEP1363 {
+			return 0, errors.New("ECDSA signatures must use the IEEE P1363 encoding")
+		}
+		var size int
+		switch params.CurveType() {
+		case ecdsa.NistP256:
+			size = 32
+		case ecdsa.NistP384:
+			size = 48
+		case ecdsa.NistP521:
+			size = 66
+		default:
+			return 0, fmt.Errorf("unsupported curve %v", params.CurveType())
+		}
+		return len(k.OutputPrefix()) + 2*size, nil
+	case *rsassapkcs1.PublicKey:
+		params, ok := k.Parameters().(*rsassapkcs1.Parameters)
+		if !ok {
+			return 0, fmt.Errorf("unexpected parameters type %T", k.Parameters())
+		}
+		return len(k.OutputPrefix()) + (params.ModulusSizeBits()+7)/8, nil
+	case *rsassapss.PublicKey:
+		params, ok := k.Parameters().(*rsassapss.Parameters)
+		if !ok {
+			return 0, fmt.Errorf("unexpected parameters type %T", k.Parameters())
+		}
+		return len(k.OutputPrefix()) + (params.ModulusSizeBits()+7)/8, nil
+	default:
+		return 0, fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+// OpenAndVerify decrypts ciphertext with contextInfo, then splits the
+// plaintext into payload and signature and verifies the signature. It returns
+// the payload only if both steps succeed.
+func (d *DecryptingVerifier) OpenAndVerify(ciphertext, contextInfo []byte) ([]byte, error) {
+	plaintext, err := d.decrypter.Decrypt(ciphertext, contextInfo)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.DecryptingVerifier: %v", err)
+	}
+	for _, length := range d.signatureLengths {
+		if length > len(plaintext) {
+			break
+		}
+		payload, sig := plaintext[:len(plaintext)-length], plaintext[len(plaintext)-length:]
+		if err := d.verifier.Verify(sig, payload); err == nil {
+			return payload, nil
+		}
+	}
+	return nil, errors.New("hybrid.DecryptingVerifier: invalid signature")
+}
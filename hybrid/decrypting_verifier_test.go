@@ -0,0 +1,262 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+type signThenEncryptFixture struct {
+	signer    tink.Signer
+	encrypter tink.HybridEncrypt
+	opener    *hybrid.DecryptingVerifier
+}
+
+func newSignThenEncryptFixture(t *testing.T, signHandle *keyset.Handle) *signThenEncryptFixture {
+	t.Helper()
+	decryptHandle, err := keyset.NewHandle(hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	encryptHandle, err := decryptHandle.Public()
+	if err != nil {
+		t.Fatalf("decryptHandle.Public() err = %v, want nil", err)
+	}
+	verifyHandle, err := signHandle.Public()
+	if err != nil {
+		t.Fatalf("signHandle.Public() err = %v, want nil", err)
+	}
+	signer, err := signature.NewSigner(signHandle)
+	if err != nil {
+		t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+	}
+	encrypter, err := hybrid.NewHybridEncrypt(encryptHandle)
+	if err != nil {
+		t.Fatalf("hybrid.NewHybridEncrypt() err = %v, want nil", err)
+	}
+	opener, err := hybrid.NewDecryptingVerifier(decryptHandle, verifyHandle)
+	if err != nil {
+		t.Fatalf("hybrid.NewDecryptingVerifier() err = %v, want nil", err)
+	}
+	return &signThenEncryptFixture{signer: signer, encrypter: encrypter, opener: opener}
+}
+
+func newSignHandle(t *testing.T, template *tinkpb.KeyTemplate) *keyset.Handle {
+	t.Helper()
+	handle, err := keyset.NewHandle(template)
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	return handle
+}
+
+// seal encrypts payload || sig, as the partner does.
+func (f *signThenEncryptFixture) seal(t *testing.T, payload, sig, contextInfo []byte) []byte {
+	t.Helper()
+	ciphertext, err := f.encrypter.Encrypt(slices.Concat(payload, sig), contextInfo)
+	if err != nil {
+		t.Fatalf("encrypter.Encrypt() err = %v, want nil", err)
+	}
+	return ciphertext
+}
+
+// signAndSeal signs payload and encrypts payload || signature.
+func (f *signThenEncryptFixture) signAndSeal(t *testing.T, payload, contextInfo []byte) []byte {
+	t.Helper()
+	sig, err := f.signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	return f.seal(t, payload, sig, contextInfo)
+}
+
+func TestDecryptingVerifierOpenAndVerify(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"ED25519", signature.ED25519KeyTemplate()},
+		{"ED25519 without prefix", signature.ED25519KeyWithoutPrefixTemplate()},
+		{"ECDSA P256 IEEE P1363", signature.ECDSAP256RawKeyTemplate()},
+		{"ECDSA P384 IEEE P1363", signature.ECDSAP384IEEEP1363KeyTemplate()},
+		{"ECDSA P521 IEEE P1363", signature.ECDSAP521IEEEP1363KeyTemplate()},
+		{"RSA SSA PKCS1", signature.RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template()},
+		{"RSA SSA PSS", signature.RSA_SSA_PSS_3072_SHA256_32_F4_Raw_Key_Template()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newSignThenEncryptFixture(t, newSignHandle(t, tc.template))
+			contextInfo := []byte("context info")
+			for _, payload := range [][]byte{nil, []byte("payload")} {
+				ciphertext := f.signAndSeal(t, payload, contextInfo)
+				got, err := f.opener.OpenAndVerify(ciphertext, contextInfo)
+				if err != nil {
+					t.Fatalf("OpenAndVerify() err = %v, want nil", err)
+				}
+				if !bytes.Equal(got, payload) {
+					t.Errorf("OpenAndVerify() = %q, want %q", got, payload)
+				}
+			}
+		})
+	}
+}
+
+func TestDecryptingVerifierOpenAndVerifyWithSignatureLengthsOfSeveralKeys(t *testing.T) {
+	signHandle := newSignHandle(t, signature.ED25519KeyTemplate())
+	oldSigner, err := signature.NewSigner(signHandle)
+	if err != nil {
+		t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+	}
+	manager := keyset.NewManagerFromHandle(signHandle)
+	keyID, err := manager.Add(signature.RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(keyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	rotatedHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	f := newSignThenEncryptFixture(t, rotatedHandle)
+	contextInfo := []byte("context info")
+	payload := []byte("payload")
+
+	oldSig, err := oldSigner.Sign(payload)
+	if err != nil {
+		t.Fatalf("oldSigner.Sign() err = %v, want nil", err)
+	}
+	for _, ciphertext := range [][]byte{
+		f.signAndSeal(t, payload, contextInfo),
+		f.seal(t, payload, oldSig, contextInfo),
+	} {
+		got, err := f.opener.OpenAndVerify(ciphertext, contextInfo)
+		if err != nil {
+			t.Fatalf("OpenAndVerify() err = %v, want nil", err)
+		}
+		if !bytes.Equal(got, payload) {
+			t.Errorf("OpenAndVerify() = %q, want %q", got, payload)
+		}
+	}
+}
+
+func TestDecryptingVerifierOpenAndVerifyFails(t *testing.T) {
+	signHandle := newSignHandle(t, signature.ED25519KeyTemplate())
+	f := newSignThenEncryptFixture(t, signHandle)
+	contextInfo := []byte("context info")
+	payload := []byte("payload")
+	sig, err := f.signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	other := newSignThenEncryptFixture(t, newSignHandle(t, signature.ED25519KeyTemplate()))
+	otherSig, err := other.signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	validCiphertext := f.seal(t, payload, sig, contextInfo)
+
+	for _, tc := range []struct {
+		name        string
+		ciphertext  []byte
+		contextInfo []byte
+	}{
+		{
+			name:        "wrong context info",
+			ciphertext:  validCiphertext,
+			contextInfo: []byte("other context info"),
+		},
+		{
+			name:        "modified ciphertext",
+			ciphertext:  append(validCiphertext[:len(validCiphertext)-1:len(validCiphertext)-1], validCiphertext[len(validCiphertext)-1]^1),
+			contextInfo: contextInfo,
+		},
+		{
+			name:        "ciphertext for another recipient",
+			ciphertext:  other.seal(t, payload, sig, contextInfo),
+			contextInfo: contextInfo,
+		},
+		{
+			name:        "signature by another signer",
+			ciphertext:  f.seal(t, payload, otherSig, contextInfo),
+			contextInfo: contextInfo,
+		},
+		{
+			name:        "signature of another payload",
+			ciphertext:  f.seal(t, []byte("other payload"), sig, contextInfo),
+			contextInfo: contextInfo,
+		},
+		{
+			name:        "truncated signature",
+			ciphertext:  f.seal(t, payload, sig[:len(sig)-1], contextInfo),
+			contextInfo: contextInfo,
+		},
+		{
+			name:        "missing signature",
+			ciphertext:  f.seal(t, payload, nil, contextInfo),
+			contextInfo: contextInfo,
+		},
+		{
+			name:        "empty plaintext",
+			ciphertext:  f.seal(t, nil, nil, contextInfo),
+			contextInfo: contextInfo,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := f.opener.OpenAndVerify(tc.ciphertext, tc.contextInfo); err == nil {
+				t.Errorf("OpenAndVerify() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewDecryptingVerifierFails(t *testing.T) {
+	decryptHandle, err := keyset.NewHandle(hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	verifyHandle, err := newSignHandle(t, signature.ED25519KeyTemplate()).Public()
+	if err != nil {
+		t.Fatalf("signHandle.Public() err = %v, want nil", err)
+	}
+	// DER-encoded ECDSA signatures don't have a fixed length.
+	derVerifyHandle, err := newSignHandle(t, signature.ECDSAP256KeyTemplate()).Public()
+	if err != nil {
+		t.Fatalf("signHandle.Public() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name          string
+		decryptHandle *keyset.Handle
+		verifyHandle  *keyset.Handle
+	}{
+		{"verification keys for decryption", verifyHandle, verifyHandle},
+		{"decryption keys for verification", decryptHandle, decryptHandle},
+		{"DER-encoded ECDSA", decryptHandle, derVerifyHandle},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := hybrid.NewDecryptingVerifier(tc.decryptHandle, tc.verifyHandle); err == nil {
+				t.Errorf("hybrid.NewDecryptingVerifier() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// DEM is the data encapsulation mechanism (DEM) half of a hybrid encryption
+// scheme, backed by a [tink.AEAD]. It lets custom key encapsulation mechanisms
+// (KEMs) reuse a Tink AEAD to encrypt the payload.
+//
+// Nonce handling is entirely delegated to the AEAD: every Tink AEAD generates
+// a fresh random nonce for each encryption and prepends it to the ciphertext,
+// so the output of [DEM.Seal] is self-contained and callers must not add or
+// manage a nonce of their own. Because nonces are random, a single DEM may
+// seal several messages, within the usage limits of the underlying AEAD.
+//
+// The DEM provides no key separation between messages; a KEM that derives a
+// fresh symmetric key per encapsulation should create a new AEAD, and thus a
+// new DEM, for every key.
+type DEM struct {
+	aead tink.AEAD
+}
+
+// DEMFromAEAD returns a [DEM] that seals and opens payloads with a.
+func DEMFromAEAD(a tink.AEAD) (*DEM, error) {
+	if a == nil {
+		return nil, fmt.Errorf("hybrid: AEAD must not be nil")
+	}
+	return &DEM{aead: a}, nil
+}
+
+// Seal encrypts plaintext and authenticates contextInfo, which should bind the
+// payload to the encapsulated key, for example by containing the KEM output.
+// The returned ciphertext includes the nonce chosen by the AEAD.
+func (d *DEM) Seal(plaintext, contextInfo []byte) ([]byte, error) {
+	ct, err := d.aead.Encrypt(plaintext, contextInfo)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: DEM seal failed: %v", err)
+	}
+	return ct, nil
+}
+
+// Open decrypts ciphertext produced by [DEM.Seal] with the same contextInfo.
+func (d *DEM) Open(ciphertext, contextInfo []byte) ([]byte, error) {
+	pt, err := d.aead.Decrypt(ciphertext, contextInfo)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: DEM open failed: %v", err)
+	}
+	return pt, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead/subtle"
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+)
+
+func TestDEMFromAEADSealOpen(t *testing.T) {
+	// A custom KEM would derive this key from the encapsulated shared secret.
+	a, err := subtle.NewAESGCM(random.GetRandomBytes(32))
+	if err != nil {
+		t.Fatalf("subtle.NewAESGCM() err = %v, want nil", err)
+	}
+	dem, err := hybrid.DEMFromAEAD(a)
+	if err != nil {
+		t.Fatalf("hybrid.DEMFromAEAD() err = %v, want nil", err)
+	}
+	plaintext := []byte("plaintext")
+	contextInfo := []byte("encapsulated key")
+	ct1, err := dem.Seal(plaintext, contextInfo)
+	if err != nil {
+		t.Fatalf("dem.Seal() err = %v, want nil", err)
+	}
+	ct2, err := dem.Seal(plaintext, contextInfo)
+	if err != nil {
+		t.Fatalf("dem.Seal() err = %v, want nil", err)
+	}
+	if bytes.Equal(ct1, ct2) {
+		t.Errorf("dem.Seal() returned the same ciphertext twice, want fresh nonces")
+	}
+	for _, ct := range [][]byte{ct1, ct2} {
+		got, err := dem.Open(ct, contextInfo)
+		if err != nil {
+			t.Fatalf("dem.Open() err = %v, want nil", err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("dem.Open() = %q, want %q", got, plaintext)
+		}
+	}
+	if _, err := dem.Open(ct1, []byte("other")); err == nil {
+		t.Errorf("dem.Open() with wrong contextInfo err = nil, want error")
+	}
+	// The nonce is part of the ciphertext, so it is authenticated too.
+	modified := bytes.Clone(ct1)
+	modified[0] ^= 1
+	if _, err := dem.Open(modified, contextInfo); err == nil {
+		t.Errorf("dem.Open() with modified nonce err = nil, want error")
+	}
+}
+
+func TestDEMFromAEADRejectsNil(t *testing.T) {
+	if _, err := hybrid.DEMFromAEAD(nil); err == nil {
+		t.Errorf("hybrid.DEMFromAEAD(nil) err = nil, want error")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid
+
+import (
+	"crypto/ecdh"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/hybrid/ecies"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	hpkepb "github.com/tink-crypto/tink-go/v2/proto/hpke_go_proto"
+)
+
+const hpkePrivateKeyTypeURL = "type.googleapis.com/google.crypto.tink.HpkePrivateKey"
+
+// ECDH computes the Diffie-Hellman shared secret between the primary key of
+// privHandle and the peer public key peerPublicKeyBytes, for protocols that
+// need the key agreement without the rest of the hybrid encryption scheme.
+//
+// The primary key must be an ECIES key on X25519 or NIST P-256, or an HPKE key
+// with KEM DHKEM_X25519_HKDF_SHA256 or DHKEM_P256_HKDF_SHA256.
+// peerPublicKeyBytes must be a 32-byte X25519 public key or an uncompressed
+// P-256 point, respectively.
+//
+// ECDH returns the raw Diffie-Hellman output, without any key derivation: the
+// 32-byte X25519 output, or the 32-byte x-coordinate of the shared P-256 point.
+// It is not uniformly random and must be passed through a KDF, such as HKDF,
+// before being used as a key. ECDH returns an error if the X25519 output is
+// all zeros.
+//
+// The private key is used as is, so a key used with ECDH should not also be
+// used for hybrid encryption.
+func ECDH(privHandle *keyset.Handle, peerPublicKeyBytes []byte) ([]byte, error) {
+	if privHandle == nil {
+		return nil, errors.New("hybrid.ECDH: nil handle")
+	}
+	entry, err := privHandle.Primary()
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.ECDH: %v", err)
+	}
+	privateKey, err := ecdhPrivateKey(entry)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.ECDH: %v", err)
+	}
+	peerPublicKey, err := privateKey.Curve().NewPublicKey(peerPublicKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.ECDH: invalid peer public key: %v", err)
+	}
+	sharedSecret, err := privateKey.ECDH(peerPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.ECDH: %v", err)
+	}
+	return sharedSecret, nil
+}
+
+// ecdhPrivateKey returns the private key of entry as an [*ecdh.PrivateKey].
+func ecdhPrivateKey(entry *keyset.Entry) (*ecdh.PrivateKey, error) {
+	if k, ok := entry.Key().(*ecies.PrivateKey); ok {
+		var curve ecdh.Curve
+		switch curveType := k.Parameters().(*ecies.Parameters).CurveType(); curveType {
+		case ecies.X25519:
+			curve = ecdh.X25519()
+		case ecies.NISTP256:
+			curve = ecdh.P256()
+		default:
+			return nil, fmt.Errorf("unsupported ECIES curve %v", curveType)
+		}
+		return curve.NewPrivateKey(k.PrivateKeyBytes().Data(insecuresecretdataaccess.Token{}))
+	}
+	// HPKE keys are not parsed into a key type, so read the serialized key.
+	keySerialization, err := protoserialization.SerializeKey(entry.Key())
+	if err != nil {
+		return nil, err
+	}
+	keyData := keySerialization.KeyData()
+	if keyData.GetTypeUrl() != hpkePrivateKeyTypeURL {
+		return nil, fmt.Errorf("unsupported key type %q", keyData.GetTypeUrl())
+	}
+	hpkeKey := new(hpkepb.HpkePrivateKey)
+	if err := proto.Unmarshal(keyData.GetValue(), hpkeKey); err != nil {
+		return nil, err
+	}
+	var curve ecdh.Curve
+	switch kem := hpkeKey.GetPublicKey().GetParams().GetKem(); kem {
+	case hpkepb.HpkeKem_DHKEM_X25519_HKDF_SHA256:
+		curve = ecdh.X25519()
+	case hpkepb.HpkeKem_DHKEM_P256_HKDF_SHA256:
+		curve = ecdh.P256()
+	default:
+		return nil, fmt.Errorf("unsupported HPKE KEM %v", kem)
+	}
+	return curve.NewPrivateKey(hpkeKey.GetPrivateKey())
+}
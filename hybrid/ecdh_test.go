@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/aead/aesgcm"
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/hybrid/ecies"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/secretdata"
+	hpkepb "github.com/tink-crypto/tink-go/v2/proto/hpke_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func newECIESX25519Handle(t *testing.T) *keyset.Handle {
+	t.Helper()
+	demParams, err := aesgcm.NewParameters(aesgcm.ParametersOpts{
+		KeySizeInBytes: 16,
+		IVSizeInBytes:  12,
+		TagSizeInBytes: 16,
+		Variant:        aesgcm.VariantNoPrefix,
+	})
+	if err != nil {
+		t.Fatalf("aesgcm.NewParameters() err = %v, want nil", err)
+	}
+	params, err := ecies.NewParameters(ecies.ParametersOpts{
+		CurveType:     ecies.X25519,
+		HashType:      ecies.SHA256,
+		DEMParameters: demParams,
+		Variant:       ecies.VariantTink,
+	})
+	if err != nil {
+		t.Fatalf("ecies.NewParameters() err = %v, want nil", err)
+	}
+	privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdh.X25519().GenerateKey() err = %v, want nil", err)
+	}
+	key, err := ecies.NewPrivateKey(secretdata.NewBytesFromData(privateKey.Bytes(), insecuresecretdataaccess.Token{}), 123, params)
+	if err != nil {
+		t.Fatalf("ecies.NewPrivateKey() err = %v, want nil", err)
+	}
+	manager := keyset.NewManager()
+	keyID, err := manager.AddKey(key)
+	if err != nil {
+		t.Fatalf("manager.AddKey() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(keyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	return handle
+}
+
+// primaryPublicKeyBytes returns the encoded public key of the primary key of
+// the ECIES or HPKE private keyset handle.
+func primaryPublicKeyBytes(t *testing.T, handle *keyset.Handle) []byte {
+	t.Helper()
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	entry, err := publicHandle.Primary()
+	if err != nil {
+		t.Fatalf("publicHandle.Primary() err = %v, want nil", err)
+	}
+	if k, ok := entry.Key().(*ecies.PublicKey); ok {
+		return k.PublicKeyBytes()
+	}
+	keySerialization, err := protoserialization.SerializeKey(entry.Key())
+	if err != nil {
+		t.Fatalf("protoserialization.SerializeKey() err = %v, want nil", err)
+	}
+	hpkeKey := new(hpkepb.HpkePublicKey)
+	if err := proto.Unmarshal(keySerialization.KeyData().GetValue(), hpkeKey); err != nil {
+		t.Fatalf("proto.Unmarshal() err = %v, want nil", err)
+	}
+	return hpkeKey.GetPublicKey()
+}
+
+func TestECDH(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		handle func(t *testing.T) *keyset.Handle
+		curve  ecdh.Curve
+	}{
+		{
+			name:   "ECIES P-256",
+			handle: func(t *testing.T) *keyset.Handle { return mustNewHandle(t, hybrid.ECIESHKDFAES128GCMKeyTemplate()) },
+			curve:  ecdh.P256(),
+		},
+		{
+			name:   "ECIES X25519",
+			handle: newECIESX25519Handle,
+			curve:  ecdh.X25519(),
+		},
+		{
+			name: "HPKE P-256",
+			handle: func(t *testing.T) *keyset.Handle {
+				return mustNewHandle(t, hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template())
+			},
+			curve: ecdh.P256(),
+		},
+		{
+			name: "HPKE X25519",
+			handle: func(t *testing.T) *keyset.Handle {
+				return mustNewHandle(t, hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template())
+			},
+			curve: ecdh.X25519(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handle := tc.handle(t)
+			peerPrivateKey, err := tc.curve.GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatalf("GenerateKey() err = %v, want nil", err)
+			}
+			got, err := hybrid.ECDH(handle, peerPrivateKey.PublicKey().Bytes())
+			if err != nil {
+				t.Fatalf("hybrid.ECDH() err = %v, want nil", err)
+			}
+			publicKey, err := tc.curve.NewPublicKey(primaryPublicKeyBytes(t, handle))
+			if err != nil {
+				t.Fatalf("NewPublicKey() err = %v, want nil", err)
+			}
+			want, err := peerPrivateKey.ECDH(publicKey)
+			if err != nil {
+				t.Fatalf("peerPrivateKey.ECDH() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("hybrid.ECDH() = %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func mustNewHandle(t *testing.T, template *tinkpb.KeyTemplate) *keyset.Handle {
+	t.Helper()
+	handle, err := keyset.NewHandle(template)
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	return handle
+}
+
+func TestECDHFails(t *testing.T) {
+	x25519Handle := mustNewHandle(t, hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template())
+	p256Handle := mustNewHandle(t, hybrid.ECIESHKDFAES128GCMKeyTemplate())
+	x25519PeerKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() err = %v, want nil", err)
+	}
+	p256PeerKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() err = %v, want nil", err)
+	}
+	publicHandle, err := x25519Handle.Public()
+	if err != nil {
+		t.Fatalf("x25519Handle.Public() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name          string
+		handle        *keyset.Handle
+		peerPublicKey []byte
+	}{
+		{
+			name:          "nil handle",
+			handle:        nil,
+			peerPublicKey: x25519PeerKey.PublicKey().Bytes(),
+		},
+		{
+			name:          "public key handle",
+			handle:        publicHandle,
+			peerPublicKey: x25519PeerKey.PublicKey().Bytes(),
+		},
+		{
+			name:          "AEAD handle",
+			handle:        mustNewHandle(t, aead.AES128GCMKeyTemplate()),
+			peerPublicKey: x25519PeerKey.PublicKey().Bytes(),
+		},
+		{
+			name:          "HPKE P-384",
+			handle:        mustNewHandle(t, hybrid.DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Key_Template()),
+			peerPublicKey: p256PeerKey.PublicKey().Bytes(),
+		},
+		{
+			name:          "peer key on another curve",
+			handle:        x25519Handle,
+			peerPublicKey: p256PeerKey.PublicKey().Bytes(),
+		},
+		{
+			name:          "P-256 point not on the curve",
+			handle:        p256Handle,
+			peerPublicKey: append([]byte{0x04}, make([]byte, 64)...),
+		},
+		{
+			name:          "X25519 low order point",
+			handle:        x25519Handle,
+			peerPublicKey: make([]byte, 32),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := hybrid.ECDH(tc.handle, tc.peerPublicKey); err == nil {
+				t.Errorf("hybrid.ECDH() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+// EncryptToMany encrypts plaintext with contextInfo to each of the public
+// keyset handles in recipients, and returns the ciphertexts in the same order.
+// Each ciphertext is the same as one produced by the primitive that
+// [NewHybridEncrypt] returns for the recipient, and is decrypted with the
+// private keyset of the recipient.
+//
+// Nothing but the inputs is shared between the recipients: in HPKE base mode,
+// and likewise in ECIES, every recipient gets a fresh ephemeral key, so the
+// KEM encapsulation, the key schedule and the AEAD encryption of plaintext are
+// done once per recipient and cannot be amortized. To encrypt a
+// large plaintext to many recipients, encrypt it once with a fresh AEAD key,
+// and use EncryptToMany to encrypt only that key to the recipients.
+//
+// EncryptToMany returns an error, and no ciphertexts, if any recipient fails.
+func EncryptToMany(recipients []*keyset.Handle, plaintext, contextInfo []byte) ([][]byte, error) {
+	ciphertexts := make([][]byte, len(recipients))
+	for i, recipient := range recipients {
+		encrypter, err := NewHybridEncrypt(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("hybrid.EncryptToMany: recipient %d: %v", i, err)
+		}
+		ciphertexts[i], err = encrypter.Encrypt(plaintext, contextInfo)
+		if err != nil {
+			return nil, fmt.Errorf("hybrid.EncryptToMany: recipient %d: %v", i, err)
+		}
+	}
+	return ciphertexts, nil
+}
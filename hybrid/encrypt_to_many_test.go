@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestEncryptToMany(t *testing.T) {
+	var privateHandles, recipients []*keyset.Handle
+	for _, template := range []*tinkpb.KeyTemplate{
+		hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template(),
+		hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template(),
+		hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template(),
+		hybrid.ECIESHKDFAES128GCMKeyTemplate(),
+	} {
+		privateHandle := mustNewHandle(t, template)
+		publicHandle, err := privateHandle.Public()
+		if err != nil {
+			t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+		}
+		privateHandles = append(privateHandles, privateHandle)
+		recipients = append(recipients, publicHandle)
+	}
+	plaintext := []byte("plaintext")
+	contextInfo := []byte("context info")
+
+	ciphertexts, err := hybrid.EncryptToMany(recipients, plaintext, contextInfo)
+	if err != nil {
+		t.Fatalf("hybrid.EncryptToMany() err = %v, want nil", err)
+	}
+	if len(ciphertexts) != len(recipients) {
+		t.Fatalf("len(hybrid.EncryptToMany()) = %d, want %d", len(ciphertexts), len(recipients))
+	}
+	for i, privateHandle := range privateHandles {
+		decrypter, err := hybrid.NewHybridDecrypt(privateHandle)
+		if err != nil {
+			t.Fatalf("hybrid.NewHybridDecrypt() err = %v, want nil", err)
+		}
+		got, err := decrypter.Decrypt(ciphertexts[i], contextInfo)
+		if err != nil {
+			t.Fatalf("decrypter.Decrypt(ciphertexts[%d]) err = %v, want nil", i, err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("decrypter.Decrypt(ciphertexts[%d]) = %q, want %q", i, got, plaintext)
+		}
+		// Ciphertexts of other recipients are not decrypted.
+		other := (i + 1) % len(ciphertexts)
+		if _, err := decrypter.Decrypt(ciphertexts[other], contextInfo); err == nil {
+			t.Errorf("decrypter.Decrypt(ciphertexts[%d]) err = nil, want error", other)
+		}
+	}
+}
+
+func TestEncryptToManyWithNoRecipients(t *testing.T) {
+	ciphertexts, err := hybrid.EncryptToMany(nil, []byte("plaintext"), nil)
+	if err != nil {
+		t.Fatalf("hybrid.EncryptToMany() err = %v, want nil", err)
+	}
+	if len(ciphertexts) != 0 {
+		t.Errorf("len(hybrid.EncryptToMany()) = %d, want 0", len(ciphertexts))
+	}
+}
+
+func TestEncryptToManyFails(t *testing.T) {
+	privateHandle := mustNewHandle(t, hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template())
+	publicHandle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name       string
+		recipients []*keyset.Handle
+	}{
+		{"nil recipient", []*keyset.Handle{publicHandle, nil}},
+		{"private keyset handle", []*keyset.Handle{publicHandle, privateHandle}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := hybrid.EncryptToMany(tc.recipients, []byte("plaintext"), nil); err == nil {
+				t.Errorf("hybrid.EncryptToMany() err = nil, want error")
+			}
+		})
+	}
+}
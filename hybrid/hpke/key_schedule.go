@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/hybrid/internal/hpke"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+)
+
+// Suite identifies an HPKE cipher suite by the KEM, KDF and AEAD algorithm
+// identifiers assigned in https://www.rfc-editor.org/rfc/rfc9180.html#section-7.
+type Suite struct {
+	KEMID  uint16
+	KDFID  uint16
+	AEADID uint16
+}
+
+// DeriveContextForTesting runs the HPKE key schedule of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1 on the given KEM
+// shared secret, and returns the resulting AEAD key, base nonce and exporter
+// secret.
+//
+// mode is one of the mode identifiers of RFC 9180, Table 1. psk and pskID must
+// be empty in the base and auth modes, and non-empty in the PSK modes.
+//
+// This function returns secret key material and is meant only for debugging
+// interoperability issues, by comparing the outputs with those of another HPKE
+// implementation. Do not use it in production code.
+func DeriveContextForTesting(mode byte, sharedSecret, info, psk, pskID []byte, suite Suite, _ insecuresecretdataaccess.Token) (key, baseNonce, exporterSecret []byte, err error) {
+	key, baseNonce, exporterSecret, err = hpke.KeyScheduleForTesting(mode, suite.KEMID, suite.KDFID, suite.AEADID, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("hpke: %v", err)
+	}
+	return key, baseNonce, exporterSecret, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/hybrid/hpke"
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+	"github.com/tink-crypto/tink-go/v2/testutil"
+)
+
+const testVectorsDir = "testdata/testvectors"
+
+func hpkeTestVectorsFilePath(t *testing.T) string {
+	t.Helper()
+	srcDir, ok := os.LookupEnv("TEST_SRCDIR")
+	if ok {
+		workspaceDir, ok := os.LookupEnv("TEST_WORKSPACE")
+		if !ok {
+			t.Fatal("TEST_WORKSPACE not found")
+		}
+		return filepath.Join(srcDir, workspaceDir, testVectorsDir, "hpke_boringssl.json")
+	}
+	return filepath.Join("../../", testVectorsDir, "hpke_boringssl.json")
+}
+
+func TestDeriveContextForTestingWithTestVectors(t *testing.T) {
+	f, err := os.Open(hpkeTestVectorsFilePath(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var vecs []struct {
+		Mode           uint8             `json:"mode"`
+		KEMID          uint16            `json:"kem_id"`
+		KDFID          uint16            `json:"kdf_id"`
+		AEADID         uint16            `json:"aead_id"`
+		Info           testutil.HexBytes `json:"info"`
+		PSK            testutil.HexBytes `json:"psk"`
+		PSKID          testutil.HexBytes `json:"psk_id"`
+		SharedSecret   testutil.HexBytes `json:"shared_secret"`
+		Key            testutil.HexBytes `json:"key"`
+		BaseNonce      testutil.HexBytes `json:"base_nonce"`
+		ExporterSecret testutil.HexBytes `json:"exporter_secret"`
+	}
+	if err := json.NewDecoder(f).Decode(&vecs); err != nil {
+		t.Fatal(err)
+	}
+
+	supportedKEMs := map[uint16]bool{0x0010: true, 0x0011: true, 0x0012: true, 0x0020: true}
+	supportedAEADs := map[uint16]bool{0x0001: true, 0x0002: true, 0x0003: true, 0xFFFF: true}
+	tested := 0
+	for i, v := range vecs {
+		if !supportedKEMs[v.KEMID] || !supportedAEADs[v.AEADID] {
+			continue
+		}
+		tested++
+		t.Run(fmt.Sprintf("%d_mode_%d_kem_%d_kdf_%d_aead_%d", i, v.Mode, v.KEMID, v.KDFID, v.AEADID), func(t *testing.T) {
+			suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+			key, baseNonce, exporterSecret, err := hpke.DeriveContextForTesting(v.Mode, v.SharedSecret, v.Info, v.PSK, v.PSKID, suite, insecuresecretdataaccess.Token{})
+			if err != nil {
+				t.Fatalf("hpke.DeriveContextForTesting() err = %v, want nil", err)
+			}
+			if !bytes.Equal(key, v.Key) {
+				t.Errorf("key = %x, want %x", key, v.Key)
+			}
+			if !bytes.Equal(baseNonce, v.BaseNonce) {
+				t.Errorf("baseNonce = %x, want %x", baseNonce, v.BaseNonce)
+			}
+			if !bytes.Equal(exporterSecret, v.ExporterSecret) {
+				t.Errorf("exporterSecret = %x, want %x", exporterSecret, v.ExporterSecret)
+			}
+		})
+	}
+	if tested == 0 {
+		t.Fatal("no test vectors were run")
+	}
+}
+
+func TestDeriveContextForTestingFailsWithInvalidInputs(t *testing.T) {
+	suite := hpke.Suite{KEMID: 0x0020, KDFID: 0x0001, AEADID: 0x0001}
+	sharedSecret := make([]byte, 32)
+	psk := []byte("0123456789abcdef0123456789abcdef")
+	pskID := []byte("psk id")
+	for _, tc := range []struct {
+		name  string
+		mode  byte
+		psk   []byte
+		pskID []byte
+		suite hpke.Suite
+	}{
+		{"base mode with PSK", 0x00, psk, pskID, suite},
+		{"auth mode with PSK", 0x02, psk, pskID, suite},
+		{"PSK mode without PSK", 0x01, nil, nil, suite},
+		{"PSK without PSK ID", 0x01, psk, nil, suite},
+		{"PSK ID without PSK", 0x03, nil, pskID, suite},
+		{"unknown mode", 0x04, nil, nil, suite},
+		{"unknown KEM", 0x00, nil, nil, hpke.Suite{KEMID: 0x0021, KDFID: 0x0001, AEADID: 0x0001}},
+		{"unknown KDF", 0x00, nil, nil, hpke.Suite{KEMID: 0x0020, KDFID: 0x0004, AEADID: 0x0001}},
+		{"unknown AEAD", 0x00, nil, nil, hpke.Suite{KEMID: 0x0020, KDFID: 0x0001, AEADID: 0x0004}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, _, err := hpke.DeriveContextForTesting(tc.mode, sharedSecret, []byte("info"), tc.psk, tc.pskID, tc.suite, insecuresecretdataaccess.Token{}); err == nil {
+				t.Errorf("hpke.DeriveContextForTesting() err = nil, want error")
+			}
+		})
+	}
+}
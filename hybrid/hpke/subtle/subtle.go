@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package subtle provides the single-shot base and auth mode HPKE (RFC 9180)
+// functions on raw keys, for interoperating with other RFC 9180 implementations,
+// and the single-shot base mode secret export for key agreement.
+//
+// Unlike the HPKE keys of a keyset, these functions do not add a Tink output
+// prefix and keep the encapsulated key and the ciphertext apart. Most users
+// should use the hybrid package instead.
+package subtle
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/hybrid/hpke"
+	internalhpke "github.com/tink-crypto/tink-go/v2/hybrid/internal/hpke"
+)
+
+// SealBase encrypts plaintext to recipientPublicKey and authenticates
+// associatedData, as SealBase in
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-6.1 for suite. It
+// returns the encapsulated key enc and the ciphertext ct, both of which the
+// recipient needs to decrypt.
+//
+// recipientPublicKey is the serialized public key of the KEM, i.e.
+// SerializePublicKey of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-7.1.1. The supported
+// KEMs are DHKEM(P-256, HKDF-SHA256), DHKEM(P-384, HKDF-SHA384),
+// DHKEM(P-521, HKDF-SHA512) and DHKEM(X25519, HKDF-SHA256), the supported KDFs
+// are HKDF-SHA256, HKDF-SHA384 and HKDF-SHA512, and the supported AEADs are
+// AES-128-GCM, AES-256-GCM and ChaCha20Poly1305. SealBase fails with the
+// export-only AEAD ID 0xFFFF; use [SendExportBase] for such suites.
+func SealBase(suite hpke.Suite, recipientPublicKey, info, associatedData, plaintext []byte) (enc, ct []byte, err error) {
+	enc, ct, err = internalhpke.SealBase(suite.KEMID, suite.KDFID, suite.AEADID, recipientPublicKey, info, associatedData, plaintext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hpke: %v", err)
+	}
+	return enc, ct, nil
+}
+
+// OpenBase decrypts ct with recipientPrivateKey and the encapsulated key enc,
+// and verifies associatedData, as OpenBase in
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-6.1 for suite.
+//
+// recipientPrivateKey is the serialized private key of the KEM, i.e.
+// SerializePrivateKey of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-7.1.2. The supported
+// suites are those of [SealBase].
+func OpenBase(suite hpke.Suite, recipientPrivateKey, enc, info, associatedData, ct []byte) ([]byte, error) {
+	pt, err := internalhpke.OpenBase(suite.KEMID, suite.KDFID, suite.AEADID, recipientPrivateKey, enc, info, associatedData, ct)
+	if err != nil {
+		return nil, fmt.Errorf("hpke: %v", err)
+	}
+	return pt, nil
+}
+
+// SendExportBase derives a secret of length bytes shared with the holder of
+// recipientPublicKey, as SendExport in
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-6.2 for suite in the
+// base mode. It returns the encapsulated key enc, which the recipient needs to
+// derive the same secret with [ReceiveExportBase], and the exported secret.
+//
+// exporterContext binds the secret to its use, so secrets exported with
+// different contexts are independent. length must be at most 255 times the
+// output size of the KDF's hash.
+//
+// The supported suites are those of [SealBase], and in addition the
+// export-only AEAD ID 0xFFFF of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-7.3, which makes sure
+// that the shared context cannot be used for encryption.
+func SendExportBase(suite hpke.Suite, recipientPublicKey, info, exporterContext []byte, length int) (enc, exported []byte, err error) {
+	enc, exported, err = internalhpke.SendExportBase(suite.KEMID, suite.KDFID, suite.AEADID, recipientPublicKey, info, exporterContext, length)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hpke: %v", err)
+	}
+	return enc, exported, nil
+}
+
+// ReceiveExportBase derives the secret of length bytes exported by
+// [SendExportBase] with recipientPrivateKey and the encapsulated key enc, as
+// ReceiveExport in https://www.rfc-editor.org/rfc/rfc9180.html#section-6.2
+// for suite in the base mode. info and exporterContext must be those of the
+// sender.
+func ReceiveExportBase(suite hpke.Suite, recipientPrivateKey, enc, info, exporterContext []byte, length int) ([]byte, error) {
+	exported, err := internalhpke.ReceiveExportBase(suite.KEMID, suite.KDFID, suite.AEADID, recipientPrivateKey, enc, info, exporterContext, length)
+	if err != nil {
+		return nil, fmt.Errorf("hpke: %v", err)
+	}
+	return exported, nil
+}
+
+// SealAuth is like [SealBase], but uses the auth mode of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1.3, which also
+// authenticates the sender. The shared secret is derived from both the
+// ephemeral-static and the static-static Diffie-Hellman outputs, the latter
+// computed with senderPrivateKey, so only a holder of senderPrivateKey can
+// produce a ciphertext that [OpenAuth] accepts for its public key.
+//
+// senderPrivateKey is a serialized private key of the same KEM as
+// recipientPublicKey. Auth mode does not prevent the recipient from forging
+// ciphertexts "from" the sender, since the recipient can compute the same
+// static-static Diffie-Hellman output; it therefore provides authentication,
+// not non-repudiation.
+func SealAuth(suite hpke.Suite, recipientPublicKey, senderPrivateKey, info, associatedData, plaintext []byte) (enc, ct []byte, err error) {
+	enc, ct, err = internalhpke.SealAuth(suite.KEMID, suite.KDFID, suite.AEADID, recipientPublicKey, senderPrivateKey, info, associatedData, plaintext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hpke: %v", err)
+	}
+	return enc, ct, nil
+}
+
+// OpenAuth is like [OpenBase], but uses the auth mode of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1.3. It only succeeds
+// if ct was produced by [SealAuth] with the private key of senderPublicKey.
+func OpenAuth(suite hpke.Suite, recipientPrivateKey, senderPublicKey, enc, info, associatedData, ct []byte) ([]byte, error) {
+	pt, err := internalhpke.OpenAuth(suite.KEMID, suite.KDFID, suite.AEADID, recipientPrivateKey, senderPublicKey, enc, info, associatedData, ct)
+	if err != nil {
+		return nil, fmt.Errorf("hpke: %v", err)
+	}
+	return pt, nil
+}
@@ -0,0 +1,386 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subtle_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/hybrid/hpke"
+	"github.com/tink-crypto/tink-go/v2/hybrid/hpke/subtle"
+	"github.com/tink-crypto/tink-go/v2/testutil"
+)
+
+const testVectorsDir = "testdata/testvectors"
+
+type encryption struct {
+	AAD        testutil.HexBytes `json:"aad"`
+	Ciphertext testutil.HexBytes `json:"ciphertext"`
+	Plaintext  testutil.HexBytes `json:"plaintext"`
+}
+
+type export struct {
+	ExporterContext testutil.HexBytes `json:"exporter_context"`
+	Length          int               `json:"L"`
+	ExportedValue   testutil.HexBytes `json:"exported_value"`
+}
+
+type hpkeVector struct {
+	Mode        uint8             `json:"mode"`
+	KEMID       uint16            `json:"kem_id"`
+	KDFID       uint16            `json:"kdf_id"`
+	AEADID      uint16            `json:"aead_id"`
+	Info        testutil.HexBytes `json:"info"`
+	PKRm        testutil.HexBytes `json:"pkRm"`
+	SKRm        testutil.HexBytes `json:"skRm"`
+	PKSm        testutil.HexBytes `json:"pkSm"`
+	SKSm        testutil.HexBytes `json:"skSm"`
+	Enc         testutil.HexBytes `json:"enc"`
+	Encryptions []encryption      `json:"encryptions"`
+	Exports     []export          `json:"exports"`
+}
+
+// baseModeVectors returns the base mode test vectors of the supported suites.
+func baseModeVectors(t *testing.T) []hpkeVector {
+	t.Helper()
+	return modeVectors(t, 0)
+}
+
+// authModeVectors returns the auth mode test vectors of the supported suites.
+func authModeVectors(t *testing.T) []hpkeVector {
+	t.Helper()
+	return modeVectors(t, 2)
+}
+
+// modeVectors returns the test vectors of the supported suites for mode.
+func modeVectors(t *testing.T, mode uint8) []hpkeVector {
+	t.Helper()
+	supportedAEADs := map[uint16]bool{0x0001: true, 0x0002: true, 0x0003: true}
+	return filterVectors(t, func(v hpkeVector) bool {
+		return v.Mode == mode && supportedAEADs[v.AEADID]
+	})
+}
+
+// exportVectors returns the base mode test vectors of the supported suites,
+// including those with the export-only AEAD.
+func exportVectors(t *testing.T) []hpkeVector {
+	t.Helper()
+	supportedAEADs := map[uint16]bool{0x0001: true, 0x0002: true, 0x0003: true, 0xFFFF: true}
+	return filterVectors(t, func(v hpkeVector) bool {
+		return v.Mode == 0 && supportedAEADs[v.AEADID]
+	})
+}
+
+// filterVectors returns the test vectors with a supported KEM for which keep
+// returns true.
+func filterVectors(t *testing.T, keep func(hpkeVector) bool) []hpkeVector {
+	t.Helper()
+	path := filepath.Join("../../../", testVectorsDir, "hpke_boringssl.json")
+	if srcDir, ok := os.LookupEnv("TEST_SRCDIR"); ok {
+		workspaceDir, ok := os.LookupEnv("TEST_WORKSPACE")
+		if !ok {
+			t.Fatal("TEST_WORKSPACE not found")
+		}
+		path = filepath.Join(srcDir, workspaceDir, testVectorsDir, "hpke_boringssl.json")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var vecs []hpkeVector
+	if err := json.NewDecoder(f).Decode(&vecs); err != nil {
+		t.Fatal(err)
+	}
+	supportedKEMs := map[uint16]bool{0x0010: true, 0x0011: true, 0x0012: true, 0x0020: true}
+	var filtered []hpkeVector
+	for _, v := range vecs {
+		if supportedKEMs[v.KEMID] && keep(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	if len(filtered) == 0 {
+		t.Fatal("no test vectors found")
+	}
+	return filtered
+}
+
+func TestOpenBaseWithTestVectors(t *testing.T) {
+	for i, v := range baseModeVectors(t) {
+		t.Run(fmt.Sprintf("%d_kem_%d_kdf_%d_aead_%d", i, v.KEMID, v.KDFID, v.AEADID), func(t *testing.T) {
+			suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+			// The single-shot API only uses the first sequence number.
+			e := v.Encryptions[0]
+			got, err := subtle.OpenBase(suite, v.SKRm, v.Enc, v.Info, e.AAD, e.Ciphertext)
+			if err != nil {
+				t.Fatalf("subtle.OpenBase() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, e.Plaintext) {
+				t.Errorf("subtle.OpenBase() = %x, want %x", got, e.Plaintext)
+			}
+		})
+	}
+}
+
+func TestSealBaseOpenBase(t *testing.T) {
+	for i, v := range baseModeVectors(t) {
+		t.Run(fmt.Sprintf("%d_kem_%d_kdf_%d_aead_%d", i, v.KEMID, v.KDFID, v.AEADID), func(t *testing.T) {
+			suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+			plaintext := []byte("plaintext")
+			aad := []byte("associated data")
+			enc, ct, err := subtle.SealBase(suite, v.PKRm, v.Info, aad, plaintext)
+			if err != nil {
+				t.Fatalf("subtle.SealBase() err = %v, want nil", err)
+			}
+			if len(enc) != len(v.Enc) {
+				t.Errorf("len(enc) = %d, want %d", len(enc), len(v.Enc))
+			}
+			got, err := subtle.OpenBase(suite, v.SKRm, enc, v.Info, aad, ct)
+			if err != nil {
+				t.Fatalf("subtle.OpenBase() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("subtle.OpenBase() = %x, want %x", got, plaintext)
+			}
+		})
+	}
+}
+
+func TestOpenBaseFails(t *testing.T) {
+	v := baseModeVectors(t)[0]
+	suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+	e := v.Encryptions[0]
+	tamperedCiphertext := bytes.Clone(e.Ciphertext)
+	tamperedCiphertext[0] ^= 1
+	for _, tc := range []struct {
+		name  string
+		suite hpke.Suite
+		enc   []byte
+		info  []byte
+		aad   []byte
+		ct    []byte
+	}{
+		{"unsupported KEM", hpke.Suite{KEMID: 0x0021, KDFID: v.KDFID, AEADID: v.AEADID}, v.Enc, v.Info, e.AAD, e.Ciphertext},
+		{"unsupported KDF", hpke.Suite{KEMID: v.KEMID, KDFID: 0xFFFF, AEADID: v.AEADID}, v.Enc, v.Info, e.AAD, e.Ciphertext},
+		{"export-only AEAD", hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: 0xFFFF}, v.Enc, v.Info, e.AAD, e.Ciphertext},
+		{"truncated encapsulated key", suite, v.Enc[1:], v.Info, e.AAD, e.Ciphertext},
+		{"wrong info", suite, v.Enc, append(bytes.Clone(v.Info), 0), e.AAD, e.Ciphertext},
+		{"wrong associated data", suite, v.Enc, v.Info, append(bytes.Clone(e.AAD), 0), e.Ciphertext},
+		{"tampered ciphertext", suite, v.Enc, v.Info, e.AAD, tamperedCiphertext},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := subtle.OpenBase(tc.suite, v.SKRm, tc.enc, tc.info, tc.aad, tc.ct); err == nil {
+				t.Errorf("subtle.OpenBase() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestSealBaseFails(t *testing.T) {
+	v := baseModeVectors(t)[0]
+	suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+	for _, tc := range []struct {
+		name   string
+		suite  hpke.Suite
+		pubKey []byte
+	}{
+		{"unsupported KEM", hpke.Suite{KEMID: 0x0021, KDFID: v.KDFID, AEADID: v.AEADID}, v.PKRm},
+		{"export-only AEAD", hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: 0xFFFF}, v.PKRm},
+		{"empty public key", suite, nil},
+		{"truncated public key", suite, v.PKRm[1:]},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := subtle.SealBase(tc.suite, tc.pubKey, v.Info, nil, []byte("plaintext")); err == nil {
+				t.Errorf("subtle.SealBase() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestOpenAuthWithTestVectors(t *testing.T) {
+	for i, v := range authModeVectors(t) {
+		t.Run(fmt.Sprintf("%d_kem_%d_kdf_%d_aead_%d", i, v.KEMID, v.KDFID, v.AEADID), func(t *testing.T) {
+			suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+			e := v.Encryptions[0]
+			got, err := subtle.OpenAuth(suite, v.SKRm, v.PKSm, v.Enc, v.Info, e.AAD, e.Ciphertext)
+			if err != nil {
+				t.Fatalf("subtle.OpenAuth() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, e.Plaintext) {
+				t.Errorf("subtle.OpenAuth() = %x, want %x", got, e.Plaintext)
+			}
+		})
+	}
+}
+
+func TestSealAuthOpenAuth(t *testing.T) {
+	for i, v := range authModeVectors(t) {
+		t.Run(fmt.Sprintf("%d_kem_%d_kdf_%d_aead_%d", i, v.KEMID, v.KDFID, v.AEADID), func(t *testing.T) {
+			suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+			plaintext := []byte("plaintext")
+			aad := []byte("associated data")
+			enc, ct, err := subtle.SealAuth(suite, v.PKRm, v.SKSm, v.Info, aad, plaintext)
+			if err != nil {
+				t.Fatalf("subtle.SealAuth() err = %v, want nil", err)
+			}
+			got, err := subtle.OpenAuth(suite, v.SKRm, v.PKSm, enc, v.Info, aad, ct)
+			if err != nil {
+				t.Fatalf("subtle.OpenAuth() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("subtle.OpenAuth() = %x, want %x", got, plaintext)
+			}
+			if _, err := subtle.OpenBase(suite, v.SKRm, enc, v.Info, aad, ct); err == nil {
+				t.Errorf("subtle.OpenBase() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestOpenAuthFails(t *testing.T) {
+	v := authModeVectors(t)[0]
+	suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+	e := v.Encryptions[0]
+	for _, tc := range []struct {
+		name         string
+		senderPubKey []byte
+		enc          []byte
+	}{
+		{"wrong sender public key", v.PKRm, v.Enc},
+		{"empty sender public key", nil, v.Enc},
+		{"truncated sender public key", v.PKSm[1:], v.Enc},
+		{"truncated encapsulated key", v.PKSm, v.Enc[1:]},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := subtle.OpenAuth(suite, v.SKRm, tc.senderPubKey, tc.enc, v.Info, e.AAD, e.Ciphertext); err == nil {
+				t.Errorf("subtle.OpenAuth() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestSealAuthFails(t *testing.T) {
+	v := authModeVectors(t)[0]
+	suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+	for _, tc := range []struct {
+		name          string
+		pubKey        []byte
+		senderPrivKey []byte
+	}{
+		{"empty public key", nil, v.SKSm},
+		{"empty sender private key", v.PKRm, nil},
+		{"truncated sender private key", v.PKRm, v.SKSm[1:]},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := subtle.SealAuth(suite, tc.pubKey, tc.senderPrivKey, v.Info, nil, []byte("plaintext")); err == nil {
+				t.Errorf("subtle.SealAuth() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestReceiveExportBaseWithTestVectors(t *testing.T) {
+	exportOnlyTested := false
+	for i, v := range exportVectors(t) {
+		exportOnlyTested = exportOnlyTested || v.AEADID == 0xFFFF
+		t.Run(fmt.Sprintf("%d_kem_%d_kdf_%d_aead_%d", i, v.KEMID, v.KDFID, v.AEADID), func(t *testing.T) {
+			suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+			for _, e := range v.Exports {
+				got, err := subtle.ReceiveExportBase(suite, v.SKRm, v.Enc, v.Info, e.ExporterContext, e.Length)
+				if err != nil {
+					t.Fatalf("subtle.ReceiveExportBase() err = %v, want nil", err)
+				}
+				if !bytes.Equal(got, e.ExportedValue) {
+					t.Errorf("subtle.ReceiveExportBase(exporterContext = %x) = %x, want %x", e.ExporterContext, got, e.ExportedValue)
+				}
+			}
+		})
+	}
+	if !exportOnlyTested {
+		t.Error("no export-only test vectors were run")
+	}
+}
+
+func TestSendExportBaseReceiveExportBase(t *testing.T) {
+	for i, v := range exportVectors(t) {
+		t.Run(fmt.Sprintf("%d_kem_%d_kdf_%d_aead_%d", i, v.KEMID, v.KDFID, v.AEADID), func(t *testing.T) {
+			suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+			exporterContext := []byte("exporter context")
+			enc, exported, err := subtle.SendExportBase(suite, v.PKRm, v.Info, exporterContext, 42)
+			if err != nil {
+				t.Fatalf("subtle.SendExportBase() err = %v, want nil", err)
+			}
+			if len(exported) != 42 {
+				t.Errorf("len(exported) = %d, want 42", len(exported))
+			}
+			got, err := subtle.ReceiveExportBase(suite, v.SKRm, enc, v.Info, exporterContext, 42)
+			if err != nil {
+				t.Fatalf("subtle.ReceiveExportBase() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, exported) {
+				t.Errorf("subtle.ReceiveExportBase() = %x, want %x", got, exported)
+			}
+			other, err := subtle.ReceiveExportBase(suite, v.SKRm, enc, v.Info, []byte("other context"), 42)
+			if err != nil {
+				t.Fatalf("subtle.ReceiveExportBase() err = %v, want nil", err)
+			}
+			if bytes.Equal(other, exported) {
+				t.Error("subtle.ReceiveExportBase() with a different exporter context gave the same secret")
+			}
+		})
+	}
+}
+
+func TestExportOnlySuiteCannotSealOrOpen(t *testing.T) {
+	v := baseModeVectors(t)[0]
+	suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: 0xFFFF}
+	if _, _, err := subtle.SealBase(suite, v.PKRm, v.Info, nil, []byte("plaintext")); err == nil {
+		t.Error("subtle.SealBase() err = nil, want error")
+	}
+	enc, _, err := subtle.SendExportBase(suite, v.PKRm, v.Info, nil, 32)
+	if err != nil {
+		t.Fatalf("subtle.SendExportBase() err = %v, want nil", err)
+	}
+	if _, err := subtle.OpenBase(suite, v.SKRm, enc, v.Info, nil, []byte("ciphertext")); err == nil {
+		t.Error("subtle.OpenBase() err = nil, want error")
+	}
+}
+
+func TestReceiveExportBaseFails(t *testing.T) {
+	v := exportVectors(t)[0]
+	suite := hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: v.AEADID}
+	for _, tc := range []struct {
+		name   string
+		suite  hpke.Suite
+		enc    []byte
+		length int
+	}{
+		{"unsupported KEM", hpke.Suite{KEMID: 0x0021, KDFID: v.KDFID, AEADID: v.AEADID}, v.Enc, 32},
+		{"unsupported AEAD", hpke.Suite{KEMID: v.KEMID, KDFID: v.KDFID, AEADID: 0x0004}, v.Enc, 32},
+		{"truncated encapsulated key", suite, v.Enc[1:], 32},
+		{"negative length", suite, v.Enc, -1},
+		{"too long", suite, v.Enc, 255*64 + 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := subtle.ReceiveExportBase(tc.suite, v.SKRm, tc.enc, v.Info, nil, tc.length); err == nil {
+				t.Errorf("subtle.ReceiveExportBase() err = nil, want error")
+			}
+		})
+	}
+}
@@ -69,7 +69,7 @@ func newWrappedHybridDecrypt(ps *primitiveset.PrimitiveSet[tink.HybridDecrypt])
 }
 
 func createDecryptLogger(ps *primitiveset.PrimitiveSet[tink.HybridDecrypt]) (monitoring.Logger, error) {
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, nil
 	}
 	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
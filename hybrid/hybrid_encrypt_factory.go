@@ -75,7 +75,7 @@ func newEncryptPrimitiveSet(ps *primitiveset.PrimitiveSet[tink.HybridEncrypt]) (
 }
 
 func createEncryptLogger(ps *primitiveset.PrimitiveSet[tink.HybridEncrypt]) (monitoring.Logger, error) {
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, nil
 	}
 	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
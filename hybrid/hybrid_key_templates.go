@@ -93,6 +93,70 @@ func DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template() *tinkpb.K
 	)
 }
 
+// DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Key_Template creates a HPKE
+// key template with:
+//   - KEM: DHKEM_P384_HKDF_SHA384,
+//   - KDF: HKDF_SHA384, and
+//   - AEAD: AES_256_GCM.
+//
+// It adds the 5-byte Tink prefix to ciphertexts.
+func DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Key_Template() *tinkpb.KeyTemplate {
+	return createHPKEKeyTemplate(
+		hpkepb.HpkeKem_DHKEM_P384_HKDF_SHA384,
+		hpkepb.HpkeKdf_HKDF_SHA384,
+		hpkepb.HpkeAead_AES_256_GCM,
+		tinkpb.OutputPrefixType_TINK,
+	)
+}
+
+// DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Raw_Key_Template creates a
+// HPKE key template with:
+//   - KEM: DHKEM_P384_HKDF_SHA384,
+//   - KDF: HKDF_SHA384, and
+//   - AEAD: AES_256_GCM.
+//
+// It does not add a prefix to ciphertexts.
+func DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Raw_Key_Template() *tinkpb.KeyTemplate {
+	return createHPKEKeyTemplate(
+		hpkepb.HpkeKem_DHKEM_P384_HKDF_SHA384,
+		hpkepb.HpkeKdf_HKDF_SHA384,
+		hpkepb.HpkeAead_AES_256_GCM,
+		tinkpb.OutputPrefixType_RAW,
+	)
+}
+
+// DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Key_Template creates a HPKE
+// key template with:
+//   - KEM: DHKEM_P521_HKDF_SHA512,
+//   - KDF: HKDF_SHA512, and
+//   - AEAD: AES_256_GCM.
+//
+// It adds the 5-byte Tink prefix to ciphertexts.
+func DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Key_Template() *tinkpb.KeyTemplate {
+	return createHPKEKeyTemplate(
+		hpkepb.HpkeKem_DHKEM_P521_HKDF_SHA512,
+		hpkepb.HpkeKdf_HKDF_SHA512,
+		hpkepb.HpkeAead_AES_256_GCM,
+		tinkpb.OutputPrefixType_TINK,
+	)
+}
+
+// DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Raw_Key_Template creates a
+// HPKE key template with:
+//   - KEM: DHKEM_P521_HKDF_SHA512,
+//   - KDF: HKDF_SHA512, and
+//   - AEAD: AES_256_GCM.
+//
+// It does not add a prefix to ciphertexts.
+func DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Raw_Key_Template() *tinkpb.KeyTemplate {
+	return createHPKEKeyTemplate(
+		hpkepb.HpkeKem_DHKEM_P521_HKDF_SHA512,
+		hpkepb.HpkeKdf_HKDF_SHA512,
+		hpkepb.HpkeAead_AES_256_GCM,
+		tinkpb.OutputPrefixType_RAW,
+	)
+}
+
 // DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template creates a HPKE
 // key template with:
 //   - KEM: DHKEM_X25519_HKDF_SHA256,
@@ -219,6 +283,20 @@ func ECIESHKDFAES128GCMKeyTemplate() *tinkpb.KeyTemplate {
 	return createECIESAEADHKDFKeyTemplate(commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, commonpb.EcPointFormat_UNCOMPRESSED, aead.AES128GCMKeyTemplate(), salt)
 }
 
+// ECIESHKDFAES128GCMCompressedKeyTemplate creates an ECIES-AEAD-HKDF key
+// template with:
+//   - KEM: ECDH over NIST P-256, with compressed points
+//   - DEM: AES128-GCM
+//   - KDF: HKDF-HMAC-SHA256 with an empty salt
+//
+// It is the same as ECIESHKDFAES128GCMKeyTemplate, except that the ephemeral
+// public key in the ciphertexts is a 33-byte compressed point instead of a
+// 65-byte uncompressed one.
+func ECIESHKDFAES128GCMCompressedKeyTemplate() *tinkpb.KeyTemplate {
+	salt := []byte{}
+	return createECIESAEADHKDFKeyTemplate(commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, commonpb.EcPointFormat_COMPRESSED, aead.AES128GCMKeyTemplate(), salt)
+}
+
 // ECIESHKDFAES128CTRHMACSHA256KeyTemplate creates an ECIES-AEAD-HKDF key
 // template with:
 //   - KEM: ECDH over NIST P-256
@@ -23,6 +23,7 @@ import (
 	"github.com/tink-crypto/tink-go/v2/aead"
 	"github.com/tink-crypto/tink-go/v2/daead"
 	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/hybrid/ecies"
 	"github.com/tink-crypto/tink-go/v2/internal/tinkerror"
 	"github.com/tink-crypto/tink-go/v2/keyset"
 	"github.com/tink-crypto/tink-go/v2/testutil"
@@ -77,6 +78,10 @@ func TestKeyTemplates(t *testing.T) {
 			name:     "ECIES_P256_HKDF_HMAC_SHA256_AES128_GCM",
 			template: hybrid.ECIESHKDFAES128GCMKeyTemplate(),
 		},
+		{
+			name:     "ECIES_P256_COMPRESSED_HKDF_HMAC_SHA256_AES128_GCM",
+			template: hybrid.ECIESHKDFAES128GCMCompressedKeyTemplate(),
+		},
 		{
 			name:     "ECIES_P384_HKDF_HMAC_SHA384_AES256_GCM",
 			template: eciesP384AES256GCMKeyTemplate(),
@@ -109,6 +114,22 @@ func TestKeyTemplates(t *testing.T) {
 			name:     "DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_RAW",
 			template: hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template(),
 		},
+		{
+			name:     "DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM",
+			template: hybrid.DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Key_Template(),
+		},
+		{
+			name:     "DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_RAW",
+			template: hybrid.DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Raw_Key_Template(),
+		},
+		{
+			name:     "DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM",
+			template: hybrid.DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Key_Template(),
+		},
+		{
+			name:     "DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_RAW",
+			template: hybrid.DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Raw_Key_Template(),
+		},
 		{
 			name:     "DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM",
 			template: hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template(),
@@ -219,3 +240,39 @@ func TestKeyTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestECIESHKDFAES128GCMCompressedKeyTemplateUsesCompressedPoints(t *testing.T) {
+	privateHandle, err := keyset.NewHandle(hybrid.ECIESHKDFAES128GCMCompressedKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	entry, err := privateHandle.Primary()
+	if err != nil {
+		t.Fatalf("privateHandle.Primary() err = %v, want nil", err)
+	}
+	params := entry.Key().Parameters().(*ecies.Parameters)
+	if got, want := params.NISTCurvePointFormat(), ecies.CompressedPointFormat; got != want {
+		t.Errorf("params.NISTCurvePointFormat() = %v, want %v", got, want)
+	}
+
+	publicHandle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	enc, err := hybrid.NewHybridEncrypt(publicHandle)
+	if err != nil {
+		t.Fatalf("hybrid.NewHybridEncrypt() err = %v, want nil", err)
+	}
+	plaintext := []byte("plaintext")
+	ciphertext, err := enc.Encrypt(plaintext, nil)
+	if err != nil {
+		t.Fatalf("enc.Encrypt() err = %v, want nil", err)
+	}
+	// Output prefix, compressed point, IV, encrypted plaintext and tag.
+	if got, want := len(ciphertext), 5+33+12+len(plaintext)+16; got != want {
+		t.Errorf("len(ciphertext) = %d, want %d", got, want)
+	}
+	if point := ciphertext[5:]; point[0] != 0x02 && point[0] != 0x03 {
+		t.Errorf("ciphertext point starts with %#x, want 0x02 or 0x03", point[0])
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/subtle"
+	"github.com/tink-crypto/tink-go/v2/testutil"
+)
+
+type authModeVector struct {
+	KEMID        uint16            `json:"kem_id"`
+	PKRm         testutil.HexBytes `json:"pkRm"`
+	SKRm         testutil.HexBytes `json:"skRm"`
+	PKSm         testutil.HexBytes `json:"pkSm"`
+	SKSm         testutil.HexBytes `json:"skSm"`
+	SKEm         testutil.HexBytes `json:"skEm"`
+	Enc          testutil.HexBytes `json:"enc"`
+	SharedSecret testutil.HexBytes `json:"shared_secret"`
+}
+
+// hpkeAuthModeVectors returns the BoringSSL test vectors for HPKE auth mode
+// with a supported KEM.
+func hpkeAuthModeVectors(t *testing.T) []authModeVector {
+	t.Helper()
+	f, err := os.Open(getTestVectorsFilePath(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var vecs []struct {
+		Mode uint8 `json:"mode"`
+		authModeVector
+	}
+	if err := json.NewDecoder(f).Decode(&vecs); err != nil {
+		t.Fatal(err)
+	}
+	var authVecs []authModeVector
+	for _, v := range vecs {
+		if v.Mode != authMode {
+			continue
+		}
+		if _, err := newKEM(v.KEMID); err != nil {
+			continue
+		}
+		authVecs = append(authVecs, v.authModeVector)
+	}
+	if len(authVecs) == 0 {
+		t.Fatal("no test vectors found")
+	}
+	return authVecs
+}
+
+func TestAuthEncapsulateBoringSSLVectors(t *testing.T) {
+	defer func() { x25519KEMGeneratePrivateKey = subtle.GeneratePrivateKeyX25519 }()
+	for i, vec := range hpkeAuthModeVectors(t) {
+		t.Run(fmt.Sprintf("%d_kem_%d", i, vec.KEMID), func(t *testing.T) {
+			kem, err := newKEM(vec.KEMID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Use the ephemeral key of the vector.
+			switch k := kem.(type) {
+			case *nistCurvesKEM:
+				k.generatePrivateKey = func(io.Reader) (*ecdh.PrivateKey, error) {
+					return k.curve.NewPrivateKey(vec.SKEm)
+				}
+			case *x25519KEM:
+				x25519KEMGeneratePrivateKey = func() ([]byte, error) {
+					return vec.SKEm, nil
+				}
+			}
+			secret, enc, err := kem.authEncapsulate(vec.PKRm, vec.SKSm)
+			if err != nil {
+				t.Fatalf("authEncapsulate() err = %v, want nil", err)
+			}
+			if !bytes.Equal(secret, vec.SharedSecret) {
+				t.Errorf("authEncapsulate() shared secret = %x, want %x", secret, vec.SharedSecret)
+			}
+			if !bytes.Equal(enc, vec.Enc) {
+				t.Errorf("authEncapsulate() encapsulated key = %x, want %x", enc, vec.Enc)
+			}
+		})
+	}
+}
+
+func TestAuthDecapsulateBoringSSLVectors(t *testing.T) {
+	for i, vec := range hpkeAuthModeVectors(t) {
+		t.Run(fmt.Sprintf("%d_kem_%d", i, vec.KEMID), func(t *testing.T) {
+			kem, err := newKEM(vec.KEMID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			secret, err := kem.authDecapsulate(vec.Enc, vec.SKRm, vec.PKSm)
+			if err != nil {
+				t.Fatalf("authDecapsulate() err = %v, want nil", err)
+			}
+			if !bytes.Equal(secret, vec.SharedSecret) {
+				t.Errorf("authDecapsulate() shared secret = %x, want %x", secret, vec.SharedSecret)
+			}
+			// The shared secret depends on the sender's key.
+			secret, err = kem.authDecapsulate(vec.Enc, vec.SKRm, vec.PKRm)
+			if err != nil {
+				t.Fatalf("authDecapsulate() err = %v, want nil", err)
+			}
+			if bytes.Equal(secret, vec.SharedSecret) {
+				t.Errorf("authDecapsulate() with the wrong sender key = %x, want a different shared secret", secret)
+			}
+		})
+	}
+}
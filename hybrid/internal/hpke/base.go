@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"fmt"
+
+	pb "github.com/tink-crypto/tink-go/v2/proto/hpke_go_proto"
+)
+
+// SealBase encrypts plaintext to recipientPubKey in the base mode of HPKE for
+// the cipher suite identified by kemID, kdfID and aeadID, as the single-shot
+// SealBase of https://www.rfc-editor.org/rfc/rfc9180.html#section-6.1.
+func SealBase(kemID, kdfID, aeadID uint16, recipientPubKey, info, associatedData, plaintext []byte) (encapsulatedKey, ciphertext []byte, err error) {
+	kem, kdf, aead, err := newPrimitives(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := newSenderContext(&pb.HpkePublicKey{PublicKey: recipientPubKey}, kem, kdf, aead, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("newSenderContext: %v", err)
+	}
+	ciphertext, err = ctx.seal(plaintext, associatedData)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx.encapsulatedKey, ciphertext, nil
+}
+
+// OpenBase decrypts ciphertext with recipientPrivKey in the base mode of HPKE
+// for the cipher suite identified by kemID, kdfID and aeadID, as the
+// single-shot OpenBase of https://www.rfc-editor.org/rfc/rfc9180.html#section-6.1.
+func OpenBase(kemID, kdfID, aeadID uint16, recipientPrivKey, encapsulatedKey, info, associatedData, ciphertext []byte) ([]byte, error) {
+	kem, kdf, aead, err := newPrimitives(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, err
+	}
+	if len(encapsulatedKey) != kem.encapsulatedKeyLength() {
+		return nil, fmt.Errorf("encapsulated key (size %d) must have size %d", len(encapsulatedKey), kem.encapsulatedKeyLength())
+	}
+	ctx, err := newRecipientContext(encapsulatedKey, &pb.HpkePrivateKey{PrivateKey: recipientPrivKey}, kem, kdf, aead, info)
+	if err != nil {
+		return nil, fmt.Errorf("newRecipientContext: %v", err)
+	}
+	return ctx.open(ciphertext, associatedData)
+}
+
+// SendExportBase sets up a base mode HPKE sender context to recipientPubKey
+// for the cipher suite identified by kemID, kdfID and aeadID, and exports a
+// secret of length bytes from it, as the single-shot SendExport of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-6.2.
+func SendExportBase(kemID, kdfID, aeadID uint16, recipientPubKey, info, exporterContext []byte, length int) (encapsulatedKey, exportedSecret []byte, err error) {
+	kem, kdf, aead, err := newPrimitives(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := newSenderContext(&pb.HpkePublicKey{PublicKey: recipientPubKey}, kem, kdf, aead, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("newSenderContext: %v", err)
+	}
+	exportedSecret, err = ctx.export(exporterContext, length)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx.encapsulatedKey, exportedSecret, nil
+}
+
+// ReceiveExportBase sets up a base mode HPKE recipient context with
+// recipientPrivKey and encapsulatedKey for the cipher suite identified by
+// kemID, kdfID and aeadID, and exports a secret of length bytes from it, as the
+// single-shot ReceiveExport of
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-6.2.
+func ReceiveExportBase(kemID, kdfID, aeadID uint16, recipientPrivKey, encapsulatedKey, info, exporterContext []byte, length int) ([]byte, error) {
+	kem, kdf, aead, err := newPrimitives(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, err
+	}
+	if len(encapsulatedKey) != kem.encapsulatedKeyLength() {
+		return nil, fmt.Errorf("encapsulated key (size %d) must have size %d", len(encapsulatedKey), kem.encapsulatedKeyLength())
+	}
+	ctx, err := newRecipientContext(encapsulatedKey, &pb.HpkePrivateKey{PrivateKey: recipientPrivKey}, kem, kdf, aead, info)
+	if err != nil {
+		return nil, fmt.Errorf("newRecipientContext: %v", err)
+	}
+	return ctx.export(exporterContext, length)
+}
+
+// SealAuth encrypts plaintext to recipientPubKey in the auth mode of HPKE,
+// which additionally authenticates the sender holding senderPrivKey, for the
+// cipher suite identified by kemID, kdfID and aeadID, as the single-shot
+// SealAuth of https://www.rfc-editor.org/rfc/rfc9180.html#section-6.1.
+func SealAuth(kemID, kdfID, aeadID uint16, recipientPubKey, senderPrivKey, info, associatedData, plaintext []byte) (encapsulatedKey, ciphertext []byte, err error) {
+	kem, kdf, aead, err := newPrimitives(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := newAuthSenderContext(recipientPubKey, senderPrivKey, kem, kdf, aead, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("newAuthSenderContext: %v", err)
+	}
+	ciphertext, err = ctx.seal(plaintext, associatedData)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx.encapsulatedKey, ciphertext, nil
+}
+
+// OpenAuth decrypts ciphertext with recipientPrivKey in the auth mode of HPKE,
+// and verifies that it was encrypted by the sender of senderPubKey, for the
+// cipher suite identified by kemID, kdfID and aeadID, as the single-shot
+// OpenAuth of https://www.rfc-editor.org/rfc/rfc9180.html#section-6.1.
+func OpenAuth(kemID, kdfID, aeadID uint16, recipientPrivKey, senderPubKey, encapsulatedKey, info, associatedData, ciphertext []byte) ([]byte, error) {
+	kem, kdf, aead, err := newPrimitives(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, err
+	}
+	if len(encapsulatedKey) != kem.encapsulatedKeyLength() {
+		return nil, fmt.Errorf("encapsulated key (size %d) must have size %d", len(encapsulatedKey), kem.encapsulatedKeyLength())
+	}
+	ctx, err := newAuthRecipientContext(encapsulatedKey, recipientPrivKey, senderPubKey, kem, kdf, aead, info)
+	if err != nil {
+		return nil, fmt.Errorf("newAuthRecipientContext: %v", err)
+	}
+	return ctx.open(ciphertext, associatedData)
+}
@@ -25,11 +25,14 @@ import (
 
 type context struct {
 	aead              aead
+	kdf               kdf
+	suiteID           []byte
 	maxSequenceNumber *big.Int
 	sequenceNumber    *big.Int
 	key               []byte
 	baseNonce         []byte
 	encapsulatedKey   []byte
+	exporterSecret    []byte
 }
 
 // newSenderContext creates the HPKE sender context as per KeySchedule()
@@ -42,7 +45,7 @@ func newSenderContext(recipientPubKey *pb.HpkePublicKey, kem kem, kdf kdf, aead
 	if err != nil {
 		return nil, fmt.Errorf("encapsulate: %v", err)
 	}
-	return createContext(encapsulatedKey, sharedSecret, kem, kdf, aead, info)
+	return createContext(baseMode, encapsulatedKey, sharedSecret, kem, kdf, aead, info)
 }
 
 // newRecipientContext creates the HPKE recipient context as per KeySchedule()
@@ -55,38 +58,126 @@ func newRecipientContext(encapsulatedKey []byte, recipientPrivKey *pb.HpkePrivat
 	if err != nil {
 		return nil, fmt.Errorf("decapsulate: %v", err)
 	}
-	return createContext(encapsulatedKey, sharedSecret, kem, kdf, aead, info)
+	return createContext(baseMode, encapsulatedKey, sharedSecret, kem, kdf, aead, info)
 }
 
-func createContext(encapsulatedKey []byte, sharedSecret []byte, kem kem, kdf kdf, aead aead, info []byte) (*context, error) {
-	suiteID := hpkeSuiteID(kem.id(), kdf.id(), aead.id())
-	// In base mode, both the pre-shared key (default_psk) and pre-shared key ID
-	// (default_psk_id) are empty strings, see
-	// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1.1-4.
-	pskIDHash := kdf.labeledExtract(emptySalt, emptyIKM /*= default PSK ID*/, "psk_id_hash", suiteID)
-	infoHash := kdf.labeledExtract(emptySalt, info, "info_hash", suiteID)
-	keyScheduleCtx := keyScheduleContext(baseMode, pskIDHash, infoHash)
-	secret := kdf.labeledExtract(sharedSecret, emptyIKM /*= default PSK*/, "secret", suiteID)
-
-	key, err := kdf.labeledExpand(secret, keyScheduleCtx, "key", suiteID, aead.keyLength())
+// newAuthSenderContext creates the HPKE sender context of the auth mode as per
+// SetupAuthS() https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1.3.
+func newAuthSenderContext(recipientPubKey, senderPrivKey []byte, kem kem, kdf kdf, aead aead, info []byte) (*context, error) {
+	if len(recipientPubKey) == 0 {
+		return nil, errors.New("empty recipient public key")
+	}
+	if len(senderPrivKey) == 0 {
+		return nil, errors.New("empty sender private key")
+	}
+	sharedSecret, encapsulatedKey, err := kem.authEncapsulate(recipientPubKey, senderPrivKey)
 	if err != nil {
-		return nil, fmt.Errorf("labeledExpand of key: %v", err)
+		return nil, fmt.Errorf("authEncapsulate: %v", err)
 	}
-	baseNonce, err := kdf.labeledExpand(secret, keyScheduleCtx, "base_nonce", suiteID, aead.nonceLength())
+	return createContext(authMode, encapsulatedKey, sharedSecret, kem, kdf, aead, info)
+}
+
+// newAuthRecipientContext creates the HPKE recipient context of the auth mode
+// as per SetupAuthR() https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1.3.
+func newAuthRecipientContext(encapsulatedKey, recipientPrivKey, senderPubKey []byte, kem kem, kdf kdf, aead aead, info []byte) (*context, error) {
+	if len(recipientPrivKey) == 0 {
+		return nil, errors.New("empty recipient private key")
+	}
+	if len(senderPubKey) == 0 {
+		return nil, errors.New("empty sender public key")
+	}
+	sharedSecret, err := kem.authDecapsulate(encapsulatedKey, recipientPrivKey, senderPubKey)
 	if err != nil {
-		return nil, fmt.Errorf("labeledExpand of base nonce: %v", err)
+		return nil, fmt.Errorf("authDecapsulate: %v", err)
 	}
+	return createContext(authMode, encapsulatedKey, sharedSecret, kem, kdf, aead, info)
+}
 
+func createContext(mode uint8, encapsulatedKey []byte, sharedSecret []byte, kem kem, kdf kdf, aead aead, info []byte) (*context, error) {
+	// In base and auth mode, both the pre-shared key (default_psk) and
+	// pre-shared key ID (default_psk_id) are empty strings, see
+	// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1.1-4.
+	key, baseNonce, exporterSecret, err := keySchedule(mode, sharedSecret, info, emptyIKM /*= default PSK*/, emptyIKM /*= default PSK ID*/, kem, kdf, aead)
+	if err != nil {
+		return nil, err
+	}
 	return &context{
 		aead:              aead,
+		kdf:               kdf,
+		suiteID:           hpkeSuiteID(kem.id(), kdf.id(), aead.id()),
 		maxSequenceNumber: maxSequenceNumber(aead.nonceLength()),
 		sequenceNumber:    big.NewInt(0),
 		key:               key,
 		baseNonce:         baseNonce,
 		encapsulatedKey:   encapsulatedKey,
+		exporterSecret:    exporterSecret,
 	}, nil
 }
 
+// verifyPSKInputs checks that psk and pskID are consistent with mode, as per
+// VerifyPSKInputs() https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1-9.
+func verifyPSKInputs(mode uint8, psk, pskID []byte) error {
+	gotPSK := len(psk) != 0
+	gotPSKID := len(pskID) != 0
+	if gotPSK != gotPSKID {
+		return errors.New("inconsistent PSK inputs")
+	}
+	switch mode {
+	case baseMode, authMode:
+		if gotPSK {
+			return errors.New("PSK input provided when not needed")
+		}
+	case pskMode, authPSKMode:
+		if !gotPSK {
+			return errors.New("missing required PSK input")
+		}
+	default:
+		return fmt.Errorf("mode %d is not supported", mode)
+	}
+	return nil
+}
+
+// keySchedule derives the AEAD key, base nonce and exporter secret of a
+// context as per KeySchedule()
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.1-10.
+func keySchedule(mode uint8, sharedSecret, info, psk, pskID []byte, kem kem, kdf kdf, aead aead) (key, baseNonce, exporterSecret []byte, err error) {
+	if err := verifyPSKInputs(mode, psk, pskID); err != nil {
+		return nil, nil, nil, err
+	}
+	suiteID := hpkeSuiteID(kem.id(), kdf.id(), aead.id())
+	pskIDHash := kdf.labeledExtract(emptySalt, pskID, "psk_id_hash", suiteID)
+	infoHash := kdf.labeledExtract(emptySalt, info, "info_hash", suiteID)
+	keyScheduleCtx := keyScheduleContext(mode, pskIDHash, infoHash)
+	secret := kdf.labeledExtract(sharedSecret, psk, "secret", suiteID)
+
+	key, err = kdf.labeledExpand(secret, keyScheduleCtx, "key", suiteID, aead.keyLength())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("labeledExpand of key: %v", err)
+	}
+	baseNonce, err = kdf.labeledExpand(secret, keyScheduleCtx, "base_nonce", suiteID, aead.nonceLength())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("labeledExpand of base nonce: %v", err)
+	}
+	exporterSecret, err = kdf.labeledExpand(secret, keyScheduleCtx, "exp", suiteID, kdf.hashLength())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("labeledExpand of exporter secret: %v", err)
+	}
+	return key, baseNonce, exporterSecret, nil
+}
+
+// KeyScheduleForTesting runs the HPKE key schedule for the cipher suite
+// identified by kemID, kdfID and aeadID, and returns its secret outputs.
+//
+// It is only meant for comparing intermediate values with other HPKE
+// implementations.
+func KeyScheduleForTesting(mode uint8, kemID, kdfID, aeadID uint16, sharedSecret, info, psk, pskID []byte) (key, baseNonce, exporterSecret []byte, err error) {
+	kem, kdf, aead, err := newPrimitives(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return keySchedule(mode, sharedSecret, info, psk, pskID, kem, kdf, aead)
+}
+
 // maxSequenceNumber returns the maximum sequence number indicating that the
 // message limit is reached, calculated as per
 // https://www.rfc-editor.org/rfc/rfc9180.html#section-5.2-11.
@@ -156,3 +247,13 @@ func (c *context) open(ciphertext, associatedData []byte) ([]byte, error) {
 	}
 	return plaintext, nil
 }
+
+// export derives a secret of length bytes from the context's exporter secret
+// and exporterContext, defined as Context.Export in
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.3.
+func (c *context) export(exporterContext []byte, length int) ([]byte, error) {
+	if length < 0 || length > 255*c.kdf.hashLength() {
+		return nil, fmt.Errorf("export length %d is not in [0, %d]", length, 255*c.kdf.hashLength())
+	}
+	return c.kdf.labeledExpand(c.exporterSecret, exporterContext, "sec", c.suiteID, length)
+}
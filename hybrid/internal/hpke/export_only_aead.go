@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import "errors"
+
+// exportOnlyAEAD is the "Export-only" HPKE AEAD variant that implements
+// interface aead. A context using it can only export secrets; seal and open
+// always fail, as required by
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-7.3.
+type exportOnlyAEAD struct{}
+
+var _ aead = (*exportOnlyAEAD)(nil)
+
+func (e *exportOnlyAEAD) seal(key, nonce, plaintext, associatedData []byte) ([]byte, error) {
+	return nil, errors.New("the export-only AEAD does not support encryption")
+}
+
+func (e *exportOnlyAEAD) open(key, nonce, ciphertext, associatedData []byte) ([]byte, error) {
+	return nil, errors.New("the export-only AEAD does not support decryption")
+}
+
+func (e *exportOnlyAEAD) id() uint16 {
+	return exportOnly
+}
+
+func (e *exportOnlyAEAD) keyLength() int {
+	return 0
+}
+
+func (e *exportOnlyAEAD) nonceLength() int {
+	return 0
+}
@@ -67,6 +67,10 @@ func (h *hkdfKDF) extractAndExpand(salt, ikm []byte, ikmLabel string, info []byt
 	return h.labeledExpand(prk, info, infoLabel, suiteID, length)
 }
 
+func (h *hkdfKDF) hashLength() int {
+	return h.hashFunction.Size()
+}
+
 func (h *hkdfKDF) id() uint16 {
 	return h.kdfID
 }
@@ -27,7 +27,10 @@ const (
 	// All identifier values are specified in
 	// https://www.rfc-editor.org/rfc/rfc9180.html.
 	// Mode identifiers.
-	baseMode uint8 = 0x00
+	baseMode    uint8 = 0x00
+	pskMode     uint8 = 0x01
+	authMode    uint8 = 0x02
+	authPSKMode uint8 = 0x03
 
 	// KEM algorithm identifiers.
 	p256HKDFSHA256   uint16 = 0x0010
@@ -44,6 +47,7 @@ const (
 	aes128GCM        uint16 = 0x0001
 	aes256GCM        uint16 = 0x0002
 	chaCha20Poly1305 uint16 = 0x0003
+	exportOnly       uint16 = 0xFFFF
 
 	sha256 = "SHA256"
 	sha384 = "SHA384"
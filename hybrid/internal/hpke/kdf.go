@@ -39,6 +39,12 @@ type kdf interface {
 	// https://www.rfc-editor.org/rfc/rfc9180.html#section-4.1-3
 	extractAndExpand(salt, ikm []byte, ikmLabel string, info []byte, infoLabel string, suiteID []byte, length int) ([]byte, error)
 
+	// hashLength returns the output size in bytes of the underlying hash
+	// function, Nh in the RFC.
+	//
+	// https://www.rfc-editor.org/rfc/rfc9180.html#section-7.2
+	hashLength() int
+
 	// id returns the HPKE KDF algorithm identifier for the underlying KDF
 	// implementation.
 	//
@@ -30,6 +30,16 @@ type kem interface {
 	// to this function as Decap(). It is used by the recipient.
 	decapsulate(encapsulatedKey, recipientPrivKey []byte) ([]byte, error)
 
+	// authEncapsulate is like encapsulate, but additionally authenticates the
+	// sender with senderPrivKey. The HPKE RFC refers to this function as
+	// AuthEncap().
+	authEncapsulate(recipientPubKey, senderPrivKey []byte) ([]byte, []byte, error)
+
+	// authDecapsulate is like decapsulate, but additionally verifies that the
+	// sender holds the private key of senderPubKey. The HPKE RFC refers to this
+	// function as AuthDecap().
+	authDecapsulate(encapsulatedKey, recipientPrivKey, senderPubKey []byte) ([]byte, error)
+
 	// id returns the HPKE KEM algorithm identifier for the underlying KEM
 	// implementation.
 	//
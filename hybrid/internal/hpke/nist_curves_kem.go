@@ -81,7 +81,7 @@ func (x *nistCurvesKEM) encapsulate(recipientPubKeyBytes []byte) (sharedSecret,
 		return nil, nil, err
 	}
 	senderPubKeyBytes = senderPrivKey.PublicKey().Bytes()
-	sharedSecret, err = x.deriveKEMSharedSecret(dh, senderPubKeyBytes, recipientPubKeyBytes)
+	sharedSecret, err = x.deriveKEMSharedSecret(dh, slices.Concat(senderPubKeyBytes, recipientPubKeyBytes))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -102,7 +102,62 @@ func (x *nistCurvesKEM) decapsulate(senderPubKeyBytes, recipientPrivKeyBytes []b
 		return nil, err
 	}
 	recipientPubKeyBytes := recipientPrivKey.PublicKey().Bytes()
-	return x.deriveKEMSharedSecret(dh, senderPubKeyBytes, recipientPubKeyBytes)
+	return x.deriveKEMSharedSecret(dh, slices.Concat(senderPubKeyBytes, recipientPubKeyBytes))
+}
+
+func (x *nistCurvesKEM) authEncapsulate(recipientPubKeyBytes, senderPrivKeyBytes []byte) (sharedSecret, encapsulatedKey []byte, err error) {
+	ephemeralPrivKey, err := x.generatePrivateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	senderPrivKey, err := x.curve.NewPrivateKey(senderPrivKeyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	recipientPubKey, err := x.curve.NewPublicKey(recipientPubKeyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	dhEphemeral, err := ephemeralPrivKey.ECDH(recipientPubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	dhStatic, err := senderPrivKey.ECDH(recipientPubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encapsulatedKey = ephemeralPrivKey.PublicKey().Bytes()
+	kemContext := slices.Concat(encapsulatedKey, recipientPubKeyBytes, senderPrivKey.PublicKey().Bytes())
+	sharedSecret, err = x.deriveKEMSharedSecret(slices.Concat(dhEphemeral, dhStatic), kemContext)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sharedSecret, encapsulatedKey, nil
+}
+
+func (x *nistCurvesKEM) authDecapsulate(encapsulatedKey, recipientPrivKeyBytes, senderPubKeyBytes []byte) ([]byte, error) {
+	recipientPrivKey, err := x.curve.NewPrivateKey(recipientPrivKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	ephemeralPubKey, err := x.curve.NewPublicKey(encapsulatedKey)
+	if err != nil {
+		return nil, err
+	}
+	senderPubKey, err := x.curve.NewPublicKey(senderPubKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	dhEphemeral, err := recipientPrivKey.ECDH(ephemeralPubKey)
+	if err != nil {
+		return nil, err
+	}
+	dhStatic, err := recipientPrivKey.ECDH(senderPubKey)
+	if err != nil {
+		return nil, err
+	}
+	kemContext := slices.Concat(encapsulatedKey, recipientPrivKey.PublicKey().Bytes(), senderPubKeyBytes)
+	return x.deriveKEMSharedSecret(slices.Concat(dhEphemeral, dhStatic), kemContext)
 }
 
 func (x *nistCurvesKEM) id() uint16 {
@@ -113,9 +168,11 @@ func (x *nistCurvesKEM) encapsulatedKeyLength() int {
 	return kemLengths[x.kemID].nEnc
 }
 
-// deriveKEMSharedSecret returns a pseudorandom key obtained via the HKDF.
-func (x *nistCurvesKEM) deriveKEMSharedSecret(dh, senderPubKey, recipientPubKey []byte) ([]byte, error) {
-	ctx := slices.Concat(senderPubKey, recipientPubKey)
+// deriveKEMSharedSecret returns a pseudorandom key obtained via the HKDF from
+// the Diffie-Hellman output dh and kemContext, which is the concatenation of
+// the encapsulated key, the recipient's public key and, in auth mode, the
+// sender's public key.
+func (x *nistCurvesKEM) deriveKEMSharedSecret(dh, kemContext []byte) ([]byte, error) {
 	suiteID := kemSuiteID(x.kemID)
 	hmacHashLength, err := subtle.GetHashDigestSize(x.hmacHashAlg)
 	if err != nil {
@@ -129,7 +186,7 @@ func (x *nistCurvesKEM) deriveKEMSharedSecret(dh, senderPubKey, recipientPubKey
 		nil, /*=salt*/
 		dh,
 		"eae_prk",
-		ctx,
+		kemContext,
 		"shared_secret",
 		suiteID,
 		int(hmacHashLength))
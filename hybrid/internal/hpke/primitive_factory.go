@@ -52,6 +52,24 @@ func newPrimitivesFromProto(params *pb.HpkeParams) (kem, kdf, aead, error) {
 	return kem, kdf, aead, nil
 }
 
+// newPrimitives constructs the HPKE KEM, KDF and AEAD primitives of the cipher
+// suite identified by kemID, kdfID and aeadID.
+func newPrimitives(kemID, kdfID, aeadID uint16) (kem, kdf, aead, error) {
+	kem, err := newKEM(kemID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	kdf, err := newKDF(kdfID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	aead, err := newAEAD(aeadID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return kem, kdf, aead, nil
+}
+
 // newKEM constructs a HPKE KEM using kemID, which are specified at
 // https://www.rfc-editor.org/rfc/rfc9180.html#section-7.1.
 func newKEM(kemID uint16) (kem, error) {
@@ -128,6 +146,8 @@ func newAEAD(aeadID uint16) (aead, error) {
 		return newAESGCMAEAD(32)
 	case chaCha20Poly1305:
 		return &chaCha20Poly1305AEAD{}, nil
+	case exportOnly:
+		return &exportOnlyAEAD{}, nil
 	default:
 		return nil, fmt.Errorf("AEAD ID %d is not supported", aeadID)
 	}
@@ -142,8 +142,24 @@ func TestNewAEAD(t *testing.T) {
 	}
 }
 
+func TestNewAEADExportOnly(t *testing.T) {
+	aead, err := newAEAD(exportOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aead.id() != exportOnly {
+		t.Errorf("id: got %d, want %d", aead.id(), exportOnly)
+	}
+	if _, err := aead.seal(nil, nil, []byte("plaintext"), nil); err == nil {
+		t.Error("seal: got success, want err")
+	}
+	if _, err := aead.open(nil, nil, []byte("ciphertext"), nil); err == nil {
+		t.Error("open: got success, want err")
+	}
+}
+
 func TestNewAEADUnsupportedID(t *testing.T) {
-	if _, err := newAEAD(0xFFFF /*= Export-only*/); err == nil {
+	if _, err := newAEAD(0x0004); err == nil {
 		t.Fatal("newAEAD(unsupported ID): got success, want err")
 	}
 }
@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"fmt"
+)
+
+// SenderContext is an HPKE sender context, which seals a sequence of
+// messages for the same encapsulated key, as described in
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.2.
+//
+// SenderContext is not safe for concurrent use.
+type SenderContext struct {
+	ctx *context
+}
+
+// NewSenderContext sets up a new sender context in base mode with info.
+func (e *Encrypt) NewSenderContext(info []byte) (*SenderContext, error) {
+	ctx, err := newSenderContext(e.recipientPubKey, e.kem, e.kdf, e.aead, info)
+	if err != nil {
+		return nil, fmt.Errorf("newSenderContext: %v", err)
+	}
+	return &SenderContext{ctx: ctx}, nil
+}
+
+// EncapsulatedKey returns the encapsulated key, which the recipient needs to
+// set up its context.
+func (s *SenderContext) EncapsulatedKey() []byte {
+	return s.ctx.encapsulatedKey
+}
+
+// Seal encrypts plaintext with associatedData using the next sequence number.
+func (s *SenderContext) Seal(plaintext, associatedData []byte) ([]byte, error) {
+	return s.ctx.seal(plaintext, associatedData)
+}
+
+// RecipientContext is an HPKE recipient context, which opens a sequence of
+// messages sealed by a [SenderContext] in the same order.
+//
+// RecipientContext is not safe for concurrent use.
+type RecipientContext struct {
+	ctx *context
+}
+
+// EncapsulatedKeyLength returns the length of the encapsulated keys of d.
+func (d *Decrypt) EncapsulatedKeyLength() int {
+	return d.encapsulatedKeyLen
+}
+
+// NewRecipientContext sets up a new recipient context in base mode from
+// encapsulatedKey and info.
+func (d *Decrypt) NewRecipientContext(encapsulatedKey, info []byte) (*RecipientContext, error) {
+	if len(encapsulatedKey) != d.encapsulatedKeyLen {
+		return nil, fmt.Errorf("encapsulated key has length %d, want %d", len(encapsulatedKey), d.encapsulatedKeyLen)
+	}
+	ctx, err := newRecipientContext(encapsulatedKey, d.recipientPrivKey, d.kem, d.kdf, d.aead, info)
+	if err != nil {
+		return nil, fmt.Errorf("newRecipientContext: %v", err)
+	}
+	return &RecipientContext{ctx: ctx}, nil
+}
+
+// Open decrypts ciphertext with associatedData using the next sequence
+// number. The sequence number only advances if decryption succeeds.
+func (r *RecipientContext) Open(ciphertext, associatedData []byte) ([]byte, error) {
+	return r.ctx.open(ciphertext, associatedData)
+}
@@ -16,6 +16,7 @@ package hpke
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/tink-crypto/tink-go/v2/subtle"
 )
@@ -56,7 +57,7 @@ func (x *x25519KEM) encapsulate(recipientPubKey []byte) (sharedSecret, senderPub
 	if err != nil {
 		return nil, nil, err
 	}
-	sharedSecret, err = x.deriveKEMSharedSecret(dh, senderPubKey, recipientPubKey)
+	sharedSecret, err = x.deriveKEMSharedSecret(dh, slices.Concat(senderPubKey, recipientPubKey))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -72,7 +73,51 @@ func (x *x25519KEM) decapsulate(encapsulatedKey, recipientPrivKey []byte) ([]byt
 	if err != nil {
 		return nil, err
 	}
-	return x.deriveKEMSharedSecret(dh, encapsulatedKey, recipientPubKey)
+	return x.deriveKEMSharedSecret(dh, slices.Concat(encapsulatedKey, recipientPubKey))
+}
+
+func (x *x25519KEM) authEncapsulate(recipientPubKey, senderPrivKey []byte) (sharedSecret, encapsulatedKey []byte, err error) {
+	ephemeralPrivKey, err := x25519KEMGeneratePrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	dhEphemeral, err := subtle.ComputeSharedSecretX25519(ephemeralPrivKey, recipientPubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	dhStatic, err := subtle.ComputeSharedSecretX25519(senderPrivKey, recipientPubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encapsulatedKey, err = x25519KEMPublicFromPrivate(ephemeralPrivKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	senderPubKey, err := x25519KEMPublicFromPrivate(senderPrivKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	sharedSecret, err = x.deriveKEMSharedSecret(slices.Concat(dhEphemeral, dhStatic), slices.Concat(encapsulatedKey, recipientPubKey, senderPubKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	return sharedSecret, encapsulatedKey, nil
+}
+
+func (x *x25519KEM) authDecapsulate(encapsulatedKey, recipientPrivKey, senderPubKey []byte) ([]byte, error) {
+	dhEphemeral, err := subtle.ComputeSharedSecretX25519(recipientPrivKey, encapsulatedKey)
+	if err != nil {
+		return nil, err
+	}
+	dhStatic, err := subtle.ComputeSharedSecretX25519(recipientPrivKey, senderPubKey)
+	if err != nil {
+		return nil, err
+	}
+	recipientPubKey, err := x25519KEMPublicFromPrivate(recipientPrivKey)
+	if err != nil {
+		return nil, err
+	}
+	return x.deriveKEMSharedSecret(slices.Concat(dhEphemeral, dhStatic), slices.Concat(encapsulatedKey, recipientPubKey, senderPubKey))
 }
 
 func (x *x25519KEM) id() uint16 {
@@ -83,12 +128,11 @@ func (x *x25519KEM) encapsulatedKeyLength() int {
 	return kemLengths[x.kemID].nEnc
 }
 
-// deriveKEMSharedSecret returns a pseudorandom key obtained via HKDF SHA256.
-func (x *x25519KEM) deriveKEMSharedSecret(dh, senderPubKey, recipientPubKey []byte) ([]byte, error) {
-	ctx := make([]byte, 0, len(senderPubKey)+len(recipientPubKey))
-	ctx = append(ctx, senderPubKey...)
-	ctx = append(ctx, recipientPubKey...)
-
+// deriveKEMSharedSecret returns a pseudorandom key obtained via HKDF SHA256
+// from the Diffie-Hellman output dh and kemContext, which is the concatenation
+// of the encapsulated key, the recipient's public key and, in auth mode, the
+// sender's public key.
+func (x *x25519KEM) deriveKEMSharedSecret(dh, kemContext []byte) ([]byte, error) {
 	suiteID := kemSuiteID(x25519HKDFSHA256)
 	macLength, err := subtle.GetHashDigestSize(x.macAlg)
 	if err != nil {
@@ -102,7 +146,7 @@ func (x *x25519KEM) deriveKEMSharedSecret(dh, senderPubKey, recipientPubKey []by
 		nil, /*=salt*/
 		dh,
 		"eae_prk",
-		ctx,
+		kemContext,
 		"shared_secret",
 		suiteID,
 		int(macLength))
@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/core/cryptofmt"
+	"github.com/tink-crypto/tink-go/v2/hybrid/internal/hpke"
+	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// EncryptSession encrypts a sequence of messages to the same recipient with a
+// single HPKE encapsulation, as described in
+// https://www.rfc-editor.org/rfc/rfc9180.html#section-5.2.
+//
+// The session is framed as follows: the header returned by
+// [EncryptSession.Header] is sent once, followed by the ciphertexts returned
+// by successive calls to [EncryptSession.Seal]. The header is the output
+// prefix of the key followed by the HPKE encapsulated key, and each
+// ciphertext is the bare AEAD ciphertext, without prefix. Every ciphertext is
+// encrypted with the next HPKE sequence number, so the recipient must decrypt
+// them in the order in which they were sealed.
+//
+// EncryptSession is not safe for concurrent use.
+type EncryptSession struct {
+	ctx    *hpke.SenderContext
+	header []byte
+}
+
+// NewHybridEncryptSession returns an [EncryptSession] that encrypts with the
+// primary key of handle, which must be an HPKE public key, and contextInfo as
+// the HPKE info. Other hybrid encryption schemes cannot encrypt more than one
+// message per encapsulation and are not supported.
+func NewHybridEncryptSession(handle *keyset.Handle, contextInfo []byte) (*EncryptSession, error) {
+	ps, err := keyset.Primitives[tink.HybridEncrypt](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.NewHybridEncryptSession: cannot obtain primitive set: %v", err)
+	}
+	encrypt, ok := ps.Primary.Primitive.(*hpke.Encrypt)
+	if !ok {
+		return nil, fmt.Errorf("hybrid.NewHybridEncryptSession: primary key is not an HPKE public key")
+	}
+	ctx, err := encrypt.NewSenderContext(contextInfo)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.NewHybridEncryptSession: %v", err)
+	}
+	encapsulatedKey := ctx.EncapsulatedKey()
+	header := make([]byte, 0, len(ps.Primary.Prefix)+len(encapsulatedKey))
+	header = append(header, ps.Primary.Prefix...)
+	header = append(header, encapsulatedKey...)
+	return &EncryptSession{ctx: ctx, header: header}, nil
+}
+
+// Header returns the session header, which must be sent to the recipient
+// once, before the ciphertexts, and passed to [NewHybridDecryptSession].
+func (s *EncryptSession) Header() []byte {
+	return s.header
+}
+
+// Seal encrypts plaintext with associatedData as the next message of the
+// session.
+func (s *EncryptSession) Seal(plaintext, associatedData []byte) ([]byte, error) {
+	ct, err := s.ctx.Seal(plaintext, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.EncryptSession: %v", err)
+	}
+	return ct, nil
+}
+
+// DecryptSession decrypts the messages of a session encrypted by an
+// [EncryptSession], in the order in which they were sealed.
+//
+// DecryptSession is not safe for concurrent use.
+type DecryptSession struct {
+	// candidates are the recipient contexts of the keys that may have been
+	// used by the sender. HPKE decapsulation does not detect a wrong key, so
+	// they are narrowed down to the one that opens the first message.
+	candidates []*hpke.RecipientContext
+}
+
+// NewHybridDecryptSession returns a [DecryptSession] for the session with the
+// given header, as returned by [EncryptSession.Header], and contextInfo. The
+// enabled HPKE private keys of handle that match the output prefix in header
+// are tried, followed by the RAW ones, as by the primitive that
+// [NewHybridDecrypt] returns.
+func NewHybridDecryptSession(handle *keyset.Handle, header, contextInfo []byte) (*DecryptSession, error) {
+	ps, err := keyset.Primitives[tink.HybridDecrypt](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("hybrid.NewHybridDecryptSession: cannot obtain primitive set: %v", err)
+	}
+	var candidates []*hpke.RecipientContext
+	addCandidates := func(prefix string, encapsulatedKey []byte) {
+		for _, entry := range ps.Entries[prefix] {
+			decrypt, ok := entry.Primitive.(*hpke.Decrypt)
+			if !ok {
+				continue
+			}
+			ctx, err := decrypt.NewRecipientContext(encapsulatedKey, contextInfo)
+			if err != nil {
+				continue
+			}
+			candidates = append(candidates, ctx)
+		}
+	}
+	if len(header) > cryptofmt.NonRawPrefixSize {
+		addCandidates(string(header[:cryptofmt.NonRawPrefixSize]), header[cryptofmt.NonRawPrefixSize:])
+	}
+	addCandidates(cryptofmt.RawPrefix, header)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("hybrid.NewHybridDecryptSession: no HPKE key matches the header")
+	}
+	return &DecryptSession{candidates: candidates}, nil
+}
+
+// Open decrypts ciphertext with associatedData as the next message of the
+// session. It fails if ciphertext is not the next message, in which case the
+// session is unchanged and the next message can still be opened.
+func (s *DecryptSession) Open(ciphertext, associatedData []byte) ([]byte, error) {
+	for _, ctx := range s.candidates {
+		pt, err := ctx.Open(ciphertext, associatedData)
+		if err == nil {
+			s.candidates = []*hpke.RecipientContext{ctx}
+			return pt, nil
+		}
+	}
+	return nil, fmt.Errorf("hybrid.DecryptSession: decryption failed")
+}
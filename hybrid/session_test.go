@@ -0,0 +1,224 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybrid_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func mustNewSession(t *testing.T, template *tinkpb.KeyTemplate, contextInfo []byte) (*keyset.Handle, *hybrid.EncryptSession) {
+	t.Helper()
+	privateHandle := mustNewHandle(t, template)
+	publicHandle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	session, err := hybrid.NewHybridEncryptSession(publicHandle, contextInfo)
+	if err != nil {
+		t.Fatalf("hybrid.NewHybridEncryptSession() err = %v, want nil", err)
+	}
+	return privateHandle, session
+}
+
+func TestSessionEncryptDecrypt(t *testing.T) {
+	contextInfo := []byte("context info")
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"TINK", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template()},
+		{"RAW", hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template()},
+		{"CHACHA20_POLY1305", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Key_Template()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			privateHandle, encSession := mustNewSession(t, tc.template, contextInfo)
+			var plaintexts, ciphertexts [][]byte
+			for i := 0; i < 5; i++ {
+				pt := []byte(fmt.Sprintf("message %d", i))
+				ct, err := encSession.Seal(pt, []byte("associated data"))
+				if err != nil {
+					t.Fatalf("encSession.Seal() err = %v, want nil", err)
+				}
+				plaintexts = append(plaintexts, pt)
+				ciphertexts = append(ciphertexts, ct)
+			}
+			decSession, err := hybrid.NewHybridDecryptSession(privateHandle, encSession.Header(), contextInfo)
+			if err != nil {
+				t.Fatalf("hybrid.NewHybridDecryptSession() err = %v, want nil", err)
+			}
+			for i, ct := range ciphertexts {
+				got, err := decSession.Open(ct, []byte("associated data"))
+				if err != nil {
+					t.Fatalf("decSession.Open(ciphertexts[%d]) err = %v, want nil", i, err)
+				}
+				if !bytes.Equal(got, plaintexts[i]) {
+					t.Errorf("decSession.Open(ciphertexts[%d]) = %q, want %q", i, got, plaintexts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSessionDecryptWithRotatedKeyset(t *testing.T) {
+	contextInfo := []byte("context info")
+	manager := keyset.NewManager()
+	oldKeyID, err := manager.Add(hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(oldKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	oldHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	newKeyID, err := manager.Add(hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(newKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	privateHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+
+	// Both keys are RAW, so the recipient cannot tell from the header which
+	// one the sender used.
+	publicHandle, err := oldHandle.Public()
+	if err != nil {
+		t.Fatalf("oldHandle.Public() err = %v, want nil", err)
+	}
+	encSession, err := hybrid.NewHybridEncryptSession(publicHandle, contextInfo)
+	if err != nil {
+		t.Fatalf("hybrid.NewHybridEncryptSession() err = %v, want nil", err)
+	}
+	decSession, err := hybrid.NewHybridDecryptSession(privateHandle, encSession.Header(), contextInfo)
+	if err != nil {
+		t.Fatalf("hybrid.NewHybridDecryptSession() err = %v, want nil", err)
+	}
+	for i := 0; i < 3; i++ {
+		pt := []byte(fmt.Sprintf("message %d", i))
+		ct, err := encSession.Seal(pt, nil)
+		if err != nil {
+			t.Fatalf("encSession.Seal() err = %v, want nil", err)
+		}
+		got, err := decSession.Open(ct, nil)
+		if err != nil {
+			t.Fatalf("decSession.Open() err = %v, want nil", err)
+		}
+		if !bytes.Equal(got, pt) {
+			t.Errorf("decSession.Open() = %q, want %q", got, pt)
+		}
+	}
+}
+
+func TestSessionOpenOutOfOrderFails(t *testing.T) {
+	contextInfo := []byte("context info")
+	privateHandle, encSession := mustNewSession(t, hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template(), contextInfo)
+	ct0, err := encSession.Seal([]byte("first"), nil)
+	if err != nil {
+		t.Fatalf("encSession.Seal() err = %v, want nil", err)
+	}
+	ct1, err := encSession.Seal([]byte("second"), nil)
+	if err != nil {
+		t.Fatalf("encSession.Seal() err = %v, want nil", err)
+	}
+	decSession, err := hybrid.NewHybridDecryptSession(privateHandle, encSession.Header(), contextInfo)
+	if err != nil {
+		t.Fatalf("hybrid.NewHybridDecryptSession() err = %v, want nil", err)
+	}
+	if _, err := decSession.Open(ct1, nil); err == nil {
+		t.Error("decSession.Open(ct1) err = nil, want error")
+	}
+	// A failed Open does not advance the session.
+	if got, err := decSession.Open(ct0, nil); err != nil || !bytes.Equal(got, []byte("first")) {
+		t.Fatalf("decSession.Open(ct0) = %q, %v, want %q, nil", got, err, "first")
+	}
+	if _, err := decSession.Open(ct0, nil); err == nil {
+		t.Error("decSession.Open(ct0) again err = nil, want error")
+	}
+	if got, err := decSession.Open(ct1, nil); err != nil || !bytes.Equal(got, []byte("second")) {
+		t.Errorf("decSession.Open(ct1) = %q, %v, want %q, nil", got, err, "second")
+	}
+}
+
+func TestSessionDecryptFails(t *testing.T) {
+	contextInfo := []byte("context info")
+	privateHandle, encSession := mustNewSession(t, hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template(), contextInfo)
+	ct, err := encSession.Seal([]byte("plaintext"), []byte("associated data"))
+	if err != nil {
+		t.Fatalf("encSession.Seal() err = %v, want nil", err)
+	}
+	header := encSession.Header()
+	modifiedHeader := bytes.Clone(header)
+	modifiedHeader[len(modifiedHeader)-1] ^= 1
+	otherHandle := mustNewHandle(t, hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template())
+
+	for _, tc := range []struct {
+		name           string
+		handle         *keyset.Handle
+		header         []byte
+		contextInfo    []byte
+		associatedData []byte
+	}{
+		{"modified header", privateHandle, modifiedHeader, contextInfo, []byte("associated data")},
+		{"wrong context info", privateHandle, header, []byte("other context info"), []byte("associated data")},
+		{"wrong associated data", privateHandle, header, contextInfo, []byte("other associated data")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			decSession, err := hybrid.NewHybridDecryptSession(tc.handle, tc.header, tc.contextInfo)
+			if err != nil {
+				t.Fatalf("hybrid.NewHybridDecryptSession() err = %v, want nil", err)
+			}
+			if _, err := decSession.Open(ct, tc.associatedData); err == nil {
+				t.Error("decSession.Open() err = nil, want error")
+			}
+		})
+	}
+
+	t.Run("wrong key", func(t *testing.T) {
+		if _, err := hybrid.NewHybridDecryptSession(otherHandle, header, contextInfo); err == nil {
+			t.Error("hybrid.NewHybridDecryptSession() err = nil, want error")
+		}
+	})
+	t.Run("truncated header", func(t *testing.T) {
+		if _, err := hybrid.NewHybridDecryptSession(privateHandle, header[:len(header)-1], contextInfo); err == nil {
+			t.Error("hybrid.NewHybridDecryptSession() err = nil, want error")
+		}
+	})
+}
+
+func TestSessionWithNonHPKEKeyFails(t *testing.T) {
+	privateHandle := mustNewHandle(t, hybrid.ECIESHKDFAES128GCMKeyTemplate())
+	publicHandle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	if _, err := hybrid.NewHybridEncryptSession(publicHandle, nil); err == nil {
+		t.Error("hybrid.NewHybridEncryptSession() err = nil, want error")
+	}
+	if _, err := hybrid.NewHybridDecryptSession(privateHandle, make([]byte, 70), nil); err == nil {
+		t.Error("hybrid.NewHybridDecryptSession() err = nil, want error")
+	}
+}
@@ -18,9 +18,12 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"google.golang.org/protobuf/proto"
 	"github.com/tink-crypto/tink-go/v2/keyset"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	eciespb "github.com/tink-crypto/tink-go/v2/proto/ecies_aead_hkdf_go_proto"
 	hpkepb "github.com/tink-crypto/tink-go/v2/proto/hpke_go_proto"
 	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
 )
@@ -32,6 +35,9 @@ const (
 
 	hpkePublicKeyTypeURL  = "type.googleapis.com/google.crypto.tink.HpkePublicKey"
 	hpkePrivateKeyTypeURL = "type.googleapis.com/google.crypto.tink.HpkePrivateKey"
+
+	eciesPublicKeyTypeURL  = "type.googleapis.com/google.crypto.tink.EciesAeadHkdfPublicKey"
+	eciesPrivateKeyTypeURL = "type.googleapis.com/google.crypto.tink.EciesAeadHkdfPrivateKey"
 )
 
 // SerializePrimaryPublicKey serializes a public keyset handle's primary key if
@@ -42,12 +48,71 @@ const (
 //   - DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Raw_Key_Template,
 //     which returns the KEM-encoding of the public key, i.e. SerializePublicKey
 //     in https://www.rfc-editor.org/rfc/rfc9180.html#section-7.1.1.
+//   - ECIES templates on NIST P-256, P-384 or P-521 with output prefix type
+//     RAW, such as ECIESHKDFAES128GCMKeyTemplate with the output prefix type
+//     set to RAW, which returns the public point encoded in the template's
+//     point format.
 func SerializePrimaryPublicKey(handle *keyset.Handle, template *tinkpb.KeyTemplate) ([]byte, error) {
+	if template.GetTypeUrl() == eciesPrivateKeyTypeURL {
+		return serializePrimaryECIESPublicKey(handle, template)
+	}
 	templateParams, err := hpkeParamsFromTemplate(template)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify key template: %v", err)
 	}
 
+	keyData, err := primaryPublicKeyData(handle, hpkePublicKeyTypeURL)
+	if err != nil {
+		return nil, err
+	}
+	hpkeKey := &hpkepb.HpkePublicKey{}
+	if err := proto.Unmarshal(keyData.GetValue(), hpkeKey); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HpkePublicKey %v: %v", hpkeKey, err)
+	}
+	// Check equality between HPKE params in handle's primary key and in
+	// template, as template's params have already been verified.
+	if !proto.Equal(templateParams, hpkeKey.GetParams()) {
+		return nil, errors.New("HPKE params in handle and template are not equal")
+	}
+	return hpkeKey.GetPublicKey(), nil
+}
+
+// serializePrimaryECIESPublicKey is SerializePrimaryPublicKey for ECIES
+// templates.
+func serializePrimaryECIESPublicKey(handle *keyset.Handle, template *tinkpb.KeyTemplate) ([]byte, error) {
+	templateParams, err := eciesParamsFromTemplate(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify key template: %v", err)
+	}
+
+	keyData, err := primaryPublicKeyData(handle, eciesPublicKeyTypeURL)
+	if err != nil {
+		return nil, err
+	}
+	eciesKey := &eciespb.EciesAeadHkdfPublicKey{}
+	if err := proto.Unmarshal(keyData.GetValue(), eciesKey); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal EciesAeadHkdfPublicKey: %v", err)
+	}
+	// Check equality between ECIES params in handle's primary key and in
+	// template, as template's params have already been verified.
+	if !proto.Equal(templateParams, eciesKey.GetParams()) {
+		return nil, errors.New("ECIES params in handle and template are not equal")
+	}
+
+	curve, err := GetCurve(templateParams.GetKemParams().GetCurveType().String())
+	if err != nil {
+		return nil, err
+	}
+	point := ECPoint{
+		X: new(big.Int).SetBytes(eciesKey.GetX()),
+		Y: new(big.Int).SetBytes(eciesKey.GetY()),
+	}
+	return PointEncode(curve, templateParams.GetEcPointFormat().String(), point)
+}
+
+// primaryPublicKeyData returns the key data of handle's primary key after
+// verifying that it is an enabled RAW public key with type URL typeURL.
+func primaryPublicKeyData(handle *keyset.Handle, typeURL string) (*tinkpb.KeyData, error) {
 	// Create keyset from handle.
 	w := new(bytes.Buffer)
 	if err := handle.WriteWithNoSecrets(keyset.NewBinaryWriter(w)); err != nil {
@@ -73,24 +138,13 @@ func SerializePrimaryPublicKey(handle *keyset.Handle, template *tinkpb.KeyTempla
 		if keyData.GetKeyMaterialType() != tinkpb.KeyData_ASYMMETRIC_PUBLIC {
 			return nil, errors.New("primary key is not asymmetric public")
 		}
-		if keyData.GetTypeUrl() != hpkePublicKeyTypeURL {
-			return nil, fmt.Errorf("primary key does not have key type URL %s", hpkePublicKeyTypeURL)
+		if keyData.GetTypeUrl() != typeURL {
+			return nil, fmt.Errorf("primary key does not have key type URL %s", typeURL)
 		}
-
-		hpkeKey := &hpkepb.HpkePublicKey{}
-		if err := proto.Unmarshal(keyData.GetValue(), hpkeKey); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal HpkePublicKey %v: %v", hpkeKey, err)
-		}
-		// Check equality between HPKE params in handle's primary key and in
-		// template, as template's params have already been verified.
-		if !proto.Equal(templateParams, hpkeKey.GetParams()) {
-			return nil, errors.New("HPKE params in handle and template are not equal")
-		}
-
-		return hpkeKey.GetPublicKey(), nil
+		return keyData, nil
 	}
 
-	return nil, errors.New("no valid primary HPKE public key in keyset")
+	return nil, errors.New("no valid primary public key in keyset")
 }
 
 // KeysetHandleFromSerializedPublicKey returns a keyset handle containing a
@@ -101,7 +155,13 @@ func SerializePrimaryPublicKey(handle *keyset.Handle, template *tinkpb.KeyTempla
 //     which requires pubKeyBytes to be the KEM-encoding of the public key, i.e.
 //     SerializePublicKey in
 //     https://www.rfc-editor.org/rfc/rfc9180.html#section-7.1.1.
+//   - ECIES templates on NIST P-256, P-384 or P-521 with output prefix type
+//     RAW, which require pubKeyBytes to be the public point encoded in the
+//     template's point format.
 func KeysetHandleFromSerializedPublicKey(pubKeyBytes []byte, template *tinkpb.KeyTemplate) (*keyset.Handle, error) {
+	if template.GetTypeUrl() == eciesPrivateKeyTypeURL {
+		return keysetHandleFromSerializedECIESPublicKey(pubKeyBytes, template)
+	}
 	params, err := hpkeParamsFromTemplate(template)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify key template: %v", err)
@@ -119,12 +179,47 @@ func KeysetHandleFromSerializedPublicKey(pubKeyBytes []byte, template *tinkpb.Ke
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal HpkePublicKey %v: %v", pubKey, err)
 	}
+	return newRawPublicKeysetHandle(hpkePublicKeyTypeURL, serializedPubKey)
+}
+
+// keysetHandleFromSerializedECIESPublicKey is
+// KeysetHandleFromSerializedPublicKey for ECIES templates.
+func keysetHandleFromSerializedECIESPublicKey(pubKeyBytes []byte, template *tinkpb.KeyTemplate) (*keyset.Handle, error) {
+	params, err := eciesParamsFromTemplate(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify key template: %v", err)
+	}
+	curve, err := GetCurve(params.GetKemParams().GetCurveType().String())
+	if err != nil {
+		return nil, err
+	}
+	point, err := PointDecode(curve, params.GetEcPointFormat().String(), pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pubKeyBytes: %v", err)
+	}
+
+	pubKey := &eciespb.EciesAeadHkdfPublicKey{
+		Version: 0,
+		Params:  params,
+		X:       point.X.Bytes(),
+		Y:       point.Y.Bytes(),
+	}
+	serializedPubKey, err := proto.Marshal(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal EciesAeadHkdfPublicKey: %v", err)
+	}
+	return newRawPublicKeysetHandle(eciesPublicKeyTypeURL, serializedPubKey)
+}
+
+// newRawPublicKeysetHandle returns a keyset handle containing a single primary
+// RAW public key with the given type URL and serialized key.
+func newRawPublicKeysetHandle(typeURL string, serializedPubKey []byte) (*keyset.Handle, error) {
 	ks := &tinkpb.Keyset{
 		PrimaryKeyId: 1,
 		Key: []*tinkpb.Keyset_Key{
 			{
 				KeyData: &tinkpb.KeyData{
-					TypeUrl:         hpkePublicKeyTypeURL,
+					TypeUrl:         typeURL,
 					Value:           serializedPubKey,
 					KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
 				},
@@ -168,3 +263,38 @@ func hpkeParamsFromTemplate(template *tinkpb.KeyTemplate) (*hpkepb.HpkeParams, e
 
 	return params, nil
 }
+
+// eciesParamsFromTemplate returns ECIES params after verifying that template is
+// supported.
+//
+// Supported templates are ECIES templates with output prefix type RAW on the
+// NIST P-256, P-384 or P-521 curves.
+func eciesParamsFromTemplate(template *tinkpb.KeyTemplate) (*eciespb.EciesAeadHkdfParams, error) {
+	if template.GetTypeUrl() != eciesPrivateKeyTypeURL {
+		return nil, fmt.Errorf("not key type URL %s", eciesPrivateKeyTypeURL)
+	}
+	if template.GetOutputPrefixType() != tinkpb.OutputPrefixType_RAW {
+		return nil, errors.New("not raw output prefix type")
+	}
+	keyFormat := &eciespb.EciesAeadHkdfKeyFormat{}
+	if err := proto.Unmarshal(template.GetValue(), keyFormat); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal EciesAeadHkdfKeyFormat(%v): %v", template.GetValue(), err)
+	}
+
+	params := keyFormat.GetParams()
+	switch curve := params.GetKemParams().GetCurveType(); curve {
+	case commonpb.EllipticCurveType_NIST_P256, commonpb.EllipticCurveType_NIST_P384, commonpb.EllipticCurveType_NIST_P521:
+	default:
+		return nil, fmt.Errorf("ECIES curve %s not supported", curve)
+	}
+	switch format := params.GetEcPointFormat(); format {
+	case commonpb.EcPointFormat_UNCOMPRESSED, commonpb.EcPointFormat_COMPRESSED, commonpb.EcPointFormat_DO_NOT_USE_CRUNCHY_UNCOMPRESSED:
+	default:
+		return nil, fmt.Errorf("ECIES point format %s not supported", format)
+	}
+	if params.GetDemParams().GetAeadDem() == nil {
+		return nil, errors.New("missing ECIES DEM key template")
+	}
+
+	return params, nil
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/tink-crypto/tink-go/v2/keyset"
 	"github.com/tink-crypto/tink-go/v2/subtle/random"
 	"github.com/tink-crypto/tink-go/v2/testutil"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	eciespb "github.com/tink-crypto/tink-go/v2/proto/ecies_aead_hkdf_go_proto"
 	hpkepb "github.com/tink-crypto/tink-go/v2/proto/hpke_go_proto"
 	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
 )
@@ -243,3 +245,171 @@ func mustCreateKeyDataFromBytes(t *testing.T, pubKeyBytes []byte, aeadID hpkepb.
 	}
 	return testutil.NewKeyData(typeURL, serializedPubKey, tinkpb.KeyData_ASYMMETRIC_PUBLIC)
 }
+
+func mustCreateECIESRawTemplate(t *testing.T, curve commonpb.EllipticCurveType, pointFormat commonpb.EcPointFormat) *tinkpb.KeyTemplate {
+	t.Helper()
+	template := hybrid.ECIESHKDFAES128GCMKeyTemplate()
+	keyFormat := &eciespb.EciesAeadHkdfKeyFormat{}
+	if err := proto.Unmarshal(template.GetValue(), keyFormat); err != nil {
+		t.Fatalf("proto.Unmarshal() err = %v, want nil", err)
+	}
+	keyFormat.GetParams().GetKemParams().CurveType = curve
+	keyFormat.GetParams().EcPointFormat = pointFormat
+	serializedKeyFormat, err := proto.Marshal(keyFormat)
+	if err != nil {
+		t.Fatalf("proto.Marshal(%v) err = %v, want nil", keyFormat, err)
+	}
+	return &tinkpb.KeyTemplate{
+		TypeUrl:          template.GetTypeUrl(),
+		Value:            serializedKeyFormat,
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}
+}
+
+func TestECIESPublicKeySerialization(t *testing.T) {
+	tests := []struct {
+		name        string
+		curve       commonpb.EllipticCurveType
+		pointFormat commonpb.EcPointFormat
+		pubKeyLen   int
+	}{
+		{"NIST_P256_UNCOMPRESSED", commonpb.EllipticCurveType_NIST_P256, commonpb.EcPointFormat_UNCOMPRESSED, 65},
+		{"NIST_P256_COMPRESSED", commonpb.EllipticCurveType_NIST_P256, commonpb.EcPointFormat_COMPRESSED, 33},
+		{"NIST_P256_DO_NOT_USE_CRUNCHY_UNCOMPRESSED", commonpb.EllipticCurveType_NIST_P256, commonpb.EcPointFormat_DO_NOT_USE_CRUNCHY_UNCOMPRESSED, 64},
+		{"NIST_P384_UNCOMPRESSED", commonpb.EllipticCurveType_NIST_P384, commonpb.EcPointFormat_UNCOMPRESSED, 97},
+		{"NIST_P521_COMPRESSED", commonpb.EllipticCurveType_NIST_P521, commonpb.EcPointFormat_COMPRESSED, 67},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyTemplate := mustCreateECIESRawTemplate(t, test.curve, test.pointFormat)
+			privHandle, err := keyset.NewHandle(keyTemplate)
+			if err != nil {
+				t.Fatalf("NewHandle(%v) err = %v, want nil", keyTemplate, err)
+			}
+			pubHandle, err := privHandle.Public()
+			if err != nil {
+				t.Fatalf("Public() err = %v, want nil", err)
+			}
+
+			// Export public key as bytes.
+			pubKeyBytes, err := subtle.SerializePrimaryPublicKey(pubHandle, keyTemplate)
+			if err != nil {
+				t.Fatalf("SerializePrimaryPublicKey(%v) err = %v, want nil", pubHandle, err)
+			}
+			if len(pubKeyBytes) != test.pubKeyLen {
+				t.Errorf("len(pubKeyBytes) = %d, want %d", len(pubKeyBytes), test.pubKeyLen)
+			}
+
+			// Import public key bytes as keyset handle.
+			gotPubHandle, err := subtle.KeysetHandleFromSerializedPublicKey(pubKeyBytes, keyTemplate)
+			if err != nil {
+				t.Fatalf("KeysetHandleFromSerializedPublicKey(%v, %v) err = %v, want nil", pubKeyBytes, keyTemplate, err)
+			}
+
+			plaintext := random.GetRandomBytes(200)
+			ctxInfo := random.GetRandomBytes(100)
+
+			// Encrypt with public keyset handle constructed from public key bytes.
+			enc, err := hybrid.NewHybridEncrypt(gotPubHandle)
+			if err != nil {
+				t.Fatalf("NewHybridEncrypt(%v) err = %v, want nil", gotPubHandle, err)
+			}
+			ciphertext, err := enc.Encrypt(plaintext, ctxInfo)
+			if err != nil {
+				t.Fatalf("Encrypt(%x, %x) err = %v, want nil", plaintext, ctxInfo, err)
+			}
+
+			// Decrypt with original private keyset handle.
+			dec, err := hybrid.NewHybridDecrypt(privHandle)
+			if err != nil {
+				t.Fatalf("NewHybridDecrypt(%v) err = %v, want nil", privHandle, err)
+			}
+			gotPlaintext, err := dec.Decrypt(ciphertext, ctxInfo)
+			if err != nil {
+				t.Fatalf("Decrypt(%x, %x) err = %v, want nil", plaintext, ctxInfo, err)
+			}
+			if !bytes.Equal(gotPlaintext, plaintext) {
+				t.Errorf("Decrypt(%x, %x) = %x, want %x", plaintext, ctxInfo, gotPlaintext, plaintext)
+			}
+		})
+	}
+}
+
+func TestSerializePrimaryPublicKeyECIESFails(t *testing.T) {
+	keyTemplate := mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_NIST_P256, commonpb.EcPointFormat_UNCOMPRESSED)
+	privHandle, err := keyset.NewHandle(keyTemplate)
+	if err != nil {
+		t.Fatalf("NewHandle(%v) err = %v, want nil", keyTemplate, err)
+	}
+	pubHandle, err := privHandle.Public()
+	if err != nil {
+		t.Fatalf("Public() err = %v, want nil", err)
+	}
+	hpkeTemplate := hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Raw_Key_Template()
+	hpkePrivHandle, err := keyset.NewHandle(hpkeTemplate)
+	if err != nil {
+		t.Fatalf("NewHandle(%v) err = %v, want nil", hpkeTemplate, err)
+	}
+	hpkePubHandle, err := hpkePrivHandle.Public()
+	if err != nil {
+		t.Fatalf("Public() err = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name     string
+		handle   *keyset.Handle
+		template *tinkpb.KeyTemplate
+	}{
+		{"TINK template", pubHandle, hybrid.ECIESHKDFAES128GCMKeyTemplate()},
+		{"CURVE25519 template", pubHandle, mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_CURVE25519, commonpb.EcPointFormat_UNCOMPRESSED)},
+		{"different point format", pubHandle, mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_NIST_P256, commonpb.EcPointFormat_COMPRESSED)},
+		{"different curve", pubHandle, mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_NIST_P384, commonpb.EcPointFormat_UNCOMPRESSED)},
+		{"HPKE handle", hpkePubHandle, keyTemplate},
+		{"ECIES handle with HPKE template", pubHandle, hpkeTemplate},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := subtle.SerializePrimaryPublicKey(test.handle, test.template); err == nil {
+				t.Errorf("SerializePrimaryPublicKey(%v, %v) err = nil, want error", test.handle, test.template)
+			}
+		})
+	}
+}
+
+func TestKeysetHandleFromSerializedPublicKeyECIESFails(t *testing.T) {
+	keyTemplate := mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_NIST_P256, commonpb.EcPointFormat_UNCOMPRESSED)
+	privHandle, err := keyset.NewHandle(keyTemplate)
+	if err != nil {
+		t.Fatalf("NewHandle(%v) err = %v, want nil", keyTemplate, err)
+	}
+	pubHandle, err := privHandle.Public()
+	if err != nil {
+		t.Fatalf("Public() err = %v, want nil", err)
+	}
+	pubKeyBytes, err := subtle.SerializePrimaryPublicKey(pubHandle, keyTemplate)
+	if err != nil {
+		t.Fatalf("SerializePrimaryPublicKey(%v) err = %v, want nil", pubHandle, err)
+	}
+	notOnCurve := bytes.Clone(pubKeyBytes)
+	notOnCurve[len(notOnCurve)-1] ^= 1
+
+	tests := []struct {
+		name        string
+		pubKeyBytes []byte
+		template    *tinkpb.KeyTemplate
+	}{
+		{"TINK template", pubKeyBytes, hybrid.ECIESHKDFAES128GCMKeyTemplate()},
+		{"CURVE25519 template", pubKeyBytes, mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_CURVE25519, commonpb.EcPointFormat_UNCOMPRESSED)},
+		{"different point format", pubKeyBytes, mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_NIST_P256, commonpb.EcPointFormat_COMPRESSED)},
+		{"different curve", pubKeyBytes, mustCreateECIESRawTemplate(t, commonpb.EllipticCurveType_NIST_P384, commonpb.EcPointFormat_UNCOMPRESSED)},
+		{"truncated point", pubKeyBytes[:len(pubKeyBytes)-1], keyTemplate},
+		{"point not on curve", notOnCurve, keyTemplate},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := subtle.KeysetHandleFromSerializedPublicKey(test.pubKeyBytes, test.template); err == nil {
+				t.Errorf("KeysetHandleFromSerializedPublicKey(%v, %v) err = nil, want error", test.pubKeyBytes, test.template)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"hash"
 
 	"github.com/tink-crypto/tink-go/v2/subtle"
+	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 const (
@@ -102,3 +103,70 @@ func (h *HMAC) VerifyMAC(mac []byte, data ...[]byte) error {
 	}
 	return errors.New("HMAC: invalid MAC")
 }
+
+// NewReusable returns a MAC with the same key and tag size as h that creates
+// the keyed hash once and resets it for every call, which is faster when
+// computing or verifying many MACs over small inputs. The returned MAC is not
+// safe for concurrent use.
+func (h *HMAC) NewReusable() (tink.MAC, error) {
+	if h.HashFunc == nil {
+		return nil, fmt.Errorf("hmac: invalid hash algorithm")
+	}
+	return &reusableHMAC{mac: hmac.New(h.HashFunc, h.key), tagSize: h.tagSize}, nil
+}
+
+type reusableHMAC struct {
+	mac     hash.Hash
+	tagSize uint32
+	buf     []byte
+}
+
+var _ tink.MAC = (*reusableHMAC)(nil)
+
+func (r *reusableHMAC) ComputeMAC(data []byte) ([]byte, error) {
+	r.mac.Reset()
+	r.mac.Write(data)
+	return r.mac.Sum(nil)[:r.tagSize], nil
+}
+
+func (r *reusableHMAC) VerifyMAC(mac, data []byte) error {
+	r.mac.Reset()
+	r.mac.Write(data)
+	r.buf = r.mac.Sum(r.buf[:0])
+	if hmac.Equal(r.buf[:r.tagSize], mac) {
+		return nil
+	}
+	return errors.New("HMAC: invalid MAC")
+}
+
+// NewStreamingMAC returns a [tink.StreamingMAC] that computes the same MAC as
+// ComputeMAC over the concatenation of all written data.
+func (h *HMAC) NewStreamingMAC() (tink.StreamingMAC, error) {
+	if h.HashFunc == nil {
+		return nil, fmt.Errorf("hmac: invalid hash algorithm")
+	}
+	return &streamingMAC{mac: hmac.New(h.HashFunc, h.key), tagSize: h.tagSize}, nil
+}
+
+type streamingMAC struct {
+	mac      hash.Hash
+	tagSize  uint32
+	finished bool
+}
+
+var _ tink.StreamingMAC = (*streamingMAC)(nil)
+
+func (s *streamingMAC) Write(p []byte) (int, error) {
+	if s.finished {
+		return 0, errors.New("hmac: write after Finish")
+	}
+	return s.mac.Write(p)
+}
+
+func (s *streamingMAC) Finish() ([]byte, error) {
+	if s.finished {
+		return nil, errors.New("hmac: Finish called twice")
+	}
+	s.finished = true
+	return s.mac.Sum(nil)[:s.tagSize], nil
+}
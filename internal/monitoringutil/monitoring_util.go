@@ -16,6 +16,7 @@
 package monitoringutil
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -37,6 +38,26 @@ func (l *DoNothingLogger) Log(uint32, int) {}
 // LogFailure drops a failure call.
 func (l *DoNothingLogger) LogFailure() {}
 
+// LogContext logs a successful use of keyID on numBytes with logger, passing
+// ctx if logger is a [monitoring.ContextLogger].
+func LogContext(ctx context.Context, logger monitoring.Logger, keyID uint32, numBytes int) {
+	if l, ok := logger.(monitoring.ContextLogger); ok {
+		l.LogContext(ctx, keyID, numBytes)
+		return
+	}
+	logger.Log(keyID, numBytes)
+}
+
+// LogFailureContext logs a failure with logger, passing ctx if logger is a
+// [monitoring.ContextLogger].
+func LogFailureContext(ctx context.Context, logger monitoring.Logger) {
+	if l, ok := logger.(monitoring.ContextLogger); ok {
+		l.LogFailureContext(ctx)
+		return
+	}
+	logger.LogFailure()
+}
+
 func keyStatusFromProto(status tpb.KeyStatusType) (monitoring.KeyStatus, error) {
 	var keyStatus monitoring.KeyStatus = 55
 	switch status {
@@ -77,10 +98,11 @@ func KeysetInfoFromPrimitiveSet[T any](ps *primitiveset.PrimitiveSet[T]) (*monit
 				return nil, err
 			}
 			e := &monitoring.Entry{
-				KeyID:     pe.KeyID,
-				Status:    keyStatus,
-				KeyType:   parseKeyTypeURL(pe.TypeURL),
-				KeyPrefix: pe.PrefixType.String(),
+				KeyID:       pe.KeyID,
+				Status:      keyStatus,
+				KeyType:     parseKeyTypeURL(pe.TypeURL),
+				KeyPrefix:   pe.PrefixType.String(),
+				Annotations: ps.KeyAnnotations[pe.KeyID],
 			}
 			entries = append(entries, e)
 		}
@@ -148,3 +148,56 @@ func TestKeysetInfoFromPrimitiveSet(t *testing.T) {
 		t.Errorf("got = %v, want = %v, with diff: %v", got, want, cmp.Diff(got, want))
 	}
 }
+
+func TestKeysetInfoFromPrimitiveSetWithKeyAnnotations(t *testing.T) {
+	ps := &primitiveset.PrimitiveSet[tink.AEAD]{
+		Primary: &primitiveset.Entry[tink.AEAD]{
+			KeyID: 1,
+		},
+		KeyAnnotations: map[uint32]map[string]string{
+			1: {"team": "payments"},
+		},
+		Entries: map[string][]*primitiveset.Entry[tink.AEAD]{
+			// Adding all entries under the same prefix to get deterministic output.
+			"one": []*primitiveset.Entry[tink.AEAD]{
+				&primitiveset.Entry[tink.AEAD]{
+					KeyID:      1,
+					Status:     tpb.KeyStatusType_ENABLED,
+					TypeURL:    "type.googleapis.com/google.crypto.tink.AesGcmKey",
+					PrefixType: tpb.OutputPrefixType_TINK,
+				},
+				&primitiveset.Entry[tink.AEAD]{
+					KeyID:      2,
+					Status:     tpb.KeyStatusType_ENABLED,
+					TypeURL:    "type.googleapis.com/google.crypto.tink.AesGcmKey",
+					PrefixType: tpb.OutputPrefixType_TINK,
+				},
+			},
+		},
+	}
+	want := &monitoring.KeysetInfo{
+		PrimaryKeyID: 1,
+		Entries: []*monitoring.Entry{
+			{
+				KeyID:       1,
+				Status:      monitoring.Enabled,
+				KeyType:     "tink.AesGcmKey",
+				KeyPrefix:   "TINK",
+				Annotations: map[string]string{"team": "payments"},
+			},
+			{
+				KeyID:     2,
+				Status:    monitoring.Enabled,
+				KeyType:   "tink.AesGcmKey",
+				KeyPrefix: "TINK",
+			},
+		},
+	}
+	got, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
+	if err != nil {
+		t.Fatalf("KeysetInfoFromPrimitiveSet() err = %v, want nil", err)
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("got = %v, want = %v, with diff: %v", got, want, cmp.Diff(got, want))
+	}
+}
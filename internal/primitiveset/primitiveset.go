@@ -59,6 +59,8 @@ type PrimitiveSet[T any] struct {
 	EntriesInKeysetOrder []*Entry[T]
 
 	Annotations map[string]string
+	// KeyAnnotations holds per-key monitoring annotations, indexed by key ID.
+	KeyAnnotations map[uint32]map[string]string
 }
 
 // New returns an empty instance of PrimitiveSet.
@@ -556,6 +556,20 @@ func setKeyID(outKey *spb.Struct, key *tinkpb.Keyset_Key, customKID *string) err
 	return nil
 }
 
+// publicKeyToStruct converts a JWT public key into a JWK.
+func publicKeyToStruct(k *tinkpb.Keyset_Key) (*spb.Struct, error) {
+	switch k.GetKeyData().GetTypeUrl() {
+	case jwtECDSAPublicKeyType:
+		return esPublicKeyToStruct(k)
+	case jwtRSPublicKeyType:
+		return rsPublicKeyToStruct(k)
+	case jwtPSPublicKeyType:
+		return psPublicKeyToStruct(k)
+	default:
+		return nil, fmt.Errorf("unsupported key type url")
+	}
+}
+
 // JWKSetFromPublicKeysetHandle converts a Tink KeysetHandle with JWT keys into a Json Web Key (JWK) set.
 // Currently only public keys for algorithms ES256, ES384, ES512, RS256, RS384, and RS512 are supported.
 // JWK is defined in https://www.rfc-editor.org/rfc/rfc7517.html.
@@ -584,18 +598,7 @@ func JWKSetFromPublicKeysetHandle(kh *keyset.Handle) ([]byte, error) {
 		if keyData.GetKeyMaterialType() != tinkpb.KeyData_ASYMMETRIC_PUBLIC {
 			return nil, fmt.Errorf("only asymmetric public keys are supported")
 		}
-		keyStruct := &spb.Struct{}
-		var err error
-		switch keyData.GetTypeUrl() {
-		case jwtECDSAPublicKeyType:
-			keyStruct, err = esPublicKeyToStruct(k)
-		case jwtRSPublicKeyType:
-			keyStruct, err = rsPublicKeyToStruct(k)
-		case jwtPSPublicKeyType:
-			keyStruct, err = psPublicKeyToStruct(k)
-		default:
-			return nil, fmt.Errorf("unsupported key type url")
-		}
+		keyStruct, err := publicKeyToStruct(k)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	spb "google.golang.org/protobuf/types/known/structpb"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+// DiscrepancyType tells on which side of the comparison made by
+// [CheckJWKSetMatchesKeyset] a key is missing.
+type DiscrepancyType int
+
+const (
+	// NotInJWKSet is used for a key of the keyset that is not in the JWK set.
+	// Tokens signed with it cannot be verified with the JWK set.
+	NotInJWKSet DiscrepancyType = iota + 1
+	// NotInKeyset is used for a key of the JWK set that is not in the keyset.
+	NotInKeyset
+)
+
+func (t DiscrepancyType) String() string {
+	switch t {
+	case NotInJWKSet:
+		return "NotInJWKSet"
+	case NotInKeyset:
+		return "NotInKeyset"
+	default:
+		return fmt.Sprintf("DiscrepancyType(%d)", int(t))
+	}
+}
+
+// Discrepancy is a key that is in only one of a JWK set and a keyset.
+type Discrepancy struct {
+	Type DiscrepancyType
+	// KID is the "kid" of the key, or "" if it has none.
+	KID string
+	// JWK is the key as a JSON Web Key, in the form produced by
+	// [JWKSetFromPublicKeysetHandle].
+	JWK string
+}
+
+// CheckJWKSetMatchesKeyset compares the public keys in jwkSet with the enabled
+// public keys of handle, and returns the keys present in only one of them. An
+// empty result means that jwkSet is exactly what [JWKSetFromPublicKeysetHandle]
+// would publish for handle, up to the order of the keys.
+//
+// handle may contain private or public JWT signature keys. Keys are compared
+// on their algorithm, key material and "kid", after normalizing the keys of
+// jwkSet as [JWKSetToPublicKeysetHandle] and [JWKSetFromPublicKeysetHandle]
+// do, so that members such as "use" do not cause discrepancies. Like
+// [JWKSetToPublicKeysetHandle], it fails if jwkSet contains a key that Tink
+// does not support.
+//
+// The keys missing from the JWK set are returned first, in keyset order,
+// followed by the keys missing from the keyset, in JWK set order.
+func CheckJWKSetMatchesKeyset(jwkSet []byte, handle *keyset.Handle) ([]Discrepancy, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("jwt.CheckJWKSetMatchesKeyset: nil handle")
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		// The keyset may already be public.
+		publicHandle = handle
+	}
+	fromKeyset, err := JWKSetFromPublicKeysetHandle(publicHandle)
+	if err != nil {
+		return nil, fmt.Errorf("jwt.CheckJWKSetMatchesKeyset: %v", err)
+	}
+	keysetKeys, err := jwkSetKeys(fromKeyset)
+	if err != nil {
+		return nil, fmt.Errorf("jwt.CheckJWKSetMatchesKeyset: %v", err)
+	}
+	publishedKeys, err := jwkSetKeys(jwkSet)
+	if err != nil {
+		return nil, fmt.Errorf("jwt.CheckJWKSetMatchesKeyset: %v", err)
+	}
+	var normalizedKeys []*spb.Struct
+	for i, key := range publishedKeys {
+		tinkKey, err := keysetKeyFromStruct(spb.NewStructValue(key), uint32(i))
+		if err != nil {
+			return nil, fmt.Errorf("jwt.CheckJWKSetMatchesKeyset: key %d: %v", i, err)
+		}
+		normalized, err := publicKeyToStruct(tinkKey)
+		if err != nil {
+			return nil, fmt.Errorf("jwt.CheckJWKSetMatchesKeyset: key %d: %v", i, err)
+		}
+		normalizedKeys = append(normalizedKeys, normalized)
+	}
+	return compareJWKs(keysetKeys, normalizedKeys)
+}
+
+// jwkSetKeys returns the keys of a JWK set.
+func jwkSetKeys(jwkSet []byte) ([]*spb.Struct, error) {
+	s := &spb.Struct{}
+	if err := s.UnmarshalJSON(jwkSet); err != nil {
+		return nil, err
+	}
+	keyList, err := listValue(s, "keys")
+	if err != nil {
+		return nil, err
+	}
+	var keys []*spb.Struct
+	for _, v := range keyList.GetValues() {
+		key := v.GetStructValue()
+		if key == nil {
+			return nil, fmt.Errorf("key is not a JSON object")
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// compareJWKs returns the keys that are only in keysetKeys or only in
+// jwkSetKeys. Duplicates are counted, so a key listed twice in one and once in
+// the other is reported once.
+func compareJWKs(keysetKeys, jwkSetKeys []*spb.Struct) ([]Discrepancy, error) {
+	// encoding/json sorts the members of maps, so equal keys have equal
+	// encodings.
+	encode := func(key *spb.Struct) (string, error) {
+		b, err := json.Marshal(key.AsMap())
+		return string(b), err
+	}
+	remaining := make(map[string]int)
+	for _, key := range jwkSetKeys {
+		jwk, err := encode(key)
+		if err != nil {
+			return nil, err
+		}
+		remaining[jwk]++
+	}
+	var discrepancies []Discrepancy
+	for _, key := range keysetKeys {
+		jwk, err := encode(key)
+		if err != nil {
+			return nil, err
+		}
+		if remaining[jwk] > 0 {
+			remaining[jwk]--
+			continue
+		}
+		discrepancies = append(discrepancies, Discrepancy{Type: NotInJWKSet, KID: key.GetFields()["kid"].GetStringValue(), JWK: jwk})
+	}
+	for _, key := range jwkSetKeys {
+		jwk, err := encode(key)
+		if err != nil {
+			return nil, err
+		}
+		if remaining[jwk] > 0 {
+			remaining[jwk]--
+			discrepancies = append(discrepancies, Discrepancy{Type: NotInKeyset, KID: key.GetFields()["kid"].GetStringValue(), JWK: jwk})
+		}
+	}
+	return discrepancies, nil
+}
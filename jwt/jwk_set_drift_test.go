@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/jwt"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// kidForKeyID returns the "kid" of a JWT key with output prefix type TINK.
+func kidForKeyID(keyID uint32) string {
+	return base64.RawURLEncoding.EncodeToString(binary.BigEndian.AppendUint32(nil, keyID))
+}
+
+func publishJWKSet(t *testing.T, handle *keyset.Handle) []byte {
+	t.Helper()
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	jwkSet, err := jwt.JWKSetFromPublicKeysetHandle(publicHandle)
+	if err != nil {
+		t.Fatalf("jwt.JWKSetFromPublicKeysetHandle() err = %v, want nil", err)
+	}
+	return jwkSet
+}
+
+func TestCheckJWKSetMatchesKeysetNoDrift(t *testing.T) {
+	manager := keyset.NewManager()
+	for _, template := range []*tinkpb.KeyTemplate{
+		jwt.ES256Template(),
+		jwt.RawES256Template(),
+		jwt.RS256_2048_F4_Key_Template(),
+		jwt.PS256_2048_F4_Key_Template(),
+	} {
+		keyID, err := manager.Add(template)
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		if err := manager.SetPrimary(keyID); err != nil {
+			t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+		}
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	jwkSet := publishJWKSet(t, handle)
+
+	// Reorder the keys and add members that are dropped by normalization.
+	var parsed struct {
+		Keys []map[string]any `json:"keys"`
+	}
+	if err := json.Unmarshal(jwkSet, &parsed); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v, want nil", err)
+	}
+	for i, j := 0, len(parsed.Keys)-1; i < j; i, j = i+1, j-1 {
+		parsed.Keys[i], parsed.Keys[j] = parsed.Keys[j], parsed.Keys[i]
+	}
+	for _, key := range parsed.Keys {
+		key["use"] = "sig"
+		delete(key, "key_ops")
+	}
+	reordered, err := json.Marshal(parsed)
+	if err != nil {
+		t.Fatalf("json.Marshal() err = %v, want nil", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		jwkSet []byte
+		handle *keyset.Handle
+	}{
+		{"private keyset", jwkSet, handle},
+		{"public keyset", jwkSet, publicHandle},
+		{"reordered with extra members", reordered, handle},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := jwt.CheckJWKSetMatchesKeyset(tc.jwkSet, tc.handle)
+			if err != nil {
+				t.Fatalf("jwt.CheckJWKSetMatchesKeyset() err = %v, want nil", err)
+			}
+			if len(got) != 0 {
+				t.Errorf("jwt.CheckJWKSetMatchesKeyset() = %v, want no discrepancies", got)
+			}
+		})
+	}
+}
+
+func TestCheckJWKSetMatchesKeysetDetectsDrift(t *testing.T) {
+	manager := keyset.NewManager()
+	oldKeyID, err := manager.Add(jwt.ES256Template())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(oldKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	oldHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	oldJWKSet := publishJWKSet(t, oldHandle)
+
+	// Rotate in a new key and remove the old one, without republishing.
+	newKeyID, err := manager.Add(jwt.ES256Template())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(newKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	if err := manager.Disable(oldKeyID); err != nil {
+		t.Fatalf("manager.Disable() err = %v, want nil", err)
+	}
+	newHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+
+	got, err := jwt.CheckJWKSetMatchesKeyset(oldJWKSet, newHandle)
+	if err != nil {
+		t.Fatalf("jwt.CheckJWKSetMatchesKeyset() err = %v, want nil", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len(jwt.CheckJWKSetMatchesKeyset()) = %d, want 2: %v", len(got), got)
+	}
+	if got[0].Type != jwt.NotInJWKSet || got[0].KID != kidForKeyID(newKeyID) {
+		t.Errorf("got[0] = %v, want type %v with kid %q", got[0], jwt.NotInJWKSet, kidForKeyID(newKeyID))
+	}
+	if got[1].Type != jwt.NotInKeyset || got[1].KID != kidForKeyID(oldKeyID) {
+		t.Errorf("got[1] = %v, want type %v with kid %q", got[1], jwt.NotInKeyset, kidForKeyID(oldKeyID))
+	}
+	for _, d := range got {
+		if !json.Valid([]byte(d.JWK)) {
+			t.Errorf("Discrepancy.JWK = %q, want valid JSON", d.JWK)
+		}
+	}
+}
+
+func TestCheckJWKSetMatchesKeysetFails(t *testing.T) {
+	handle, err := keyset.NewHandle(jwt.ES256Template())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	macHandle, err := keyset.NewHandle(jwt.HS256Template())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	jwkSet := publishJWKSet(t, handle)
+	for _, tc := range []struct {
+		name   string
+		jwkSet []byte
+		handle *keyset.Handle
+	}{
+		{"nil handle", jwkSet, nil},
+		{"MAC keyset", jwkSet, macHandle},
+		{"invalid JSON", []byte("{"), handle},
+		{"no keys member", []byte(`{}`), handle},
+		{"unsupported key", []byte(`{"keys":[{"kty":"OKP","crv":"Ed25519","alg":"EdDSA","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`), handle},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := jwt.CheckJWKSetMatchesKeyset(tc.jwkSet, tc.handle); err == nil {
+				t.Error("jwt.CheckJWKSetMatchesKeyset() err = nil, want error")
+			}
+		})
+	}
+}
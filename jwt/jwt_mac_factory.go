@@ -63,7 +63,7 @@ func newWrappedJWTMAC(ps *primitiveset.PrimitiveSet[*macWithKID]) (*wrappedJWTMA
 }
 
 func createLoggers(ps *primitiveset.PrimitiveSet[*macWithKID]) (monitoring.Logger, monitoring.Logger, error) {
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, &monitoringutil.DoNothingLogger{}, nil
 	}
 	client := internalregistry.GetMonitoringClient()
@@ -48,7 +48,7 @@ var _ Signer = (*wrappedSigner)(nil)
 
 func createSignerLogger(ps *primitiveset.PrimitiveSet[*signerWithKID]) (monitoring.Logger, error) {
 	// only keysets which contain annotations are monitored.
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, nil
 	}
 	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
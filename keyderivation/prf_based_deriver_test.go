@@ -103,6 +103,18 @@ func TestPRFBasedDeriver(t *testing.T) {
 			name:     "ED25519",
 			template: signature.ED25519KeyTemplate(),
 		},
+		{
+			name:     "ECDSA_P256",
+			template: signature.ECDSAP256KeyTemplate(),
+		},
+		{
+			name:     "ECDSA_P384",
+			template: signature.ECDSAP384SHA384KeyTemplate(),
+		},
+		{
+			name:     "ECDSA_P521",
+			template: signature.ECDSAP521KeyTemplate(),
+		},
 		{
 			name:     "AES128_GCM_HKDF_4KB",
 			template: streamingaead.AES128GCMHKDF4KBKeyTemplate(),
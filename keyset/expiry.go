@@ -0,0 +1,246 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"time"
+
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// SetKeyExpiry returns a copy of handle in which the expiry time of the key
+// with ID keyID is t, replacing any expiry time previously set for that key.
+// The zero time removes it. handle itself is not modified.
+//
+// Like key annotations, expiry times are not serialized with the keyset.
+// Primitives obtained from the returned handle check the expiry time of a key
+// each time they use it, so long-lived primitives are affected too: once a key
+// has expired, encrypting, signing or computing a MAC with it fails, while
+// decrypting and verifying with it still succeed. This gives a grace period
+// until the key is disabled; use [ExpiredKeys] to find such keys.
+//
+// Expired keys are not excluded from primary selection: the primary stays the
+// key chosen by the keyset owner, so encrypting or signing fails once it has
+// expired instead of silently switching to another key, which other readers
+// of the keyset would not know about. Rotate to a new primary with
+// [Manager.SetPrimary] before the primary expires.
+//
+// Expiry times are only supported for the AEAD, deterministic AEAD, MAC,
+// signature, hybrid encryption and streaming AEAD primitives of the tink
+// package. Creating any other primitive from a handle in which a key has an
+// expiry time fails, for example JWT, PRF or key derivation primitives, as do
+// features that need the concrete primitive of a key, such as HPKE sessions.
+func SetKeyExpiry(handle *Handle, keyID uint32, t time.Time) (*Handle, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("keyset.SetKeyExpiry: nil handle")
+	}
+	if !handle.hasKeyID(keyID) {
+		return nil, fmt.Errorf("keyset.SetKeyExpiry: key %d not found", keyID)
+	}
+	keyExpiries := maps.Clone(handle.keyExpiries)
+	if t.IsZero() {
+		delete(keyExpiries, keyID)
+	} else {
+		if keyExpiries == nil {
+			keyExpiries = make(map[uint32]time.Time)
+		}
+		keyExpiries[keyID] = t
+	}
+	h := *handle
+	h.keyExpiries = keyExpiries
+	return &h, nil
+}
+
+// ExpiredKeys returns the IDs of the keys in handle whose expiry time, set
+// with [SetKeyExpiry], is not after now, in keyset order.
+func ExpiredKeys(handle *Handle, now time.Time) []uint32 {
+	if handle == nil {
+		return nil
+	}
+	var expired []uint32
+	for _, entry := range handle.entries {
+		if isExpired(handle, entry.keyID, now) {
+			expired = append(expired, entry.keyID)
+		}
+	}
+	return expired
+}
+
+func isExpired(handle *Handle, keyID uint32, now time.Time) bool {
+	expiry, ok := handle.keyExpiries[keyID]
+	return ok && !expiry.After(now)
+}
+
+// withExpiry returns primitive wrapped so that it checks the expiry time of
+// the key with ID keyID on each operation. It returns false if the type of
+// primitive is not one of the primitives of the tink package.
+func withExpiry(primitive any, keyID uint32, expiry time.Time) (any, bool) {
+	e := &keyExpiry{keyID: keyID, expiry: expiry}
+	switch p := primitive.(type) {
+	case tink.AEAD:
+		return &expiringAEAD{p, e}, true
+	case tink.DeterministicAEAD:
+		return &expiringDeterministicAEAD{p, e}, true
+	case tink.MAC:
+		return &expiringMAC{p, e}, true
+	case tink.Signer:
+		return &expiringSigner{p, e}, true
+	case tink.Verifier:
+		return &expiringVerifier{p, e}, true
+	case tink.HybridEncrypt:
+		return &expiringHybridEncrypt{p, e}, true
+	case tink.HybridDecrypt:
+		return &expiringHybridDecrypt{p, e}, true
+	case tink.StreamingAEAD:
+		return &expiringStreamingAEAD{p, e}, true
+	}
+	return nil, false
+}
+
+// keyExpiry is the expiry time of a key, as checked by the expiring primitives.
+type keyExpiry struct {
+	keyID  uint32
+	expiry time.Time
+}
+
+// checkCanProduce returns an error if the key has expired.
+func (e *keyExpiry) checkCanProduce() error {
+	if !e.expiry.After(time.Now()) {
+		return fmt.Errorf("keyset: key %d expired at %v", e.keyID, e.expiry)
+	}
+	return nil
+}
+
+type expiringAEAD struct {
+	p tink.AEAD
+	e *keyExpiry
+}
+
+func (a *expiringAEAD) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	if err := a.e.checkCanProduce(); err != nil {
+		return nil, err
+	}
+	return a.p.Encrypt(plaintext, associatedData)
+}
+
+func (a *expiringAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	return a.p.Decrypt(ciphertext, associatedData)
+}
+
+type expiringDeterministicAEAD struct {
+	p tink.DeterministicAEAD
+	e *keyExpiry
+}
+
+func (a *expiringDeterministicAEAD) EncryptDeterministically(plaintext, associatedData []byte) ([]byte, error) {
+	if err := a.e.checkCanProduce(); err != nil {
+		return nil, err
+	}
+	return a.p.EncryptDeterministically(plaintext, associatedData)
+}
+
+func (a *expiringDeterministicAEAD) DecryptDeterministically(ciphertext, associatedData []byte) ([]byte, error) {
+	return a.p.DecryptDeterministically(ciphertext, associatedData)
+}
+
+type expiringMAC struct {
+	p tink.MAC
+	e *keyExpiry
+}
+
+func (m *expiringMAC) ComputeMAC(data []byte) ([]byte, error) {
+	if err := m.e.checkCanProduce(); err != nil {
+		return nil, err
+	}
+	return m.p.ComputeMAC(data)
+}
+
+func (m *expiringMAC) VerifyMAC(mac, data []byte) error {
+	return m.p.VerifyMAC(mac, data)
+}
+
+// NewStreamingMAC implements [tink.StreamingMACComputer] if the wrapped MAC
+// does.
+func (m *expiringMAC) NewStreamingMAC() (tink.StreamingMAC, error) {
+	computer, ok := m.p.(tink.StreamingMACComputer)
+	if !ok {
+		return nil, fmt.Errorf("keyset: key %d does not support streaming", m.e.keyID)
+	}
+	if err := m.e.checkCanProduce(); err != nil {
+		return nil, err
+	}
+	return computer.NewStreamingMAC()
+}
+
+type expiringSigner struct {
+	p tink.Signer
+	e *keyExpiry
+}
+
+func (s *expiringSigner) Sign(data []byte) ([]byte, error) {
+	if err := s.e.checkCanProduce(); err != nil {
+		return nil, err
+	}
+	return s.p.Sign(data)
+}
+
+type expiringVerifier struct {
+	p tink.Verifier
+	e *keyExpiry
+}
+
+func (v *expiringVerifier) Verify(signature, data []byte) error {
+	return v.p.Verify(signature, data)
+}
+
+type expiringHybridEncrypt struct {
+	p tink.HybridEncrypt
+	e *keyExpiry
+}
+
+func (h *expiringHybridEncrypt) Encrypt(plaintext, contextInfo []byte) ([]byte, error) {
+	if err := h.e.checkCanProduce(); err != nil {
+		return nil, err
+	}
+	return h.p.Encrypt(plaintext, contextInfo)
+}
+
+type expiringHybridDecrypt struct {
+	p tink.HybridDecrypt
+	e *keyExpiry
+}
+
+func (h *expiringHybridDecrypt) Decrypt(ciphertext, contextInfo []byte) ([]byte, error) {
+	return h.p.Decrypt(ciphertext, contextInfo)
+}
+
+type expiringStreamingAEAD struct {
+	p tink.StreamingAEAD
+	e *keyExpiry
+}
+
+func (s *expiringStreamingAEAD) NewEncryptingWriter(w io.Writer, associatedData []byte) (io.WriteCloser, error) {
+	if err := s.e.checkCanProduce(); err != nil {
+		return nil, err
+	}
+	return s.p.NewEncryptingWriter(w, associatedData)
+}
+
+func (s *expiringStreamingAEAD) NewDecryptingReader(r io.Reader, associatedData []byte) (io.Reader, error) {
+	return s.p.NewDecryptingReader(r, associatedData)
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/jwt"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/prf"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestExpiredKeys(t *testing.T) {
+	manager := keyset.NewManager()
+	var keyIDs []uint32
+	for i := 0; i < 3; i++ {
+		keyID, err := manager.Add(aead.AES128GCMKeyTemplate())
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		keyIDs = append(keyIDs, keyID)
+	}
+	if err := manager.SetPrimary(keyIDs[2]); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i, expiry := range []time.Time{now.Add(-time.Hour), now, now.Add(time.Hour)} {
+		handle, err = keyset.SetKeyExpiry(handle, keyIDs[i], expiry)
+		if err != nil {
+			t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		now  time.Time
+		want []uint32
+	}{
+		{"before all", now.Add(-2 * time.Hour), nil},
+		{"at expiry", now, keyIDs[:2]},
+		{"after all", now.Add(2 * time.Hour), keyIDs},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, keyset.ExpiredKeys(handle, tc.now)); diff != "" {
+				t.Errorf("keyset.ExpiredKeys() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	removed, err := keyset.SetKeyExpiry(handle, keyIDs[0], time.Time{})
+	if err != nil {
+		t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+	}
+	if diff := cmp.Diff(keyIDs[1:2], keyset.ExpiredKeys(removed, now)); diff != "" {
+		t.Errorf("keyset.ExpiredKeys() after removing an expiry diff (-want +got):\n%s", diff)
+	}
+	// The handle passed to SetKeyExpiry is not modified.
+	if diff := cmp.Diff(keyIDs[:2], keyset.ExpiredKeys(handle, now)); diff != "" {
+		t.Errorf("keyset.ExpiredKeys() of the original handle diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestExpiredPrimaryCannotEncrypt(t *testing.T) {
+	manager := keyset.NewManager()
+	oldKeyID, err := manager.Add(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	newKeyID, err := manager.Add(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(oldKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	oldHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	oldAEAD, err := aead.New(oldHandle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	plaintext := []byte("plaintext")
+	ciphertext, err := oldAEAD.Encrypt(plaintext, nil)
+	if err != nil {
+		t.Fatalf("oldAEAD.Encrypt() err = %v, want nil", err)
+	}
+
+	expired := time.Now().Add(-time.Minute)
+	expiredHandle, err := keyset.SetKeyExpiry(oldHandle, oldKeyID, expired)
+	if err != nil {
+		t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+	}
+	expiredAEAD, err := aead.New(expiredHandle)
+	if err != nil {
+		t.Fatalf("aead.New() with an expired primary err = %v, want nil", err)
+	}
+	if _, err := expiredAEAD.Encrypt(plaintext, nil); err == nil {
+		t.Error("expiredAEAD.Encrypt() err = nil, want error")
+	}
+	if _, err := expiredAEAD.Decrypt(ciphertext, nil); err != nil {
+		t.Errorf("expiredAEAD.Decrypt() err = %v, want nil", err)
+	}
+
+	// After rotation, the expired key still decrypts.
+	if err := manager.SetPrimary(newKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	newHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	newHandle, err = keyset.SetKeyExpiry(newHandle, oldKeyID, expired)
+	if err != nil {
+		t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+	}
+	newAEAD, err := aead.New(newHandle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	if _, err := newAEAD.Encrypt(plaintext, nil); err != nil {
+		t.Errorf("newAEAD.Encrypt() err = %v, want nil", err)
+	}
+	if _, err := newAEAD.Decrypt(ciphertext, nil); err != nil {
+		t.Errorf("newAEAD.Decrypt() err = %v, want nil", err)
+	}
+}
+
+func TestPrimitiveStopsProducingAfterExpiry(t *testing.T) {
+	expiry := time.Now().Add(100 * time.Millisecond)
+
+	macHandle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	macHandle, err = keyset.SetKeyExpiry(macHandle, macHandle.KeysetInfo().GetPrimaryKeyId(), expiry)
+	if err != nil {
+		t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+	}
+	m, err := mac.New(macHandle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+
+	privateHandle, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	publicHandle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	keyID := privateHandle.KeysetInfo().GetPrimaryKeyId()
+	privateHandle, err = keyset.SetKeyExpiry(privateHandle, keyID, expiry)
+	if err != nil {
+		t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+	}
+	publicHandle, err = keyset.SetKeyExpiry(publicHandle, keyID, expiry)
+	if err != nil {
+		t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+	}
+	signer, err := signature.NewSigner(privateHandle)
+	if err != nil {
+		t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+	}
+	verifier, err := signature.NewVerifier(publicHandle)
+	if err != nil {
+		t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+	}
+
+	data := []byte("data")
+	tag, err := m.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("m.ComputeMAC() before expiry err = %v, want nil", err)
+	}
+	sig, err := signer.Sign(data)
+	if err != nil {
+		t.Fatalf("signer.Sign() before expiry err = %v, want nil", err)
+	}
+
+	time.Sleep(time.Until(expiry))
+
+	if _, err := m.ComputeMAC(data); err == nil {
+		t.Error("m.ComputeMAC() after expiry err = nil, want error")
+	}
+	if err := m.VerifyMAC(tag, data); err != nil {
+		t.Errorf("m.VerifyMAC() after expiry err = %v, want nil", err)
+	}
+	if _, err := signer.Sign(data); err == nil {
+		t.Error("signer.Sign() after expiry err = nil, want error")
+	}
+	if err := verifier.Verify(sig, data); err != nil {
+		t.Errorf("verifier.Verify() after expiry err = %v, want nil", err)
+	}
+}
+
+func TestUnsupportedPrimitiveWithExpiryFails(t *testing.T) {
+	expiry := time.Now().Add(time.Hour)
+	for _, tc := range []struct {
+		name      string
+		template  *tinkpb.KeyTemplate
+		primitive func(*keyset.Handle) error
+	}{
+		{
+			name:     "PRF",
+			template: prf.HMACSHA256PRFKeyTemplate(),
+			primitive: func(h *keyset.Handle) error {
+				_, err := prf.NewPRFSet(h)
+				return err
+			},
+		},
+		{
+			name:     "JWT MAC",
+			template: jwt.HS256Template(),
+			primitive: func(h *keyset.Handle) error {
+				_, err := jwt.NewMAC(h)
+				return err
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handle, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			if err := tc.primitive(handle); err != nil {
+				t.Fatalf("creating the primitive without expiry err = %v, want nil", err)
+			}
+			handle, err = keyset.SetKeyExpiry(handle, handle.KeysetInfo().GetPrimaryKeyId(), expiry)
+			if err != nil {
+				t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+			}
+			if err := tc.primitive(handle); err == nil {
+				t.Error("creating the primitive with expiry err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestSetKeyExpiryFails(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	if _, err := keyset.SetKeyExpiry(nil, 1, time.Now()); err == nil {
+		t.Error("keyset.SetKeyExpiry(nil) err = nil, want error")
+	}
+	unknownKeyID := handle.KeysetInfo().GetPrimaryKeyId() + 1
+	if _, err := keyset.SetKeyExpiry(handle, unknownKeyID, time.Now()); err == nil {
+		t.Error("keyset.SetKeyExpiry() with an unknown key err = nil, want error")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+const publicFingerprintContext = "tink-public-keyset-fingerprint-v1"
+
+// canonicalKeyMaterial returns the canonical encoding of the serialized key
+// proto value of the given type. See [PublicFingerprint] for the encoding.
+func canonicalKeyMaterial(typeURL string, value []byte) ([]byte, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, fmt.Errorf("unknown key proto %q: %v", typeURL, err)
+	}
+	m := mt.New().Interface()
+	if err := proto.Unmarshal(value, m); err != nil {
+		return nil, err
+	}
+	return appendCanonicalMessage(nil, m.ProtoReflect())
+}
+
+// appendCanonicalMessage appends the canonical encoding of m to b: its set
+// fields in field number order, each as its field number as a big-endian
+// uint32 followed by its value. The values of repeated fields are preceded by
+// their number as a big-endian uint64.
+func appendCanonicalMessage(b []byte, m protoreflect.Message) ([]byte, error) {
+	if len(m.GetUnknown()) > 0 {
+		return nil, fmt.Errorf("%s has unknown fields", m.Descriptor().FullName())
+	}
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	slices.SortFunc(fields, func(a, b protoreflect.FieldDescriptor) int { return int(a.Number()) - int(b.Number()) })
+	var err error
+	for _, fd := range fields {
+		b = binary.BigEndian.AppendUint32(b, uint32(fd.Number()))
+		v := m.Get(fd)
+		switch {
+		case fd.IsMap():
+			return nil, fmt.Errorf("%s is a map field", fd.FullName())
+		case fd.IsList():
+			list := v.List()
+			b = binary.BigEndian.AppendUint64(b, uint64(list.Len()))
+			for i := 0; i < list.Len(); i++ {
+				if b, err = appendCanonicalValue(b, fd, list.Get(i)); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			if b, err = appendCanonicalValue(b, fd, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// appendCanonicalValue appends the canonical encoding of the single value v
+// of field fd to b. Integers, booleans and enums are encoded as big-endian
+// uint64s, negative integers in two's complement; floating-point numbers as
+// their IEEE 754 bits as a big-endian uint64; strings and bytes as their
+// length-prefixed bytes; and messages as their length-prefixed canonical
+// encoding.
+func appendCanonicalValue(b []byte, fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		var u uint64
+		if v.Bool() {
+			u = 1
+		}
+		return binary.BigEndian.AppendUint64(b, u), nil
+	case protoreflect.EnumKind:
+		return binary.BigEndian.AppendUint64(b, uint64(v.Enum())), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return binary.BigEndian.AppendUint64(b, uint64(v.Int())), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return binary.BigEndian.AppendUint64(b, v.Uint()), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(v.Float())), nil
+	case protoreflect.StringKind:
+		return appendLengthPrefixed(b, []byte(v.String())), nil
+	case protoreflect.BytesKind:
+		return appendLengthPrefixed(b, v.Bytes()), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		encoded, err := appendCanonicalMessage(nil, v.Message())
+		if err != nil {
+			return nil, err
+		}
+		return appendLengthPrefixed(b, encoded), nil
+	}
+	return nil, fmt.Errorf("%s has unsupported kind %v", fd.FullName(), fd.Kind())
+}
+
+func appendLengthPrefixed(b, data []byte) []byte {
+	b = binary.BigEndian.AppendUint64(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// PublicFingerprint returns a SHA-256 fingerprint of the public keys in handle.
+//
+// The fingerprint covers the type, key material, output prefix type, key ID
+// and status of every key. It does not depend on the order of the keys in the
+// keyset, on which key is primary, or on the field order of the serialized key
+// protos, so parties holding the same set of public keys compute the same
+// fingerprint independently.
+//
+// Each key is encoded as the length-prefixed type URL, the length-prefixed
+// canonical key material, and the output prefix type, key ID and status as
+// big-endian uint32s. The canonical key material is the canonical encoding of
+// the key proto, which is defined from the parsed message rather than from its
+// serialization: the fields that are set, in field number order, each as its
+// field number as a big-endian uint32 followed by its value. Integers,
+// booleans and enums are encoded as big-endian uint64s, strings and bytes as
+// their length-prefixed bytes, and nested messages, such as the key
+// parameters, as their length-prefixed canonical encoding. The values of a
+// repeated field are preceded by their number as a big-endian uint64. Fields
+// of proto3 scalar types that hold their default value are not set, so they
+// are omitted whether or not they were serialized. The fingerprint is the
+// SHA-256 hash of the string "tink-public-keyset-fingerprint-v1", the number of
+// keys as a big-endian uint64, and the length-prefixed encodings of the keys
+// in lexicographic order, where all length prefixes are big-endian uint64s.
+//
+// It returns an error if the keyset contains secret or remote key material,
+// or keys of a type that is not registered with Tink.
+func PublicFingerprint(handle *Handle) ([]byte, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("keyset.PublicFingerprint: nil handle")
+	}
+	records := make([][]byte, 0, len(handle.entries))
+	for _, entry := range handle.entries {
+		protoKey, err := entryToProtoKey(entry)
+		if err != nil {
+			return nil, fmt.Errorf("keyset.PublicFingerprint: %v", err)
+		}
+		keyData := protoKey.GetKeyData()
+		if keyData.GetKeyMaterialType() != tinkpb.KeyData_ASYMMETRIC_PUBLIC {
+			return nil, fmt.Errorf("keyset.PublicFingerprint: key %d is not a public key", protoKey.GetKeyId())
+		}
+		// Keys of unknown types keep their serialization as read, so their
+		// nested messages could not be compared canonically.
+		if _, ok := entry.Key().(*protoserialization.FallbackProtoKey); ok {
+			return nil, fmt.Errorf("keyset.PublicFingerprint: key %d has unsupported type %q", protoKey.GetKeyId(), keyData.GetTypeUrl())
+		}
+		value, err := canonicalKeyMaterial(keyData.GetTypeUrl(), keyData.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("keyset.PublicFingerprint: key %d: %v", protoKey.GetKeyId(), err)
+		}
+		var record []byte
+		record = appendLengthPrefixed(record, []byte(keyData.GetTypeUrl()))
+		record = appendLengthPrefixed(record, value)
+		record = binary.BigEndian.AppendUint32(record, uint32(protoKey.GetOutputPrefixType()))
+		record = binary.BigEndian.AppendUint32(record, protoKey.GetKeyId())
+		record = binary.BigEndian.AppendUint32(record, uint32(protoKey.GetStatus()))
+		records = append(records, record)
+	}
+	slices.SortFunc(records, bytes.Compare)
+
+	h := sha256.New()
+	h.Write([]byte(publicFingerprintContext))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(records))))
+	for _, record := range records {
+		h.Write(appendLengthPrefixed(nil, record))
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// publicKeysetForFingerprint returns a public keyset with an ECDSA and an
+// Ed25519 key.
+func publicKeysetForFingerprint(t *testing.T) *tinkpb.Keyset {
+	t.Helper()
+	manager := keyset.NewManager()
+	for _, template := range []*tinkpb.KeyTemplate{
+		signature.ECDSAP256KeyTemplate(),
+		signature.ED25519KeyTemplate(),
+	} {
+		id, err := manager.Add(template)
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		if err := manager.SetPrimary(id); err != nil {
+			t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+		}
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	return testkeyset.KeysetMaterial(publicHandle)
+}
+
+func mustFingerprint(t *testing.T, ks *tinkpb.Keyset) []byte {
+	t.Helper()
+	handle, err := keyset.NewHandleWithNoSecrets(ks)
+	if err != nil {
+		t.Fatalf("keyset.NewHandleWithNoSecrets() err = %v, want nil", err)
+	}
+	fingerprint, err := keyset.PublicFingerprint(handle)
+	if err != nil {
+		t.Fatalf("keyset.PublicFingerprint() err = %v, want nil", err)
+	}
+	return fingerprint
+}
+
+// reverseFields returns the serialized proto message b with its top-level
+// fields in reverse order.
+func reverseFields(t *testing.T, b []byte) []byte {
+	t.Helper()
+	var fields [][]byte
+	for len(b) > 0 {
+		_, _, n := protowire.ConsumeField(b)
+		if n < 0 {
+			t.Fatalf("protowire.ConsumeField() failed: %v", protowire.ParseError(n))
+		}
+		fields = append(fields, b[:n])
+		b = b[n:]
+	}
+	slices.Reverse(fields)
+	return slices.Concat(fields...)
+}
+
+func TestPublicFingerprintIsCanonical(t *testing.T) {
+	ks := publicKeysetForFingerprint(t)
+	want := mustFingerprint(t, ks)
+	if len(want) != 32 {
+		t.Errorf("len(keyset.PublicFingerprint()) = %d, want 32", len(want))
+	}
+
+	reordered := proto.Clone(ks).(*tinkpb.Keyset)
+	slices.Reverse(reordered.Key)
+	if got := mustFingerprint(t, reordered); !bytes.Equal(got, want) {
+		t.Errorf("fingerprint with reordered keys = %x, want %x", got, want)
+	}
+
+	otherPrimary := proto.Clone(ks).(*tinkpb.Keyset)
+	otherPrimary.PrimaryKeyId = ks.GetKey()[0].GetKeyId()
+	if got := mustFingerprint(t, otherPrimary); !bytes.Equal(got, want) {
+		t.Errorf("fingerprint with other primary = %x, want %x", got, want)
+	}
+
+	reorderedFields := proto.Clone(ks).(*tinkpb.Keyset)
+	for _, k := range reorderedFields.GetKey() {
+		k.GetKeyData().Value = reverseFields(t, k.GetKeyData().GetValue())
+	}
+	if bytes.Equal(reorderedFields.GetKey()[0].GetKeyData().GetValue(), ks.GetKey()[0].GetKeyData().GetValue()) {
+		t.Fatal("reverseFields() did not change the serialized key")
+	}
+	if got := mustFingerprint(t, reorderedFields); !bytes.Equal(got, want) {
+		t.Errorf("fingerprint with reordered proto fields = %x, want %x", got, want)
+	}
+}
+
+func TestPublicFingerprintChangesWithKeys(t *testing.T) {
+	ks := publicKeysetForFingerprint(t)
+	fingerprint := mustFingerprint(t, ks)
+
+	if got := mustFingerprint(t, publicKeysetForFingerprint(t)); bytes.Equal(got, fingerprint) {
+		t.Errorf("fingerprints of different keysets are equal: %x", got)
+	}
+
+	fewerKeys := proto.Clone(ks).(*tinkpb.Keyset)
+	fewerKeys.Key = fewerKeys.Key[:1]
+	fewerKeys.PrimaryKeyId = fewerKeys.Key[0].GetKeyId()
+	if got := mustFingerprint(t, fewerKeys); bytes.Equal(got, fingerprint) {
+		t.Errorf("fingerprint with one key removed = %x, want different", got)
+	}
+
+	disabled := proto.Clone(ks).(*tinkpb.Keyset)
+	for _, k := range disabled.GetKey() {
+		if k.GetKeyId() != disabled.GetPrimaryKeyId() {
+			k.Status = tinkpb.KeyStatusType_DISABLED
+		}
+	}
+	if got := mustFingerprint(t, disabled); bytes.Equal(got, fingerprint) {
+		t.Errorf("fingerprint with a disabled key = %x, want different", got)
+	}
+}
+
+func TestPublicFingerprintFailsWithSecrets(t *testing.T) {
+	for _, template := range []*tinkpb.KeyTemplate{
+		signature.ECDSAP256KeyTemplate(),
+		mac.HMACSHA256Tag128KeyTemplate(),
+	} {
+		handle, err := keyset.NewHandle(template)
+		if err != nil {
+			t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+		}
+		if _, err := keyset.PublicFingerprint(handle); err == nil {
+			t.Errorf("keyset.PublicFingerprint() err = nil, want error")
+		}
+	}
+}
+
+func TestPublicFingerprintFailsWithUnknownKeyType(t *testing.T) {
+	ks := &tinkpb.Keyset{
+		PrimaryKeyId: 1,
+		Key: []*tinkpb.Keyset_Key{{
+			KeyData: &tinkpb.KeyData{
+				TypeUrl:         "type.googleapis.com/unknown.PublicKey",
+				Value:           []byte{0x12, 0x01, 0x01},
+				KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+			},
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			KeyId:            1,
+			OutputPrefixType: tinkpb.OutputPrefixType_TINK,
+		}},
+	}
+	handle, err := keyset.NewHandleWithNoSecrets(ks)
+	if err != nil {
+		t.Fatalf("keyset.NewHandleWithNoSecrets() err = %v, want nil", err)
+	}
+	if _, err := keyset.PublicFingerprint(handle); err == nil {
+		t.Error("keyset.PublicFingerprint() err = nil, want error")
+	}
+}
+
+func appendLengthPrefixed(b, data []byte) []byte {
+	return append(binary.BigEndian.AppendUint64(b, uint64(len(data))), data...)
+}
+
+func TestPublicFingerprintEncoding(t *testing.T) {
+	handle, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	key := testkeyset.KeysetMaterial(publicHandle).GetKey()[0]
+	publicKey := new(ecdsapb.EcdsaPublicKey)
+	if err := proto.Unmarshal(key.GetKeyData().GetValue(), publicKey); err != nil {
+		t.Fatalf("proto.Unmarshal() err = %v, want nil", err)
+	}
+
+	// The version is 0, so it is omitted.
+	var params []byte
+	params = binary.BigEndian.AppendUint32(params, 1)
+	params = binary.BigEndian.AppendUint64(params, uint64(publicKey.GetParams().GetHashType()))
+	params = binary.BigEndian.AppendUint32(params, 2)
+	params = binary.BigEndian.AppendUint64(params, uint64(publicKey.GetParams().GetCurve()))
+	params = binary.BigEndian.AppendUint32(params, 3)
+	params = binary.BigEndian.AppendUint64(params, uint64(publicKey.GetParams().GetEncoding()))
+	var material []byte
+	material = binary.BigEndian.AppendUint32(material, 2)
+	material = appendLengthPrefixed(material, params)
+	material = binary.BigEndian.AppendUint32(material, 3)
+	material = appendLengthPrefixed(material, publicKey.GetX())
+	material = binary.BigEndian.AppendUint32(material, 4)
+	material = appendLengthPrefixed(material, publicKey.GetY())
+	var record []byte
+	record = appendLengthPrefixed(record, []byte(key.GetKeyData().GetTypeUrl()))
+	record = appendLengthPrefixed(record, material)
+	record = binary.BigEndian.AppendUint32(record, uint32(key.GetOutputPrefixType()))
+	record = binary.BigEndian.AppendUint32(record, key.GetKeyId())
+	record = binary.BigEndian.AppendUint32(record, uint32(key.GetStatus()))
+	h := sha256.New()
+	h.Write([]byte("tink-public-keyset-fingerprint-v1"))
+	h.Write(binary.BigEndian.AppendUint64(nil, 1))
+	h.Write(appendLengthPrefixed(nil, record))
+	want := h.Sum(nil)
+
+	got, err := keyset.PublicFingerprint(publicHandle)
+	if err != nil {
+		t.Fatalf("keyset.PublicFingerprint() err = %v, want nil", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("keyset.PublicFingerprint() = %x, want %x", got, want)
+	}
+}
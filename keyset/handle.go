@@ -15,9 +15,12 @@
 package keyset
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"time"
 
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
@@ -39,6 +42,8 @@ var errInvalidKeyset = fmt.Errorf("keyset.Handle: invalid keyset")
 type Handle struct {
 	entries          []*Entry
 	annotations      map[string]string
+	keyAnnotations   map[uint32]map[string]string
+	keyExpiries      map[uint32]time.Time
 	keysetHasSecrets bool // Whether the keyset contains secret key material.
 	primaryKeyEntry  *Entry
 }
@@ -213,7 +218,15 @@ func newWithOptions(ks *tinkpb.Keyset, opts ...Option) (*Handle, error) {
 // NewHandle creates a keyset handle that contains a single fresh key generated according
 // to the given KeyTemplate.
 func NewHandle(kt *tinkpb.KeyTemplate) (*Handle, error) {
+	return NewHandleWithOptions(kt)
+}
+
+// NewHandleWithOptions is like [NewHandle], and applies opts, such as
+// [WithKeyIDSource] or [WithAnnotations], to the new handle.
+func NewHandleWithOptions(kt *tinkpb.KeyTemplate, opts ...Option) (*Handle, error) {
+	keyIDSource, opts := splitKeyIDSource(opts)
 	manager := NewManager()
+	manager.keyIDSource = keyIDSource
 	keyID, err := manager.Add(kt)
 	if err != nil {
 		return nil, fmt.Errorf("keyset.Handle: cannot generate new keyset: %s", err)
@@ -226,6 +239,9 @@ func NewHandle(kt *tinkpb.KeyTemplate) (*Handle, error) {
 	if err != nil {
 		return nil, fmt.Errorf("keyset.Handle: cannot get keyset handle: %s", err)
 	}
+	if err := applyOptions(handle, opts...); err != nil {
+		return nil, fmt.Errorf("keyset.Handle: %v", err)
+	}
 	return handle, nil
 }
 
@@ -248,8 +264,18 @@ func Read(reader Reader, masterKey tink.AEAD) (*Handle, error) {
 	return ReadWithAssociatedData(reader, masterKey, []byte{})
 }
 
+// ReadWithOptions is like [Read], and applies opts, such as
+// [RejectDuplicateKeyMaterial] or [WithAnnotations], to the handle.
+func ReadWithOptions(reader Reader, masterKey tink.AEAD, opts ...Option) (*Handle, error) {
+	return readWithOptions(reader, masterKey, []byte{}, opts...)
+}
+
 // ReadWithAssociatedData tries to create a Handle from an encrypted keyset obtained via reader using the provided associated data.
 func ReadWithAssociatedData(reader Reader, masterKey tink.AEAD, associatedData []byte) (*Handle, error) {
+	return readWithOptions(reader, masterKey, associatedData)
+}
+
+func readWithOptions(reader Reader, masterKey tink.AEAD, associatedData []byte, opts ...Option) (*Handle, error) {
 	encryptedKeyset, err := reader.ReadEncrypted()
 	if err != nil {
 		return nil, err
@@ -258,7 +284,7 @@ func ReadWithAssociatedData(reader Reader, masterKey tink.AEAD, associatedData [
 	if err != nil {
 		return nil, err
 	}
-	return newWithOptions(protoKeyset)
+	return newWithOptions(protoKeyset, opts...)
 }
 
 // ReadWithContext creates a keyset.Handle from an encrypted keyset obtained via
@@ -284,6 +310,17 @@ func ReadWithNoSecrets(reader Reader) (*Handle, error) {
 	return NewHandleWithNoSecrets(protoKeyset)
 }
 
+// UnmarshalNoSecrets creates a keyset.Handle from a keyset in binary proto
+// format, like ReadWithNoSecrets with a [BinaryReader]. It fails if the keyset
+// contains secret key material.
+func UnmarshalNoSecrets(b []byte) (*Handle, error) {
+	protoKeyset := &tinkpb.Keyset{}
+	if err := proto.Unmarshal(b, protoKeyset); err != nil {
+		return nil, fmt.Errorf("keyset.UnmarshalNoSecrets: %v", err)
+	}
+	return NewHandleWithNoSecrets(protoKeyset)
+}
+
 // Primary returns the primary key of the keyset.
 func (h *Handle) Primary() (*Entry, error) {
 	if h == nil {
@@ -295,6 +332,19 @@ func (h *Handle) Primary() (*Entry, error) {
 	return h.primaryKeyEntry, nil
 }
 
+// PrimaryKeyID returns the ID of the primary key of the keyset. It fails if
+// the keyset has no enabled primary key.
+func (h *Handle) PrimaryKeyID() (uint32, error) {
+	primary, err := h.Primary()
+	if err != nil {
+		return 0, err
+	}
+	if primary.KeyStatus() != Enabled {
+		return 0, fmt.Errorf("keyset.Handle: primary key is not enabled")
+	}
+	return primary.KeyID(), nil
+}
+
 // Entry returns the key at index i from the keyset.
 // i must be within the range [0, Handle.Len()).
 func (h *Handle) Entry(i int) (*Entry, error) {
@@ -348,6 +398,78 @@ func (h *Handle) Public() (*Handle, error) {
 	}, nil
 }
 
+// SetKeyAnnotations returns a copy of handle in which the monitoring
+// annotations of the key with ID keyID are replaced by annotations. A nil or
+// empty map removes them. handle itself is not modified.
+//
+// Key annotations are not serialized with the keyset. They are reported in
+// the Annotations field of the corresponding monitoring.Entry of primitives
+// obtained from the returned handle.
+func SetKeyAnnotations(handle *Handle, keyID uint32, annotations map[string]string) (*Handle, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("keyset.SetKeyAnnotations: nil handle")
+	}
+	if !handle.hasKeyID(keyID) {
+		return nil, fmt.Errorf("keyset.SetKeyAnnotations: key %d not found", keyID)
+	}
+	keyAnnotations := maps.Clone(handle.keyAnnotations)
+	if len(annotations) == 0 {
+		delete(keyAnnotations, keyID)
+	} else {
+		if keyAnnotations == nil {
+			keyAnnotations = make(map[uint32]map[string]string)
+		}
+		keyAnnotations[keyID] = maps.Clone(annotations)
+	}
+	h := *handle
+	h.keyAnnotations = keyAnnotations
+	return &h, nil
+}
+
+// hasKeyID tells whether h contains a key with ID keyID.
+func (h *Handle) hasKeyID(keyID uint32) bool {
+	for _, entry := range h.entries {
+		if entry.keyID == keyID {
+			return true
+		}
+	}
+	return false
+}
+
+// OutputPrefixTypes returns the number of keys in handle for each output
+// prefix type used in the keyset.
+func OutputPrefixTypes(handle *Handle) (map[tinkpb.OutputPrefixType]int, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("keyset.OutputPrefixTypes: nil handle")
+	}
+	counts := make(map[tinkpb.OutputPrefixType]int)
+	for _, keyInfo := range handle.KeysetInfo().GetKeyInfo() {
+		counts[keyInfo.GetOutputPrefixType()]++
+	}
+	return counts, nil
+}
+
+// ExpectPrimaryType returns an error if the primary key of handle does not
+// have the key type URL typeURL, for example
+// "type.googleapis.com/google.crypto.tink.AesGcmKey".
+//
+// This is meant for checking a keyset at startup, before it is passed to code
+// that only supports one key type.
+func ExpectPrimaryType(handle *Handle, typeURL string) error {
+	primary, err := handle.Primary()
+	if err != nil {
+		return fmt.Errorf("keyset.ExpectPrimaryType: %v", err)
+	}
+	protoKey, err := entryToProtoKey(primary)
+	if err != nil {
+		return fmt.Errorf("keyset.ExpectPrimaryType: %v", err)
+	}
+	if got := protoKey.GetKeyData().GetTypeUrl(); got != typeURL {
+		return fmt.Errorf("keyset.ExpectPrimaryType: primary key %d has type URL %q, want %q", primary.KeyID(), got, typeURL)
+	}
+	return nil
+}
+
 // String returns a string representation of the managed keyset.
 // The result does not contain any sensitive key material.
 func (h *Handle) String() string {
@@ -366,6 +488,32 @@ func (h *Handle) Len() int {
 	return len(h.entries)
 }
 
+// Equal reports whether h and other contain the same keys, in the same order,
+// with the same key IDs, statuses and primary key. Keys are compared with
+// [key.Key.Equal], which covers the key type, parameters (including the output
+// prefix type) and key material.
+//
+// Metadata attached to the handles, such as annotations, is not compared.
+// Equal is intended for tests and debugging. Two nil handles are equal.
+func (h *Handle) Equal(other *Handle) bool {
+	if h == nil || other == nil {
+		return h == other
+	}
+	if len(h.entries) != len(other.entries) {
+		return false
+	}
+	for i, entry := range h.entries {
+		o := other.entries[i]
+		if entry.keyID != o.keyID || entry.status != o.status || entry.isPrimary != o.isPrimary {
+			return false
+		}
+		if !entry.key.Equal(o.key) {
+			return false
+		}
+	}
+	return true
+}
+
 // KeysetInfo returns KeysetInfo representation of the managed keyset.
 // The result does not contain any sensitive key material.
 func (h *Handle) KeysetInfo() *tinkpb.KeysetInfo {
@@ -428,6 +576,17 @@ func (h *Handle) WriteWithNoSecrets(w Writer) error {
 	return w.Write(protoKeyset)
 }
 
+// MarshalNoSecrets serializes the keyset in handle in binary proto format, like
+// WriteWithNoSecrets with a [BinaryWriter]. It fails if the keyset contains
+// secret key material.
+func MarshalNoSecrets(handle *Handle) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := handle.WriteWithNoSecrets(NewBinaryWriter(buf)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Config defines methods in the config.Config concrete type that are used by keyset.Handle.
 // The config.Config concrete type is not used directly due to circular dependencies.
 type Config interface {
@@ -499,7 +658,7 @@ func PrimitivesWithKeyManager[T any](h *Handle, km registry.KeyManager, _ intern
 	return p, nil
 }
 
-func addToPrimitiveSet[T any](primitiveSet *primitiveset.PrimitiveSet[T], entry *Entry, km registry.KeyManager, config Config) (*primitiveset.Entry[T], error) {
+func addToPrimitiveSet[T any](h *Handle, primitiveSet *primitiveset.PrimitiveSet[T], entry *Entry, km registry.KeyManager, config Config) (*primitiveset.Entry[T], error) {
 	protoKey, err := entryToProtoKey(entry)
 	if err != nil {
 		return nil, err
@@ -522,6 +681,13 @@ func addToPrimitiveSet[T any](primitiveSet *primitiveset.PrimitiveSet[T], entry
 			}
 		}
 	}
+	if expiry, ok := h.keyExpiries[entry.keyID]; ok {
+		expiring, ok := withExpiry(primitive, entry.keyID, expiry)
+		if _, isT := expiring.(T); !ok || !isT {
+			return nil, fmt.Errorf("key %d has an expiry time, which is not supported for primitives of type %T", entry.keyID, primitive)
+		}
+		primitive = expiring
+	}
 	actualPrimitive, ok := primitive.(T)
 	if !ok {
 		return nil, fmt.Errorf("primitive is of type %T, want %T", primitive, (*T)(nil))
@@ -551,11 +717,12 @@ func primitives[T any](h *Handle, km registry.KeyManager, opts ...PrimitivesOpti
 	}
 	primitiveSet := primitiveset.New[T]()
 	primitiveSet.Annotations = h.annotations
+	primitiveSet.KeyAnnotations = h.keyAnnotations
 	for _, entry := range h.entries {
 		if entry.KeyStatus() != Enabled {
 			continue
 		}
-		primitiveSetEntry, err := addToPrimitiveSet(primitiveSet, entry, km, config)
+		primitiveSetEntry, err := addToPrimitiveSet(h, primitiveSet, entry, km, config)
 		if err != nil {
 			return nil, fmt.Errorf("cannot add primitive: %v", err)
 		}
@@ -563,6 +730,7 @@ func primitives[T any](h *Handle, km registry.KeyManager, opts ...PrimitivesOpti
 			primitiveSet.Primary = primitiveSetEntry
 		}
 	}
+	logKeyLoad(h, primitiveSet)
 	return primitiveSet, nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"testing"
 
 	"google.golang.org/protobuf/proto"
@@ -60,6 +61,49 @@ func TestNewHandle(t *testing.T) {
 	}
 }
 
+func TestNewHandleWithOptionsWithKeyIDSource(t *testing.T) {
+	for _, template := range []*tinkpb.KeyTemplate{
+		mac.HMACSHA256Tag128KeyTemplate(),
+		signature.ED25519KeyWithoutPrefixTemplate(),
+	} {
+		nextID := uint32(41)
+		source := func() uint32 {
+			nextID++
+			return nextID
+		}
+		handle, err := keyset.NewHandleWithOptions(template, keyset.WithKeyIDSource(source), keyset.WithAnnotations(map[string]string{"foo": "bar"}))
+		if err != nil {
+			t.Fatalf("keyset.NewHandleWithOptions() err = %v, want nil", err)
+		}
+		if got := handle.KeysetInfo().GetPrimaryKeyId(); got != 42 {
+			t.Errorf("handle.KeysetInfo().GetPrimaryKeyId() = %d, want 42", got)
+		}
+	}
+}
+
+func TestWithKeyIDSourceIsRejectedByReadWithOptions(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	keysetEncryptionHandle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	keysetEncryptionAEAD, err := aead.New(keysetEncryptionHandle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := handle.Write(keyset.NewBinaryWriter(buff), keysetEncryptionAEAD); err != nil {
+		t.Fatalf("handle.Write() err = %v, want nil", err)
+	}
+	source := func() uint32 { return 42 }
+	if _, err := keyset.ReadWithOptions(keyset.NewBinaryReader(buff), keysetEncryptionAEAD, keyset.WithKeyIDSource(source)); err == nil {
+		t.Errorf("keyset.ReadWithOptions() with keyset.WithKeyIDSource() err = nil, want error")
+	}
+}
+
 func TestKeysetMaterialMakesACopy(t *testing.T) {
 	wantProtoKeyset := testutil.NewKeyset(1, []*tinkpb.Keyset_Key{
 		testutil.NewKey(testutil.NewKeyData("some type url", []byte{0}, tinkpb.KeyData_SYMMETRIC), tinkpb.KeyStatusType_ENABLED, 1, tinkpb.OutputPrefixType_TINK),
@@ -412,6 +456,50 @@ func TestPrimaryReturnsError(t *testing.T) {
 	}
 }
 
+func TestPrimaryKeyID(t *testing.T) {
+	ks := &tinkpb.Keyset{
+		Key: []*tinkpb.Keyset_Key{
+			testutil.NewDummyKey(1, tinkpb.KeyStatusType_ENABLED, tinkpb.OutputPrefixType_TINK),
+			testutil.NewDummyKey(2, tinkpb.KeyStatusType_ENABLED, tinkpb.OutputPrefixType_RAW),
+		},
+		PrimaryKeyId: 2,
+	}
+	handle, err := testkeyset.NewHandle(ks)
+	if err != nil {
+		t.Fatalf("testkeyset.NewHandle(%v) err = %v, want nil", ks, err)
+	}
+	got, err := handle.PrimaryKeyID()
+	if err != nil {
+		t.Fatalf("handle.PrimaryKeyID() err = %v, want nil", err)
+	}
+	if got != 2 {
+		t.Errorf("handle.PrimaryKeyID() = %v, want 2", got)
+	}
+}
+
+func TestPrimaryKeyIDReturnsError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		handle *keyset.Handle
+	}{
+		{
+			name:   "zero value handle",
+			handle: &keyset.Handle{},
+		},
+		{
+			name:   "nil handle",
+			handle: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.handle.PrimaryKeyID(); err == nil {
+				t.Errorf("handle.PrimaryKeyID() err = nil, want err")
+			}
+		})
+	}
+}
+
 func TestLenReturnsZero(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -788,6 +876,85 @@ func TestReadWithNoSecretsFailsWithPrivateKey(t *testing.T) {
 	}
 }
 
+func TestMarshalAndUnmarshalNoSecrets(t *testing.T) {
+	privateHandle, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle(signature.ECDSAP256KeyTemplate()) err = %v, want nil", err)
+	}
+	handle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	serialized, err := keyset.MarshalNoSecrets(handle)
+	if err != nil {
+		t.Fatalf("keyset.MarshalNoSecrets() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := handle.WriteWithNoSecrets(keyset.NewBinaryWriter(buff)); err != nil {
+		t.Fatalf("handle.WriteWithNoSecrets() err = %v, want nil", err)
+	}
+	if !bytes.Equal(serialized, buff.Bytes()) {
+		t.Errorf("keyset.MarshalNoSecrets() = %x, want %x", serialized, buff.Bytes())
+	}
+	handle2, err := keyset.UnmarshalNoSecrets(serialized)
+	if err != nil {
+		t.Fatalf("keyset.UnmarshalNoSecrets() err = %v, want nil", err)
+	}
+	if !proto.Equal(testkeyset.KeysetMaterial(handle), testkeyset.KeysetMaterial(handle2)) {
+		t.Errorf("keyset.UnmarshalNoSecrets() = %v, want %v", handle2, handle)
+	}
+}
+
+func TestMarshalNoSecretsFails(t *testing.T) {
+	privateHandle, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle(signature.ECDSAP256KeyTemplate()) err = %v, want nil", err)
+	}
+	symmetricHandle, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate()) err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		handle *keyset.Handle
+	}{
+		{"nil handle", nil},
+		{"private key", privateHandle},
+		{"symmetric key", symmetricHandle},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := keyset.MarshalNoSecrets(tc.handle); err == nil {
+				t.Error("keyset.MarshalNoSecrets() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestUnmarshalNoSecretsFails(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate()) err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := testkeyset.Write(handle, keyset.NewBinaryWriter(buff)); err != nil {
+		t.Fatalf("testkeyset.Write() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name       string
+		serialized []byte
+	}{
+		{"secret key", buff.Bytes()},
+		{"invalid proto", []byte("invalid")},
+		{"empty", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := keyset.UnmarshalNoSecrets(tc.serialized); err == nil {
+				t.Error("keyset.UnmarshalNoSecrets() err = nil, want error")
+			}
+		})
+	}
+}
+
 func TestWriteAndReadWithNoSecretsFailsWithUnknownKeyMaterial(t *testing.T) {
 	// Create a keyset that contains unknown key material.
 	keyData := testutil.NewKeyData("some type url", []byte{0}, tinkpb.KeyData_UNKNOWN_KEYMATERIAL)
@@ -1436,3 +1603,286 @@ func TestWriteWithNoSecretsIsThreadSafe(t *testing.T) {
 		})
 	}
 }
+
+func TestReadWithOptionsWithRejectDuplicateKeyMaterial(t *testing.T) {
+	masterKey, err := fakekms.NewAEAD(fakeKeyURI)
+	if err != nil {
+		t.Fatalf("fakekms.NewAEAD() err = %v, want nil", err)
+	}
+	keyData := testutil.NewAESGCMKeyData(16)
+	otherKeyData := testutil.NewAESGCMKeyData(16)
+	for _, tc := range []struct {
+		name    string
+		keys    []*tinkpb.Keyset_Key
+		wantErr bool
+	}{
+		{
+			name: "distinct keys",
+			keys: []*tinkpb.Keyset_Key{
+				testutil.NewKey(keyData, tinkpb.KeyStatusType_ENABLED, 1, tinkpb.OutputPrefixType_TINK),
+				testutil.NewKey(otherKeyData, tinkpb.KeyStatusType_ENABLED, 2, tinkpb.OutputPrefixType_TINK),
+			},
+		},
+		{
+			name: "same key under different IDs",
+			keys: []*tinkpb.Keyset_Key{
+				testutil.NewKey(keyData, tinkpb.KeyStatusType_ENABLED, 1, tinkpb.OutputPrefixType_TINK),
+				testutil.NewKey(otherKeyData, tinkpb.KeyStatusType_ENABLED, 2, tinkpb.OutputPrefixType_TINK),
+				testutil.NewKey(keyData, tinkpb.KeyStatusType_DISABLED, 3, tinkpb.OutputPrefixType_RAW),
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handle, err := testkeyset.NewHandle(testutil.NewKeyset(1, tc.keys))
+			if err != nil {
+				t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+			}
+			buf := &bytes.Buffer{}
+			if err := handle.Write(keyset.NewBinaryWriter(buf), masterKey); err != nil {
+				t.Fatalf("handle.Write() err = %v, want nil", err)
+			}
+			encrypted := buf.Bytes()
+
+			// Without the option, duplicates are accepted.
+			if _, err := keyset.Read(keyset.NewBinaryReader(bytes.NewReader(encrypted)), masterKey); err != nil {
+				t.Fatalf("keyset.Read() err = %v, want nil", err)
+			}
+			_, err = keyset.ReadWithOptions(keyset.NewBinaryReader(bytes.NewReader(encrypted)), masterKey, keyset.RejectDuplicateKeyMaterial())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("keyset.ReadWithOptions(keyset.RejectDuplicateKeyMaterial()) err = %v, want error = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetKeyAnnotationsFailsWithNilHandle(t *testing.T) {
+	if _, err := keyset.SetKeyAnnotations(nil, 1, map[string]string{"team": "a"}); err == nil {
+		t.Errorf("keyset.SetKeyAnnotations(nil, 1, ...) err = nil, want error")
+	}
+}
+
+func TestSetKeyAnnotationsFailsWithUnknownKeyID(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	keyID := handle.KeysetInfo().GetPrimaryKeyId() + 1
+	if _, err := keyset.SetKeyAnnotations(handle, keyID, map[string]string{"team": "a"}); err == nil {
+		t.Errorf("keyset.SetKeyAnnotations(handle, %d, ...) err = nil, want error", keyID)
+	}
+}
+
+func TestSetKeyAnnotationsIsNotSerialized(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	want := testkeyset.KeysetMaterial(handle)
+	keyID := handle.KeysetInfo().GetPrimaryKeyId()
+	annotated, err := keyset.SetKeyAnnotations(handle, keyID, map[string]string{"team": "a"})
+	if err != nil {
+		t.Fatalf("keyset.SetKeyAnnotations(handle, %d, ...) err = %v, want nil", keyID, err)
+	}
+	if got := testkeyset.KeysetMaterial(annotated); !proto.Equal(got, want) {
+		t.Errorf("testkeyset.KeysetMaterial(annotated) = %v, want %v", got, want)
+	}
+}
+
+func TestSetKeyAnnotationsDoesNotModifyHandle(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	keyID := handle.KeysetInfo().GetPrimaryKeyId()
+	annotated, err := keyset.SetKeyAnnotations(handle, keyID, map[string]string{"team": "a"})
+	if err != nil {
+		t.Fatalf("keyset.SetKeyAnnotations(handle, %d, ...) err = %v, want nil", keyID, err)
+	}
+	for _, tc := range []struct {
+		name   string
+		handle *keyset.Handle
+		want   map[string]string
+	}{
+		{"original", handle, nil},
+		{"annotated", annotated, map[string]string{"team": "a"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := keyset.MonitoringKeysetInfo(tc.handle)
+			if err != nil {
+				t.Fatalf("keyset.MonitoringKeysetInfo() err = %v, want nil", err)
+			}
+			if got := info.Entries[0].Annotations; !maps.Equal(got, tc.want) {
+				t.Errorf("info.Entries[0].Annotations = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOutputPrefixTypes(t *testing.T) {
+	keyData := testutil.NewKeyData("some type url", []byte{0}, tinkpb.KeyData_SYMMETRIC)
+	ks := testutil.NewKeyset(1, []*tinkpb.Keyset_Key{
+		testutil.NewKey(keyData, tinkpb.KeyStatusType_ENABLED, 1, tinkpb.OutputPrefixType_TINK),
+		testutil.NewKey(keyData, tinkpb.KeyStatusType_ENABLED, 2, tinkpb.OutputPrefixType_LEGACY),
+		testutil.NewKey(keyData, tinkpb.KeyStatusType_DISABLED, 3, tinkpb.OutputPrefixType_LEGACY),
+		testutil.NewKey(keyData, tinkpb.KeyStatusType_ENABLED, 4, tinkpb.OutputPrefixType_RAW),
+	})
+	handle, err := testkeyset.NewHandle(ks)
+	if err != nil {
+		t.Fatalf("testkeyset.NewHandle(ks) err = %v, want nil", err)
+	}
+	got, err := keyset.OutputPrefixTypes(handle)
+	if err != nil {
+		t.Fatalf("keyset.OutputPrefixTypes(handle) err = %v, want nil", err)
+	}
+	want := map[tinkpb.OutputPrefixType]int{
+		tinkpb.OutputPrefixType_TINK:   1,
+		tinkpb.OutputPrefixType_LEGACY: 2,
+		tinkpb.OutputPrefixType_RAW:    1,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("keyset.OutputPrefixTypes(handle) = %v, want %v", got, want)
+	}
+}
+
+func TestOutputPrefixTypesFailsWithNilHandle(t *testing.T) {
+	if _, err := keyset.OutputPrefixTypes(nil); err == nil {
+		t.Errorf("keyset.OutputPrefixTypes(nil) err = nil, want error")
+	}
+}
+
+func TestExpectPrimaryType(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	if err := keyset.ExpectPrimaryType(handle, "type.googleapis.com/google.crypto.tink.AesGcmKey"); err != nil {
+		t.Errorf("keyset.ExpectPrimaryType() err = %v, want nil", err)
+	}
+}
+
+func TestExpectPrimaryTypeFails(t *testing.T) {
+	handle, err := keyset.NewHandle(aead.AES128GCMSIVKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name    string
+		handle  *keyset.Handle
+		typeURL string
+	}{
+		{"nil handle", nil, "type.googleapis.com/google.crypto.tink.AesGcmKey"},
+		{"empty handle", &keyset.Handle{}, "type.googleapis.com/google.crypto.tink.AesGcmKey"},
+		{"different type URL", handle, "type.googleapis.com/google.crypto.tink.AesGcmKey"},
+		{"empty type URL", handle, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := keyset.ExpectPrimaryType(tc.handle, tc.typeURL); err == nil {
+				t.Errorf("keyset.ExpectPrimaryType() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestHandleEqual(t *testing.T) {
+	manager := keyset.NewManager()
+	firstID, err := manager.Add(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	secondID, err := manager.Add(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(firstID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := testkeyset.Write(handle, keyset.NewBinaryWriter(buff)); err != nil {
+		t.Fatalf("testkeyset.Write() err = %v, want nil", err)
+	}
+	sameHandle, err := testkeyset.Read(keyset.NewBinaryReader(buff))
+	if err != nil {
+		t.Fatalf("testkeyset.Read() err = %v, want nil", err)
+	}
+	sameHandle, err = keyset.SetKeyAnnotations(sameHandle, firstID, map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatalf("keyset.SetKeyAnnotations() err = %v, want nil", err)
+	}
+	if !handle.Equal(sameHandle) {
+		t.Error("handle.Equal(sameHandle) = false, want true")
+	}
+	if !handle.Equal(handle) {
+		t.Error("handle.Equal(handle) = false, want true")
+	}
+	var nilHandle *keyset.Handle
+	if !nilHandle.Equal(nil) {
+		t.Error("nilHandle.Equal(nil) = false, want true")
+	}
+
+	otherPrimary := mustHandle(t, func(m *keyset.Manager) error { return m.SetPrimary(secondID) }, handle)
+	disabled := mustHandle(t, func(m *keyset.Manager) error { return m.Disable(secondID) }, handle)
+	deleted := mustHandle(t, func(m *keyset.Manager) error { return m.Delete(secondID) }, handle)
+	otherKey, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name  string
+		other *keyset.Handle
+	}{
+		{"nil", nil},
+		{"other primary", otherPrimary},
+		{"disabled key", disabled},
+		{"deleted key", deleted},
+		{"other key", otherKey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if handle.Equal(tc.other) {
+				t.Error("handle.Equal() = true, want false")
+			}
+			if tc.other.Equal(handle) {
+				t.Error("other.Equal(handle) = true, want false")
+			}
+		})
+	}
+}
+
+func TestHandleEqualPublicAndPrivate(t *testing.T) {
+	handle, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	samePublicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	if handle.Equal(publicHandle) {
+		t.Error("handle.Equal(publicHandle) = true, want false")
+	}
+	if !publicHandle.Equal(samePublicHandle) {
+		t.Error("publicHandle.Equal(samePublicHandle) = false, want true")
+	}
+}
+
+// mustHandle returns a handle of the keyset of handle modified by update.
+func mustHandle(t *testing.T, update func(*keyset.Manager) error, handle *keyset.Handle) *keyset.Handle {
+	t.Helper()
+	manager := keyset.NewManagerFromHandle(handle)
+	if err := update(manager); err != nil {
+		t.Fatalf("update() err = %v, want nil", err)
+	}
+	updated, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	return updated
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// ReadJSON creates a Handle from an encrypted keyset in JSON format, as
+// written by [JSONWriter], decrypted with masterKey and empty associated data.
+//
+// It is equivalent to [Read] with a [JSONReader]. The encrypted keyset is a
+// single ciphertext, which must be in memory to be decrypted, so unlike
+// [ReadNoSecretsJSON] it is not decoded incrementally.
+func ReadJSON(r io.Reader, masterKey tink.AEAD) (*Handle, error) {
+	return Read(NewJSONReader(r), masterKey)
+}
+
+// ReadNoSecretsJSON creates a Handle from a keyset in JSON format, as written by
+// [JSONWriter], that contains no secret key material. It is equivalent to
+// [ReadWithNoSecrets] with a [JSONReader], but decodes the keys one at a time
+// as they are read from r, instead of reading all of r into memory first.
+// This bounds the memory used while parsing large public keysets, such as
+// ones with many verification keys.
+func ReadNoSecretsJSON(r io.Reader) (*Handle, error) {
+	protoKeyset, err := decodeKeysetJSON(json.NewDecoder(r))
+	if err != nil {
+		return nil, fmt.Errorf("keyset.ReadNoSecretsJSON: %v", err)
+	}
+	return NewHandleWithNoSecrets(protoKeyset)
+}
+
+// decodeKeysetJSON decodes a keyset in the protobuf JSON format from dec. The
+// elements of the "key" field are decoded one at a time; the other fields are
+// small and are decoded together at the end.
+func decodeKeysetJSON(dec *json.Decoder) (*tinkpb.Keyset, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	var keys []*tinkpb.Keyset_Key
+	seenKeys := false
+	otherFields := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid field name %v", tok)
+		}
+		if name != "key" {
+			if _, ok := otherFields[name]; ok {
+				return nil, fmt.Errorf("duplicate field %q", name)
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			otherFields[name] = value
+			continue
+		}
+		if seenKeys {
+			return nil, fmt.Errorf("duplicate field %q", name)
+		}
+		seenKeys = true
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			continue
+		}
+		if tok != json.Delim('[') {
+			return nil, fmt.Errorf("field %q is not an array", name)
+		}
+		for dec.More() {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			key := &tinkpb.Keyset_Key{}
+			if err := protojson.Unmarshal(value, key); err != nil {
+				return nil, fmt.Errorf("key %d: %v", len(keys), err)
+			}
+			keys = append(keys, key)
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after keyset")
+	}
+	b, err := json.Marshal(otherFields)
+	if err != nil {
+		return nil, err
+	}
+	protoKeyset := &tinkpb.Keyset{}
+	if err := protojson.Unmarshal(b, protoKeyset); err != nil {
+		return nil, err
+	}
+	protoKeyset.Key = keys
+	return protoKeyset, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("got %v, want %v", tok, want)
+	}
+	return nil
+}
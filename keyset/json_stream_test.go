@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+)
+
+func TestReadNoSecretsJSON(t *testing.T) {
+	manager := keyset.NewManager()
+	for i := 0; i < 10; i++ {
+		keyID, err := manager.Add(signature.ECDSAP256KeyTemplate())
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		if i == 5 {
+			if err := manager.SetPrimary(keyID); err != nil {
+				t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+			}
+		}
+	}
+	privateHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := publicHandle.WriteWithNoSecrets(keyset.NewJSONWriter(buff)); err != nil {
+		t.Fatalf("publicHandle.WriteWithNoSecrets() err = %v, want nil", err)
+	}
+
+	got, err := keyset.ReadNoSecretsJSON(iotest.OneByteReader(bytes.NewReader(buff.Bytes())))
+	if err != nil {
+		t.Fatalf("keyset.ReadNoSecretsJSON() err = %v, want nil", err)
+	}
+	if !proto.Equal(testkeyset.KeysetMaterial(got), testkeyset.KeysetMaterial(publicHandle)) {
+		t.Errorf("keyset.ReadNoSecretsJSON() = %v, want %v", got, publicHandle)
+	}
+}
+
+func TestReadNoSecretsJSONAcceptsProtoFieldNames(t *testing.T) {
+	const publicKeyset = `{
+		"primary_key_id": 42,
+		"key": [{
+			"key_data": {
+				"type_url": "type.googleapis.com/google.crypto.tink.Ed25519PublicKey",
+				"value": "EiBhqHXJAqFfGA25zpTtDVpVeaHOe4RGBtHgl1AlZAWXDQ==",
+				"key_material_type": "ASYMMETRIC_PUBLIC"
+			},
+			"status": "ENABLED",
+			"key_id": 42,
+			"output_prefix_type": "TINK"
+		}]
+	}`
+	handle, err := keyset.ReadNoSecretsJSON(strings.NewReader(publicKeyset))
+	if err != nil {
+		t.Fatalf("keyset.ReadNoSecretsJSON() err = %v, want nil", err)
+	}
+	want, err := keyset.ReadWithNoSecrets(keyset.NewJSONReader(strings.NewReader(publicKeyset)))
+	if err != nil {
+		t.Fatalf("keyset.ReadWithNoSecrets() err = %v, want nil", err)
+	}
+	if !handle.Equal(want) {
+		t.Errorf("keyset.ReadNoSecretsJSON() = %v, want %v", handle, want)
+	}
+}
+
+func TestReadNoSecretsJSONFails(t *testing.T) {
+	privateHandle, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := testkeyset.Write(privateHandle, keyset.NewJSONWriter(buff)); err != nil {
+		t.Fatalf("testkeyset.Write() err = %v, want nil", err)
+	}
+	privateKeyset := buff.String()
+	publicHandle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+	buff = &bytes.Buffer{}
+	if err := publicHandle.WriteWithNoSecrets(keyset.NewJSONWriter(buff)); err != nil {
+		t.Fatalf("publicHandle.WriteWithNoSecrets() err = %v, want nil", err)
+	}
+	publicKeyset := buff.String()
+
+	for _, tc := range []struct {
+		name   string
+		keyset string
+	}{
+		{"empty", ""},
+		{"not an object", "[]"},
+		{"private key", privateKeyset},
+		{"truncated", publicKeyset[:len(publicKeyset)/2]},
+		{"trailing data", publicKeyset + "{}"},
+		{"unknown field", strings.Replace(publicKeyset, "{", `{"unknown": 1,`, 1)},
+		{"duplicate key field", strings.Replace(publicKeyset, "{", `{"key": [],`, 1)},
+		{"key is not an array", strings.Replace(publicKeyset, "{", `{"key": {},`, 1)},
+		{"no keys", `{"primaryKeyId": 1}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := keyset.ReadNoSecretsJSON(strings.NewReader(tc.keyset)); err == nil {
+				t.Error("keyset.ReadNoSecretsJSON() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	keysetEncryptionHandle, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	keysetEncryptionAEAD, err := aead.New(keysetEncryptionHandle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := handle.Write(keyset.NewJSONWriter(buff), keysetEncryptionAEAD); err != nil {
+		t.Fatalf("handle.Write() err = %v, want nil", err)
+	}
+	encrypted := buff.Bytes()
+
+	got, err := keyset.ReadJSON(bytes.NewReader(encrypted), keysetEncryptionAEAD)
+	if err != nil {
+		t.Fatalf("keyset.ReadJSON() err = %v, want nil", err)
+	}
+	if !proto.Equal(testkeyset.KeysetMaterial(got), testkeyset.KeysetMaterial(handle)) {
+		t.Errorf("keyset.ReadJSON() = %v, want %v", got, handle)
+	}
+	if _, err := keyset.ReadJSON(bytes.NewReader(encrypted[:len(encrypted)/2]), keysetEncryptionAEAD); err == nil {
+		t.Error("keyset.ReadJSON() with truncated keyset err = nil, want error")
+	}
+}
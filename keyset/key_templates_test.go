@@ -0,0 +1,443 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/daead"
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/jwt"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/prf"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/streamingaead"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// templatePackages are the packages whose exported key templates are checked
+// by TestAllKeyTemplates, relative to the root of the module.
+var templatePackages = []string{
+	"aead",
+	"daead",
+	"hybrid",
+	"jwt",
+	"mac",
+	"prf",
+	"signature",
+	"streamingaead",
+}
+
+type keyTemplateTest struct {
+	name     string
+	template func() *tinkpb.KeyTemplate
+	// check creates the primitive of handle and uses it once.
+	check func(handle *keyset.Handle) error
+}
+
+// allKeyTemplates lists every exported function without parameters that
+// returns a key template in templatePackages.
+var allKeyTemplates = []keyTemplateTest{
+		{"aead.AES128GCMKeyTemplate", aead.AES128GCMKeyTemplate, checkAEAD},
+		{"aead.AES256GCMKeyTemplate", aead.AES256GCMKeyTemplate, checkAEAD},
+		{"aead.AES256GCMNoPrefixKeyTemplate", aead.AES256GCMNoPrefixKeyTemplate, checkAEAD},
+		{"aead.XAES256GCM192BitNonceKeyTemplate", aead.XAES256GCM192BitNonceKeyTemplate, checkAEAD},
+		{"aead.XAES256GCM192BitNonceNoPrefixKeyTemplate", aead.XAES256GCM192BitNonceNoPrefixKeyTemplate, checkAEAD},
+		{"aead.XAES256GCM160BitNonceKeyTemplate", aead.XAES256GCM160BitNonceKeyTemplate, checkAEAD},
+		{"aead.XAES256GCM160BitNonceNoPrefixKeyTemplate", aead.XAES256GCM160BitNonceNoPrefixKeyTemplate, checkAEAD},
+		{"aead.AES128GCMSIVKeyTemplate", aead.AES128GCMSIVKeyTemplate, checkAEAD},
+		{"aead.AES256GCMSIVKeyTemplate", aead.AES256GCMSIVKeyTemplate, checkAEAD},
+		{"aead.AES256GCMSIVNoPrefixKeyTemplate", aead.AES256GCMSIVNoPrefixKeyTemplate, checkAEAD},
+		{"aead.AES128CTRHMACSHA256KeyTemplate", aead.AES128CTRHMACSHA256KeyTemplate, checkAEAD},
+		{"aead.AES256CTRHMACSHA256KeyTemplate", aead.AES256CTRHMACSHA256KeyTemplate, checkAEAD},
+		{"aead.ChaCha20Poly1305KeyTemplate", aead.ChaCha20Poly1305KeyTemplate, checkAEAD},
+		{"aead.XChaCha20Poly1305KeyTemplate", aead.XChaCha20Poly1305KeyTemplate, checkAEAD},
+		{"aead.FastestTemplate", aead.FastestTemplate, checkAEAD},
+		{"daead.AESSIVKeyTemplate", daead.AESSIVKeyTemplate, checkDeterministicAEAD},
+		{"daead.AES128SIVKeyTemplate", daead.AES128SIVKeyTemplate, checkDeterministicAEAD},
+		{"daead.AES192SIVKeyTemplate", daead.AES192SIVKeyTemplate, checkDeterministicAEAD},
+		{"hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template", hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template", hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template", hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template", hybrid.DHKEM_P256_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Key_Template", hybrid.DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Raw_Key_Template", hybrid.DHKEM_P384_HKDF_SHA384_HKDF_SHA384_AES_256_GCM_Raw_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Key_Template", hybrid.DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Raw_Key_Template", hybrid.DHKEM_P521_HKDF_SHA512_HKDF_SHA512_AES_256_GCM_Raw_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Key_Template", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Key_Template, checkHybrid},
+		{"hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Raw_Key_Template", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Raw_Key_Template, checkHybrid},
+		{"hybrid.ECIESHKDFAES128GCMKeyTemplate", hybrid.ECIESHKDFAES128GCMKeyTemplate, checkHybrid},
+		{"hybrid.ECIESHKDFAES128GCMCompressedKeyTemplate", hybrid.ECIESHKDFAES128GCMCompressedKeyTemplate, checkHybrid},
+		{"hybrid.ECIESHKDFAES128CTRHMACSHA256KeyTemplate", hybrid.ECIESHKDFAES128CTRHMACSHA256KeyTemplate, checkHybrid},
+		{"jwt.HS256Template", jwt.HS256Template, checkJWTMAC},
+		{"jwt.RawHS256Template", jwt.RawHS256Template, checkJWTMAC},
+		{"jwt.HS384Template", jwt.HS384Template, checkJWTMAC},
+		{"jwt.RawHS384Template", jwt.RawHS384Template, checkJWTMAC},
+		{"jwt.HS512Template", jwt.HS512Template, checkJWTMAC},
+		{"jwt.RawHS512Template", jwt.RawHS512Template, checkJWTMAC},
+		{"jwt.ES256Template", jwt.ES256Template, checkJWTSignature},
+		{"jwt.RawES256Template", jwt.RawES256Template, checkJWTSignature},
+		{"jwt.ES384Template", jwt.ES384Template, checkJWTSignature},
+		{"jwt.RawES384Template", jwt.RawES384Template, checkJWTSignature},
+		{"jwt.ES512Template", jwt.ES512Template, checkJWTSignature},
+		{"jwt.RawES512Template", jwt.RawES512Template, checkJWTSignature},
+		{"jwt.RS256_2048_F4_Key_Template", jwt.RS256_2048_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawRS256_2048_F4_Key_Template", jwt.RawRS256_2048_F4_Key_Template, checkJWTSignature},
+		{"jwt.RS256_3072_F4_Key_Template", jwt.RS256_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawRS256_3072_F4_Key_Template", jwt.RawRS256_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.RS384_3072_F4_Key_Template", jwt.RS384_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawRS384_3072_F4_Key_Template", jwt.RawRS384_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.RS512_4096_F4_Key_Template", jwt.RS512_4096_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawRS512_4096_F4_Key_Template", jwt.RawRS512_4096_F4_Key_Template, checkJWTSignature},
+		{"jwt.PS256_2048_F4_Key_Template", jwt.PS256_2048_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawPS256_2048_F4_Key_Template", jwt.RawPS256_2048_F4_Key_Template, checkJWTSignature},
+		{"jwt.PS256_3072_F4_Key_Template", jwt.PS256_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawPS256_3072_F4_Key_Template", jwt.RawPS256_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.PS384_3072_F4_Key_Template", jwt.PS384_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawPS384_3072_F4_Key_Template", jwt.RawPS384_3072_F4_Key_Template, checkJWTSignature},
+		{"jwt.PS512_4096_F4_Key_Template", jwt.PS512_4096_F4_Key_Template, checkJWTSignature},
+		{"jwt.RawPS512_4096_F4_Key_Template", jwt.RawPS512_4096_F4_Key_Template, checkJWTSignature},
+		{"mac.HMACSHA256Tag128KeyTemplate", mac.HMACSHA256Tag128KeyTemplate, checkMAC},
+		{"mac.HMACSHA256Tag256KeyTemplate", mac.HMACSHA256Tag256KeyTemplate, checkMAC},
+		{"mac.HMACSHA512Tag256KeyTemplate", mac.HMACSHA512Tag256KeyTemplate, checkMAC},
+		{"mac.HMACSHA512Tag512KeyTemplate", mac.HMACSHA512Tag512KeyTemplate, checkMAC},
+		{"mac.AESCMACTag128KeyTemplate", mac.AESCMACTag128KeyTemplate, checkMAC},
+		{"prf.HMACSHA256PRFKeyTemplate", prf.HMACSHA256PRFKeyTemplate, checkPRF},
+		{"prf.HMACSHA512PRFKeyTemplate", prf.HMACSHA512PRFKeyTemplate, checkPRF},
+		{"prf.HKDFSHA256PRFKeyTemplate", prf.HKDFSHA256PRFKeyTemplate, checkPRF},
+		{"prf.AESCMACPRFKeyTemplate", prf.AESCMACPRFKeyTemplate, checkPRF},
+		{"signature.ECDSAP256KeyTemplate", signature.ECDSAP256KeyTemplate, checkSignature},
+		{"signature.ECDSAP256KeyWithoutPrefixTemplate", signature.ECDSAP256KeyWithoutPrefixTemplate, checkSignature},
+		{"signature.ECDSAP256RawKeyTemplate", signature.ECDSAP256RawKeyTemplate, checkSignature},
+		{"signature.ECDSAP256SHA512KeyTemplate", signature.ECDSAP256SHA512KeyTemplate, checkSignature},
+		{"signature.ECDSAP256SHA512KeyWithoutPrefixTemplate", signature.ECDSAP256SHA512KeyWithoutPrefixTemplate, checkSignature},
+		{"signature.ECDSAP384SHA384KeyTemplate", signature.ECDSAP384SHA384KeyTemplate, checkSignature},
+		{"signature.ECDSAP384SHA384KeyWithoutPrefixTemplate", signature.ECDSAP384SHA384KeyWithoutPrefixTemplate, checkSignature},
+		{"signature.ECDSAP384SHA512KeyTemplate", signature.ECDSAP384SHA512KeyTemplate, checkSignature},
+		{"signature.ECDSAP384KeyWithoutPrefixTemplate", signature.ECDSAP384KeyWithoutPrefixTemplate, checkSignature},
+		{"signature.ECDSAP384IEEEP1363KeyTemplate", signature.ECDSAP384IEEEP1363KeyTemplate, checkSignature},
+		{"signature.ECDSAP384IEEEP1363RawKeyTemplate", signature.ECDSAP384IEEEP1363RawKeyTemplate, checkSignature},
+		{"signature.ECDSAP521KeyTemplate", signature.ECDSAP521KeyTemplate, checkSignature},
+		{"signature.ECDSAP521KeyWithoutPrefixTemplate", signature.ECDSAP521KeyWithoutPrefixTemplate, checkSignature},
+		{"signature.ECDSAP521IEEEP1363KeyTemplate", signature.ECDSAP521IEEEP1363KeyTemplate, checkSignature},
+		{"signature.ECDSAP521IEEEP1363RawKeyTemplate", signature.ECDSAP521IEEEP1363RawKeyTemplate, checkSignature},
+		{"signature.ED25519KeyTemplate", signature.ED25519KeyTemplate, checkSignature},
+		{"signature.ED25519KeyWithoutPrefixTemplate", signature.ED25519KeyWithoutPrefixTemplate, checkSignature},
+		{"signature.RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template", signature.RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template", signature.RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template", signature.RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PKCS1_3072_SHA256_F4_RAW_Key_Template", signature.RSA_SSA_PKCS1_3072_SHA256_F4_RAW_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PKCS1_4096_SHA512_F4_Key_Template", signature.RSA_SSA_PKCS1_4096_SHA512_F4_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PKCS1_4096_SHA512_F4_RAW_Key_Template", signature.RSA_SSA_PKCS1_4096_SHA512_F4_RAW_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PSS_3072_SHA256_32_F4_Key_Template", signature.RSA_SSA_PSS_3072_SHA256_32_F4_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PSS_3072_SHA256_32_F4_Raw_Key_Template", signature.RSA_SSA_PSS_3072_SHA256_32_F4_Raw_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PSS_4096_SHA512_64_F4_Key_Template", signature.RSA_SSA_PSS_4096_SHA512_64_F4_Key_Template, checkSignature},
+		{"signature.RSA_SSA_PSS_4096_SHA512_64_F4_Raw_Key_Template", signature.RSA_SSA_PSS_4096_SHA512_64_F4_Raw_Key_Template, checkSignature},
+		{"streamingaead.AES128GCMHKDF4KBKeyTemplate", streamingaead.AES128GCMHKDF4KBKeyTemplate, checkStreamingAEAD},
+		{"streamingaead.AES128GCMHKDF1MBKeyTemplate", streamingaead.AES128GCMHKDF1MBKeyTemplate, checkStreamingAEAD},
+		{"streamingaead.AES256GCMHKDF4KBKeyTemplate", streamingaead.AES256GCMHKDF4KBKeyTemplate, checkStreamingAEAD},
+		{"streamingaead.AES256GCMHKDF1MBKeyTemplate", streamingaead.AES256GCMHKDF1MBKeyTemplate, checkStreamingAEAD},
+		{"streamingaead.AES128CTRHMACSHA256Segment4KBKeyTemplate", streamingaead.AES128CTRHMACSHA256Segment4KBKeyTemplate, checkStreamingAEAD},
+		{"streamingaead.AES128CTRHMACSHA256Segment1MBKeyTemplate", streamingaead.AES128CTRHMACSHA256Segment1MBKeyTemplate, checkStreamingAEAD},
+		{"streamingaead.AES256CTRHMACSHA256Segment4KBKeyTemplate", streamingaead.AES256CTRHMACSHA256Segment4KBKeyTemplate, checkStreamingAEAD},
+		{"streamingaead.AES256CTRHMACSHA256Segment1MBKeyTemplate", streamingaead.AES256CTRHMACSHA256Segment1MBKeyTemplate, checkStreamingAEAD},
+}
+
+// TestAllKeyTemplates checks that every key template generates a key whose
+// primitive works, so that a template with an unregistered type URL or an
+// invalid key format fails here rather than when it is first used.
+func TestAllKeyTemplates(t *testing.T) {
+	for _, tc := range allKeyTemplates {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			handle, err := keyset.NewHandle(tc.template())
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			if err := tc.check(handle); err != nil {
+				t.Errorf("check() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+// TestAllKeyTemplatesIsComplete checks that allKeyTemplates is not missing
+// any template, by looking for them in the source of templatePackages.
+func TestAllKeyTemplatesIsComplete(t *testing.T) {
+	listed := make(map[string]bool)
+	for _, tc := range allKeyTemplates {
+		listed[tc.name] = true
+	}
+	for _, pkg := range templatePackages {
+		names, err := exportedKeyTemplates(filepath.Join("..", pkg))
+		if err != nil {
+			t.Fatalf("exportedKeyTemplates(%q) err = %v, want nil", pkg, err)
+		}
+		for _, name := range names {
+			if !listed[pkg+"."+name] {
+				t.Errorf("%s.%s is missing from allKeyTemplates", pkg, name)
+			}
+		}
+	}
+}
+
+// exportedKeyTemplates returns the names of the exported functions in dir
+// that take no parameters and return a *tinkpb.KeyTemplate.
+func exportedKeyTemplates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() {
+				continue
+			}
+			if fn.Type.Params.NumFields() != 0 || fn.Type.Results.NumFields() != 1 {
+				continue
+			}
+			star, ok := fn.Type.Results.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			sel, ok := star.X.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "KeyTemplate" {
+				continue
+			}
+			names = append(names, fn.Name.Name)
+		}
+	}
+	return names, nil
+}
+
+var (
+	templateTestData           = []byte("data")
+	templateTestAssociatedData = []byte("associated data")
+)
+
+func checkAEAD(handle *keyset.Handle) error {
+	a, err := aead.New(handle)
+	if err != nil {
+		return err
+	}
+	ct, err := a.Encrypt(templateTestData, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	pt, err := a.Decrypt(ct, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(pt, templateTestData) {
+		return fmt.Errorf("Decrypt() = %q, want %q", pt, templateTestData)
+	}
+	return nil
+}
+
+func checkDeterministicAEAD(handle *keyset.Handle) error {
+	d, err := daead.New(handle)
+	if err != nil {
+		return err
+	}
+	ct, err := d.EncryptDeterministically(templateTestData, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	pt, err := d.DecryptDeterministically(ct, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(pt, templateTestData) {
+		return fmt.Errorf("DecryptDeterministically() = %q, want %q", pt, templateTestData)
+	}
+	return nil
+}
+
+func checkHybrid(handle *keyset.Handle) error {
+	publicHandle, err := handle.Public()
+	if err != nil {
+		return err
+	}
+	enc, err := hybrid.NewHybridEncrypt(publicHandle)
+	if err != nil {
+		return err
+	}
+	dec, err := hybrid.NewHybridDecrypt(handle)
+	if err != nil {
+		return err
+	}
+	ct, err := enc.Encrypt(templateTestData, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	pt, err := dec.Decrypt(ct, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(pt, templateTestData) {
+		return fmt.Errorf("Decrypt() = %q, want %q", pt, templateTestData)
+	}
+	return nil
+}
+
+func checkMAC(handle *keyset.Handle) error {
+	m, err := mac.New(handle)
+	if err != nil {
+		return err
+	}
+	tag, err := m.ComputeMAC(templateTestData)
+	if err != nil {
+		return err
+	}
+	return m.VerifyMAC(tag, templateTestData)
+}
+
+func checkPRF(handle *keyset.Handle) error {
+	ps, err := prf.NewPRFSet(handle)
+	if err != nil {
+		return err
+	}
+	_, err = ps.ComputePrimaryPRF(templateTestData, 16)
+	return err
+}
+
+func checkSignature(handle *keyset.Handle) error {
+	publicHandle, err := handle.Public()
+	if err != nil {
+		return err
+	}
+	signer, err := signature.NewSigner(handle)
+	if err != nil {
+		return err
+	}
+	verifier, err := signature.NewVerifier(publicHandle)
+	if err != nil {
+		return err
+	}
+	sig, err := signer.Sign(templateTestData)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(sig, templateTestData)
+}
+
+func checkStreamingAEAD(handle *keyset.Handle) error {
+	s, err := streamingaead.New(handle)
+	if err != nil {
+		return err
+	}
+	ct := &bytes.Buffer{}
+	w, err := s.NewEncryptingWriter(ct, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(templateTestData); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	r, err := s.NewDecryptingReader(ct, templateTestAssociatedData)
+	if err != nil {
+		return err
+	}
+	pt, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(pt, templateTestData) {
+		return fmt.Errorf("decrypted %q, want %q", pt, templateTestData)
+	}
+	return nil
+}
+
+func newTemplateTestJWT() (*jwt.RawJWT, *jwt.Validator, error) {
+	rawJWT, err := jwt.NewRawJWT(&jwt.RawJWTOptions{WithoutExpiration: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	validator, err := jwt.NewValidator(&jwt.ValidatorOpts{AllowMissingExpiration: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rawJWT, validator, nil
+}
+
+func checkJWTMAC(handle *keyset.Handle) error {
+	m, err := jwt.NewMAC(handle)
+	if err != nil {
+		return err
+	}
+	rawJWT, validator, err := newTemplateTestJWT()
+	if err != nil {
+		return err
+	}
+	compact, err := m.ComputeMACAndEncode(rawJWT)
+	if err != nil {
+		return err
+	}
+	_, err = m.VerifyMACAndDecode(compact, validator)
+	return err
+}
+
+func checkJWTSignature(handle *keyset.Handle) error {
+	publicHandle, err := handle.Public()
+	if err != nil {
+		return err
+	}
+	signer, err := jwt.NewSigner(handle)
+	if err != nil {
+		return err
+	}
+	verifier, err := jwt.NewVerifier(publicHandle)
+	if err != nil {
+		return err
+	}
+	rawJWT, validator, err := newTemplateTestJWT()
+	if err != nil {
+		return err
+	}
+	compact, err := signer.SignAndEncode(rawJWT)
+	if err != nil {
+		return err
+	}
+	_, err = verifier.VerifyAndDecode(compact, validator)
+	return err
+}
@@ -33,6 +33,7 @@ import (
 type Manager struct {
 	ks                *tinkpb.Keyset
 	unavailableKeyIDs map[uint32]bool // set of key IDs that are not available for new keys
+	keyIDSource       func() uint32   // generates the IDs of new keys; random if nil
 }
 
 // NewManager creates a new instance with an empty Keyset.
@@ -224,8 +225,12 @@ func (km *Manager) Handle() (*Handle, error) {
 
 // newRandomKeyID generates a key id that has not been used by any key in the keyset.
 func (km *Manager) newRandomKeyID() uint32 {
+	source := km.keyIDSource
+	if source == nil {
+		source = random.GetRandomUint32
+	}
 	for {
-		newRandomID := random.GetRandomUint32()
+		newRandomID := source()
 		if _, found := km.unavailableKeyIDs[newRandomID]; !found {
 			km.unavailableKeyIDs[newRandomID] = true
 			return newRandomID
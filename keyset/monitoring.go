@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/internal/internalregistry"
+	"github.com/tink-crypto/tink-go/v2/internal/monitoringutil"
+	"github.com/tink-crypto/tink-go/v2/internal/primitiveset"
+	"github.com/tink-crypto/tink-go/v2/monitoring"
+
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// MonitoringKeysetInfo returns the [monitoring.KeysetInfo] of handle, as
+// passed to [monitoring.Client.NewLogger] by the primitive factories, such as
+// mac.New, when they create a primitive from handle.
+//
+// It contains the annotations set with [WithAnnotations] and
+// [SetKeyAnnotations], and only the enabled keys, like the primitives
+// themselves. The order of the entries is not specified.
+func MonitoringKeysetInfo(handle *Handle) (*monitoring.KeysetInfo, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("keyset.MonitoringKeysetInfo: nil handle")
+	}
+	// Build the same primitive set as Primitives, without the primitives, so
+	// that the result is computed exactly as in the factories.
+	ps := primitiveset.New[struct{}]()
+	ps.Annotations = handle.annotations
+	ps.KeyAnnotations = handle.keyAnnotations
+	for _, entry := range handle.entries {
+		if entry.KeyStatus() != Enabled {
+			continue
+		}
+		protoKey, err := entryToProtoKey(entry)
+		if err != nil {
+			return nil, fmt.Errorf("keyset.MonitoringKeysetInfo: %v", err)
+		}
+		psEntry, err := ps.Add(struct{}{}, protoKey)
+		if err != nil {
+			return nil, fmt.Errorf("keyset.MonitoringKeysetInfo: %v", err)
+		}
+		if entry.IsPrimary() {
+			ps.Primary = psEntry
+		}
+	}
+	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
+	if err != nil {
+		return nil, fmt.Errorf("keyset.MonitoringKeysetInfo: %v", err)
+	}
+	return keysetInfo, nil
+}
+
+// logKeyLoad notifies the registered monitoring client that ps was created
+// from h, if the client is a [monitoring.KeyLoadLogger] and h has annotations.
+//
+// This is best effort: nothing is logged if the keyset info cannot be
+// computed.
+func logKeyLoad[T any](h *Handle, ps *primitiveset.PrimitiveSet[T]) {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
+		return
+	}
+	logger, ok := internalregistry.GetMonitoringClient().(monitoring.KeyLoadLogger)
+	if !ok {
+		return
+	}
+	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
+	if err != nil {
+		return
+	}
+	logger.LogKeyLoad(keysetInfo, keysetChecksum(h.KeysetInfo()))
+}
+
+// keysetChecksum returns the SHA-256 hash of the public view of a keyset: the
+// ID of the primary key, followed by the ID, status, output prefix type and
+// length-prefixed type URL of each key, in keyset order, all as big-endian
+// integers. Unlike the serialized keyset, it does not depend on any key
+// material, so it can be sent to a monitoring backend.
+func keysetChecksum(info *tinkpb.KeysetInfo) []byte {
+	b := binary.BigEndian.AppendUint32(nil, info.GetPrimaryKeyId())
+	for _, k := range info.GetKeyInfo() {
+		b = binary.BigEndian.AppendUint32(b, k.GetKeyId())
+		b = binary.BigEndian.AppendUint32(b, uint32(k.GetStatus()))
+		b = binary.BigEndian.AppendUint32(b, uint32(k.GetOutputPrefixType()))
+		b = binary.BigEndian.AppendUint32(b, uint32(len(k.GetTypeUrl())))
+		b = append(b, k.GetTypeUrl()...)
+	}
+	checksum := sha256.Sum256(b)
+	return checksum[:]
+}
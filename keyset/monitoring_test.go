@@ -0,0 +1,269 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/insecurecleartextkeyset"
+	"github.com/tink-crypto/tink-go/v2/internal/internalregistry"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/monitoring"
+	"github.com/tink-crypto/tink-go/v2/testing/fakemonitoring"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+	hmacpb "github.com/tink-crypto/tink-go/v2/proto/hmac_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestMonitoringKeysetInfoMatchesLoggers(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := fakemonitoring.NewClient("fake-client")
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("internalregistry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	manager := keyset.NewManager()
+	var keyIDs []uint32
+	for _, template := range []*tinkpb.KeyTemplate{
+		mac.HMACSHA256Tag256KeyTemplate(),
+		mac.HMACSHA512Tag512KeyTemplate(),
+		mac.AESCMACTag128KeyTemplate(),
+	} {
+		keyID, err := manager.Add(template)
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		keyIDs = append(keyIDs, keyID)
+	}
+	if err := manager.SetPrimary(keyIDs[1]); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	if err := manager.Disable(keyIDs[0]); err != nil {
+		t.Fatalf("manager.Disable() err = %v, want nil", err)
+	}
+	kh, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := insecurecleartextkeyset.Write(kh, keyset.NewBinaryWriter(buff)); err != nil {
+		t.Fatalf("insecurecleartextkeyset.Write() err = %v, want nil", err)
+	}
+	annotations := map[string]string{"foo": "bar"}
+	handle, err := insecurecleartextkeyset.Read(keyset.NewBinaryReader(buff), keyset.WithAnnotations(annotations))
+	if err != nil {
+		t.Fatalf("insecurecleartextkeyset.Read() err = %v, want nil", err)
+	}
+	keyAnnotations := map[string]string{"owner": "team"}
+	handle, err = keyset.SetKeyAnnotations(handle, keyIDs[2], keyAnnotations)
+	if err != nil {
+		t.Fatalf("keyset.SetKeyAnnotations() err = %v, want nil", err)
+	}
+
+	p, err := mac.New(handle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	if _, err := p.ComputeMAC([]byte("data")); err != nil {
+		t.Fatalf("p.ComputeMAC() err = %v, want nil", err)
+	}
+	events := client.Events()
+	if len(events) != 1 {
+		t.Fatalf("len(client.Events()) = %d, want 1", len(events))
+	}
+
+	got, err := keyset.MonitoringKeysetInfo(handle)
+	if err != nil {
+		t.Fatalf("keyset.MonitoringKeysetInfo() err = %v, want nil", err)
+	}
+	want := &monitoring.KeysetInfo{
+		Annotations:  annotations,
+		PrimaryKeyID: keyIDs[1],
+		Entries: []*monitoring.Entry{
+			{
+				KeyID:     keyIDs[1],
+				Status:    monitoring.Enabled,
+				KeyType:   "tink.HmacKey",
+				KeyPrefix: "TINK",
+			},
+			{
+				KeyID:       keyIDs[2],
+				Status:      monitoring.Enabled,
+				KeyType:     "tink.AesCmacKey",
+				KeyPrefix:   "TINK",
+				Annotations: keyAnnotations,
+			},
+		},
+	}
+	sortEntries := cmpopts.SortSlices(func(a, b *monitoring.Entry) bool { return a.KeyID < b.KeyID })
+	if diff := cmp.Diff(want, got, sortEntries); diff != "" {
+		t.Errorf("keyset.MonitoringKeysetInfo() diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(events[0].Context.KeysetInfo, got, sortEntries); diff != "" {
+		t.Errorf("keyset.MonitoringKeysetInfo() differs from the logger's keyset info (-logger +got):\n%s", diff)
+	}
+}
+
+func TestMonitoringKeysetInfoWithNilHandleFails(t *testing.T) {
+	if _, err := keyset.MonitoringKeysetInfo(nil); err == nil {
+		t.Error("keyset.MonitoringKeysetInfo(nil) err = nil, want error")
+	}
+}
+
+// keyLoadClient is a monitoring client that records key load events.
+type keyLoadClient struct {
+	*fakemonitoring.Client
+	primaryKeyIDs []uint32
+	checksums     [][]byte
+}
+
+var _ monitoring.KeyLoadLogger = (*keyLoadClient)(nil)
+
+func (c *keyLoadClient) LogKeyLoad(keysetInfo *monitoring.KeysetInfo, checksum []byte) {
+	c.primaryKeyIDs = append(c.primaryKeyIDs, keysetInfo.PrimaryKeyID)
+	c.checksums = append(c.checksums, checksum)
+}
+
+func annotatedHandle(t *testing.T, kh *keyset.Handle) *keyset.Handle {
+	t.Helper()
+	buff := &bytes.Buffer{}
+	if err := insecurecleartextkeyset.Write(kh, keyset.NewBinaryWriter(buff)); err != nil {
+		t.Fatalf("insecurecleartextkeyset.Write() err = %v, want nil", err)
+	}
+	handle, err := insecurecleartextkeyset.Read(keyset.NewBinaryReader(buff), keyset.WithAnnotations(map[string]string{"foo": "bar"}))
+	if err != nil {
+		t.Fatalf("insecurecleartextkeyset.Read() err = %v, want nil", err)
+	}
+	return handle
+}
+
+func TestKeyLoadLoggerDetectsRotation(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := &keyLoadClient{Client: fakemonitoring.NewClient("fake-client")}
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("internalregistry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	manager := keyset.NewManager()
+	firstID, err := manager.Add(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(firstID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	secondID, err := manager.Add(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	kh, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	handle := annotatedHandle(t, kh)
+	if _, err := mac.New(handle); err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	if _, err := mac.New(annotatedHandle(t, kh)); err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(secondID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	rotated, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	if _, err := mac.New(annotatedHandle(t, rotated)); err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+
+	if diff := cmp.Diff([]uint32{firstID, firstID, secondID}, client.primaryKeyIDs); diff != "" {
+		t.Errorf("primary key IDs diff (-want +got):\n%s", diff)
+	}
+	if len(client.checksums) != 3 {
+		t.Fatalf("len(client.checksums) = %d, want 3", len(client.checksums))
+	}
+	if len(client.checksums[0]) != 32 {
+		t.Errorf("len(checksum) = %d, want 32", len(client.checksums[0]))
+	}
+	if !bytes.Equal(client.checksums[0], client.checksums[1]) {
+		t.Error("checksums of the same keyset differ")
+	}
+	if bytes.Equal(client.checksums[1], client.checksums[2]) {
+		t.Error("checksums of the rotated keyset are equal")
+	}
+}
+
+func TestKeyLoadLoggerChecksumDoesNotDependOnKeyMaterial(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := &keyLoadClient{Client: fakemonitoring.NewClient("fake-client")}
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("internalregistry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	kh, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	ks := testkeyset.KeysetMaterial(kh)
+	hmacKey := new(hmacpb.HmacKey)
+	if err := proto.Unmarshal(ks.GetKey()[0].GetKeyData().GetValue(), hmacKey); err != nil {
+		t.Fatalf("proto.Unmarshal() err = %v, want nil", err)
+	}
+	hmacKey.KeyValue[0] ^= 1
+	value, err := proto.Marshal(hmacKey)
+	if err != nil {
+		t.Fatalf("proto.Marshal() err = %v, want nil", err)
+	}
+	ks.GetKey()[0].GetKeyData().Value = value
+	otherKH, err := testkeyset.NewHandle(ks)
+	if err != nil {
+		t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+	}
+
+	if _, err := mac.New(annotatedHandle(t, kh)); err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	if _, err := mac.New(annotatedHandle(t, otherKH)); err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	if len(client.checksums) != 2 {
+		t.Fatalf("len(client.checksums) = %d, want 2", len(client.checksums))
+	}
+	if !bytes.Equal(client.checksums[0], client.checksums[1]) {
+		t.Error("checksums of keysets that only differ in key material differ")
+	}
+}
+
+func TestKeyLoadLoggerWithoutAnnotationsDoesNotLog(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := &keyLoadClient{Client: fakemonitoring.NewClient("fake-client")}
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("internalregistry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	kh, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	if _, err := mac.New(kh); err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	if len(client.checksums) != 0 {
+		t.Errorf("len(client.checksums) = %d, want 0", len(client.checksums))
+	}
+}
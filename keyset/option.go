@@ -14,7 +14,12 @@
 
 package keyset
 
-import "fmt"
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+)
 
 // Option is used to pass options for a keyset handle.
 type Option interface {
@@ -36,6 +41,74 @@ func WithAnnotations(annotations map[string]string) Option {
 	})
 }
 
+// RejectDuplicateKeyMaterial makes the creation of a keyset handle fail if two
+// of its keys have the same type and identical key material, for example
+// because the same key was imported twice under different key IDs. It is
+// meant for reading keysets, with [ReadWithOptions] or
+// insecurecleartextkeyset.Read.
+//
+// Key material is compared in constant time.
+func RejectDuplicateKeyMaterial() Option {
+	return option(func(h *Handle) error {
+		typeURLs := make([]string, len(h.entries))
+		values := make([][]byte, len(h.entries))
+		for i, entry := range h.entries {
+			keySerialization, err := protoserialization.SerializeKey(entry.key)
+			if err != nil {
+				return err
+			}
+			typeURLs[i] = keySerialization.KeyData().GetTypeUrl()
+			values[i] = keySerialization.KeyData().GetValue()
+		}
+		for i := range values {
+			for j := i + 1; j < len(values); j++ {
+				if typeURLs[i] != typeURLs[j] {
+					continue
+				}
+				if subtle.ConstantTimeCompare(values[i], values[j]) == 1 {
+					return fmt.Errorf("keys %d and %d have identical key material", h.entries[i].keyID, h.entries[j].keyID)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// keyIDSourceOption is the Option returned by WithKeyIDSource. It is consumed
+// by NewHandleWithOptions before the other options are applied.
+type keyIDSourceOption func() uint32
+
+func (o keyIDSourceOption) set(h *Handle) error {
+	return fmt.Errorf("WithKeyIDSource is only supported by NewHandleWithOptions")
+}
+
+// WithKeyIDSource makes [NewHandleWithOptions] take the ID of the new key from
+// source instead of generating a random one, for example to create
+// reproducible keysets in tests. If source returns an ID that is already in
+// use, it is called again.
+//
+// Key IDs are not secret, but keysets whose IDs come from the same
+// deterministic source are likely to have colliding IDs, which makes
+// combining them harder. Use the default random IDs in production.
+func WithKeyIDSource(source func() uint32) Option {
+	return keyIDSourceOption(source)
+}
+
+// splitKeyIDSource returns the source of the last WithKeyIDSource option in
+// opts, or nil if there is none, and the other options.
+func splitKeyIDSource(opts []Option) (func() uint32, []Option) {
+	var source func() uint32
+	var rest []Option
+	for _, opt := range opts {
+		if o, ok := opt.(keyIDSourceOption); ok {
+			source = o
+			continue
+		}
+		rest = append(rest, opt)
+	}
+	return source, rest
+}
+
 func applyOptions(h *Handle, opts ...Option) error {
 	for _, opt := range opts {
 		if err := opt.set(h); err != nil {
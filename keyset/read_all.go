@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// ReadAll reads a sequence of encrypted keysets from r until EOF, and returns
+// a Handle for each of them, in order.
+//
+// Each record in the stream is a binary EncryptedKeyset, as written by
+// [BinaryWriter], preceded by its length encoded as a protobuf varint. This is
+// the same framing as protobuf's length-delimited streams. All keysets are
+// decrypted with masterKey and empty associated data.
+//
+// It returns an error if the stream ends in the middle of a record.
+func ReadAll(r io.Reader, masterKey tink.AEAD) ([]*Handle, error) {
+	br := bufio.NewReader(r)
+	var handles []*Handle
+	for i := 0; ; i++ {
+		if _, err := br.Peek(1); err == io.EOF {
+			return handles, nil
+		}
+		length, err := binary.ReadUvarint(br)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("keyset.ReadAll: record %d: truncated length prefix", i)
+			}
+			return nil, fmt.Errorf("keyset.ReadAll: record %d: %v", i, err)
+		}
+		// Don't trust length for allocation; grow the buffer as data arrives.
+		data, err := io.ReadAll(io.LimitReader(br, int64(min(length, 1<<63-1))))
+		if err != nil {
+			return nil, fmt.Errorf("keyset.ReadAll: record %d: %v", i, err)
+		}
+		if uint64(len(data)) != length {
+			return nil, fmt.Errorf("keyset.ReadAll: record %d: truncated, got %d bytes, want %d", i, len(data), length)
+		}
+		handle, err := Read(NewBinaryReader(bytes.NewReader(data)), masterKey)
+		if err != nil {
+			return nil, fmt.Errorf("keyset.ReadAll: record %d: %v", i, err)
+		}
+		handles = append(handles, handle)
+	}
+}
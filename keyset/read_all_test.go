@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/testing/fakekms"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func writeDelimited(t *testing.T, handles []*keyset.Handle, masterKey tink.AEAD) []byte {
+	t.Helper()
+	var stream []byte
+	for _, h := range handles {
+		buf := &bytes.Buffer{}
+		if err := h.Write(keyset.NewBinaryWriter(buf), masterKey); err != nil {
+			t.Fatalf("h.Write() err = %v, want nil", err)
+		}
+		stream = binary.AppendUvarint(stream, uint64(buf.Len()))
+		stream = append(stream, buf.Bytes()...)
+	}
+	return stream
+}
+
+func TestReadAll(t *testing.T) {
+	masterKey, err := fakekms.NewAEAD(fakeKeyURI)
+	if err != nil {
+		t.Fatalf("fakekms.NewAEAD() err = %v, want nil", err)
+	}
+	var handles []*keyset.Handle
+	for _, template := range []*tinkpb.KeyTemplate{
+		mac.HMACSHA256Tag128KeyTemplate(),
+		aead.AES128GCMKeyTemplate(),
+		aead.ChaCha20Poly1305KeyTemplate(),
+	} {
+		h, err := keyset.NewHandle(template)
+		if err != nil {
+			t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+		}
+		handles = append(handles, h)
+	}
+	stream := writeDelimited(t, handles, masterKey)
+
+	got, err := keyset.ReadAll(bytes.NewReader(stream), masterKey)
+	if err != nil {
+		t.Fatalf("keyset.ReadAll() err = %v, want nil", err)
+	}
+	if len(got) != len(handles) {
+		t.Fatalf("len(keyset.ReadAll()) = %d, want %d", len(got), len(handles))
+	}
+	for i := range handles {
+		if !proto.Equal(testkeyset.KeysetMaterial(got[i]), testkeyset.KeysetMaterial(handles[i])) {
+			t.Errorf("keyset.ReadAll()[%d] = %v, want %v", i, got[i], handles[i])
+		}
+	}
+}
+
+func TestReadAllEmptyStream(t *testing.T) {
+	masterKey, err := fakekms.NewAEAD(fakeKeyURI)
+	if err != nil {
+		t.Fatalf("fakekms.NewAEAD() err = %v, want nil", err)
+	}
+	got, err := keyset.ReadAll(bytes.NewReader(nil), masterKey)
+	if err != nil {
+		t.Fatalf("keyset.ReadAll() err = %v, want nil", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("len(keyset.ReadAll()) = %d, want 0", len(got))
+	}
+}
+
+func TestReadAllFailsWithTruncatedRecord(t *testing.T) {
+	masterKey, err := fakekms.NewAEAD(fakeKeyURI)
+	if err != nil {
+		t.Fatalf("fakekms.NewAEAD() err = %v, want nil", err)
+	}
+	h, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	stream := writeDelimited(t, []*keyset.Handle{h, h}, masterKey)
+	for _, tc := range []struct {
+		name   string
+		stream []byte
+	}{
+		{"truncated payload", stream[:len(stream)-1]},
+		{"truncated length prefix", append(bytes.Clone(stream), 0x80)},
+		{"length larger than stream", binary.AppendUvarint(bytes.Clone(stream), 1<<40)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := keyset.ReadAll(bytes.NewReader(tc.stream), masterKey)
+			if err == nil {
+				t.Fatalf("keyset.ReadAll() err = nil, want error")
+			}
+			if !strings.Contains(err.Error(), "truncated") {
+				t.Errorf("keyset.ReadAll() err = %v, want error mentioning truncation", err)
+			}
+		})
+	}
+}
+
+func TestReadAllFailsWithCorruptedRecord(t *testing.T) {
+	masterKey, err := fakekms.NewAEAD(fakeKeyURI)
+	if err != nil {
+		t.Fatalf("fakekms.NewAEAD() err = %v, want nil", err)
+	}
+	h, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	stream := writeDelimited(t, []*keyset.Handle{h}, masterKey)
+	stream[len(stream)-1] ^= 1
+	if _, err := keyset.ReadAll(bytes.NewReader(stream), masterKey); err == nil {
+		t.Errorf("keyset.ReadAll() err = nil, want error")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"fmt"
+	"math"
+)
+
+// ReassignIDs returns a copy of handle in which the keys have the sequential
+// IDs startID, startID+1, ... in keyset order, together with a map from the
+// old to the new key IDs. The primary key remains primary under its new ID.
+// This can be used to avoid ID collisions before combining keysets from
+// independent sources.
+//
+// Only keys without an ID requirement, i.e. with output prefix type RAW, can
+// be reassigned: the ID of any other key is part of its ciphertexts, tags and
+// signatures, which would no longer match the key. ReassignIDs returns an
+// error if handle contains such a key.
+//
+// Key annotations set with SetKeyAnnotations are not copied.
+func ReassignIDs(handle *Handle, startID uint32) (*Handle, map[uint32]uint32, error) {
+	if handle == nil {
+		return nil, nil, fmt.Errorf("keyset.ReassignIDs: nil handle")
+	}
+	if n := uint64(len(handle.entries)); n > 0 && uint64(startID)+n-1 > math.MaxUint32 {
+		return nil, nil, fmt.Errorf("keyset.ReassignIDs: %d keys do not fit in the IDs starting at %d", len(handle.entries), startID)
+	}
+	entries := make([]*Entry, len(handle.entries))
+	idMap := make(map[uint32]uint32, len(handle.entries))
+	var primaryKeyEntry *Entry
+	for i, entry := range handle.entries {
+		if _, hasIDRequirement := entry.Key().IDRequirement(); hasIDRequirement {
+			return nil, nil, fmt.Errorf("keyset.ReassignIDs: key %d has an ID requirement", entry.KeyID())
+		}
+		newID := startID + uint32(i)
+		idMap[entry.KeyID()] = newID
+		entries[i] = &Entry{
+			key:       entry.key,
+			isPrimary: entry.isPrimary,
+			keyID:     newID,
+			status:    entry.status,
+		}
+		if entry.isPrimary {
+			primaryKeyEntry = entries[i]
+		}
+	}
+	return &Handle{
+		entries:          entries,
+		annotations:      handle.annotations,
+		keysetHasSecrets: handle.keysetHasSecrets,
+		primaryKeyEntry:  primaryKeyEntry,
+	}, idMap, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+func TestReassignIDs(t *testing.T) {
+	manager := keyset.NewManager()
+	var signers []tink.Signer
+	var oldIDs []uint32
+	for i := 0; i < 3; i++ {
+		handle, err := keyset.NewHandle(signature.ED25519KeyWithoutPrefixTemplate())
+		if err != nil {
+			t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+		}
+		signer, err := signature.NewSigner(handle)
+		if err != nil {
+			t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+		}
+		signers = append(signers, signer)
+		entry, err := handle.Primary()
+		if err != nil {
+			t.Fatalf("handle.Primary() err = %v, want nil", err)
+		}
+		keyID, err := manager.AddKey(entry.Key())
+		if err != nil {
+			t.Fatalf("manager.AddKey() err = %v, want nil", err)
+		}
+		oldIDs = append(oldIDs, keyID)
+	}
+	if err := manager.SetPrimary(oldIDs[1]); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	privateHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	handle, err := privateHandle.Public()
+	if err != nil {
+		t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+	}
+
+	got, idMap, err := keyset.ReassignIDs(handle, 100)
+	if err != nil {
+		t.Fatalf("keyset.ReassignIDs() err = %v, want nil", err)
+	}
+	wantIDMap := map[uint32]uint32{oldIDs[0]: 100, oldIDs[1]: 101, oldIDs[2]: 102}
+	if diff := cmp.Diff(wantIDMap, idMap); diff != "" {
+		t.Errorf("keyset.ReassignIDs() ID map mismatch (-want +got):\n%s", diff)
+	}
+	if got.Len() != handle.Len() {
+		t.Fatalf("got.Len() = %d, want %d", got.Len(), handle.Len())
+	}
+	for i := 0; i < got.Len(); i++ {
+		entry, err := got.Entry(i)
+		if err != nil {
+			t.Fatalf("got.Entry(%d) err = %v, want nil", i, err)
+		}
+		oldEntry, err := handle.Entry(i)
+		if err != nil {
+			t.Fatalf("handle.Entry(%d) err = %v, want nil", i, err)
+		}
+		if entry.KeyID() != wantIDMap[oldEntry.KeyID()] {
+			t.Errorf("got.Entry(%d).KeyID() = %d, want %d", i, entry.KeyID(), wantIDMap[oldEntry.KeyID()])
+		}
+		if entry.IsPrimary() != oldEntry.IsPrimary() {
+			t.Errorf("got.Entry(%d).IsPrimary() = %v, want %v", i, entry.IsPrimary(), oldEntry.IsPrimary())
+		}
+		if !entry.Key().Equal(oldEntry.Key()) {
+			t.Errorf("got.Entry(%d).Key() is not equal to the original key", i)
+		}
+	}
+	primary, err := got.Primary()
+	if err != nil {
+		t.Fatalf("got.Primary() err = %v, want nil", err)
+	}
+	if primary.KeyID() != 101 {
+		t.Errorf("got.Primary().KeyID() = %d, want 101", primary.KeyID())
+	}
+	// The original handle is unchanged.
+	if handle.KeysetInfo().GetPrimaryKeyId() != oldIDs[1] {
+		t.Errorf("handle.KeysetInfo().GetPrimaryKeyId() = %d, want %d", handle.KeysetInfo().GetPrimaryKeyId(), oldIDs[1])
+	}
+
+	verifier, err := signature.NewVerifier(got)
+	if err != nil {
+		t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	for i, signer := range signers {
+		sig, err := signer.Sign(data)
+		if err != nil {
+			t.Fatalf("signer.Sign() err = %v, want nil", err)
+		}
+		if err := verifier.Verify(sig, data); err != nil {
+			t.Errorf("verifier.Verify() with key %d err = %v, want nil", i, err)
+		}
+	}
+}
+
+func TestReassignIDsFails(t *testing.T) {
+	rawHandle, err := keyset.NewHandle(signature.ED25519KeyWithoutPrefixTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	tinkHandle, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	manager := keyset.NewManagerFromHandle(rawHandle)
+	if _, err := manager.Add(signature.ED25519KeyWithoutPrefixTemplate()); err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	twoKeyHandle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name    string
+		handle  *keyset.Handle
+		startID uint32
+	}{
+		{
+			name:    "nil handle",
+			handle:  nil,
+			startID: 1,
+		},
+		{
+			name:    "key with ID requirement",
+			handle:  tinkHandle,
+			startID: 1,
+		},
+		{
+			name:    "IDs overflow",
+			handle:  twoKeyHandle,
+			startID: math.MaxUint32,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := keyset.ReassignIDs(tc.handle, tc.startID); err == nil {
+				t.Errorf("keyset.ReassignIDs() err = nil, want error")
+			}
+		})
+	}
+
+	// The largest ID can be used.
+	if _, _, err := keyset.ReassignIDs(rawHandle, math.MaxUint32); err != nil {
+		t.Errorf("keyset.ReassignIDs(rawHandle, math.MaxUint32) err = %v, want nil", err)
+	}
+}
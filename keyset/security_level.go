@@ -0,0 +1,353 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+	aescmacpb "github.com/tink-crypto/tink-go/v2/proto/aes_cmac_go_proto"
+	aescmacprfpb "github.com/tink-crypto/tink-go/v2/proto/aes_cmac_prf_go_proto"
+	aesctrhmacpb "github.com/tink-crypto/tink-go/v2/proto/aes_ctr_hmac_aead_go_proto"
+	aesctrhmacstreamingpb "github.com/tink-crypto/tink-go/v2/proto/aes_ctr_hmac_streaming_go_proto"
+	aesgcmpb "github.com/tink-crypto/tink-go/v2/proto/aes_gcm_go_proto"
+	aesgcmhkdfpb "github.com/tink-crypto/tink-go/v2/proto/aes_gcm_hkdf_streaming_go_proto"
+	aesgcmsivpb "github.com/tink-crypto/tink-go/v2/proto/aes_gcm_siv_go_proto"
+	aessivpb "github.com/tink-crypto/tink-go/v2/proto/aes_siv_go_proto"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
+	eciespb "github.com/tink-crypto/tink-go/v2/proto/ecies_aead_hkdf_go_proto"
+	hkdfprfpb "github.com/tink-crypto/tink-go/v2/proto/hkdf_prf_go_proto"
+	hmacpb "github.com/tink-crypto/tink-go/v2/proto/hmac_go_proto"
+	hmacprfpb "github.com/tink-crypto/tink-go/v2/proto/hmac_prf_go_proto"
+	hpkepb "github.com/tink-crypto/tink-go/v2/proto/hpke_go_proto"
+	jwtecdsapb "github.com/tink-crypto/tink-go/v2/proto/jwt_ecdsa_go_proto"
+	jwthmacpb "github.com/tink-crypto/tink-go/v2/proto/jwt_hmac_go_proto"
+	jwtrsassapkcs1pb "github.com/tink-crypto/tink-go/v2/proto/jwt_rsa_ssa_pkcs1_go_proto"
+	jwtrsassapsspb "github.com/tink-crypto/tink-go/v2/proto/jwt_rsa_ssa_pss_go_proto"
+	rsassapkcs1pb "github.com/tink-crypto/tink-go/v2/proto/rsa_ssa_pkcs1_go_proto"
+	rsassapsspb "github.com/tink-crypto/tink-go/v2/proto/rsa_ssa_pss_go_proto"
+)
+
+const (
+	typeURLPrefix        = "type.googleapis.com/google.crypto.tink."
+	maxSecurityLevelBits = 256
+)
+
+// securityLevelFuncs maps key type URLs to functions that compute the security
+// level in bits of a serialized key of that type.
+var securityLevelFuncs = map[string]func(value []byte) (int, error){
+	typeURLPrefix + "AesGcmKey":    symmetricKeyLevel(new(aesgcmpb.AesGcmKey)),
+	typeURLPrefix + "AesGcmSivKey": symmetricKeyLevel(new(aesgcmsivpb.AesGcmSivKey)),
+	typeURLPrefix + "AesSivKey": func(value []byte) (int, error) {
+		// The key is split into a MAC key and an encryption key of equal size.
+		k := new(aessivpb.AesSivKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return len(k.GetKeyValue()) * 8 / 2, nil
+	},
+	typeURLPrefix + "AesCtrHmacAeadKey": func(value []byte) (int, error) {
+		k := new(aesctrhmacpb.AesCtrHmacAeadKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return min(len(k.GetAesCtrKey().GetKeyValue())*8, hmacLevel(k.GetHmacKey())), nil
+	},
+	typeURLPrefix + "ChaCha20Poly1305Key":  fixedLevel(256),
+	typeURLPrefix + "XChaCha20Poly1305Key": fixedLevel(256),
+	typeURLPrefix + "XAesGcmKey":           fixedLevel(256),
+	typeURLPrefix + "HmacKey": func(value []byte) (int, error) {
+		k := new(hmacpb.HmacKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return hmacLevel(k), nil
+	},
+	typeURLPrefix + "AesCmacKey": func(value []byte) (int, error) {
+		k := new(aescmacpb.AesCmacKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return min(len(k.GetKeyValue())*8, int(k.GetParams().GetTagSize())*8), nil
+	},
+	typeURLPrefix + "HmacPrfKey":    symmetricKeyLevel(new(hmacprfpb.HmacPrfKey)),
+	typeURLPrefix + "HkdfPrfKey":    symmetricKeyLevel(new(hkdfprfpb.HkdfPrfKey)),
+	typeURLPrefix + "AesCmacPrfKey": symmetricKeyLevel(new(aescmacprfpb.AesCmacPrfKey)),
+	typeURLPrefix + "JwtHmacKey":    symmetricKeyLevel(new(jwthmacpb.JwtHmacKey)),
+	typeURLPrefix + "AesCtrHmacStreamingKey": func(value []byte) (int, error) {
+		k := new(aesctrhmacstreamingpb.AesCtrHmacStreamingKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return min(len(k.GetKeyValue()), int(k.GetParams().GetDerivedKeySize())) * 8, nil
+	},
+	typeURLPrefix + "AesGcmHkdfStreamingKey": func(value []byte) (int, error) {
+		k := new(aesgcmhkdfpb.AesGcmHkdfStreamingKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return min(len(k.GetKeyValue()), int(k.GetParams().GetDerivedKeySize())) * 8, nil
+	},
+	typeURLPrefix + "Ed25519PrivateKey": fixedLevel(128),
+	typeURLPrefix + "Ed25519PublicKey":  fixedLevel(128),
+	typeURLPrefix + "EcdsaPrivateKey": func(value []byte) (int, error) {
+		k := new(ecdsapb.EcdsaPrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return curveLevel(k.GetPublicKey().GetParams().GetCurve())
+	},
+	typeURLPrefix + "EcdsaPublicKey": func(value []byte) (int, error) {
+		k := new(ecdsapb.EcdsaPublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return curveLevel(k.GetParams().GetCurve())
+	},
+	typeURLPrefix + "EciesAeadHkdfPrivateKey": func(value []byte) (int, error) {
+		k := new(eciespb.EciesAeadHkdfPrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return curveLevel(k.GetPublicKey().GetParams().GetKemParams().GetCurveType())
+	},
+	typeURLPrefix + "EciesAeadHkdfPublicKey": func(value []byte) (int, error) {
+		k := new(eciespb.EciesAeadHkdfPublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return curveLevel(k.GetParams().GetKemParams().GetCurveType())
+	},
+	typeURLPrefix + "HpkePrivateKey": func(value []byte) (int, error) {
+		k := new(hpkepb.HpkePrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return hpkeLevel(k.GetPublicKey().GetParams())
+	},
+	typeURLPrefix + "HpkePublicKey": func(value []byte) (int, error) {
+		k := new(hpkepb.HpkePublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return hpkeLevel(k.GetParams())
+	},
+	typeURLPrefix + "JwtEcdsaPrivateKey": func(value []byte) (int, error) {
+		k := new(jwtecdsapb.JwtEcdsaPrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return jwtECDSALevel(k.GetPublicKey().GetAlgorithm())
+	},
+	typeURLPrefix + "JwtEcdsaPublicKey": func(value []byte) (int, error) {
+		k := new(jwtecdsapb.JwtEcdsaPublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return jwtECDSALevel(k.GetAlgorithm())
+	},
+	typeURLPrefix + "RsaSsaPkcs1PrivateKey": func(value []byte) (int, error) {
+		k := new(rsassapkcs1pb.RsaSsaPkcs1PrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetPublicKey().GetN()), nil
+	},
+	typeURLPrefix + "RsaSsaPkcs1PublicKey": func(value []byte) (int, error) {
+		k := new(rsassapkcs1pb.RsaSsaPkcs1PublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetN()), nil
+	},
+	typeURLPrefix + "RsaSsaPssPrivateKey": func(value []byte) (int, error) {
+		k := new(rsassapsspb.RsaSsaPssPrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetPublicKey().GetN()), nil
+	},
+	typeURLPrefix + "RsaSsaPssPublicKey": func(value []byte) (int, error) {
+		k := new(rsassapsspb.RsaSsaPssPublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetN()), nil
+	},
+	typeURLPrefix + "JwtRsaSsaPkcs1PrivateKey": func(value []byte) (int, error) {
+		k := new(jwtrsassapkcs1pb.JwtRsaSsaPkcs1PrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetPublicKey().GetN()), nil
+	},
+	typeURLPrefix + "JwtRsaSsaPkcs1PublicKey": func(value []byte) (int, error) {
+		k := new(jwtrsassapkcs1pb.JwtRsaSsaPkcs1PublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetN()), nil
+	},
+	typeURLPrefix + "JwtRsaSsaPssPrivateKey": func(value []byte) (int, error) {
+		k := new(jwtrsassapsspb.JwtRsaSsaPssPrivateKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetPublicKey().GetN()), nil
+	},
+	typeURLPrefix + "JwtRsaSsaPssPublicKey": func(value []byte) (int, error) {
+		k := new(jwtrsassapsspb.JwtRsaSsaPssPublicKey)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return rsaLevel(k.GetN()), nil
+	},
+}
+
+type keyValueMessage interface {
+	proto.Message
+	GetKeyValue() []byte
+}
+
+// symmetricKeyLevel returns a function that rates a key of the same type as m
+// by the size of its key value.
+func symmetricKeyLevel(m keyValueMessage) func(value []byte) (int, error) {
+	return func(value []byte) (int, error) {
+		k := m.ProtoReflect().New().Interface().(keyValueMessage)
+		if err := proto.Unmarshal(value, k); err != nil {
+			return 0, err
+		}
+		return len(k.GetKeyValue()) * 8, nil
+	}
+}
+
+func fixedLevel(bits int) func(value []byte) (int, error) {
+	return func([]byte) (int, error) { return bits, nil }
+}
+
+// hmacLevel rates an HMAC key by the smaller of its key size and tag size.
+func hmacLevel(k *hmacpb.HmacKey) int {
+	return min(len(k.GetKeyValue()), int(k.GetParams().GetTagSize())) * 8
+}
+
+func curveLevel(curve commonpb.EllipticCurveType) (int, error) {
+	switch curve {
+	case commonpb.EllipticCurveType_NIST_P256, commonpb.EllipticCurveType_CURVE25519:
+		return 128, nil
+	case commonpb.EllipticCurveType_NIST_P384:
+		return 192, nil
+	case commonpb.EllipticCurveType_NIST_P521:
+		return 256, nil
+	default:
+		return 0, fmt.Errorf("unsupported curve: %v", curve)
+	}
+}
+
+func jwtECDSALevel(algorithm jwtecdsapb.JwtEcdsaAlgorithm) (int, error) {
+	switch algorithm {
+	case jwtecdsapb.JwtEcdsaAlgorithm_ES256:
+		return 128, nil
+	case jwtecdsapb.JwtEcdsaAlgorithm_ES384:
+		return 192, nil
+	case jwtecdsapb.JwtEcdsaAlgorithm_ES512:
+		return 256, nil
+	default:
+		return 0, fmt.Errorf("unsupported JWT ECDSA algorithm: %v", algorithm)
+	}
+}
+
+func hpkeLevel(params *hpkepb.HpkeParams) (int, error) {
+	var kemLevel, aeadLevel int
+	switch params.GetKem() {
+	case hpkepb.HpkeKem_DHKEM_X25519_HKDF_SHA256, hpkepb.HpkeKem_DHKEM_P256_HKDF_SHA256:
+		kemLevel = 128
+	case hpkepb.HpkeKem_DHKEM_P384_HKDF_SHA384:
+		kemLevel = 192
+	case hpkepb.HpkeKem_DHKEM_P521_HKDF_SHA512:
+		kemLevel = 256
+	default:
+		return 0, fmt.Errorf("unsupported HPKE KEM: %v", params.GetKem())
+	}
+	switch params.GetAead() {
+	case hpkepb.HpkeAead_AES_128_GCM:
+		aeadLevel = 128
+	case hpkepb.HpkeAead_AES_256_GCM, hpkepb.HpkeAead_CHACHA20_POLY1305:
+		aeadLevel = 256
+	default:
+		return 0, fmt.Errorf("unsupported HPKE AEAD: %v", params.GetAead())
+	}
+	return min(kemLevel, aeadLevel), nil
+}
+
+// rsaLevel rates an RSA modulus following Table 2 of NIST SP 800-57 Part 1.
+func rsaLevel(n []byte) int {
+	bits := new(big.Int).SetBytes(n).BitLen()
+	switch {
+	case bits >= 15360:
+		return 256
+	case bits >= 7680:
+		return 192
+	case bits >= 3072:
+		return 128
+	case bits >= 2048:
+		return 112
+	default:
+		return 80
+	}
+}
+
+// SecurityLevelBits returns the estimated security level, in bits, of the
+// weakest key in the keyset, for example 128 for AES-128 or P-256 keys and
+// 256 for AES-256 or P-521 keys. Levels are capped at 256 bits. Keys marked as
+// destroyed are ignored.
+//
+// Both private and public keysets are supported. It returns an error if the
+// keyset contains a key whose type has no known security level, such as keys
+// stored in a KMS.
+func SecurityLevelBits(handle *Handle) (int, error) {
+	if handle == nil {
+		return 0, fmt.Errorf("keyset.SecurityLevelBits: nil handle")
+	}
+	level := 0
+	found := false
+	for _, entry := range handle.entries {
+		if entry.KeyStatus() == Destroyed {
+			continue
+		}
+		protoKey, err := entryToProtoKey(entry)
+		if err != nil {
+			return 0, fmt.Errorf("keyset.SecurityLevelBits: %v", err)
+		}
+		typeURL := protoKey.GetKeyData().GetTypeUrl()
+		levelFunc, ok := securityLevelFuncs[typeURL]
+		if !ok {
+			return 0, fmt.Errorf("keyset.SecurityLevelBits: unsupported key type %q", typeURL)
+		}
+		keyLevel, err := levelFunc(protoKey.GetKeyData().GetValue())
+		if err != nil {
+			return 0, fmt.Errorf("keyset.SecurityLevelBits: key %d: %v", entry.KeyID(), err)
+		}
+		keyLevel = min(keyLevel, maxSecurityLevelBits)
+		if !found || keyLevel < level {
+			level, found = keyLevel, true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("keyset.SecurityLevelBits: keyset has no keys")
+	}
+	return level, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/daead"
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/streamingaead"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestSecurityLevelBits(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+		want     int
+	}{
+		{"AES128GCM", aead.AES128GCMKeyTemplate(), 128},
+		{"AES256GCM", aead.AES256GCMKeyTemplate(), 256},
+		{"AES128CTRHMACSHA256", aead.AES128CTRHMACSHA256KeyTemplate(), 128},
+		{"ChaCha20Poly1305", aead.ChaCha20Poly1305KeyTemplate(), 256},
+		{"AESSIV", daead.AESSIVKeyTemplate(), 256},
+		{"HMACSHA256Tag128", mac.HMACSHA256Tag128KeyTemplate(), 128},
+		{"HMACSHA512Tag512", mac.HMACSHA512Tag512KeyTemplate(), 256},
+		{"AES128GCMHKDF4KB", streamingaead.AES128GCMHKDF4KBKeyTemplate(), 128},
+		{"ECDSAP256", signature.ECDSAP256KeyTemplate(), 128},
+		{"ECDSAP384", signature.ECDSAP384SHA384KeyTemplate(), 192},
+		{"ECDSAP521", signature.ECDSAP521KeyTemplate(), 256},
+		{"ED25519", signature.ED25519KeyTemplate(), 128},
+		{"RSASSAPSS3072", signature.RSA_SSA_PSS_3072_SHA256_32_F4_Key_Template(), 128},
+		{"HPKEX25519AES256GCM", hybrid.DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template(), 128},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kh, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			got, err := keyset.SecurityLevelBits(kh)
+			if err != nil {
+				t.Fatalf("keyset.SecurityLevelBits() err = %v, want nil", err)
+			}
+			if got != tc.want {
+				t.Errorf("keyset.SecurityLevelBits() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSecurityLevelBitsReturnsMinimum(t *testing.T) {
+	km := keyset.NewManager()
+	keyID, err := km.Add(signature.ECDSAP521KeyTemplate())
+	if err != nil {
+		t.Fatalf("km.Add() err = %v, want nil", err)
+	}
+	if err := km.SetPrimary(keyID); err != nil {
+		t.Fatalf("km.SetPrimary() err = %v, want nil", err)
+	}
+	if _, err := km.Add(signature.ECDSAP256KeyTemplate()); err != nil {
+		t.Fatalf("km.Add() err = %v, want nil", err)
+	}
+	kh, err := km.Handle()
+	if err != nil {
+		t.Fatalf("km.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := kh.Public()
+	if err != nil {
+		t.Fatalf("kh.Public() err = %v, want nil", err)
+	}
+	for _, h := range []*keyset.Handle{kh, publicHandle} {
+		got, err := keyset.SecurityLevelBits(h)
+		if err != nil {
+			t.Fatalf("keyset.SecurityLevelBits() err = %v, want nil", err)
+		}
+		if got != 128 {
+			t.Errorf("keyset.SecurityLevelBits() = %d, want 128", got)
+		}
+	}
+}
+
+func TestSecurityLevelBitsFailsWithUnsupportedKeyType(t *testing.T) {
+	kh, err := keyset.NewHandle(aead.KMSEnvelopeAEADKeyTemplate("fake-kms://key", aead.AES128GCMKeyTemplate()))
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	if _, err := keyset.SecurityLevelBits(kh); err == nil {
+		t.Errorf("keyset.SecurityLevelBits() err = nil, want error")
+	}
+	if _, err := keyset.SecurityLevelBits(nil); err == nil {
+		t.Errorf("keyset.SecurityLevelBits(nil) err = nil, want error")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	"github.com/tink-crypto/tink-go/v2/internal/internalregistry"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+const (
+	seedHKDFInfo = "tink-go keyset.NewHandleFromSeed v1"
+	minSeedSize  = 16
+)
+
+// NewHandleFromSeed creates a keyset handle that contains a single key
+// generated according to kt, using pseudorandomness expanded from seed
+// instead of the system's randomness. The same template and seed always give
+// the same key and key ID.
+//
+// NewHandleFromSeed is meant ONLY for tests and benchmarks that need the same
+// keyset across runs. Do not use it in production: the keyset is exactly as
+// secret as seed, and anyone who learns or guesses seed can recreate it. Use
+// [NewHandle] instead. To derive production keys from secret key material, use
+// the keyderivation package.
+//
+// seed must be at least 16 bytes long. kt must be of a key type that supports
+// key derivation, such as AES-GCM, HMAC, Ed25519 or ECDSA; RSA keys are not
+// supported.
+func NewHandleFromSeed(kt *tinkpb.KeyTemplate, seed []byte) (*Handle, error) {
+	if len(seed) < minSeedSize {
+		return nil, fmt.Errorf("keyset.NewHandleFromSeed: seed has %d bytes, want at least %d", len(seed), minSeedSize)
+	}
+	if !internalregistry.CanDeriveKeys(kt.GetTypeUrl()) {
+		return nil, fmt.Errorf("keyset.NewHandleFromSeed: key type %q does not support key derivation", kt.GetTypeUrl())
+	}
+	pseudorandomness := hkdf.New(sha256.New, seed, nil, []byte(seedHKDFInfo))
+	var keyIDBytes [4]byte
+	if _, err := io.ReadFull(pseudorandomness, keyIDBytes[:]); err != nil {
+		return nil, fmt.Errorf("keyset.NewHandleFromSeed: %v", err)
+	}
+	keyID := binary.BigEndian.Uint32(keyIDBytes[:])
+	keyData, err := internalregistry.DeriveKey(kt, pseudorandomness)
+	if err != nil {
+		return nil, fmt.Errorf("keyset.NewHandleFromSeed: %v", err)
+	}
+	handle, err := newWithOptions(&tinkpb.Keyset{
+		PrimaryKeyId: keyID,
+		Key: []*tinkpb.Keyset_Key{
+			&tinkpb.Keyset_Key{
+				KeyData:          keyData,
+				Status:           tinkpb.KeyStatusType_ENABLED,
+				KeyId:            keyID,
+				OutputPrefixType: kt.GetOutputPrefixType(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("keyset.NewHandleFromSeed: %v", err)
+	}
+	return handle, nil
+}
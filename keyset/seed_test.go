@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyset_test
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestNewHandleFromSeedIsDeterministic(t *testing.T) {
+	seed := []byte("0123456789abcdef")
+	otherSeed := []byte("0123456789abcdeF")
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"AES256_GCM", aead.AES256GCMKeyTemplate()},
+		{"HMAC_SHA256_TAG256", mac.HMACSHA256Tag256KeyTemplate()},
+		{"ED25519", signature.ED25519KeyTemplate()},
+		{"ECDSA_P256_RAW", signature.ECDSAP256RawKeyTemplate()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handle, err := keyset.NewHandleFromSeed(tc.template, seed)
+			if err != nil {
+				t.Fatalf("keyset.NewHandleFromSeed() err = %v, want nil", err)
+			}
+			sameHandle, err := keyset.NewHandleFromSeed(tc.template, seed)
+			if err != nil {
+				t.Fatalf("keyset.NewHandleFromSeed() err = %v, want nil", err)
+			}
+			otherHandle, err := keyset.NewHandleFromSeed(tc.template, otherSeed)
+			if err != nil {
+				t.Fatalf("keyset.NewHandleFromSeed() err = %v, want nil", err)
+			}
+			ks := testkeyset.KeysetMaterial(handle)
+			if !proto.Equal(ks, testkeyset.KeysetMaterial(sameHandle)) {
+				t.Error("keyset.NewHandleFromSeed() with the same seed gave different keysets")
+			}
+			otherKs := testkeyset.KeysetMaterial(otherHandle)
+			if bytes.Equal(ks.GetKey()[0].GetKeyData().GetValue(), otherKs.GetKey()[0].GetKeyData().GetValue()) {
+				t.Error("keyset.NewHandleFromSeed() with different seeds gave the same key")
+			}
+			if got, want := ks.GetKey()[0].GetOutputPrefixType(), tc.template.GetOutputPrefixType(); got != want {
+				t.Errorf("OutputPrefixType = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNewHandleFromSeedPrimitiveWorks(t *testing.T) {
+	handle, err := keyset.NewHandleFromSeed(aead.AES128GCMKeyTemplate(), []byte("benchmark seed 0"))
+	if err != nil {
+		t.Fatalf("keyset.NewHandleFromSeed() err = %v, want nil", err)
+	}
+	a, err := aead.New(handle)
+	if err != nil {
+		t.Fatalf("aead.New() err = %v, want nil", err)
+	}
+	ciphertext, err := a.Encrypt([]byte("plaintext"), []byte("associated data"))
+	if err != nil {
+		t.Fatalf("a.Encrypt() err = %v, want nil", err)
+	}
+	if _, err := a.Decrypt(ciphertext, []byte("associated data")); err != nil {
+		t.Errorf("a.Decrypt() err = %v, want nil", err)
+	}
+}
+
+func TestNewHandleFromSeedFails(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+		seed     []byte
+	}{
+		{"short seed", aead.AES256GCMKeyTemplate(), []byte("short seed")},
+		{"not derivable", signature.RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template(), []byte("0123456789abcdef")},
+		{"unknown type", &tinkpb.KeyTemplate{TypeUrl: "unknown"}, []byte("0123456789abcdef")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := keyset.NewHandleFromSeed(tc.template, tc.seed); err == nil {
+				t.Error("keyset.NewHandleFromSeed() err = nil, want error")
+			}
+		})
+	}
+}
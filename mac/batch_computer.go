@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/monitoring"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+var legacySuffix = []byte{0}
+
+// BatchComputer computes MACs with the primary key of a keyset, and is
+// optimized for computing many MACs over small inputs.
+//
+// If the primitive of the primary key can create a reusable instance, as HMAC
+// does, that instance is created once and used for every call, so the keyed
+// hash is reset between calls instead of being created anew for every MAC.
+// Otherwise BatchComputer computes MACs exactly like the primitive returned by
+// [New]. In both cases the output, including the output prefix, is identical
+// to that of [New], and the operations are logged like those of [New].
+//
+// BatchComputer is not safe for concurrent use. Create one instance per
+// goroutine.
+type BatchComputer struct {
+	prefix    []byte
+	legacy    bool
+	keyID     uint32
+	primitive tink.MAC
+	logger    monitoring.Logger
+	// legacyData is the buffer for the data of LEGACY keys, which is the data
+	// followed by a zero byte.
+	legacyData []byte
+}
+
+// NewBatchComputer creates a [BatchComputer] from the given keyset handle.
+func NewBatchComputer(handle *keyset.Handle) (*BatchComputer, error) {
+	ps, err := keyset.Primitives[tink.MAC](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("mac_factory: cannot obtain primitive set: %s", err)
+	}
+	computeLogger, _, err := createLoggers(ps)
+	if err != nil {
+		return nil, err
+	}
+	primitive := ps.Primary.Primitive
+	if r, ok := primitive.(reusableMAC); ok {
+		if reusable, err := r.NewReusable(); err == nil {
+			primitive = reusable
+		}
+	}
+	return &BatchComputer{
+		prefix:    []byte(ps.Primary.Prefix),
+		legacy:    ps.Primary.PrefixType == tinkpb.OutputPrefixType_LEGACY,
+		keyID:     ps.Primary.KeyID,
+		primitive: primitive,
+		logger:    computeLogger,
+	}, nil
+}
+
+// ComputeMAC computes a MAC over data with the primary key.
+func (c *BatchComputer) ComputeMAC(data []byte) ([]byte, error) {
+	if c.legacy {
+		if len(data) >= maxInt {
+			c.logger.LogFailure()
+			return nil, fmt.Errorf("mac_factory: data too long")
+		}
+		c.legacyData = append(append(c.legacyData[:0], data...), legacySuffix...)
+		data = c.legacyData
+	}
+	tag, err := c.primitive.ComputeMAC(data)
+	if err != nil {
+		c.logger.LogFailure()
+		return nil, err
+	}
+	c.logger.Log(c.keyID, len(data))
+	out := make([]byte, 0, len(c.prefix)+len(tag))
+	out = append(out, c.prefix...)
+	return append(out, tag...), nil
+}
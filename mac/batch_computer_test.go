@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func withPrefixType(template *tinkpb.KeyTemplate, prefixType tinkpb.OutputPrefixType) *tinkpb.KeyTemplate {
+	t := proto.Clone(template).(*tinkpb.KeyTemplate)
+	t.OutputPrefixType = prefixType
+	return t
+}
+
+func TestBatchComputerMatchesStandardPrimitive(t *testing.T) {
+	for _, template := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"HMAC_SHA256_128", mac.HMACSHA256Tag128KeyTemplate()},
+		{"HMAC_SHA256_256", mac.HMACSHA256Tag256KeyTemplate()},
+		{"HMAC_SHA512_256", mac.HMACSHA512Tag256KeyTemplate()},
+		{"HMAC_SHA512_512", mac.HMACSHA512Tag512KeyTemplate()},
+		{"AES_CMAC", mac.AESCMACTag128KeyTemplate()},
+	} {
+		for _, prefixType := range []tinkpb.OutputPrefixType{
+			tinkpb.OutputPrefixType_TINK,
+			tinkpb.OutputPrefixType_LEGACY,
+			tinkpb.OutputPrefixType_CRUNCHY,
+			tinkpb.OutputPrefixType_RAW,
+		} {
+			t.Run(template.name+"_"+prefixType.String(), func(t *testing.T) {
+				handle, err := keyset.NewHandle(withPrefixType(template.template, prefixType))
+				if err != nil {
+					t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+				}
+				primitive, err := mac.New(handle)
+				if err != nil {
+					t.Fatalf("mac.New() err = %v, want nil", err)
+				}
+				computer, err := mac.NewBatchComputer(handle)
+				if err != nil {
+					t.Fatalf("mac.NewBatchComputer() err = %v, want nil", err)
+				}
+				for _, size := range []uint32{0, 1, 16, 100, 16 * 1024} {
+					data := random.GetRandomBytes(size)
+					got, err := computer.ComputeMAC(data)
+					if err != nil {
+						t.Fatalf("computer.ComputeMAC() err = %v, want nil", err)
+					}
+					want, err := primitive.ComputeMAC(data)
+					if err != nil {
+						t.Fatalf("primitive.ComputeMAC() err = %v, want nil", err)
+					}
+					if !bytes.Equal(got, want) {
+						t.Errorf("computer.ComputeMAC() = %x, want %x", got, want)
+					}
+					if err := primitive.VerifyMAC(got, data); err != nil {
+						t.Errorf("primitive.VerifyMAC() err = %v, want nil", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestBatchComputerDoesNotModifyInput(t *testing.T) {
+	handle, err := keyset.NewHandle(withPrefixType(mac.AESCMACTag128KeyTemplate(), tinkpb.OutputPrefixType_LEGACY))
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	computer, err := mac.NewBatchComputer(handle)
+	if err != nil {
+		t.Fatalf("mac.NewBatchComputer() err = %v, want nil", err)
+	}
+	buf := []byte("data and more")
+	data := buf[:4]
+	if _, err := computer.ComputeMAC(data); err != nil {
+		t.Fatalf("computer.ComputeMAC() err = %v, want nil", err)
+	}
+	if got, want := string(buf), "data and more"; got != want {
+		t.Errorf("buf = %q, want %q", got, want)
+	}
+}
+
+func TestBatchComputerIsolatesOutputs(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	computer, err := mac.NewBatchComputer(handle)
+	if err != nil {
+		t.Fatalf("mac.NewBatchComputer() err = %v, want nil", err)
+	}
+	first, err := computer.ComputeMAC([]byte("first"))
+	if err != nil {
+		t.Fatalf("computer.ComputeMAC() err = %v, want nil", err)
+	}
+	firstCopy := bytes.Clone(first)
+	if _, err := computer.ComputeMAC([]byte("second")); err != nil {
+		t.Fatalf("computer.ComputeMAC() err = %v, want nil", err)
+	}
+	if !bytes.Equal(first, firstCopy) {
+		t.Errorf("first MAC changed after second ComputeMAC call: got %x, want %x", first, firstCopy)
+	}
+}
+
+func TestBatchComputerFailsWithExpiredPrimary(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	expired, err := keyset.SetKeyExpiry(handle, handle.KeysetInfo().GetPrimaryKeyId(), time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("keyset.SetKeyExpiry() err = %v, want nil", err)
+	}
+	computer, err := mac.NewBatchComputer(expired)
+	if err != nil {
+		t.Fatalf("mac.NewBatchComputer() err = %v, want nil", err)
+	}
+	if _, err := computer.ComputeMAC([]byte("data")); err == nil {
+		t.Errorf("computer.ComputeMAC() err = nil, want error")
+	}
+}
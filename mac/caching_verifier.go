@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// CachingVerifier verifies MACs like the primitive returned by [New], and
+// remembers the most recently verified MACs, so that verifying the same MAC
+// over the same data again, e.g. for a redelivered message, does not
+// recompute it.
+//
+// This is purely a performance optimization and does not change which MACs
+// are accepted: only successful verifications are cached, and the keyset
+// cannot change after construction. The cache holds the MACs and the SHA-256
+// hashes of the data, never the data itself. A cache hit is faster than a
+// verification, so the timing of VerifyMAC reveals whether the same MAC and
+// data were verified recently.
+//
+// CachingVerifier is safe for concurrent use.
+type CachingVerifier struct {
+	verifier  tink.MAC
+	cacheSize int
+
+	mu sync.Mutex
+	// lru holds the cached entries, most recently used first.
+	lru     *list.List
+	entries map[verifiedMAC]*list.Element
+}
+
+// verifiedMAC identifies a MAC that was successfully verified.
+type verifiedMAC struct {
+	mac      string
+	dataHash [sha256.Size]byte
+}
+
+// NewVerifierWithCache creates a [CachingVerifier] from the given keyset
+// handle, which caches at most cacheSize verified MACs.
+func NewVerifierWithCache(handle *keyset.Handle, cacheSize int) (*CachingVerifier, error) {
+	if cacheSize <= 0 {
+		return nil, fmt.Errorf("mac_factory: invalid cache size %d, want > 0", cacheSize)
+	}
+	primitive, err := New(handle)
+	if err != nil {
+		return nil, err
+	}
+	return &CachingVerifier{
+		verifier:  primitive,
+		cacheSize: cacheSize,
+		lru:       list.New(),
+		entries:   make(map[verifiedMAC]*list.Element),
+	}, nil
+}
+
+// VerifyMAC verifies whether mac is a correct authentication code for data.
+func (v *CachingVerifier) VerifyMAC(mac, data []byte) error {
+	key := verifiedMAC{mac: string(mac), dataHash: sha256.Sum256(data)}
+	v.mu.Lock()
+	if e, ok := v.entries[key]; ok {
+		v.lru.MoveToFront(e)
+		v.mu.Unlock()
+		return nil
+	}
+	v.mu.Unlock()
+
+	if err := v.verifier.VerifyMAC(mac, data); err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if e, ok := v.entries[key]; ok {
+		// Verified concurrently by another call.
+		v.lru.MoveToFront(e)
+		return nil
+	}
+	v.entries[key] = v.lru.PushFront(key)
+	if v.lru.Len() > v.cacheSize {
+		oldest := v.lru.Back()
+		v.lru.Remove(oldest)
+		delete(v.entries, oldest.Value.(verifiedMAC))
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac_test
+
+import (
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/internal/internalregistry"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/testing/fakemonitoring"
+)
+
+func TestCachingVerifier(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	primitive, err := mac.New(handle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	verifier, err := mac.NewVerifierWithCache(handle, 10)
+	if err != nil {
+		t.Fatalf("mac.NewVerifierWithCache() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	tag, err := primitive.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("primitive.ComputeMAC() err = %v, want nil", err)
+	}
+	// The second verification is a cache hit.
+	for i := 0; i < 2; i++ {
+		if err := verifier.VerifyMAC(tag, data); err != nil {
+			t.Errorf("verifier.VerifyMAC() err = %v, want nil", err)
+		}
+	}
+
+	invalidTag := append([]byte{}, tag...)
+	invalidTag[len(invalidTag)-1] ^= 1
+	for _, tc := range []struct {
+		name string
+		tag  []byte
+		data []byte
+	}{
+		{"invalid tag", invalidTag, data},
+		{"other data", tag, []byte("other data")},
+		{"empty tag", nil, data},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Failures are not cached.
+			for i := 0; i < 2; i++ {
+				if err := verifier.VerifyMAC(tc.tag, tc.data); err == nil {
+					t.Errorf("verifier.VerifyMAC() err = nil, want error")
+				}
+			}
+		})
+	}
+}
+
+func TestCachingVerifierEvictsLeastRecentlyUsed(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := fakemonitoring.NewClient("fake-client")
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("internalregistry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	// With key annotations, every verification that is not a cache hit is
+	// logged.
+	handle, err = keyset.SetKeyAnnotations(handle, handle.KeysetInfo().GetPrimaryKeyId(), map[string]string{"team": "payments"})
+	if err != nil {
+		t.Fatalf("keyset.SetKeyAnnotations() err = %v, want nil", err)
+	}
+	primitive, err := mac.New(handle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	verifier, err := mac.NewVerifierWithCache(handle, 2)
+	if err != nil {
+		t.Fatalf("mac.NewVerifierWithCache() err = %v, want nil", err)
+	}
+	messages := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	tags := make([][]byte, len(messages))
+	for i, m := range messages {
+		tags[i], err = primitive.ComputeMAC(m)
+		if err != nil {
+			t.Fatalf("primitive.ComputeMAC() err = %v, want nil", err)
+		}
+	}
+	numVerifyEvents := func() int {
+		n := 0
+		for _, e := range client.Events() {
+			if e.Context.APIFunction == "verify" {
+				n++
+			}
+		}
+		return n
+	}
+
+	for _, step := range []struct {
+		message    int
+		wantEvents int
+	}{
+		{message: 0, wantEvents: 1},
+		{message: 0, wantEvents: 1}, // Cached.
+		{message: 1, wantEvents: 2},
+		{message: 0, wantEvents: 2}, // Cached, and now more recent than 1.
+		{message: 2, wantEvents: 3}, // Evicts 1.
+		{message: 0, wantEvents: 3}, // Cached.
+		{message: 1, wantEvents: 4}, // Was evicted.
+	} {
+		if err := verifier.VerifyMAC(tags[step.message], messages[step.message]); err != nil {
+			t.Fatalf("verifier.VerifyMAC(message %d) err = %v, want nil", step.message, err)
+		}
+		if got := numVerifyEvents(); got != step.wantEvents {
+			t.Errorf("after verifying message %d: number of verify events = %d, want %d", step.message, got, step.wantEvents)
+		}
+	}
+}
+
+func TestNewVerifierWithCacheFails(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name      string
+		handle    *keyset.Handle
+		cacheSize int
+	}{
+		{"nil handle", nil, 10},
+		{"zero cache size", handle, 0},
+		{"negative cache size", handle, -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := mac.NewVerifierWithCache(tc.handle, tc.cacheSize); err == nil {
+				t.Errorf("mac.NewVerifierWithCache() err = nil, want error")
+			}
+		})
+	}
+}
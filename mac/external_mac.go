@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/core/cryptofmt"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// externalMAC is a [tink.MAC] whose MACs are computed by a function outside
+// of Tink.
+type externalMAC struct {
+	compute func(data []byte) ([]byte, error)
+	prefix  []byte
+	legacy  bool
+}
+
+var _ tink.MAC = (*externalMAC)(nil)
+
+// NewExternalMAC returns a [tink.MAC] that computes MACs with computeFn, for
+// example a MAC key stored in an HSM, and adds the output prefix of a key with
+// ID keyID and the given output prefix type.
+//
+// The MACs are framed exactly as by the primitive returned by [New] for a key
+// with the same ID and output prefix type: the prefix of non-RAW keys is
+// prepended, and for LEGACY keys computeFn is called on the data followed by
+// a zero byte. The MACs are thus interchangeable with those of a Tink key with
+// the same key material, which makes it possible to move keys between an HSM
+// and a keyset.
+//
+// VerifyMAC recomputes the MAC with computeFn and compares it in constant
+// time, so computeFn must be deterministic, as HMAC and CMAC are. MACs shorter
+// than [MinVariableTagLength] bytes, the minimum tag size of Tink's HMAC and
+// AES-CMAC keys, are rejected.
+//
+// The returned MAC is not backed by a key in a keyset. To use it alongside the
+// keys of a keyset, combine them with [NewWithExternalMACs].
+func NewExternalMAC(computeFn func(data []byte) ([]byte, error), keyID uint32, prefixType tinkpb.OutputPrefixType) (tink.MAC, error) {
+	if computeFn == nil {
+		return nil, fmt.Errorf("mac.NewExternalMAC: nil compute function")
+	}
+	prefix, err := cryptofmt.OutputPrefix(&tinkpb.Keyset_Key{KeyId: keyID, OutputPrefixType: prefixType})
+	if err != nil {
+		return nil, fmt.Errorf("mac.NewExternalMAC: %v", err)
+	}
+	return &externalMAC{
+		compute: computeFn,
+		prefix:  []byte(prefix),
+		legacy:  prefixType == tinkpb.OutputPrefixType_LEGACY,
+	}, nil
+}
+
+// computeTag returns the MAC of data computed by the external function,
+// without the prefix.
+func (m *externalMAC) computeTag(data []byte) ([]byte, error) {
+	if m.legacy {
+		d := data
+		data = make([]byte, 0, len(d)+1)
+		data = append(data, d...)
+		data = append(data, byte(0))
+	}
+	tag, err := m.compute(data)
+	if err != nil {
+		return nil, fmt.Errorf("mac.externalMAC: %v", err)
+	}
+	if len(tag) < MinVariableTagLength {
+		return nil, fmt.Errorf("mac.externalMAC: compute function returned a MAC of %d bytes, want at least %d", len(tag), MinVariableTagLength)
+	}
+	return tag, nil
+}
+
+// ComputeMAC computes the MAC of data with the external function and returns
+// the concatenation of the output prefix and the MAC.
+func (m *externalMAC) ComputeMAC(data []byte) ([]byte, error) {
+	tag, err := m.computeTag(data)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]byte, 0, len(m.prefix)+len(tag))
+	output = append(output, m.prefix...)
+	output = append(output, tag...)
+	return output, nil
+}
+
+// VerifyMAC verifies whether mac is a correct authentication code for data.
+func (m *externalMAC) VerifyMAC(mac, data []byte) error {
+	if len(mac) <= len(m.prefix) || !bytes.Equal(mac[:len(m.prefix)], m.prefix) {
+		return errInvalidMAC
+	}
+	tag, err := m.computeTag(data)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(mac[len(m.prefix):], tag) {
+		return errInvalidMAC
+	}
+	return nil
+}
+
+// keysetWithExternalMACs is a [tink.MAC] that combines the keys of a keyset
+// with external MACs.
+type keysetWithExternalMACs struct {
+	keyset tink.MAC
+	// external maps output prefixes to the external MACs with that prefix.
+	external map[string][]*externalMAC
+}
+
+var _ tink.MAC = (*keysetWithExternalMACs)(nil)
+
+// NewWithExternalMACs returns a [tink.MAC] that combines the keys of handle
+// with MACs created by [NewExternalMAC], for example to rotate between keys
+// stored in an HSM and keys in a keyset.
+//
+// ComputeMAC computes MACs with the primary key of handle, exactly like the
+// primitive returned by [New]. To compute MACs with an external key instead,
+// use its external MAC directly. VerifyMAC accepts MACs computed by any
+// enabled key of handle or by any of the external MACs: like [New], it
+// selects the candidate keys by the output prefix of mac, and then tries the
+// keys without output prefix.
+func NewWithExternalMACs(handle *keyset.Handle, externalMACs ...tink.MAC) (tink.MAC, error) {
+	primitive, err := New(handle)
+	if err != nil {
+		return nil, err
+	}
+	external := make(map[string][]*externalMAC)
+	for _, m := range externalMACs {
+		e, ok := m.(*externalMAC)
+		if !ok {
+			return nil, fmt.Errorf("mac.NewWithExternalMACs: %T was not created by NewExternalMAC", m)
+		}
+		external[string(e.prefix)] = append(external[string(e.prefix)], e)
+	}
+	return &keysetWithExternalMACs{keyset: primitive, external: external}, nil
+}
+
+// ComputeMAC computes a MAC over data with the primary key of the keyset.
+func (m *keysetWithExternalMACs) ComputeMAC(data []byte) ([]byte, error) {
+	return m.keyset.ComputeMAC(data)
+}
+
+// VerifyMAC verifies whether mac is a correct authentication code for data
+// for any key of the keyset or any external MAC.
+func (m *keysetWithExternalMACs) VerifyMAC(mac, data []byte) error {
+	if err := m.keyset.VerifyMAC(mac, data); err == nil {
+		return nil
+	}
+	if len(mac) > cryptofmt.NonRawPrefixSize {
+		for _, e := range m.external[string(mac[:cryptofmt.NonRawPrefixSize])] {
+			if err := e.VerifyMAC(mac, data); err == nil {
+				return nil
+			}
+		}
+	}
+	for _, e := range m.external[cryptofmt.RawPrefix] {
+		if err := e.VerifyMAC(mac, data); err == nil {
+			return nil
+		}
+	}
+	return errInvalidMAC
+}
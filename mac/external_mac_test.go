@@ -0,0 +1,253 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac_test
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// externalKey returns a function computing MACs with the single key of a RAW
+// HMAC keyset, as an HSM would, and a keyset with the same key using the given
+// output prefix type.
+func externalKey(t *testing.T, prefixType tinkpb.OutputPrefixType) (func([]byte) ([]byte, error), *keyset.Handle) {
+	t.Helper()
+	rawTemplate := proto.Clone(mac.HMACSHA256Tag256KeyTemplate()).(*tinkpb.KeyTemplate)
+	rawTemplate.OutputPrefixType = tinkpb.OutputPrefixType_RAW
+	rawHandle, err := keyset.NewHandle(rawTemplate)
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	rawMAC, err := mac.New(rawHandle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	ks := testkeyset.KeysetMaterial(rawHandle)
+	ks.GetKey()[0].OutputPrefixType = prefixType
+	handle, err := testkeyset.NewHandle(ks)
+	if err != nil {
+		t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+	}
+	return rawMAC.ComputeMAC, handle
+}
+
+func TestExternalMACInteroperatesWithKeyset(t *testing.T) {
+	for _, prefixType := range []tinkpb.OutputPrefixType{
+		tinkpb.OutputPrefixType_TINK,
+		tinkpb.OutputPrefixType_LEGACY,
+		tinkpb.OutputPrefixType_CRUNCHY,
+		tinkpb.OutputPrefixType_RAW,
+	} {
+		t.Run(prefixType.String(), func(t *testing.T) {
+			computeFn, handle := externalKey(t, prefixType)
+			keyID := handle.KeysetInfo().GetPrimaryKeyId()
+			external, err := mac.NewExternalMAC(computeFn, keyID, prefixType)
+			if err != nil {
+				t.Fatalf("mac.NewExternalMAC() err = %v, want nil", err)
+			}
+			software, err := mac.New(handle)
+			if err != nil {
+				t.Fatalf("mac.New() err = %v, want nil", err)
+			}
+			data := []byte("data")
+			externalTag, err := external.ComputeMAC(data)
+			if err != nil {
+				t.Fatalf("external.ComputeMAC() err = %v, want nil", err)
+			}
+			if err := software.VerifyMAC(externalTag, data); err != nil {
+				t.Errorf("software.VerifyMAC() err = %v, want nil", err)
+			}
+			softwareTag, err := software.ComputeMAC(data)
+			if err != nil {
+				t.Fatalf("software.ComputeMAC() err = %v, want nil", err)
+			}
+			if err := external.VerifyMAC(softwareTag, data); err != nil {
+				t.Errorf("external.VerifyMAC() err = %v, want nil", err)
+			}
+			if err := external.VerifyMAC(externalTag, []byte("other data")); err == nil {
+				t.Error("external.VerifyMAC() with other data err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestExternalMACVerifyFails(t *testing.T) {
+	computeFn, handle := externalKey(t, tinkpb.OutputPrefixType_TINK)
+	keyID := handle.KeysetInfo().GetPrimaryKeyId()
+	external, err := mac.NewExternalMAC(computeFn, keyID, tinkpb.OutputPrefixType_TINK)
+	if err != nil {
+		t.Fatalf("mac.NewExternalMAC() err = %v, want nil", err)
+	}
+	otherKeyID, err := mac.NewExternalMAC(computeFn, keyID+1, tinkpb.OutputPrefixType_TINK)
+	if err != nil {
+		t.Fatalf("mac.NewExternalMAC() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	tag, err := external.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("external.ComputeMAC() err = %v, want nil", err)
+	}
+	modified := append([]byte{}, tag...)
+	modified[len(modified)-1] ^= 1
+	for _, tc := range []struct {
+		name     string
+		verifier interface{ VerifyMAC(mac, data []byte) error }
+		tag      []byte
+	}{
+		{"modified tag", external, modified},
+		{"prefix only", external, tag[:5]},
+		{"empty", external, nil},
+		{"other key ID", otherKeyID, tag},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.verifier.VerifyMAC(tc.tag, data); err == nil {
+				t.Error("VerifyMAC() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestExternalMACComputeFails(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		computeFn func([]byte) ([]byte, error)
+	}{
+		{"error", func([]byte) ([]byte, error) { return nil, fmt.Errorf("HSM unavailable") }},
+		{"empty MAC", func([]byte) ([]byte, error) { return nil, nil }},
+		{"short MAC", func([]byte) ([]byte, error) { return make([]byte, mac.MinVariableTagLength-1), nil }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			external, err := mac.NewExternalMAC(tc.computeFn, 42, tinkpb.OutputPrefixType_TINK)
+			if err != nil {
+				t.Fatalf("mac.NewExternalMAC() err = %v, want nil", err)
+			}
+			if _, err := external.ComputeMAC([]byte("data")); err == nil {
+				t.Error("external.ComputeMAC() err = nil, want error")
+			}
+			if err := external.VerifyMAC([]byte("\x01\x00\x00\x00\x2atag"), []byte("data")); err == nil {
+				t.Error("external.VerifyMAC() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewExternalMACFails(t *testing.T) {
+	computeFn := func(data []byte) ([]byte, error) { return []byte("tag"), nil }
+	for _, tc := range []struct {
+		name       string
+		computeFn  func([]byte) ([]byte, error)
+		prefixType tinkpb.OutputPrefixType
+	}{
+		{"nil function", nil, tinkpb.OutputPrefixType_TINK},
+		{"unknown prefix", computeFn, tinkpb.OutputPrefixType_UNKNOWN_PREFIX},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := mac.NewExternalMAC(tc.computeFn, 42, tc.prefixType); err == nil {
+				t.Error("mac.NewExternalMAC() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewWithExternalMACs(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	software, err := mac.New(handle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	hsmComputeFn, _ := externalKey(t, tinkpb.OutputPrefixType_TINK)
+	hsm, err := mac.NewExternalMAC(hsmComputeFn, handle.KeysetInfo().GetPrimaryKeyId()+1, tinkpb.OutputPrefixType_TINK)
+	if err != nil {
+		t.Fatalf("mac.NewExternalMAC() err = %v, want nil", err)
+	}
+	rawComputeFn, _ := externalKey(t, tinkpb.OutputPrefixType_RAW)
+	rawHSM, err := mac.NewExternalMAC(rawComputeFn, 0, tinkpb.OutputPrefixType_RAW)
+	if err != nil {
+		t.Fatalf("mac.NewExternalMAC() err = %v, want nil", err)
+	}
+	otherComputeFn, _ := externalKey(t, tinkpb.OutputPrefixType_TINK)
+	other, err := mac.NewExternalMAC(otherComputeFn, handle.KeysetInfo().GetPrimaryKeyId()+1, tinkpb.OutputPrefixType_TINK)
+	if err != nil {
+		t.Fatalf("mac.NewExternalMAC() err = %v, want nil", err)
+	}
+	combined, err := mac.NewWithExternalMACs(handle, hsm, rawHSM)
+	if err != nil {
+		t.Fatalf("mac.NewWithExternalMACs() err = %v, want nil", err)
+	}
+
+	data := []byte("data")
+	tag, err := combined.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("combined.ComputeMAC() err = %v, want nil", err)
+	}
+	if err := software.VerifyMAC(tag, data); err != nil {
+		t.Errorf("software.VerifyMAC() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name string
+		m    tink.MAC
+	}{
+		{"keyset", software},
+		{"external", hsm},
+		{"external RAW", rawHSM},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tag, err := tc.m.ComputeMAC(data)
+			if err != nil {
+				t.Fatalf("ComputeMAC() err = %v, want nil", err)
+			}
+			if err := combined.VerifyMAC(tag, data); err != nil {
+				t.Errorf("combined.VerifyMAC() err = %v, want nil", err)
+			}
+			if err := combined.VerifyMAC(tag, []byte("other data")); err == nil {
+				t.Error("combined.VerifyMAC() with other data err = nil, want error")
+			}
+		})
+	}
+	otherTag, err := other.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("other.ComputeMAC() err = %v, want nil", err)
+	}
+	if err := combined.VerifyMAC(otherTag, data); err == nil {
+		t.Error("combined.VerifyMAC() with a MAC of another key err = nil, want error")
+	}
+}
+
+func TestNewWithExternalMACsFails(t *testing.T) {
+	handle, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	software, err := mac.New(handle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	if _, err := mac.NewWithExternalMACs(handle, software); err == nil {
+		t.Error("mac.NewWithExternalMACs() with a keyset MAC err = nil, want error")
+	}
+	if _, err := mac.NewWithExternalMACs(nil); err == nil {
+		t.Error("mac.NewWithExternalMACs() with nil handle err = nil, want error")
+	}
+}
@@ -20,6 +20,9 @@ import (
 	"github.com/tink-crypto/tink-go/v2/keyset"
 	"github.com/tink-crypto/tink-go/v2/mac"
 	"github.com/tink-crypto/tink-go/v2/subtle/random"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+	"github.com/tink-crypto/tink-go/v2/testutil"
+	"github.com/tink-crypto/tink-go/v2/tink"
 	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
 )
 
@@ -109,3 +112,92 @@ func BenchmarkVerifyMac(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkBatchComputeMac is the same as BenchmarkComputeMac, but uses
+// [mac.BatchComputer]. Compare the two to see the effect of reusing the keyed
+// hash, in particular for small inputs.
+func BenchmarkBatchComputeMac(b *testing.B) {
+	for _, tc := range benchmarkTestCases {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			handle, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				b.Fatal(err)
+			}
+			computer, err := mac.NewBatchComputer(handle)
+			if err != nil {
+				b.Fatal(err)
+			}
+			data := random.GetRandomBytes(tc.dataSize)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := computer.ComputeMAC(data)
+				if err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	}
+}
+
+const batchSize = 10000
+
+func newBatchVerifyInputs(b *testing.B, prefixType tinkpb.OutputPrefixType) (tink.MAC, []mac.MACPair) {
+	b.Helper()
+	handle, err := testkeyset.NewHandle(testutil.NewTestHMACKeyset(16, prefixType))
+	if err != nil {
+		b.Fatal(err)
+	}
+	primitive, err := mac.New(handle)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pairs := make([]mac.MACPair, batchSize)
+	for i := range pairs {
+		data := random.GetRandomBytes(16)
+		tag, err := primitive.ComputeMAC(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pairs[i] = mac.MACPair{MAC: tag, Data: data}
+	}
+	return primitive, pairs
+}
+
+// BenchmarkVerifyMacLoop and BenchmarkVerifyMacBatch verify the same 10k pairs
+// with VerifyMAC in a loop and with [mac.BatchVerifier] respectively.
+func BenchmarkVerifyMacLoop(b *testing.B) {
+	for _, prefixType := range []tinkpb.OutputPrefixType{tinkpb.OutputPrefixType_TINK, tinkpb.OutputPrefixType_LEGACY} {
+		b.Run(prefixType.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			primitive, pairs := newBatchVerifyInputs(b, prefixType)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, p := range pairs {
+					if err := primitive.VerifyMAC(p.MAC, p.Data); err != nil {
+						b.Error(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyMacBatch(b *testing.B) {
+	for _, prefixType := range []tinkpb.OutputPrefixType{tinkpb.OutputPrefixType_TINK, tinkpb.OutputPrefixType_LEGACY} {
+		b.Run(prefixType.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			primitive, pairs := newBatchVerifyInputs(b, prefixType)
+			verifier := primitive.(mac.BatchVerifier)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, err := range verifier.VerifyMACBatch(pairs) {
+					if err != nil {
+						b.Error(err)
+					}
+				}
+			}
+		})
+	}
+}
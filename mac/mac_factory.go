@@ -15,6 +15,7 @@
 package mac
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/tink-crypto/tink-go/v2/core/cryptofmt"
@@ -34,6 +35,10 @@ const (
 )
 
 // New creates a MAC primitive from the given keyset handle.
+//
+// The returned primitive also implements [tink.StreamingMACComputer],
+// [KeyIDVerifier], [BatchVerifier] and [ContextMAC]. Creating a [tink.StreamingMAC] fails
+// if the primary key is not an HMAC key.
 func New(handle *keyset.Handle) (tink.MAC, error) {
 	ps, err := keyset.Primitives[tink.MAC](handle, internalapi.Token{})
 	if err != nil {
@@ -42,15 +47,102 @@ func New(handle *keyset.Handle) (tink.MAC, error) {
 	return newWrappedMAC(ps)
 }
 
+// Option configures the MAC primitive returned by [NewWithOptions].
+type Option func(*wrapperOptions)
+
+type wrapperOptions struct {
+	skipLegacyPadding bool
+}
+
+// SkipLegacyPadding makes keys with the LEGACY output prefix type compute and
+// verify MACs over the data alone, instead of over the data followed by a zero
+// byte.
+//
+// This is only meant for verifying MACs produced by systems other than Tink
+// that use the LEGACY prefix without the trailing zero byte. Such MACs are
+// not compatible with any other Tink primitive, and MACs computed with this
+// option cannot be verified by a primitive created without it. Keys with
+// other output prefix types are not affected.
+func SkipLegacyPadding() Option {
+	return func(o *wrapperOptions) {
+		o.skipLegacyPadding = true
+	}
+}
+
+// NewWithOptions creates a MAC primitive from the given keyset handle,
+// configured with the given options.
+func NewWithOptions(handle *keyset.Handle, opts ...Option) (tink.MAC, error) {
+	ps, err := keyset.Primitives[tink.MAC](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("mac_factory: cannot obtain primitive set: %s", err)
+	}
+	m, err := newWrappedMAC(ps)
+	if err != nil {
+		return nil, err
+	}
+	o := new(wrapperOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	m.skipLegacyPadding = o.skipLegacyPadding
+	return m, nil
+}
+
 // wrappedMAC is a MAC implementation that uses the underlying primitive set to compute and
 // verify MACs.
 type wrappedMAC struct {
 	ps            *primitiveset.PrimitiveSet[tink.MAC]
 	computeLogger monitoring.Logger
 	verifyLogger  monitoring.Logger
+	// skipLegacyPadding is true if LEGACY keys do not append a zero byte to
+	// the data.
+	skipLegacyPadding bool
 }
 
 var _ (tink.MAC) = (*wrappedMAC)(nil)
+var _ (tink.StreamingMACComputer) = (*wrappedMAC)(nil)
+var _ (KeyIDVerifier) = (*wrappedMAC)(nil)
+var _ (BatchVerifier) = (*wrappedMAC)(nil)
+var _ (ContextMAC) = (*wrappedMAC)(nil)
+
+// KeyIDVerifier is implemented by the MAC primitive returned by [New]. It
+// verifies MACs like [tink.MAC.VerifyMAC], and additionally reports which key
+// of the keyset verified the MAC, for example for audit logging during key
+// rotation.
+type KeyIDVerifier interface {
+	// VerifyMACAndKeyID returns the ID of the key for which mac is a correct
+	// authentication code for data, or an error if there is no such key.
+	VerifyMACAndKeyID(mac, data []byte) (uint32, error)
+}
+
+// MACPair is a MAC and the data it authenticates.
+type MACPair struct {
+	MAC  []byte
+	Data []byte
+}
+
+// BatchVerifier is implemented by the MAC primitive returned by [New]. It
+// verifies many MACs at once, which is faster than calling
+// [tink.MAC.VerifyMAC] for each of them.
+type BatchVerifier interface {
+	// VerifyMACBatch returns, for each pair, nil if pair.MAC is a correct
+	// authentication code for pair.Data, and an error otherwise.
+	VerifyMACBatch(pairs []MACPair) []error
+}
+
+// ContextMAC is implemented by the MAC primitive returned by [New]. It
+// computes and verifies MACs like [tink.MAC], and passes ctx to the monitoring
+// logger if the registered monitoring client returns
+// [monitoring.ContextLogger]s, for example to trace the operation.
+type ContextMAC interface {
+	// ComputeMACContext is like [tink.MAC.ComputeMAC], for an operation
+	// carried out with ctx.
+	ComputeMACContext(ctx context.Context, data []byte) ([]byte, error)
+
+	// VerifyMACContext is like [tink.MAC.VerifyMAC], for an operation carried
+	// out with ctx.
+	VerifyMACContext(ctx context.Context, mac, data []byte) error
+}
 
 func newWrappedMAC(ps *primitiveset.PrimitiveSet[tink.MAC]) (*wrappedMAC, error) {
 	computeLogger, verifyLogger, err := createLoggers(ps)
@@ -65,7 +157,7 @@ func newWrappedMAC(ps *primitiveset.PrimitiveSet[tink.MAC]) (*wrappedMAC, error)
 }
 
 func createLoggers(ps *primitiveset.PrimitiveSet[tink.MAC]) (monitoring.Logger, monitoring.Logger, error) {
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, &monitoringutil.DoNothingLogger{}, nil
 	}
 	client := internalregistry.GetMonitoringClient()
@@ -95,43 +187,131 @@ func createLoggers(ps *primitiveset.PrimitiveSet[tink.MAC]) (monitoring.Logger,
 // ComputeMAC calculates a MAC over the given data using the primary primitive
 // and returns the concatenation of the primary's identifier and the calculated mac.
 func (m *wrappedMAC) ComputeMAC(data []byte) ([]byte, error) {
-	primary := m.ps.Primary
-	if m.ps.Primary.PrefixType == tinkpb.OutputPrefixType_LEGACY {
+	return m.computeMAC(context.Background(), m.ps.Primary, data)
+}
+
+// ComputeMACContext is like ComputeMAC, and passes ctx to the monitoring
+// logger.
+func (m *wrappedMAC) ComputeMACContext(ctx context.Context, data []byte) ([]byte, error) {
+	return m.computeMAC(ctx, m.ps.Primary, data)
+}
+
+// computeMAC calculates a MAC over data using the primitive of entry and
+// returns the concatenation of the entry's identifier and the calculated mac.
+func (m *wrappedMAC) computeMAC(ctx context.Context, entry *primitiveset.Entry[tink.MAC], data []byte) ([]byte, error) {
+	if m.isPaddedLegacy(entry) {
 		d := data
 		if len(d) >= maxInt {
-			m.computeLogger.LogFailure()
+			monitoringutil.LogFailureContext(ctx, m.computeLogger)
 			return nil, fmt.Errorf("mac_factory: data too long")
 		}
 		data = make([]byte, 0, len(d)+1)
 		data = append(data, d...)
 		data = append(data, byte(0))
 	}
-	mac, err := primary.Primitive.ComputeMAC(data)
+	mac, err := entry.Primitive.ComputeMAC(data)
 	if err != nil {
-		m.computeLogger.LogFailure()
+		monitoringutil.LogFailureContext(ctx, m.computeLogger)
 		return nil, err
 	}
-	m.computeLogger.Log(primary.KeyID, len(data))
-	if len(primary.Prefix) == 0 {
+	monitoringutil.LogContext(ctx, m.computeLogger, entry.KeyID, len(data))
+	if len(entry.Prefix) == 0 {
 		return mac, nil
 	}
-	output := make([]byte, 0, len(primary.Prefix)+len(mac))
-	output = append(output, primary.Prefix...)
+	output := make([]byte, 0, len(entry.Prefix)+len(mac))
+	output = append(output, entry.Prefix...)
 	output = append(output, mac...)
 	return output, nil
 }
 
+// isPaddedLegacy returns true if the data must be followed by a zero byte
+// when computing or verifying MACs with entry.
+func (m *wrappedMAC) isPaddedLegacy(entry *primitiveset.Entry[tink.MAC]) bool {
+	return entry.PrefixType == tinkpb.OutputPrefixType_LEGACY && !m.skipLegacyPadding
+}
+
 var errInvalidMAC = fmt.Errorf("mac_factory: invalid mac")
 
 // VerifyMAC verifies whether the given mac is a correct authentication code
 // for the given data.
 func (m *wrappedMAC) VerifyMAC(mac, data []byte) error {
+	_, err := m.VerifyMACAndKeyID(mac, data)
+	return err
+}
+
+// VerifyMACContext is like VerifyMAC, and passes ctx to the monitoring
+// logger.
+func (m *wrappedMAC) VerifyMACContext(ctx context.Context, mac, data []byte) error {
+	_, err := m.verifyMAC(ctx, mac, data, &verifyState{})
+	return err
+}
+
+// VerifyMACAndKeyID verifies whether the given mac is a correct authentication
+// code for the given data, and returns the ID of the key that verified it.
+//
+// The candidate keys are tried exactly as in VerifyMAC, so this does not
+// reveal more through timing than VerifyMAC does.
+func (m *wrappedMAC) VerifyMACAndKeyID(mac, data []byte) (uint32, error) {
+	return m.verifyMAC(context.Background(), mac, data, &verifyState{})
+}
+
+// VerifyMACBatch verifies each pair like VerifyMAC, and returns the results in
+// the same order.
+//
+// It is faster than calling VerifyMAC in a loop: the keyed hash of HMAC keys
+// is created once per key instead of once per pair, and the buffer holding
+// the data of LEGACY keys is reused across pairs.
+func (m *wrappedMAC) VerifyMACBatch(pairs []MACPair) []error {
+	errs := make([]error, len(pairs))
+	state := &verifyState{reusable: make(map[*primitiveset.Entry[tink.MAC]]tink.MAC)}
+	for i, p := range pairs {
+		_, errs[i] = m.verifyMAC(context.Background(), p.MAC, p.Data, state)
+	}
+	return errs
+}
+
+// reusableMAC is implemented by MAC primitives that can create a faster,
+// non-concurrent instance for computing or verifying many MACs.
+type reusableMAC interface {
+	NewReusable() (tink.MAC, error)
+}
+
+// verifyState holds what verifyMAC reuses across calls.
+type verifyState struct {
+	// legacyData is the buffer for the data of LEGACY keys, which is the data
+	// followed by a zero byte.
+	legacyData []byte
+	// reusable caches the reusable instances of the primitives, if not nil.
+	reusable map[*primitiveset.Entry[tink.MAC]]tink.MAC
+}
+
+// primitive returns the primitive of entry to verify MACs with.
+func (s *verifyState) primitive(entry *primitiveset.Entry[tink.MAC]) tink.MAC {
+	if s.reusable == nil {
+		return entry.Primitive
+	}
+	if p, ok := s.reusable[entry]; ok {
+		return p
+	}
+	p := entry.Primitive
+	if r, ok := p.(reusableMAC); ok {
+		if reusable, err := r.NewReusable(); err == nil {
+			p = reusable
+		}
+	}
+	s.reusable[entry] = p
+	return p
+}
+
+// verifyMAC verifies mac over data and returns the ID of the key that
+// verified it.
+func (m *wrappedMAC) verifyMAC(ctx context.Context, mac, data []byte, state *verifyState) (uint32, error) {
 	// This also rejects raw MAC with size of 4 bytes or fewer. Those MACs are
 	// clearly insecure, thus should be discouraged.
 	prefixSize := cryptofmt.NonRawPrefixSize
 	if len(mac) <= prefixSize {
-		m.verifyLogger.LogFailure()
-		return errInvalidMAC
+		monitoringutil.LogFailureContext(ctx, m.verifyLogger)
+		return 0, errInvalidMAC
 	}
 
 	// try non raw keys
@@ -139,21 +319,24 @@ func (m *wrappedMAC) VerifyMAC(mac, data []byte) error {
 	macNoPrefix := mac[prefixSize:]
 	entries, err := m.ps.EntriesForPrefix(string(prefix))
 	if err == nil {
+		hasLegacyData := false
 		for i := 0; i < len(entries); i++ {
 			entry := entries[i]
-			if entry.PrefixType == tinkpb.OutputPrefixType_LEGACY {
-				d := data
-				if len(d) >= maxInt {
-					m.verifyLogger.LogFailure()
-					return fmt.Errorf("mac_factory: data too long")
+			d := data
+			if m.isPaddedLegacy(entry) {
+				if !hasLegacyData {
+					if len(data) >= maxInt {
+						monitoringutil.LogFailureContext(ctx, m.verifyLogger)
+						return 0, fmt.Errorf("mac_factory: data too long")
+					}
+					state.legacyData = append(append(state.legacyData[:0], data...), legacySuffix...)
+					hasLegacyData = true
 				}
-				data = make([]byte, 0, len(d)+1)
-				data = append(data, d...)
-				data = append(data, byte(0))
+				d = state.legacyData
 			}
-			if err := entry.Primitive.VerifyMAC(macNoPrefix, data); err == nil {
-				m.verifyLogger.Log(entry.KeyID, len(data))
-				return nil
+			if err := state.primitive(entry).VerifyMAC(macNoPrefix, d); err == nil {
+				monitoringutil.LogContext(ctx, m.verifyLogger, entry.KeyID, len(d))
+				return entry.KeyID, nil
 			}
 		}
 	}
@@ -162,14 +345,74 @@ func (m *wrappedMAC) VerifyMAC(mac, data []byte) error {
 	entries, err = m.ps.RawEntries()
 	if err == nil {
 		for i := 0; i < len(entries); i++ {
-			if err := entries[i].Primitive.VerifyMAC(mac, data); err == nil {
-				m.verifyLogger.Log(entries[i].KeyID, len(data))
-				return nil
+			if err := state.primitive(entries[i]).VerifyMAC(mac, data); err == nil {
+				monitoringutil.LogContext(ctx, m.verifyLogger, entries[i].KeyID, len(data))
+				return entries[i].KeyID, nil
 			}
 		}
 	}
 
 	// nothing worked
-	m.verifyLogger.LogFailure()
-	return errInvalidMAC
+	monitoringutil.LogFailureContext(ctx, m.verifyLogger)
+	return 0, errInvalidMAC
+}
+
+// NewStreamingMAC returns a StreamingMAC that computes a MAC with the primary
+// primitive. Its output is the same as that of ComputeMAC over the
+// concatenation of all written data.
+func (m *wrappedMAC) NewStreamingMAC() (tink.StreamingMAC, error) {
+	primary := m.ps.Primary
+	computer, ok := primary.Primitive.(tink.StreamingMACComputer)
+	if !ok {
+		return nil, fmt.Errorf("mac_factory: primary key does not support streaming")
+	}
+	s, err := computer.NewStreamingMAC()
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedStreamingMAC{
+		s:      s,
+		keyID:  primary.KeyID,
+		prefix: []byte(primary.Prefix),
+		legacy: m.isPaddedLegacy(primary),
+		logger: m.computeLogger,
+	}, nil
+}
+
+// wrappedStreamingMAC adds the output prefix of the primary key to the MAC of
+// the underlying StreamingMAC.
+type wrappedStreamingMAC struct {
+	s        tink.StreamingMAC
+	keyID    uint32
+	prefix   []byte
+	legacy   bool
+	logger   monitoring.Logger
+	numBytes int
+}
+
+var _ (tink.StreamingMAC) = (*wrappedStreamingMAC)(nil)
+
+func (w *wrappedStreamingMAC) Write(p []byte) (int, error) {
+	n, err := w.s.Write(p)
+	w.numBytes += n
+	return n, err
+}
+
+func (w *wrappedStreamingMAC) Finish() ([]byte, error) {
+	if w.legacy {
+		if _, err := w.Write(legacySuffix); err != nil {
+			w.logger.LogFailure()
+			return nil, err
+		}
+	}
+	mac, err := w.s.Finish()
+	if err != nil {
+		w.logger.LogFailure()
+		return nil, err
+	}
+	w.logger.Log(w.keyID, w.numBytes)
+	output := make([]byte, 0, len(w.prefix)+len(mac))
+	output = append(output, w.prefix...)
+	output = append(output, mac...)
+	return output, nil
 }
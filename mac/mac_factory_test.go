@@ -16,6 +16,7 @@ package mac_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -155,6 +156,272 @@ func TestFactoryLegacyKey(t *testing.T) {
 	}
 }
 
+func TestNewWithOptionsSkipLegacyPadding(t *testing.T) {
+	legacyKeyset := testutil.NewTestHMACKeyset(16, tinkpb.OutputPrefixType_LEGACY)
+	legacyHandle, err := testkeyset.NewHandle(legacyKeyset)
+	if err != nil {
+		t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+	}
+	rawKeyset := proto.Clone(legacyKeyset).(*tinkpb.Keyset)
+	rawKeyset.GetKey()[0].OutputPrefixType = tinkpb.OutputPrefixType_RAW
+	rawHandle, err := testkeyset.NewHandle(rawKeyset)
+	if err != nil {
+		t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+	}
+	rawMAC, err := mac.New(rawHandle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	padded, err := mac.New(legacyHandle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	unpadded, err := mac.NewWithOptions(legacyHandle, mac.SkipLegacyPadding())
+	if err != nil {
+		t.Fatalf("mac.NewWithOptions() err = %v, want nil", err)
+	}
+
+	data := []byte("some data")
+	tag, err := rawMAC.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("rawMAC.ComputeMAC() err = %v, want nil", err)
+	}
+	prefix, err := cryptofmt.OutputPrefix(legacyKeyset.GetKey()[0])
+	if err != nil {
+		t.Fatalf("cryptofmt.OutputPrefix() err = %v, want nil", err)
+	}
+	// An unpadded LEGACY MAC is the output prefix followed by the MAC of the
+	// data alone.
+	unpaddedTag := append([]byte(prefix), tag...)
+
+	if err := unpadded.VerifyMAC(unpaddedTag, data); err != nil {
+		t.Errorf("unpadded.VerifyMAC() err = %v, want nil", err)
+	}
+	if err := padded.VerifyMAC(unpaddedTag, data); err == nil {
+		t.Error("padded.VerifyMAC() err = nil, want error")
+	}
+	got, err := unpadded.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("unpadded.ComputeMAC() err = %v, want nil", err)
+	}
+	if !bytes.Equal(got, unpaddedTag) {
+		t.Errorf("unpadded.ComputeMAC() = %x, want %x", got, unpaddedTag)
+	}
+	paddedTag, err := padded.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("padded.ComputeMAC() err = %v, want nil", err)
+	}
+	if err := unpadded.VerifyMAC(paddedTag, data); err == nil {
+		t.Error("unpadded.VerifyMAC() err = nil, want error")
+	}
+}
+
+func TestFactoryStreamingMAC(t *testing.T) {
+	tagSize := uint32(16)
+	data := bytes.Repeat([]byte("some data"), 1000)
+	for _, prefixType := range []tinkpb.OutputPrefixType{
+		tinkpb.OutputPrefixType_TINK,
+		tinkpb.OutputPrefixType_CRUNCHY,
+		tinkpb.OutputPrefixType_LEGACY,
+		tinkpb.OutputPrefixType_RAW,
+	} {
+		t.Run(prefixType.String(), func(t *testing.T) {
+			keysetHandle, err := testkeyset.NewHandle(testutil.NewTestHMACKeyset(tagSize, prefixType))
+			if err != nil {
+				t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+			}
+			p, err := mac.New(keysetHandle)
+			if err != nil {
+				t.Fatalf("mac.New() err = %v, want nil", err)
+			}
+			computer, ok := p.(tink.StreamingMACComputer)
+			if !ok {
+				t.Fatalf("mac.New() = %T, want tink.StreamingMACComputer", p)
+			}
+			s, err := computer.NewStreamingMAC()
+			if err != nil {
+				t.Fatalf("computer.NewStreamingMAC() err = %v, want nil", err)
+			}
+			for i := 0; i < len(data); i += 100 {
+				if _, err := s.Write(data[i:min(i+100, len(data))]); err != nil {
+					t.Fatalf("s.Write() err = %v, want nil", err)
+				}
+			}
+			tag, err := s.Finish()
+			if err != nil {
+				t.Fatalf("s.Finish() err = %v, want nil", err)
+			}
+			want, err := p.ComputeMAC(data)
+			if err != nil {
+				t.Fatalf("p.ComputeMAC() err = %v, want nil", err)
+			}
+			if !bytes.Equal(tag, want) {
+				t.Errorf("s.Finish() = %x, want %x", tag, want)
+			}
+			if err := p.VerifyMAC(tag, data); err != nil {
+				t.Errorf("p.VerifyMAC() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestFactoryStreamingMACFailsWithNonHMACPrimary(t *testing.T) {
+	keysetHandle, err := keyset.NewHandle(mac.AESCMACTag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	p, err := mac.New(keysetHandle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	computer, ok := p.(tink.StreamingMACComputer)
+	if !ok {
+		t.Fatalf("mac.New() = %T, want tink.StreamingMACComputer", p)
+	}
+	if _, err := computer.NewStreamingMAC(); err == nil {
+		t.Errorf("computer.NewStreamingMAC() err = nil, want error")
+	}
+}
+
+func TestFactoryVerifyMACAndKeyID(t *testing.T) {
+	manager := keyset.NewManager()
+	var keyIDs []uint32
+	for _, template := range []*tinkpb.KeyTemplate{
+		mac.HMACSHA256Tag256KeyTemplate(),
+		mac.AESCMACTag128KeyTemplate(),
+		mac.HMACSHA512Tag256KeyTemplate(),
+	} {
+		keyID, err := manager.Add(template)
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		keyIDs = append(keyIDs, keyID)
+	}
+	data := []byte("some data")
+	var tags [][]byte
+	for _, keyID := range keyIDs {
+		if err := manager.SetPrimary(keyID); err != nil {
+			t.Fatalf("manager.SetPrimary(%d) err = %v, want nil", keyID, err)
+		}
+		handle, err := manager.Handle()
+		if err != nil {
+			t.Fatalf("manager.Handle() err = %v, want nil", err)
+		}
+		p, err := mac.New(handle)
+		if err != nil {
+			t.Fatalf("mac.New() err = %v, want nil", err)
+		}
+		tag, err := p.ComputeMAC(data)
+		if err != nil {
+			t.Fatalf("p.ComputeMAC() err = %v, want nil", err)
+		}
+		tags = append(tags, tag)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	p, err := mac.New(handle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	verifier, ok := p.(mac.KeyIDVerifier)
+	if !ok {
+		t.Fatalf("mac.New() = %T, want mac.KeyIDVerifier", p)
+	}
+	for i, tag := range tags {
+		keyID, err := verifier.VerifyMACAndKeyID(tag, data)
+		if err != nil {
+			t.Errorf("verifier.VerifyMACAndKeyID() err = %v, want nil", err)
+		}
+		if keyID != keyIDs[i] {
+			t.Errorf("verifier.VerifyMACAndKeyID() = %d, want %d", keyID, keyIDs[i])
+		}
+	}
+	if _, err := verifier.VerifyMACAndKeyID(tags[0], []byte("other data")); err == nil {
+		t.Errorf("verifier.VerifyMACAndKeyID() with other data err = nil, want error")
+	}
+	if _, err := verifier.VerifyMACAndKeyID(tags[0][:4], data); err == nil {
+		t.Errorf("verifier.VerifyMACAndKeyID() with short MAC err = nil, want error")
+	}
+}
+
+func TestFactoryVerifyMACBatch(t *testing.T) {
+	manager := keyset.NewManager()
+	var templates []*tinkpb.KeyTemplate
+	for _, prefixType := range []tinkpb.OutputPrefixType{
+		tinkpb.OutputPrefixType_TINK,
+		tinkpb.OutputPrefixType_LEGACY,
+		tinkpb.OutputPrefixType_RAW,
+	} {
+		template := mac.HMACSHA256Tag256KeyTemplate()
+		template.OutputPrefixType = prefixType
+		templates = append(templates, template)
+	}
+	templates = append(templates, mac.AESCMACTag128KeyTemplate())
+	var primitives []tink.MAC
+	for _, template := range templates {
+		keyID, err := manager.Add(template)
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		if err := manager.SetPrimary(keyID); err != nil {
+			t.Fatalf("manager.SetPrimary(%d) err = %v, want nil", keyID, err)
+		}
+		handle, err := manager.Handle()
+		if err != nil {
+			t.Fatalf("manager.Handle() err = %v, want nil", err)
+		}
+		p, err := mac.New(handle)
+		if err != nil {
+			t.Fatalf("mac.New() err = %v, want nil", err)
+		}
+		primitives = append(primitives, p)
+	}
+	// The last primitive uses the keyset with all keys.
+	p := primitives[len(primitives)-1]
+	verifier, ok := p.(mac.BatchVerifier)
+	if !ok {
+		t.Fatalf("mac.New() = %T, want mac.BatchVerifier", p)
+	}
+
+	var pairs []mac.MACPair
+	var wantValid []bool
+	for i := 0; i < 3; i++ {
+		for _, primitive := range primitives {
+			data := random.GetRandomBytes(uint32(i * 10))
+			tag, err := primitive.ComputeMAC(data)
+			if err != nil {
+				t.Fatalf("primitive.ComputeMAC() err = %v, want nil", err)
+			}
+			modifiedTag := bytes.Clone(tag)
+			modifiedTag[len(modifiedTag)-1] ^= 1
+			pairs = append(pairs,
+				mac.MACPair{MAC: tag, Data: data},
+				mac.MACPair{MAC: modifiedTag, Data: data},
+				mac.MACPair{MAC: tag, Data: append(bytes.Clone(data), 'x')},
+				mac.MACPair{MAC: tag[:4], Data: data},
+			)
+			wantValid = append(wantValid, true, false, false, false)
+		}
+	}
+	errs := verifier.VerifyMACBatch(pairs)
+	if len(errs) != len(pairs) {
+		t.Fatalf("len(verifier.VerifyMACBatch()) = %d, want %d", len(errs), len(pairs))
+	}
+	for i, err := range errs {
+		if got := err == nil; got != wantValid[i] {
+			t.Errorf("verifier.VerifyMACBatch()[%d] = %v, want valid = %v", i, err, wantValid[i])
+		}
+		if got := p.VerifyMAC(pairs[i].MAC, pairs[i].Data) == nil; got != wantValid[i] {
+			t.Errorf("p.VerifyMAC(pairs[%d]) valid = %v, want %v", i, got, wantValid[i])
+		}
+	}
+	if errs := verifier.VerifyMACBatch(nil); len(errs) != 0 {
+		t.Errorf("verifier.VerifyMACBatch(nil) = %v, want empty", errs)
+	}
+}
+
 func TestFactoryLegacyFixedKeyFixedTag(t *testing.T) {
 	tagSize := uint32(16)
 	params := testutil.NewHMACParams(commonpb.HashType_SHA256, tagSize)
@@ -282,6 +549,54 @@ func TestPrimitiveFactoryMonitoringWithoutAnnotationsDoesNotLog(t *testing.T) {
 	}
 }
 
+func TestPrimitiveFactoryMonitoringWithKeyAnnotationsLogsKeyAnnotations(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := fakemonitoring.NewClient("fake-client")
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("registry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	kh, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate()) err = %v, want nil", err)
+	}
+	keyID := kh.KeysetInfo().GetPrimaryKeyId()
+	keyAnnotations := map[string]string{"team": "payments"}
+	kh, err = keyset.SetKeyAnnotations(kh, keyID, keyAnnotations)
+	if err != nil {
+		t.Fatalf("keyset.SetKeyAnnotations() err = %v, want nil", err)
+	}
+	p, err := mac.New(kh)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	if _, err := p.ComputeMAC(data); err != nil {
+		t.Fatalf("p.ComputeMAC() err = %v, want nil", err)
+	}
+	wantKeysetInfo := &monitoring.KeysetInfo{
+		PrimaryKeyID: keyID,
+		Entries: []*monitoring.Entry{
+			{
+				KeyID:       keyID,
+				Status:      monitoring.Enabled,
+				KeyType:     "tink.HmacKey",
+				KeyPrefix:   "TINK",
+				Annotations: keyAnnotations,
+			},
+		},
+	}
+	want := []*fakemonitoring.LogEvent{
+		{
+			KeyID:    keyID,
+			NumBytes: len(data),
+			Context:  monitoring.NewContext("mac", "compute", wantKeysetInfo),
+		},
+	}
+	if diff := cmp.Diff(want, client.Events()); diff != "" {
+		t.Errorf("client.Events() diff (-want +got):\n%s", diff)
+	}
+}
+
 func TestFactoryWithMonitoringPrimitiveWithMultipleKeysLogsComputeVerify(t *testing.T) {
 	defer internalregistry.ClearMonitoringClient()
 	client := fakemonitoring.NewClient("fake-client")
@@ -660,3 +975,120 @@ func TestPrimitiveFactoryMonitoringWithAnnotationsComputeVerifyLogs(t *testing.T
 		t.Errorf("got = %v, want = %v, with diff: %v", got, want, cmp.Diff(got, want))
 	}
 }
+
+type traceIDKey struct{}
+
+// contextLogger is a monitoring.ContextLogger that records the trace IDs of
+// the contexts it is called with.
+type contextLogger struct {
+	apiFunction string
+	events      *[]string
+}
+
+var _ monitoring.ContextLogger = (*contextLogger)(nil)
+
+func (l *contextLogger) Log(keyID uint32, numBytes int) {
+	*l.events = append(*l.events, fmt.Sprintf("%s log without context", l.apiFunction))
+}
+
+func (l *contextLogger) LogFailure() {
+	*l.events = append(*l.events, fmt.Sprintf("%s failure without context", l.apiFunction))
+}
+
+func (l *contextLogger) LogContext(ctx context.Context, keyID uint32, numBytes int) {
+	*l.events = append(*l.events, fmt.Sprintf("%s log %v", l.apiFunction, ctx.Value(traceIDKey{})))
+}
+
+func (l *contextLogger) LogFailureContext(ctx context.Context) {
+	*l.events = append(*l.events, fmt.Sprintf("%s failure %v", l.apiFunction, ctx.Value(traceIDKey{})))
+}
+
+type contextLoggerClient struct {
+	events []string
+}
+
+func (c *contextLoggerClient) NewLogger(context *monitoring.Context) (monitoring.Logger, error) {
+	return &contextLogger{apiFunction: context.APIFunction, events: &c.events}, nil
+}
+
+func newAnnotatedMAC(t *testing.T) tink.MAC {
+	t.Helper()
+	kh, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	buff := &bytes.Buffer{}
+	if err := insecurecleartextkeyset.Write(kh, keyset.NewBinaryWriter(buff)); err != nil {
+		t.Fatalf("insecurecleartextkeyset.Write() err = %v, want nil", err)
+	}
+	mh, err := insecurecleartextkeyset.Read(keyset.NewBinaryReader(buff), keyset.WithAnnotations(map[string]string{"foo": "bar"}))
+	if err != nil {
+		t.Fatalf("insecurecleartextkeyset.Read() err = %v, want nil", err)
+	}
+	p, err := mac.New(mh)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	return p
+}
+
+func TestPrimitiveFactoryMonitoringPassesContextToLogger(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := &contextLoggerClient{}
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("registry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	p := newAnnotatedMAC(t)
+	cm, ok := p.(mac.ContextMAC)
+	if !ok {
+		t.Fatalf("mac.New() = %T, want mac.ContextMAC", p)
+	}
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "trace-1")
+	data := []byte("data")
+	tag, err := cm.ComputeMACContext(ctx, data)
+	if err != nil {
+		t.Fatalf("cm.ComputeMACContext() err = %v, want nil", err)
+	}
+	if err := cm.VerifyMACContext(ctx, tag, data); err != nil {
+		t.Fatalf("cm.VerifyMACContext() err = %v, want nil", err)
+	}
+	if err := cm.VerifyMACContext(ctx, tag, []byte("other data")); err == nil {
+		t.Fatal("cm.VerifyMACContext() err = nil, want error")
+	}
+	if _, err := p.ComputeMAC(data); err != nil {
+		t.Fatalf("p.ComputeMAC() err = %v, want nil", err)
+	}
+	want := []string{
+		"compute log trace-1",
+		"verify log trace-1",
+		"verify failure trace-1",
+		"compute log <nil>",
+	}
+	if diff := cmp.Diff(want, client.events); diff != "" {
+		t.Errorf("logged events diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrimitiveFactoryMonitoringWithContextAndLoggerWithoutContext(t *testing.T) {
+	defer internalregistry.ClearMonitoringClient()
+	client := fakemonitoring.NewClient("fake-client")
+	if err := internalregistry.RegisterMonitoringClient(client); err != nil {
+		t.Fatalf("registry.RegisterMonitoringClient() err = %v, want nil", err)
+	}
+	cm, ok := newAnnotatedMAC(t).(mac.ContextMAC)
+	if !ok {
+		t.Fatal("mac.New() does not implement mac.ContextMAC")
+	}
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "trace-1")
+	data := []byte("data")
+	tag, err := cm.ComputeMACContext(ctx, data)
+	if err != nil {
+		t.Fatalf("cm.ComputeMACContext() err = %v, want nil", err)
+	}
+	if err := cm.VerifyMACContext(ctx, tag, data); err != nil {
+		t.Fatalf("cm.VerifyMACContext() err = %v, want nil", err)
+	}
+	if got := len(client.Events()); got != 2 {
+		t.Errorf("len(client.Events()) = %d, want 2", got)
+	}
+}
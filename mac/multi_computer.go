@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// MultiComputer computes MACs with every enabled key of a keyset, not only
+// the primary key.
+//
+// This supports MAC key rotation without a flag day: while verifiers are
+// being upgraded to a new key, the producer attaches the MACs of both the old
+// and the new key, and each verifier checks the one it recognizes.
+type MultiComputer struct {
+	m *wrappedMAC
+}
+
+// NewMultiComputer creates a [MultiComputer] from the given keyset handle.
+func NewMultiComputer(handle *keyset.Handle) (*MultiComputer, error) {
+	ps, err := keyset.Primitives[tink.MAC](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("mac_factory: cannot obtain primitive set: %s", err)
+	}
+	m, err := newWrappedMAC(ps)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiComputer{m: m}, nil
+}
+
+// ComputeAll computes a MAC over data with each enabled key of the keyset, and
+// returns the MACs indexed by key ID. Each MAC is identical to the one the
+// primitive returned by [New] would compute if that key were the primary, so
+// it includes the output prefix of the key and can be checked with
+// [tink.MAC.VerifyMAC].
+func (c *MultiComputer) ComputeAll(data []byte) (map[uint32][]byte, error) {
+	macs := make(map[uint32][]byte, len(c.m.ps.EntriesInKeysetOrder))
+	for _, entry := range c.m.ps.EntriesInKeysetOrder {
+		mac, err := c.m.computeMAC(context.Background(), entry, data)
+		if err != nil {
+			return nil, err
+		}
+		macs[entry.KeyID] = mac
+	}
+	return macs, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestMultiComputerComputeAll(t *testing.T) {
+	manager := keyset.NewManager()
+	var keyIDs []uint32
+	for _, template := range []*tinkpb.KeyTemplate{
+		mac.HMACSHA256Tag256KeyTemplate(),
+		withPrefixType(mac.AESCMACTag128KeyTemplate(), tinkpb.OutputPrefixType_RAW),
+		withPrefixType(mac.HMACSHA512Tag256KeyTemplate(), tinkpb.OutputPrefixType_LEGACY),
+	} {
+		keyID, err := manager.Add(template)
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		keyIDs = append(keyIDs, keyID)
+	}
+	if err := manager.SetPrimary(keyIDs[0]); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	disabledKeyID, err := manager.Add(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.Disable(disabledKeyID); err != nil {
+		t.Fatalf("manager.Disable() err = %v, want nil", err)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+
+	computer, err := mac.NewMultiComputer(handle)
+	if err != nil {
+		t.Fatalf("mac.NewMultiComputer() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	macs, err := computer.ComputeAll(data)
+	if err != nil {
+		t.Fatalf("computer.ComputeAll() err = %v, want nil", err)
+	}
+	if len(macs) != len(keyIDs) {
+		t.Errorf("len(computer.ComputeAll()) = %d, want %d", len(macs), len(keyIDs))
+	}
+	if _, ok := macs[disabledKeyID]; ok {
+		t.Errorf("computer.ComputeAll() has a MAC for disabled key %d", disabledKeyID)
+	}
+	verifier, err := mac.New(handle)
+	if err != nil {
+		t.Fatalf("mac.New() err = %v, want nil", err)
+	}
+	for _, keyID := range keyIDs {
+		got, ok := macs[keyID]
+		if !ok {
+			t.Fatalf("computer.ComputeAll() has no MAC for key %d", keyID)
+		}
+		// The MAC is the one computed when the key is the primary.
+		m := keyset.NewManagerFromHandle(handle)
+		if err := m.SetPrimary(keyID); err != nil {
+			t.Fatalf("m.SetPrimary() err = %v, want nil", err)
+		}
+		h, err := m.Handle()
+		if err != nil {
+			t.Fatalf("m.Handle() err = %v, want nil", err)
+		}
+		primitive, err := mac.New(h)
+		if err != nil {
+			t.Fatalf("mac.New() err = %v, want nil", err)
+		}
+		want, err := primitive.ComputeMAC(data)
+		if err != nil {
+			t.Fatalf("primitive.ComputeMAC() err = %v, want nil", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("computer.ComputeAll()[%d] = %x, want %x", keyID, got, want)
+		}
+		verifiedKeyID, err := verifier.(mac.KeyIDVerifier).VerifyMACAndKeyID(got, data)
+		if err != nil {
+			t.Errorf("verifier.VerifyMACAndKeyID() err = %v, want nil", err)
+		}
+		if verifiedKeyID != keyID {
+			t.Errorf("verifier.VerifyMACAndKeyID() = %d, want %d", verifiedKeyID, keyID)
+		}
+	}
+}
+
+func TestNewMultiComputerFailsWithNilHandle(t *testing.T) {
+	if _, err := mac.NewMultiComputer(nil); err == nil {
+		t.Errorf("mac.NewMultiComputer(nil) err = nil, want error")
+	}
+}
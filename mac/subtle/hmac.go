@@ -20,6 +20,7 @@ import (
 	"hash"
 
 	"github.com/tink-crypto/tink-go/v2/internal/mac/hmac"
+	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 var errHMACInvalidInput = errors.New("HMAC: invalid input")
@@ -31,6 +32,8 @@ type HMAC struct {
 	hmac     *hmac.HMAC
 }
 
+var _ tink.StreamingMACComputer = (*HMAC)(nil)
+
 // NewHMAC creates a new instance of HMAC with the specified key and tag size.
 func NewHMAC(hashAlg string, key []byte, tagSize uint32) (*HMAC, error) {
 	h, err := hmac.New(hashAlg, key, tagSize)
@@ -55,3 +58,17 @@ func (h *HMAC) ComputeMAC(data []byte) ([]byte, error) {
 func (h *HMAC) VerifyMAC(mac []byte, data []byte) error {
 	return h.hmac.VerifyMAC(mac, data)
 }
+
+// NewReusable returns a MAC with the same key and tag size as h that creates
+// the keyed hash once and resets it for every call, which is faster when
+// computing or verifying many MACs over small inputs. The returned MAC is not
+// safe for concurrent use.
+func (h *HMAC) NewReusable() (tink.MAC, error) {
+	return h.hmac.NewReusable()
+}
+
+// NewStreamingMAC returns a [tink.StreamingMAC] that computes the same MAC as
+// ComputeMAC over the concatenation of all written data.
+func (h *HMAC) NewStreamingMAC() (tink.StreamingMAC, error) {
+	return h.hmac.NewStreamingMAC()
+}
@@ -83,6 +83,72 @@ func TestHMACBasic(t *testing.T) {
 	}
 }
 
+func TestHMACStreaming(t *testing.T) {
+	for _, test := range hmacTests {
+		t.Run(test.desc, func(t *testing.T) {
+			cipher, err := subtle.NewHMAC(test.hashAlg, test.key, test.tagSize)
+			if err != nil {
+				t.Fatalf("subtle.NewHMAC() err = %q, want nil", err)
+			}
+			s, err := cipher.NewStreamingMAC()
+			if err != nil {
+				t.Fatalf("cipher.NewStreamingMAC() err = %q, want nil", err)
+			}
+			for i := range test.data {
+				if _, err := s.Write(test.data[i : i+1]); err != nil {
+					t.Fatalf("s.Write() err = %q, want nil", err)
+				}
+			}
+			mac, err := s.Finish()
+			if err != nil {
+				t.Fatalf("s.Finish() err = %q, want nil", err)
+			}
+			if hex.EncodeToString(mac) != test.expectedMac {
+				t.Errorf("hex.EncodeToString(mac) = %q, want %q",
+					hex.EncodeToString(mac), test.expectedMac)
+			}
+			if _, err := s.Write(test.data); err == nil {
+				t.Errorf("s.Write() after Finish err = nil, want error")
+			}
+			if _, err := s.Finish(); err == nil {
+				t.Errorf("s.Finish() after Finish err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestHMACReusable(t *testing.T) {
+	for _, test := range hmacTests {
+		t.Run(test.desc, func(t *testing.T) {
+			cipher, err := subtle.NewHMAC(test.hashAlg, test.key, test.tagSize)
+			if err != nil {
+				t.Fatalf("subtle.NewHMAC() err = %q, want nil", err)
+			}
+			reusable, err := cipher.NewReusable()
+			if err != nil {
+				t.Fatalf("cipher.NewReusable() err = %q, want nil", err)
+			}
+			// Repeat to check that the keyed hash is reset between calls.
+			for i := 0; i < 3; i++ {
+				mac, err := reusable.ComputeMAC(test.data)
+				if err != nil {
+					t.Fatalf("reusable.ComputeMAC() err = %q, want nil", err)
+				}
+				if hex.EncodeToString(mac) != test.expectedMac {
+					t.Errorf("hex.EncodeToString(mac) = %q, want %q",
+						hex.EncodeToString(mac), test.expectedMac)
+				}
+				if err := reusable.VerifyMAC(mac, test.data); err != nil {
+					t.Errorf("reusable.VerifyMAC() err = %q, want nil", err)
+				}
+				if err := reusable.VerifyMAC(mac, append(test.data[:len(test.data):len(test.data)], 'x')); err == nil {
+					t.Errorf("reusable.VerifyMAC() with modified data err = nil, want error")
+				}
+			}
+		})
+	}
+}
+
 func TestNewHMACWithInvalidInput(t *testing.T) {
 	// invalid hash algorithm
 	_, err := subtle.NewHMAC("MD5", random.GetRandomBytes(16), 32)
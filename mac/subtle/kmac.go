@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subtle
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+const (
+	minKMAC128KeySizeInBytes = 16
+	minKMAC256KeySizeInBytes = 32
+	maxKMACTagSizeInBytes    = uint32(64)
+
+	// Rates of cSHAKE128 and cSHAKE256 in bytes.
+	kmac128Rate = 168
+	kmac256Rate = 136
+)
+
+// kmacFunctionName is the cSHAKE function name of KMAC.
+var kmacFunctionName = []byte("KMAC")
+
+// KMAC implements KMAC128 and KMAC256 as specified in NIST SP 800-185.
+type KMAC struct {
+	// keyed is the cSHAKE state after absorbing the padded key.
+	keyed   sha3.ShakeHash
+	tagSize uint32
+}
+
+var _ tink.MAC = (*KMAC)(nil)
+
+// NewKMAC128 creates a new KMAC128 instance with the specified key,
+// customization string and tag size.
+//
+// The key must be at least 16 bytes long, and the tag size must be between
+// 10 and 64 bytes.
+func NewKMAC128(key, customization []byte, tagSize uint32) (*KMAC, error) {
+	if len(key) < minKMAC128KeySizeInBytes {
+		return nil, fmt.Errorf("kmac: invalid key size %d, want at least %d", len(key), minKMAC128KeySizeInBytes)
+	}
+	return newKMAC(sha3.NewCShake128(kmacFunctionName, customization), kmac128Rate, key, tagSize)
+}
+
+// NewKMAC256 creates a new KMAC256 instance with the specified key,
+// customization string and tag size.
+//
+// The key must be at least 32 bytes long, and the tag size must be between
+// 10 and 64 bytes.
+func NewKMAC256(key, customization []byte, tagSize uint32) (*KMAC, error) {
+	if len(key) < minKMAC256KeySizeInBytes {
+		return nil, fmt.Errorf("kmac: invalid key size %d, want at least %d", len(key), minKMAC256KeySizeInBytes)
+	}
+	return newKMAC(sha3.NewCShake256(kmacFunctionName, customization), kmac256Rate, key, tagSize)
+}
+
+func newKMAC(h sha3.ShakeHash, rate int, key []byte, tagSize uint32) (*KMAC, error) {
+	if tagSize < minTagLengthInBytes {
+		return nil, fmt.Errorf("kmac: invalid tag size %d, want at least %d", tagSize, minTagLengthInBytes)
+	}
+	if tagSize > maxKMACTagSizeInBytes {
+		return nil, fmt.Errorf("kmac: invalid tag size %d, want at most %d", tagSize, maxKMACTagSizeInBytes)
+	}
+	h.Write(bytepad(encodeString(key), rate))
+	return &KMAC{keyed: h, tagSize: tagSize}, nil
+}
+
+// ComputeMAC computes the KMAC of data.
+func (k *KMAC) ComputeMAC(data []byte) ([]byte, error) {
+	h := k.keyed.Clone()
+	h.Write(data)
+	h.Write(rightEncode(uint64(k.tagSize) * 8))
+	tag := make([]byte, k.tagSize)
+	h.Read(tag)
+	return tag, nil
+}
+
+// VerifyMAC returns nil if mac is a correct KMAC of data, and an error
+// otherwise.
+func (k *KMAC) VerifyMAC(mac, data []byte) error {
+	computed, err := k.ComputeMAC(data)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(mac, computed) != 1 {
+		return fmt.Errorf("kmac: invalid MAC")
+	}
+	return nil
+}
+
+// leftEncode returns left_encode(x) of SP 800-185.
+func leftEncode(x uint64) []byte {
+	b := bigEndianMinimal(x)
+	return append([]byte{byte(len(b))}, b...)
+}
+
+// rightEncode returns right_encode(x) of SP 800-185.
+func rightEncode(x uint64) []byte {
+	b := bigEndianMinimal(x)
+	return append(b, byte(len(b)))
+}
+
+// bigEndianMinimal returns the big-endian encoding of x in as few bytes as
+// possible, but at least one.
+func bigEndianMinimal(x uint64) []byte {
+	n := 1
+	for v := x >> 8; v != 0; v >>= 8 {
+		n++
+	}
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(x)
+		x >>= 8
+	}
+	return b
+}
+
+// encodeString returns encode_string(s) of SP 800-185.
+func encodeString(s []byte) []byte {
+	return append(leftEncode(uint64(len(s))*8), s...)
+}
+
+// bytepad returns bytepad(x, w) of SP 800-185.
+func bytepad(x []byte, w int) []byte {
+	b := append(leftEncode(uint64(w)), x...)
+	if r := len(b) % w; r != 0 {
+		b = append(b, make([]byte, w-r)...)
+	}
+	return b
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subtle_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/mac/subtle"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+)
+
+// sequence returns the bytes start, start+1, ..., start+n-1.
+func sequence(start byte, n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = start + byte(i)
+	}
+	return b
+}
+
+func TestKMACVectors(t *testing.T) {
+	// Samples from NIST SP 800-185, "KMAC_samples.pdf".
+	key := sequence(0x40, 32)
+	customization := []byte("My Tagged Application")
+	for _, tc := range []struct {
+		name          string
+		newKMAC       func(key, customization []byte, tagSize uint32) (*subtle.KMAC, error)
+		data          []byte
+		customization []byte
+		want          string
+	}{
+		{
+			name:    "KMAC128 sample 1",
+			newKMAC: subtle.NewKMAC128,
+			data:    sequence(0, 4),
+			want:    "e5780b0d3ea6f7d3a429c5706aa43a00fadbd7d49628839e3187243f456ee14e",
+		},
+		{
+			name:          "KMAC128 sample 2",
+			newKMAC:       subtle.NewKMAC128,
+			data:          sequence(0, 4),
+			customization: customization,
+			want:          "3b1fba963cd8b0b59e8c1a6d71888b7143651af8ba0a7070c0979e2811324aa5",
+		},
+		{
+			name:          "KMAC128 sample 3",
+			newKMAC:       subtle.NewKMAC128,
+			data:          sequence(0, 200),
+			customization: customization,
+			want:          "1f5b4e6cca02209e0dcb5ca635b89a15e271ecc760071dfd805faa38f9729230",
+		},
+		{
+			name:          "KMAC256 sample 4",
+			newKMAC:       subtle.NewKMAC256,
+			data:          sequence(0, 4),
+			customization: customization,
+			want:          "20c570c31346f703c9ac36c61c03cb64c3970d0cfc787e9b79599d273a68d2f7f69d4cc3de9d104a351689f27cf6f5951f0103f33f4f24871024d9c27773a8dd",
+		},
+		{
+			name:    "KMAC256 sample 5",
+			newKMAC: subtle.NewKMAC256,
+			data:    sequence(0, 200),
+			want:    "75358cf39e41494e949707927cee0af20a3ff553904c86b08f21cc414bcfd691589d27cf5e15369cbbff8b9a4c2eb17800855d0235ff635da82533ec6b759b69",
+		},
+		{
+			name:          "KMAC256 sample 6",
+			newKMAC:       subtle.NewKMAC256,
+			data:          sequence(0, 200),
+			customization: customization,
+			want:          "b58618f71f92e1d56c1b8c55ddd7cd188b97b4ca4d99831eb2699a837da2e4d970fbacfde50033aea585f1a2708510c32d07880801bd182898fe476876fc8965",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := hex.DecodeString(tc.want)
+			if err != nil {
+				t.Fatalf("hex.DecodeString() err = %v, want nil", err)
+			}
+			k, err := tc.newKMAC(key, tc.customization, uint32(len(want)))
+			if err != nil {
+				t.Fatalf("tc.newKMAC() err = %v, want nil", err)
+			}
+			got, err := k.ComputeMAC(tc.data)
+			if err != nil {
+				t.Fatalf("k.ComputeMAC() err = %v, want nil", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("k.ComputeMAC() = %x, want %x", got, want)
+			}
+			if err := k.VerifyMAC(want, tc.data); err != nil {
+				t.Errorf("k.VerifyMAC() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestKMACVerifyMACFailsWithWrongInput(t *testing.T) {
+	k, err := subtle.NewKMAC256(random.GetRandomBytes(32), []byte("customization"), 32)
+	if err != nil {
+		t.Fatalf("subtle.NewKMAC256() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	tag, err := k.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("k.ComputeMAC() err = %v, want nil", err)
+	}
+	if err := k.VerifyMAC(tag, []byte("other data")); err == nil {
+		t.Error("k.VerifyMAC(tag, otherData) err = nil, want error")
+	}
+	if err := k.VerifyMAC(tag[:len(tag)-1], data); err == nil {
+		t.Error("k.VerifyMAC(truncatedTag, data) err = nil, want error")
+	}
+	other, err := subtle.NewKMAC256(random.GetRandomBytes(32), []byte("other customization"), 32)
+	if err != nil {
+		t.Fatalf("subtle.NewKMAC256() err = %v, want nil", err)
+	}
+	if err := other.VerifyMAC(tag, data); err == nil {
+		t.Error("other.VerifyMAC() err = nil, want error")
+	}
+}
+
+func TestNewKMACWithInvalidInput(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		newKMAC func(key, customization []byte, tagSize uint32) (*subtle.KMAC, error)
+		keySize int
+		tagSize uint32
+	}{
+		{"KMAC128 short key", subtle.NewKMAC128, 15, 32},
+		{"KMAC256 short key", subtle.NewKMAC256, 31, 32},
+		{"KMAC128 short tag", subtle.NewKMAC128, 16, 9},
+		{"KMAC256 long tag", subtle.NewKMAC256, 32, 65},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.newKMAC(random.GetRandomBytes(uint32(tc.keySize)), nil, tc.tagSize); err == nil {
+				t.Error("tc.newKMAC() err = nil, want error")
+			}
+		})
+	}
+}
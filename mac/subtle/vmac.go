@@ -0,0 +1,283 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subtle
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+const (
+	// VMACNonceSize is the size in bytes of the nonces used by VMAC.
+	VMACNonceSize = aes.BlockSize
+	// VMAC64TagSize and VMAC128TagSize are the supported VMAC tag sizes in
+	// bytes, without the nonce.
+	VMAC64TagSize  = 8
+	VMAC128TagSize = 16
+
+	// vmacBlockSize is the size in bytes of the blocks hashed by NH.
+	vmacBlockSize = 128
+
+	// vmacNHKeyWords is the number of 64-bit words of the NH key for 128-bit
+	// tags. The NH key of the second half of the tag is shifted by 2 words.
+	vmacNHKeyWords = vmacBlockSize/8 + 2*(VMAC128TagSize/8-1)
+	// KDF indices of the NH, polynomial and L3 keys.
+	vmacKDFNH = 0x80
+	vmacKDFL2 = 0xc0
+	vmacKDFL3 = 0xe0
+
+	vmacMPoly = 0x1fffffff1fffffff // Mask of the polynomial keys.
+	vmacM62   = 0x3fffffffffffffff
+	vmacM63   = 0x7fffffffffffffff
+	vmacP64   = 0xfffffffffffffeff // 2^64 - 257
+	vmacL3Div = 0xffffffff00000000 // 2^64 - 2^32
+)
+
+// VMAC implements VMAC-AES with 64-bit or 128-bit tags, as specified in
+// draft-krovetz-vmac-01.
+//
+// VMAC is a Wegman-Carter MAC: the message is hashed with the VHASH universal
+// hash function, and the hash is masked by encrypting a nonce with AES. This
+// makes it considerably faster than HMAC on long messages, but its security
+// rests on different assumptions:
+//   - a nonce must never be reused with the same key, since two tags with the
+//     same nonce leak information about the hash key and allow forgeries;
+//   - the forgery probability of each verification attempt is bounded by the
+//     collision probability of VHASH, roughly 2^-59 for 64-bit tags and
+//     2^-118 for 128-bit tags on messages of moderate length, plus the
+//     advantage against AES, whereas the security of HMAC only relies on its
+//     hash function;
+//   - VMAC is not a PRF, and its tags must not be used as keys or
+//     identifiers.
+//
+// ComputeMAC uses a fresh random nonce for every tag, and returns the nonce
+// followed by the tag, as is usual for GMAC. With random 16-byte nonces, a key
+// can be used for about 2^48 tags before nonce collisions become a concern.
+type VMAC struct {
+	block   cipher.Block
+	tagSize int
+	nhKey   [vmacNHKeyWords]uint64
+	l2Key   [2][2]uint64 // {hi, lo} for each 64-bit half of the tag.
+	l3Key   [2][2]uint64
+}
+
+var _ tink.MAC = (*VMAC)(nil)
+
+// NewVMAC returns a VMAC instance with the given AES key, which must be 16, 24
+// or 32 bytes long, and tag size, which must be VMAC64TagSize or
+// VMAC128TagSize.
+func NewVMAC(key []byte, tagSize uint32) (*VMAC, error) {
+	if tagSize != VMAC64TagSize && tagSize != VMAC128TagSize {
+		return nil, fmt.Errorf("vmac: invalid tag size %d, want %d or %d", tagSize, VMAC64TagSize, VMAC128TagSize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("vmac: %v", err)
+	}
+	v := &VMAC{block: block, tagSize: int(tagSize)}
+	var in, out [aes.BlockSize]byte
+	in[0] = vmacKDFNH
+	for i := 0; i < len(v.nhKey); i += 2 {
+		block.Encrypt(out[:], in[:])
+		v.nhKey[i] = binary.BigEndian.Uint64(out[:8])
+		v.nhKey[i+1] = binary.BigEndian.Uint64(out[8:])
+		in[15]++
+	}
+	in = [aes.BlockSize]byte{vmacKDFL2}
+	for i := range v.l2Key {
+		block.Encrypt(out[:], in[:])
+		v.l2Key[i][0] = binary.BigEndian.Uint64(out[:8]) & vmacMPoly
+		v.l2Key[i][1] = binary.BigEndian.Uint64(out[8:]) & vmacMPoly
+		in[15]++
+	}
+	in = [aes.BlockSize]byte{vmacKDFL3}
+	for i := range v.l3Key {
+		// Both halves of the L3 key must be smaller than p64.
+		for {
+			block.Encrypt(out[:], in[:])
+			in[15]++
+			v.l3Key[i][0] = binary.BigEndian.Uint64(out[:8])
+			v.l3Key[i][1] = binary.BigEndian.Uint64(out[8:])
+			if v.l3Key[i][0] < vmacP64 && v.l3Key[i][1] < vmacP64 {
+				break
+			}
+		}
+	}
+	return v, nil
+}
+
+// ComputeMAC computes the VMAC of data with a random nonce, and returns the
+// nonce followed by the tag.
+func (v *VMAC) ComputeMAC(data []byte) ([]byte, error) {
+	nonce := random.GetRandomBytes(VMACNonceSize)
+	tag, err := v.ComputeMACWithNonce(nonce, data)
+	if err != nil {
+		return nil, err
+	}
+	return append(nonce, tag...), nil
+}
+
+// VerifyMAC returns nil if mac is a nonce followed by a correct VMAC tag of
+// data, and an error otherwise.
+func (v *VMAC) VerifyMAC(mac, data []byte) error {
+	if len(mac) != VMACNonceSize+v.tagSize {
+		return fmt.Errorf("vmac: invalid MAC size %d, want %d", len(mac), VMACNonceSize+v.tagSize)
+	}
+	tag, err := v.ComputeMACWithNonce(mac[:VMACNonceSize], data)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(mac[VMACNonceSize:], tag) != 1 {
+		return fmt.Errorf("vmac: invalid MAC")
+	}
+	return nil
+}
+
+// ComputeMACWithNonce returns the VMAC tag of data with the given 16-byte
+// nonce, without the nonce. A nonce must never be used twice with the same
+// key; for 64-bit tags, two nonces that differ only in their last bit count
+// as the same nonce.
+func (v *VMAC) ComputeMACWithNonce(nonce, data []byte) ([]byte, error) {
+	if len(nonce) != VMACNonceSize {
+		return nil, fmt.Errorf("vmac: invalid nonce size %d, want %d", len(nonce), VMACNonceSize)
+	}
+	var in, pad [aes.BlockSize]byte
+	copy(in[:], nonce)
+	tag := make([]byte, v.tagSize)
+	if v.tagSize == VMAC64TagSize {
+		// The last bit of the nonce selects one half of the AES output.
+		half := int(in[15] & 1)
+		in[15] &^= 1
+		v.block.Encrypt(pad[:], in[:])
+		p := binary.BigEndian.Uint64(pad[8*half:])
+		binary.BigEndian.PutUint64(tag, v.vhash(0, data)+p)
+		return tag, nil
+	}
+	v.block.Encrypt(pad[:], in[:])
+	for i := 0; i < 2; i++ {
+		p := binary.BigEndian.Uint64(pad[8*i:])
+		binary.BigEndian.PutUint64(tag[8*i:], v.vhash(i, data)+p)
+	}
+	return tag, nil
+}
+
+// vhash computes the i-th 64-bit VHASH output of data.
+func (v *VMAC) vhash(i int, data []byte) uint64 {
+	nhKey := v.nhKey[2*i : 2*i+vmacBlockSize/8]
+	kh, kl := v.l2Key[i][0], v.l2Key[i][1]
+	// The L2 polynomial evaluation starts at 1, and there is at least one NH
+	// output, so that the empty message hashes to the key.
+	yh, yl := uint64(0), uint64(1)
+	rest := data
+	for {
+		var mh, ml uint64
+		if len(rest) >= vmacBlockSize {
+			mh, ml = vmacNHHash(rest[:vmacBlockSize], nhKey)
+			rest = rest[vmacBlockSize:]
+		} else {
+			// The last block is zero-padded to a multiple of 16 bytes.
+			var last [vmacBlockSize]byte
+			n := copy(last[:], rest)
+			mh, ml = vmacNHHash(last[:(n+15)/16*16], nhKey)
+			rest = nil
+		}
+		yh, yl = vmacPolyStep(yh, yl, kh, kl, mh, ml)
+		if len(rest) == 0 {
+			break
+		}
+	}
+	bitLen := uint64(len(data)%vmacBlockSize) * 8
+	return vmacL3Hash(yh, yl, bitLen, v.l3Key[i][0], v.l3Key[i][1])
+}
+
+// vmacNHHash returns NH(key, block) mod 2^126. The message words are little
+// endian, and len(block) is a multiple of 16 bytes.
+func vmacNHHash(block []byte, key []uint64) (uint64, uint64) {
+	var hi, lo uint64
+	for j := 0; j < len(block)/8; j += 2 {
+		a := binary.LittleEndian.Uint64(block[8*j:]) + key[j]
+		b := binary.LittleEndian.Uint64(block[8*j+8:]) + key[j+1]
+		ph, pl := bits.Mul64(a, b)
+		var c uint64
+		lo, c = bits.Add64(lo, pl, 0)
+		hi += ph + c
+	}
+	return hi & vmacM62, lo
+}
+
+// vmacPolyStep returns y*k + m modulo 2^127-1, not necessarily fully
+// reduced. y must be smaller than 2^128, k smaller than 2^125 and m smaller
+// than 2^126.
+func vmacPolyStep(yh, yl, kh, kl, mh, ml uint64) (uint64, uint64) {
+	// 256-bit product w3:w2:w1:w0 = y * k.
+	h00, w0 := bits.Mul64(yl, kl)
+	h01, l01 := bits.Mul64(yl, kh)
+	h10, l10 := bits.Mul64(yh, kl)
+	h11, l11 := bits.Mul64(yh, kh)
+	w1, c1 := bits.Add64(h00, l01, 0)
+	w1, c2 := bits.Add64(w1, l10, 0)
+	w2, c3 := bits.Add64(h01, h10, c1)
+	w2, c4 := bits.Add64(w2, l11, c2)
+	w3 := h11 + c3 + c4
+	// Since 2^127 = 1 mod 2^127-1, y*k = (y*k >> 127) + (y*k mod 2^127).
+	ah := w3<<1 | w2>>63
+	al := w2<<1 | w1>>63
+	rl, c := bits.Add64(al, w0, 0)
+	rh := ah + (w1 & vmacM63) + c
+	rl, c = bits.Add64(rl, ml, 0)
+	rh += mh + c
+	return rh, rl
+}
+
+// vmacL3Hash fully reduces y + (bitLen << 64) modulo 2^127-1 and hashes the
+// result to 64 bits with the L3 key (k1, k2).
+func vmacL3Hash(yh, yl, bitLen, k1, k2 uint64) uint64 {
+	// Fold the top bit, add the length and reduce until below 2^127-1.
+	t := yh >> 63
+	yh &= vmacM63
+	var c uint64
+	yl, c = bits.Add64(yl, t, 0)
+	yh += bitLen + c
+	for yh > vmacM63 || (yh == vmacM63 && yl == ^uint64(0)) {
+		t = yh >> 63
+		yh &= vmacM63
+		yl, c = bits.Add64(yl, t, 0)
+		yh += c
+		if yh == vmacM63 && yl == ^uint64(0) {
+			yh, yl = 0, 0
+		}
+	}
+	// Split y into two digits in base 2^64-2^32.
+	m1, m2 := bits.Div64(yh, yl, vmacL3Div)
+	a := vmacAddModP64(m1, k1)
+	b := vmacAddModP64(m2, k2)
+	ph, pl := bits.Mul64(a, b)
+	_, r := bits.Div64(ph, pl, vmacP64)
+	return r
+}
+
+// vmacAddModP64 returns (x + k) mod 2^64-257, where k < 2^64-257.
+func vmacAddModP64(x, k uint64) uint64 {
+	s, c := bits.Add64(x, k, 0)
+	_, r := bits.Div64(c, s, vmacP64)
+	return r
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subtle_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/mac/subtle"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+)
+
+// TestVMACVectors checks the test vectors of Appendix A of
+// draft-krovetz-vmac-01, computed with the key "abcdefghijklmnop" and the
+// nonce "bcdefghi", over "abc" repeated a number of times.
+func TestVMACVectors(t *testing.T) {
+	key := []byte("abcdefghijklmnop")
+	nonce := append(make([]byte, 8), []byte("bcdefghi")...)
+	for _, tc := range []struct {
+		tagSize uint32
+		repeat  int
+		want    string
+	}{
+		{subtle.VMAC64TagSize, 0, "2576be1c56d8b81b"},
+		{subtle.VMAC64TagSize, 1, "2d376cf5b1813ce5"},
+		{subtle.VMAC64TagSize, 16, "e8421f61d573d298"},
+		{subtle.VMAC64TagSize, 100, "4492df6c5cac1bbe"},
+		{subtle.VMAC64TagSize, 1000000, "09ba597dd7601113"},
+		{subtle.VMAC128TagSize, 0, "472766c70f74ed23481d6d7de4e80dac"},
+		{subtle.VMAC128TagSize, 1, "4ee815a06a1d71edd36fc75d51188a42"},
+		{subtle.VMAC128TagSize, 16, "09f2c80c8e1007a0c12fae19fe4504ae"},
+		{subtle.VMAC128TagSize, 100, "66438817154850c61d8a412164803bcb"},
+		{subtle.VMAC128TagSize, 1000000, "2b6b02288ffc461b75485de893c629dc"},
+	} {
+		t.Run(fmt.Sprintf("VMAC%d_abc*%d", tc.tagSize*8, tc.repeat), func(t *testing.T) {
+			v, err := subtle.NewVMAC(key, tc.tagSize)
+			if err != nil {
+				t.Fatalf("subtle.NewVMAC() err = %v, want nil", err)
+			}
+			data := []byte(strings.Repeat("abc", tc.repeat))
+			tag, err := v.ComputeMACWithNonce(nonce, data)
+			if err != nil {
+				t.Fatalf("v.ComputeMACWithNonce() err = %v, want nil", err)
+			}
+			if got := hex.EncodeToString(tag); got != tc.want {
+				t.Errorf("v.ComputeMACWithNonce() = %s, want %s", got, tc.want)
+			}
+			if err := v.VerifyMAC(append(nonce, tag...), data); err != nil {
+				t.Errorf("v.VerifyMAC() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestVMACComputeAndVerify(t *testing.T) {
+	for _, keySize := range []int{16, 24, 32} {
+		for _, tagSize := range []uint32{subtle.VMAC64TagSize, subtle.VMAC128TagSize} {
+			t.Run(fmt.Sprintf("key%d_tag%d", keySize, tagSize), func(t *testing.T) {
+				v, err := subtle.NewVMAC(random.GetRandomBytes(uint32(keySize)), tagSize)
+				if err != nil {
+					t.Fatalf("subtle.NewVMAC() err = %v, want nil", err)
+				}
+				// Cover empty, partial, full and multiple NH blocks.
+				for _, size := range []int{0, 1, 15, 16, 127, 128, 129, 256, 1000} {
+					data := random.GetRandomBytes(uint32(size))
+					mac, err := v.ComputeMAC(data)
+					if err != nil {
+						t.Fatalf("v.ComputeMAC() err = %v, want nil", err)
+					}
+					if got, want := len(mac), subtle.VMACNonceSize+int(tagSize); got != want {
+						t.Errorf("len(v.ComputeMAC()) = %d, want %d", got, want)
+					}
+					if err := v.VerifyMAC(mac, data); err != nil {
+						t.Errorf("v.VerifyMAC() err = %v, want nil", err)
+					}
+					other, err := v.ComputeMAC(data)
+					if err != nil {
+						t.Fatalf("v.ComputeMAC() err = %v, want nil", err)
+					}
+					if bytes.Equal(mac, other) {
+						t.Error("v.ComputeMAC() returned the same MAC twice, want random nonces")
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestVMACVerifyMACFailsWithWrongInput(t *testing.T) {
+	v, err := subtle.NewVMAC(random.GetRandomBytes(16), subtle.VMAC128TagSize)
+	if err != nil {
+		t.Fatalf("subtle.NewVMAC() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	mac, err := v.ComputeMAC(data)
+	if err != nil {
+		t.Fatalf("v.ComputeMAC() err = %v, want nil", err)
+	}
+	otherKey, err := subtle.NewVMAC(random.GetRandomBytes(16), subtle.VMAC128TagSize)
+	if err != nil {
+		t.Fatalf("subtle.NewVMAC() err = %v, want nil", err)
+	}
+	if err := otherKey.VerifyMAC(mac, data); err == nil {
+		t.Error("otherKey.VerifyMAC() err = nil, want error")
+	}
+	if err := v.VerifyMAC(mac, []byte("other data")); err == nil {
+		t.Error("v.VerifyMAC() with other data err = nil, want error")
+	}
+	if err := v.VerifyMAC(mac[:len(mac)-1], data); err == nil {
+		t.Error("v.VerifyMAC() with truncated MAC err = nil, want error")
+	}
+	for i := range mac {
+		modified := bytes.Clone(mac)
+		modified[i] ^= 0x80
+		if err := v.VerifyMAC(modified, data); err == nil {
+			t.Errorf("v.VerifyMAC() with byte %d modified err = nil, want error", i)
+		}
+	}
+}
+
+func TestNewVMACWithInvalidInput(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		key     []byte
+		tagSize uint32
+	}{
+		{"short key", random.GetRandomBytes(15), subtle.VMAC64TagSize},
+		{"long key", random.GetRandomBytes(33), subtle.VMAC64TagSize},
+		{"tag size 4", random.GetRandomBytes(16), 4},
+		{"tag size 12", random.GetRandomBytes(16), 12},
+		{"tag size 32", random.GetRandomBytes(16), 32},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := subtle.NewVMAC(tc.key, tc.tagSize); err == nil {
+				t.Error("subtle.NewVMAC() err = nil, want error")
+			}
+		})
+	}
+	v, err := subtle.NewVMAC(random.GetRandomBytes(16), subtle.VMAC64TagSize)
+	if err != nil {
+		t.Fatalf("subtle.NewVMAC() err = %v, want nil", err)
+	}
+	if _, err := v.ComputeMACWithNonce(make([]byte, 8), []byte("data")); err == nil {
+		t.Error("v.ComputeMACWithNonce() with an 8-byte nonce err = nil, want error")
+	}
+}
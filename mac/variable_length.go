@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"slices"
+
+	"github.com/tink-crypto/tink-go/v2/core/cryptofmt"
+	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/internal/primitiveset"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// MinVariableTagLength is the smallest tag length, in bytes, accepted by
+// [VariableLengthComputer]. It provides 80-bit security against forgeries.
+const MinVariableTagLength = 10
+
+// MinLegacyTagLength is the smallest tag length, in bytes, accepted by
+// [VariableLengthComputer.InsecureVerifyLegacyMAC]. It provides only 64-bit
+// security against forgeries.
+const MinLegacyTagLength = 8
+
+// VariableLengthComputer computes and verifies MACs whose length is chosen at
+// call time, by truncating the tag of the underlying key.
+//
+// The forgery resistance of a MAC is bounded by its tag length: an attacker
+// succeeds with probability 2^-(8*tagLen) per guess. Short tags should only be
+// used when the number of verification attempts an attacker can make is
+// limited.
+type VariableLengthComputer struct {
+	ps *primitiveset.PrimitiveSet[tink.MAC]
+}
+
+// NewVariableLengthComputer creates a [VariableLengthComputer] from the given
+// keyset handle.
+func NewVariableLengthComputer(handle *keyset.Handle) (*VariableLengthComputer, error) {
+	ps, err := keyset.Primitives[tink.MAC](handle, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("mac_factory: cannot obtain primitive set: %s", err)
+	}
+	return &VariableLengthComputer{ps: ps}, nil
+}
+
+// fullTag computes the untruncated tag of entry over data, checking that it is
+// at least tagLen bytes long.
+func fullTag(entry *primitiveset.Entry[tink.MAC], data []byte, tagLen int) ([]byte, error) {
+	if entry.PrefixType == tinkpb.OutputPrefixType_LEGACY {
+		if len(data) >= maxInt {
+			return nil, fmt.Errorf("mac_factory: data too long")
+		}
+		data = slices.Concat(data, []byte{0})
+	}
+	tag, err := entry.Primitive.ComputeMAC(data)
+	if err != nil {
+		return nil, err
+	}
+	if tagLen > len(tag) {
+		return nil, fmt.Errorf("mac_factory: tag length %d exceeds key tag size %d", tagLen, len(tag))
+	}
+	return tag, nil
+}
+
+func validateTagLen(tagLen int) error {
+	if tagLen < MinVariableTagLength {
+		return fmt.Errorf("mac_factory: tag length %d is smaller than the minimum %d", tagLen, MinVariableTagLength)
+	}
+	return nil
+}
+
+// ComputeMAC computes a MAC over data with the primary key and truncates it to
+// tagLen bytes. It returns the concatenation of the primary's output prefix and
+// the truncated tag.
+//
+// tagLen must be at least [MinVariableTagLength] and at most the tag size of
+// the primary key.
+func (c *VariableLengthComputer) ComputeMAC(data []byte, tagLen int) ([]byte, error) {
+	if err := validateTagLen(tagLen); err != nil {
+		return nil, err
+	}
+	primary := c.ps.Primary
+	tag, err := fullTag(primary, data, tagLen)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat([]byte(primary.Prefix), tag[:tagLen]), nil
+}
+
+// VerifyMAC verifies that mac is a correct MAC of tagLen bytes for data.
+//
+// The verifier must use the same tagLen that was used to compute mac; tags of
+// any other length are rejected.
+func (c *VariableLengthComputer) VerifyMAC(mac, data []byte, tagLen int) error {
+	if err := validateTagLen(tagLen); err != nil {
+		return err
+	}
+	return c.verifyMAC(mac, data, tagLen)
+}
+
+// InsecureVerifyLegacyMAC is like VerifyMAC, but also accepts tag lengths
+// from [MinLegacyTagLength] up to [MinVariableTagLength].
+//
+// This is insecure: an attacker who can make many verification attempts can
+// forge an 8-byte tag far more easily than a full-length one. It is only meant
+// for verifying tags produced by legacy systems that truncate HMAC tags to
+// fewer than 10 bytes. There is deliberately no way to compute such tags.
+func (c *VariableLengthComputer) InsecureVerifyLegacyMAC(mac, data []byte, tagLen int) error {
+	if tagLen < MinLegacyTagLength {
+		return fmt.Errorf("mac_factory: tag length %d is smaller than the minimum %d", tagLen, MinLegacyTagLength)
+	}
+	return c.verifyMAC(mac, data, tagLen)
+}
+
+func (c *VariableLengthComputer) verifyMAC(mac, data []byte, tagLen int) error {
+	// Try non-raw keys.
+	if len(mac) == cryptofmt.NonRawPrefixSize+tagLen {
+		prefix := mac[:cryptofmt.NonRawPrefixSize]
+		entries, err := c.ps.EntriesForPrefix(string(prefix))
+		if err == nil {
+			for _, entry := range entries {
+				if verifyTruncated(entry, mac[cryptofmt.NonRawPrefixSize:], data, tagLen) {
+					return nil
+				}
+			}
+		}
+	}
+	// Try raw keys.
+	if len(mac) == tagLen {
+		entries, err := c.ps.RawEntries()
+		if err == nil {
+			for _, entry := range entries {
+				if verifyTruncated(entry, mac, data, tagLen) {
+					return nil
+				}
+			}
+		}
+	}
+	return errInvalidMAC
+}
+
+func verifyTruncated(entry *primitiveset.Entry[tink.MAC], tag, data []byte, tagLen int) bool {
+	want, err := fullTag(entry, data, tagLen)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(want[:tagLen], tag) == 1
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mac_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/testkeyset"
+	"github.com/tink-crypto/tink-go/v2/testutil"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	hmacpb "github.com/tink-crypto/tink-go/v2/proto/hmac_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestVariableLengthComputer(t *testing.T) {
+	data := []byte("data")
+	for _, prefixType := range []tinkpb.OutputPrefixType{
+		tinkpb.OutputPrefixType_TINK,
+		tinkpb.OutputPrefixType_LEGACY,
+		tinkpb.OutputPrefixType_CRUNCHY,
+		tinkpb.OutputPrefixType_RAW,
+	} {
+		t.Run(prefixType.String(), func(t *testing.T) {
+			ks := testutil.NewTestHMACKeyset(32, prefixType)
+			kh, err := testkeyset.NewHandle(ks)
+			if err != nil {
+				t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+			}
+			c, err := mac.NewVariableLengthComputer(kh)
+			if err != nil {
+				t.Fatalf("mac.NewVariableLengthComputer() err = %v, want nil", err)
+			}
+			full, err := mac.New(kh)
+			if err != nil {
+				t.Fatalf("mac.New() err = %v, want nil", err)
+			}
+			fullTag, err := full.ComputeMAC(data)
+			if err != nil {
+				t.Fatalf("full.ComputeMAC() err = %v, want nil", err)
+			}
+			for _, tagLen := range []int{mac.MinVariableTagLength, 16, 32} {
+				tag, err := c.ComputeMAC(data, tagLen)
+				if err != nil {
+					t.Fatalf("c.ComputeMAC(data, %d) err = %v, want nil", tagLen, err)
+				}
+				prefixLen := len(fullTag) - 32
+				if got, want := tag, fullTag[:prefixLen+tagLen]; !bytes.Equal(got, want) {
+					t.Errorf("c.ComputeMAC(data, %d) = %x, want %x", tagLen, got, want)
+				}
+				if err := c.VerifyMAC(tag, data, tagLen); err != nil {
+					t.Errorf("c.VerifyMAC(tag, data, %d) err = %v, want nil", tagLen, err)
+				}
+				if err := c.VerifyMAC(tag, []byte("other data"), tagLen); err == nil {
+					t.Errorf("c.VerifyMAC(tag, otherData, %d) err = nil, want error", tagLen)
+				}
+				if tagLen > mac.MinVariableTagLength {
+					if err := c.VerifyMAC(tag, data, tagLen-1); err == nil {
+						t.Errorf("c.VerifyMAC(tag, data, %d) err = nil, want error", tagLen-1)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestVariableLengthComputerRejectsInvalidTagLength(t *testing.T) {
+	kh, err := keyset.NewHandle(mac.HMACSHA256Tag128KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	c, err := mac.NewVariableLengthComputer(kh)
+	if err != nil {
+		t.Fatalf("mac.NewVariableLengthComputer() err = %v, want nil", err)
+	}
+	for _, tagLen := range []int{-1, 0, mac.MinVariableTagLength - 1, 17} {
+		if _, err := c.ComputeMAC([]byte("data"), tagLen); err == nil {
+			t.Errorf("c.ComputeMAC(data, %d) err = nil, want error", tagLen)
+		}
+	}
+	tag, err := c.ComputeMAC([]byte("data"), 16)
+	if err != nil {
+		t.Fatalf("c.ComputeMAC() err = %v, want nil", err)
+	}
+	if err := c.VerifyMAC(tag[:len(tag)-7], []byte("data"), mac.MinVariableTagLength-1); err == nil {
+		t.Errorf("c.VerifyMAC() err = nil, want error")
+	}
+}
+
+func TestVariableLengthComputerInsecureVerifyLegacyMAC(t *testing.T) {
+	keyData := testutil.NewHMACKeyData(commonpb.HashType_SHA1, 20)
+	key := &hmacpb.HmacKey{}
+	if err := proto.Unmarshal(keyData.GetValue(), key); err != nil {
+		t.Fatalf("proto.Unmarshal() err = %v, want nil", err)
+	}
+	kh, err := testkeyset.NewHandle(testutil.NewTestKeyset(keyData, tinkpb.OutputPrefixType_RAW))
+	if err != nil {
+		t.Fatalf("testkeyset.NewHandle() err = %v, want nil", err)
+	}
+	c, err := mac.NewVariableLengthComputer(kh)
+	if err != nil {
+		t.Fatalf("mac.NewVariableLengthComputer() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	// A tag truncated to 8 bytes by a system other than Tink.
+	h := hmac.New(sha1.New, key.GetKeyValue())
+	h.Write(data)
+	tag := h.Sum(nil)[:mac.MinLegacyTagLength]
+
+	if err := c.InsecureVerifyLegacyMAC(tag, data, mac.MinLegacyTagLength); err != nil {
+		t.Errorf("c.InsecureVerifyLegacyMAC() err = %v, want nil", err)
+	}
+	if err := c.InsecureVerifyLegacyMAC(tag, []byte("other data"), mac.MinLegacyTagLength); err == nil {
+		t.Error("c.InsecureVerifyLegacyMAC(tag, otherData) err = nil, want error")
+	}
+	if err := c.VerifyMAC(tag, data, mac.MinLegacyTagLength); err == nil {
+		t.Error("c.VerifyMAC() err = nil, want error")
+	}
+	if err := c.InsecureVerifyLegacyMAC(tag[:mac.MinLegacyTagLength-1], data, mac.MinLegacyTagLength-1); err == nil {
+		t.Errorf("c.InsecureVerifyLegacyMAC(tagLen = %d) err = nil, want error", mac.MinLegacyTagLength-1)
+	}
+}
@@ -16,6 +16,8 @@
 // This package isn't yet production ready and might go through various changes.
 package monitoring
 
+import "context"
+
 // KeyStatus represents KeyStatusType in tink/proto/tink.proto.
 type KeyStatus int
 
@@ -49,6 +51,9 @@ type Entry struct {
 	KeyID     uint32
 	KeyType   string
 	KeyPrefix string
+	// Annotations are the per-key labels set with keyset.SetKeyAnnotations.
+	// It is nil if the key has no annotations.
+	Annotations map[string]string
 }
 
 // KeysetInfo represents a keyset in a certain point in time for the
@@ -106,6 +111,36 @@ type Logger interface {
 	LogFailure()
 }
 
+// ContextLogger is a Logger that also receives the context.Context of the
+// operation, e.g. to attach trace or span IDs to the logged events. Loggers
+// returned by a Client may implement it. Primitives that take a context, such
+// as the ComputeMACContext method of the MAC primitive returned by mac.New,
+// then call LogContext and LogFailureContext instead of Log and LogFailure.
+type ContextLogger interface {
+	Logger
+
+	// LogContext is like Log, for an operation carried out with ctx.
+	LogContext(ctx context.Context, keyID uint32, numBytes int)
+
+	// LogFailureContext is like LogFailure, for an operation carried out with
+	// ctx.
+	LogFailureContext(ctx context.Context)
+}
+
+// KeyLoadLogger is implemented by a Client that wants to be notified each time
+// primitives are created from a keyset, for example to alert on unexpected key
+// rotations. As for the loggers, this only happens for keysets with
+// annotations.
+type KeyLoadLogger interface {
+	// LogKeyLoad is called with the info of the keyset, whose PrimaryKeyID is
+	// the ID of its primary key, and a SHA-256 checksum of the public view of
+	// the keyset: its key IDs, statuses, output prefix types, type URLs and
+	// primary key ID. Key material is not included. Keysets with the same
+	// keys, statuses and primary have the same checksum, so a changed checksum
+	// means that the keyset was swapped or rotated.
+	LogKeyLoad(keysetInfo *KeysetInfo, checksum []byte)
+}
+
 // Client represents an interface to hold monitoring client context to create a `Logger`.
 // A Client is registered with Tink's registry and used by primitives to obtain a `Logger`.
 type Client interface {
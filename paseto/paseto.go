@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package paseto implements the v4.public token format of Platform-Agnostic
+// Security Tokens (PASETO), as specified in
+// https://github.com/paseto-standard/paseto-spec, on top of Tink Ed25519
+// keysets.
+//
+// Only Ed25519 keys without output prefix can be used, for example keys
+// created with [signature.ED25519KeyWithoutPrefixTemplate]. The payload of a
+// token is opaque to this package; callers are responsible for encoding and
+// validating claims.
+package paseto
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const v4PublicHeader = "v4.public."
+
+var errInvalidToken = errors.New("paseto: invalid token")
+
+// pae returns the pre-authentication encoding of pieces, as defined in
+// section 2.2.1 of the PASETO specification.
+func pae(pieces ...[]byte) []byte {
+	size := 8
+	for _, p := range pieces {
+		size += 8 + len(p)
+	}
+	out := make([]byte, 0, size)
+	out = le64(out, uint64(len(pieces)))
+	for _, p := range pieces {
+		out = le64(out, uint64(len(p)))
+		out = append(out, p...)
+	}
+	return out
+}
+
+// le64 appends the little-endian encoding of n to b, with the most
+// significant bit cleared as required by the specification.
+func le64(b []byte, n uint64) []byte {
+	return binary.LittleEndian.AppendUint64(b, n&(1<<63-1))
+}
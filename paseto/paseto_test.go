@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paseto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPAE(t *testing.T) {
+	// Examples from section 2.2.1 of the PASETO specification.
+	for _, tc := range []struct {
+		name   string
+		pieces [][]byte
+		want   []byte
+	}{
+		{
+			name: "no pieces",
+			want: []byte("\x00\x00\x00\x00\x00\x00\x00\x00"),
+		},
+		{
+			name:   "empty piece",
+			pieces: [][]byte{[]byte("")},
+			want:   []byte("\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"),
+		},
+		{
+			name:   "one piece",
+			pieces: [][]byte{[]byte("test")},
+			want:   []byte("\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00test"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pae(tc.pieces...); !bytes.Equal(got, tc.want) {
+				t.Errorf("pae() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paseto
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/key"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature/ed25519"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+const ed25519SignatureSize = 64
+
+// V4PublicSigner issues v4.public tokens using the primary key of a keyset.
+type V4PublicSigner struct {
+	signer tink.Signer
+}
+
+// NewV4PublicSigner creates a [V4PublicSigner] from a keyset handle whose
+// primary key is an Ed25519 private key without output prefix.
+func NewV4PublicSigner(handle *keyset.Handle) (*V4PublicSigner, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("paseto: keyset handle can't be nil")
+	}
+	primary, err := handle.Primary()
+	if err != nil {
+		return nil, fmt.Errorf("paseto: %v", err)
+	}
+	privateKey, ok := primary.Key().(*ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("paseto: primary key is %T, want *ed25519.PrivateKey", primary.Key())
+	}
+	if err := checkParameters(privateKey.Parameters()); err != nil {
+		return nil, err
+	}
+	signer, err := ed25519.NewSigner(privateKey, internalapi.Token{})
+	if err != nil {
+		return nil, fmt.Errorf("paseto: %v", err)
+	}
+	return &V4PublicSigner{signer: signer}, nil
+}
+
+// Sign returns a v4.public token for message.
+//
+// footer is appended to the token in the clear and authenticated; it is
+// omitted from the token when empty. implicit is authenticated but not
+// included in the token, so verifiers must supply the same value.
+func (s *V4PublicSigner) Sign(message, footer, implicit []byte) (string, error) {
+	sig, err := s.signer.Sign(pae([]byte(v4PublicHeader), message, footer, implicit))
+	if err != nil {
+		return "", fmt.Errorf("paseto: %v", err)
+	}
+	token := v4PublicHeader + base64.RawURLEncoding.EncodeToString(slices.Concat(message, sig))
+	if len(footer) > 0 {
+		token += "." + base64.RawURLEncoding.EncodeToString(footer)
+	}
+	return token, nil
+}
+
+// V4PublicVerifier verifies v4.public tokens against the keys of a keyset.
+type V4PublicVerifier struct {
+	verifiers []tink.Verifier
+}
+
+// NewV4PublicVerifier creates a [V4PublicVerifier] from a keyset handle
+// containing Ed25519 public keys without output prefix. A token is accepted if
+// any enabled key in the keyset verifies it.
+func NewV4PublicVerifier(handle *keyset.Handle) (*V4PublicVerifier, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("paseto: keyset handle can't be nil")
+	}
+	var verifiers []tink.Verifier
+	for i := 0; i < handle.Len(); i++ {
+		entry, err := handle.Entry(i)
+		if err != nil {
+			return nil, fmt.Errorf("paseto: %v", err)
+		}
+		if entry.KeyStatus() != keyset.Enabled {
+			continue
+		}
+		publicKey, ok := entry.Key().(*ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("paseto: key %d is %T, want *ed25519.PublicKey", entry.KeyID(), entry.Key())
+		}
+		if err := checkParameters(publicKey.Parameters()); err != nil {
+			return nil, err
+		}
+		verifier, err := ed25519.NewVerifier(publicKey, internalapi.Token{})
+		if err != nil {
+			return nil, fmt.Errorf("paseto: %v", err)
+		}
+		verifiers = append(verifiers, verifier)
+	}
+	if len(verifiers) == 0 {
+		return nil, fmt.Errorf("paseto: keyset has no enabled keys")
+	}
+	return &V4PublicVerifier{verifiers: verifiers}, nil
+}
+
+// Verify verifies token and returns its message.
+//
+// footer must be equal to the footer of the token, and implicit to the
+// implicit assertion it was signed with.
+func (v *V4PublicVerifier) Verify(token string, footer, implicit []byte) ([]byte, error) {
+	if !strings.HasPrefix(token, v4PublicHeader) {
+		return nil, errInvalidToken
+	}
+	parts := strings.Split(token[len(v4PublicHeader):], ".")
+	if len(parts) > 2 || (len(parts) == 2 && parts[1] == "") {
+		return nil, errInvalidToken
+	}
+	var gotFooter []byte
+	if len(parts) == 2 {
+		var err error
+		if gotFooter, err = base64.RawURLEncoding.Strict().DecodeString(parts[1]); err != nil {
+			return nil, errInvalidToken
+		}
+	}
+	if subtle.ConstantTimeCompare(gotFooter, footer) != 1 {
+		return nil, errInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.Strict().DecodeString(parts[0])
+	if err != nil || len(payload) < ed25519SignatureSize {
+		return nil, errInvalidToken
+	}
+	message := payload[:len(payload)-ed25519SignatureSize]
+	sig := payload[len(payload)-ed25519SignatureSize:]
+	data := pae([]byte(v4PublicHeader), message, footer, implicit)
+	for _, verifier := range v.verifiers {
+		if err := verifier.Verify(sig, data); err == nil {
+			return message, nil
+		}
+	}
+	return nil, errInvalidToken
+}
+
+// checkParameters returns an error if keys with params can't be used for
+// v4.public tokens, which are plain Ed25519 signatures without output prefix.
+func checkParameters(p key.Parameters) error {
+	params, ok := p.(*ed25519.Parameters)
+	if !ok {
+		return fmt.Errorf("paseto: key parameters are %T, want *ed25519.Parameters", p)
+	}
+	if params.ContextStringOnly() {
+		return fmt.Errorf("paseto: Ed25519ctx keys are not supported")
+	}
+	if params.Variant() != ed25519.VariantNoPrefix {
+		return fmt.Errorf("paseto: key variant is %v, want %v", params.Variant(), ed25519.VariantNoPrefix)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paseto_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/paseto"
+	"github.com/tink-crypto/tink-go/v2/secretdata"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/signature/ed25519"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) err = %v, want nil", s, err)
+	}
+	return b
+}
+
+func newHandleFromSeed(t *testing.T, seed []byte) *keyset.Handle {
+	t.Helper()
+	params, err := ed25519.NewParameters(ed25519.VariantNoPrefix)
+	if err != nil {
+		t.Fatalf("ed25519.NewParameters() err = %v, want nil", err)
+	}
+	privateKey, err := ed25519.NewPrivateKey(secretdata.NewBytesFromData(seed, insecuresecretdataaccess.Token{}), 0, params)
+	if err != nil {
+		t.Fatalf("ed25519.NewPrivateKey() err = %v, want nil", err)
+	}
+	km := keyset.NewManager()
+	keyID, err := km.AddKey(privateKey)
+	if err != nil {
+		t.Fatalf("km.AddKey() err = %v, want nil", err)
+	}
+	if err := km.SetPrimary(keyID); err != nil {
+		t.Fatalf("km.SetPrimary() err = %v, want nil", err)
+	}
+	kh, err := km.Handle()
+	if err != nil {
+		t.Fatalf("km.Handle() err = %v, want nil", err)
+	}
+	return kh
+}
+
+// Test vector 4-S-1 from
+// https://github.com/paseto-standard/test-vectors/blob/master/v4.json.
+const (
+	testVectorSeedHex = "b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774"
+	testVectorMessage = `{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`
+	testVectorToken   = "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+)
+
+func TestV4PublicTestVector(t *testing.T) {
+	kh := newHandleFromSeed(t, mustDecodeHex(t, testVectorSeedHex))
+	signer, err := paseto.NewV4PublicSigner(kh)
+	if err != nil {
+		t.Fatalf("paseto.NewV4PublicSigner() err = %v, want nil", err)
+	}
+	token, err := signer.Sign([]byte(testVectorMessage), nil, nil)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	if token != testVectorToken {
+		t.Errorf("signer.Sign() = %q, want %q", token, testVectorToken)
+	}
+	publicHandle, err := kh.Public()
+	if err != nil {
+		t.Fatalf("kh.Public() err = %v, want nil", err)
+	}
+	verifier, err := paseto.NewV4PublicVerifier(publicHandle)
+	if err != nil {
+		t.Fatalf("paseto.NewV4PublicVerifier() err = %v, want nil", err)
+	}
+	message, err := verifier.Verify(testVectorToken, nil, nil)
+	if err != nil {
+		t.Fatalf("verifier.Verify() err = %v, want nil", err)
+	}
+	if got, want := string(message), testVectorMessage; got != want {
+		t.Errorf("verifier.Verify() = %q, want %q", got, want)
+	}
+}
+
+func TestV4PublicSignVerifyWithFooterAndImplicitAssertion(t *testing.T) {
+	kh, err := keyset.NewHandle(signature.ED25519KeyWithoutPrefixTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	signer, err := paseto.NewV4PublicSigner(kh)
+	if err != nil {
+		t.Fatalf("paseto.NewV4PublicSigner() err = %v, want nil", err)
+	}
+	publicHandle, err := kh.Public()
+	if err != nil {
+		t.Fatalf("kh.Public() err = %v, want nil", err)
+	}
+	verifier, err := paseto.NewV4PublicVerifier(publicHandle)
+	if err != nil {
+		t.Fatalf("paseto.NewV4PublicVerifier() err = %v, want nil", err)
+	}
+	message := []byte("message")
+	footer := []byte(`{"kid":"key-1"}`)
+	implicit := []byte("implicit")
+	token, err := signer.Sign(message, footer, implicit)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	if got := strings.Count(token, "."); got != 3 {
+		t.Errorf("strings.Count(token, \".\") = %d, want 3", got)
+	}
+	got, err := verifier.Verify(token, footer, implicit)
+	if err != nil {
+		t.Fatalf("verifier.Verify() err = %v, want nil", err)
+	}
+	if !bytes.Equal(got, message) {
+		t.Errorf("verifier.Verify() = %q, want %q", got, message)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		token    string
+		footer   []byte
+		implicit []byte
+	}{
+		{"wrong footer", token, []byte("other"), implicit},
+		{"missing footer", token, nil, implicit},
+		{"wrong implicit assertion", token, footer, []byte("other")},
+		{"missing implicit assertion", token, footer, nil},
+		{"wrong header", strings.Replace(token, "v4.public.", "v4.local.", 1), footer, implicit},
+		{"truncated", token[:30], footer, implicit},
+		{"extra part", token + ".AA", footer, implicit},
+		{"trailing dot without footer", token[:strings.LastIndex(token, ".")+1], nil, implicit},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := verifier.Verify(tc.token, tc.footer, tc.implicit); err == nil {
+				t.Errorf("verifier.Verify() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestV4PublicVerifierUsesAllKeys(t *testing.T) {
+	oldHandle, err := keyset.NewHandle(signature.ED25519KeyWithoutPrefixTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	signer, err := paseto.NewV4PublicSigner(oldHandle)
+	if err != nil {
+		t.Fatalf("paseto.NewV4PublicSigner() err = %v, want nil", err)
+	}
+	token, err := signer.Sign([]byte("message"), nil, nil)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	km := keyset.NewManagerFromHandle(oldHandle)
+	keyID, err := km.Add(signature.ED25519KeyWithoutPrefixTemplate())
+	if err != nil {
+		t.Fatalf("km.Add() err = %v, want nil", err)
+	}
+	if err := km.SetPrimary(keyID); err != nil {
+		t.Fatalf("km.SetPrimary() err = %v, want nil", err)
+	}
+	rotated, err := km.Handle()
+	if err != nil {
+		t.Fatalf("km.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := rotated.Public()
+	if err != nil {
+		t.Fatalf("rotated.Public() err = %v, want nil", err)
+	}
+	verifier, err := paseto.NewV4PublicVerifier(publicHandle)
+	if err != nil {
+		t.Fatalf("paseto.NewV4PublicVerifier() err = %v, want nil", err)
+	}
+	if _, err := verifier.Verify(token, nil, nil); err != nil {
+		t.Errorf("verifier.Verify() err = %v, want nil", err)
+	}
+}
+
+func TestV4PublicRejectsUnsupportedKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		kh   func() (*keyset.Handle, error)
+	}{
+		{"prefixed Ed25519", func() (*keyset.Handle, error) { return keyset.NewHandle(signature.ED25519KeyTemplate()) }},
+		{"ECDSA", func() (*keyset.Handle, error) { return keyset.NewHandle(signature.ECDSAP256RawKeyTemplate()) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kh, err := tc.kh()
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			if _, err := paseto.NewV4PublicSigner(kh); err == nil {
+				t.Errorf("paseto.NewV4PublicSigner() err = nil, want error")
+			}
+			publicHandle, err := kh.Public()
+			if err != nil {
+				t.Fatalf("kh.Public() err = %v, want nil", err)
+			}
+			if _, err := paseto.NewV4PublicVerifier(publicHandle); err == nil {
+				t.Errorf("paseto.NewV4PublicVerifier() err = nil, want error")
+			}
+		})
+	}
+}
@@ -63,7 +63,7 @@ func wrapPRFset(ps *primitiveset.PrimitiveSet[PRF]) (*Set, error) {
 }
 
 func createLogger(ps *primitiveset.PrimitiveSet[PRF]) (monitoring.Logger, error) {
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, nil
 	}
 	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
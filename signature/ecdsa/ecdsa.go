@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/tink-crypto/tink-go/v2/core/registry"
+	"github.com/tink-crypto/tink-go/v2/internal/internalregistry"
 	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
 	"github.com/tink-crypto/tink-go/v2/internal/registryconfig"
 )
@@ -28,6 +29,9 @@ func init() {
 	if err := registry.RegisterKeyManager(new(signerKeyManager)); err != nil {
 		panic(fmt.Sprintf("ecdsa.init() failed: %v", err))
 	}
+	if err := internalregistry.AllowKeyDerivation(signerTypeURL); err != nil {
+		panic(fmt.Sprintf("ecdsa.init() failed: %v", err))
+	}
 	if err := registry.RegisterKeyManager(new(verifierKeyManager)); err != nil {
 		panic(fmt.Sprintf("ecdsa.init() failed: %v", err))
 	}
@@ -167,7 +167,7 @@ func (p *Parameters) Variant() Variant { return p.variant }
 func checkValidHashForCurve(curveType CurveType, hashType HashType) error {
 	switch curveType {
 	case NistP256:
-		if hashType != SHA256 {
+		if hashType != SHA256 && hashType != SHA512 {
 			return fmt.Errorf("ecdsa.Parameters: unsupported hash type for curve type: %v, %v", curveType, hashType)
 		}
 	case NistP384:
@@ -125,13 +125,6 @@ func TestNewParametersInvalidValues(t *testing.T) {
 			encoding:  ecdsa.DER,
 			variant:   ecdsa.VariantTink,
 		},
-		{
-			name:      "NistP256 with SHA512",
-			curveType: ecdsa.NistP256,
-			hashType:  ecdsa.SHA512,
-			encoding:  ecdsa.DER,
-			variant:   ecdsa.VariantTink,
-		},
 		{
 			name:      "NistP384 with SHA256",
 			curveType: ecdsa.NistP384,
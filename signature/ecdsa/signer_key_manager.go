@@ -15,10 +15,13 @@
 package ecdsa
 
 import (
+	"crypto/ecdh"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
+	"math/big"
 
 	"google.golang.org/protobuf/proto"
 	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
@@ -150,6 +153,85 @@ func (km *signerKeyManager) DoesSupport(typeURL string) bool {
 // TypeURL returns the key type of keys managed by this key manager.
 func (km *signerKeyManager) TypeURL() string { return signerTypeURL }
 
+// KeyMaterialType returns the key material type of this key manager.
+func (km *signerKeyManager) KeyMaterialType() tinkpb.KeyData_KeyMaterialType {
+	return tinkpb.KeyData_ASYMMETRIC_PRIVATE
+}
+
+// DeriveKey derives a new [ecdsapb.EcdsaPrivateKey] from serializedKeyFormat
+// and pseudorandomness.
+//
+// The private scalar is derived by rejection sampling: DeriveKey reads as many
+// bytes as the curve order has, clears the bits above the bit length of the
+// order, and interprets the result as a big-endian integer. It repeats this
+// until the integer is in [1, n-1], where n is the order of the curve.
+func (km *signerKeyManager) DeriveKey(serializedKeyFormat []byte, pseudorandomness io.Reader) (proto.Message, error) {
+	keyFormat := new(ecdsapb.EcdsaKeyFormat)
+	if err := proto.Unmarshal(serializedKeyFormat, keyFormat); err != nil {
+		return nil, fmt.Errorf("ecdsa_signer_key_manager: invalid proto: %s", err)
+	}
+	if err := keyset.ValidateKeyVersion(keyFormat.GetVersion(), signerKeyVersion); err != nil {
+		return nil, fmt.Errorf("ecdsa_signer_key_manager: %s", err)
+	}
+	if err := km.validateKeyFormat(keyFormat); err != nil {
+		return nil, fmt.Errorf("ecdsa_signer_key_manager: invalid key format: %s", err)
+	}
+	params := keyFormat.GetParams()
+	curve := subtle.GetCurve(commonpb.EllipticCurveType_name[int32(params.GetCurve())])
+	ecdhCurve, err := ecdhCurveFromProto(params.GetCurve())
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa_signer_key_manager: %s", err)
+	}
+	privKey, err := deriveECDHPrivateKey(ecdhCurve, curve.Params().N, pseudorandomness)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa_signer_key_manager: cannot derive ECDSA key: %s", err)
+	}
+	// The public key is the uncompressed point 0x04 || X || Y.
+	point := privKey.PublicKey().Bytes()
+	coordinateSize := (len(point) - 1) / 2
+	return &ecdsapb.EcdsaPrivateKey{
+		Version: signerKeyVersion,
+		PublicKey: &ecdsapb.EcdsaPublicKey{
+			Version: signerKeyVersion,
+			Params:  params,
+			X:       new(big.Int).SetBytes(point[1 : 1+coordinateSize]).Bytes(),
+			Y:       new(big.Int).SetBytes(point[1+coordinateSize:]).Bytes(),
+		},
+		KeyValue: new(big.Int).SetBytes(privKey.Bytes()).Bytes(),
+	}, nil
+}
+
+// deriveECDHPrivateKey derives a private key of curve, whose order is n, from
+// pseudorandomness by rejection sampling.
+func deriveECDHPrivateKey(curve ecdh.Curve, n *big.Int, pseudorandomness io.Reader) (*ecdh.PrivateKey, error) {
+	size := (n.BitLen() + 7) / 8
+	excessBits := 8*size - n.BitLen()
+	b := make([]byte, size)
+	for {
+		if _, err := io.ReadFull(pseudorandomness, b); err != nil {
+			return nil, err
+		}
+		b[0] &= 0xff >> excessBits
+		// NewPrivateKey rejects zero and values not lower than n.
+		if privKey, err := curve.NewPrivateKey(b); err == nil {
+			return privKey, nil
+		}
+	}
+}
+
+func ecdhCurveFromProto(curve commonpb.EllipticCurveType) (ecdh.Curve, error) {
+	switch curve {
+	case commonpb.EllipticCurveType_NIST_P256:
+		return ecdh.P256(), nil
+	case commonpb.EllipticCurveType_NIST_P384:
+		return ecdh.P384(), nil
+	case commonpb.EllipticCurveType_NIST_P521:
+		return ecdh.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %s", curve)
+	}
+}
+
 // validateKey validates the given [ecdsapb.EcdsaPrivateKey].
 func (km *signerKeyManager) validateKey(key *ecdsapb.EcdsaPrivateKey) error {
 	if err := keyset.ValidateKeyVersion(key.Version, signerKeyVersion); err != nil {
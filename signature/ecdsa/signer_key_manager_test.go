@@ -15,13 +15,16 @@
 package ecdsa_test
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"slices"
 	"testing"
 
 	"google.golang.org/protobuf/proto"
 	"github.com/tink-crypto/tink-go/v2/core/registry"
 	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
+	"github.com/tink-crypto/tink-go/v2/internal/internalregistry"
 	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
 	"github.com/tink-crypto/tink-go/v2/signature/ecdsa"
 	"github.com/tink-crypto/tink-go/v2/signature/subtle"
@@ -491,6 +494,10 @@ func genValidECDSAParams() []ecdsaParams {
 			hashType: commonpb.HashType_SHA256,
 			curve:    commonpb.EllipticCurveType_NIST_P256,
 		},
+		ecdsaParams{
+			hashType: commonpb.HashType_SHA512,
+			curve:    commonpb.EllipticCurveType_NIST_P256,
+		},
 		ecdsaParams{
 			hashType: commonpb.HashType_SHA384,
 			curve:    commonpb.EllipticCurveType_NIST_P384,
@@ -542,7 +549,7 @@ func genInvalidECDSAParams() []ecdsaParams {
 			curve:    commonpb.EllipticCurveType_NIST_P521,
 		},
 		ecdsaParams{
-			hashType: commonpb.HashType_SHA512,
+			hashType: commonpb.HashType_SHA384,
 			curve:    commonpb.EllipticCurveType_NIST_P256,
 		},
 	}
@@ -556,3 +563,129 @@ func mustMarshal(t *testing.T, msg proto.Message) []byte {
 	}
 	return serialized
 }
+
+func derivableSignerKeyManager(t *testing.T) internalregistry.DerivableKeyManager {
+	t.Helper()
+	km, err := registry.GetKeyManager(testutil.ECDSASignerTypeURL)
+	if err != nil {
+		t.Fatalf("registry.GetKeyManager(%q) err = %v, want nil", testutil.ECDSASignerTypeURL, err)
+	}
+	keyManager, ok := km.(internalregistry.DerivableKeyManager)
+	if !ok {
+		t.Fatalf("key manager is not DerivableKeyManager")
+	}
+	return keyManager
+}
+
+func TestSignerKeyManagerDeriveKey(t *testing.T) {
+	keyManager := derivableSignerKeyManager(t)
+	for _, p := range genValidECDSAParams() {
+		t.Run(fmt.Sprintf("%s_%s", p.curve, p.hashType), func(t *testing.T) {
+			params := testutil.NewECDSAParams(p.hashType, p.curve, ecdsapb.EcdsaSignatureEncoding_DER)
+			keyFormat := mustMarshal(t, testutil.NewECDSAKeyFormat(params))
+			randomness := random.GetRandomBytes(200)
+			k, err := keyManager.DeriveKey(keyFormat, bytes.NewReader(randomness))
+			if err != nil {
+				t.Fatalf("keyManager.DeriveKey() err = %v, want nil", err)
+			}
+			key := k.(*ecdsapb.EcdsaPrivateKey)
+			validateECDSAPrivateKey(t, key, params)
+
+			// The same pseudorandomness derives the same key.
+			k2, err := keyManager.DeriveKey(keyFormat, bytes.NewReader(randomness))
+			if err != nil {
+				t.Fatalf("keyManager.DeriveKey() err = %v, want nil", err)
+			}
+			if !proto.Equal(key, k2) {
+				t.Errorf("keyManager.DeriveKey() = %v, want %v", k2, key)
+			}
+			k3, err := keyManager.DeriveKey(keyFormat, bytes.NewReader(random.GetRandomBytes(200)))
+			if err != nil {
+				t.Fatalf("keyManager.DeriveKey() err = %v, want nil", err)
+			}
+			if proto.Equal(key, k3) {
+				t.Errorf("keyManager.DeriveKey() with other pseudorandomness = %v, want a different key", k3)
+			}
+		})
+	}
+}
+
+func TestSignerKeyManagerDeriveKeyRejectsOutOfRangeScalars(t *testing.T) {
+	keyManager := derivableSignerKeyManager(t)
+	for _, tc := range []struct {
+		curve commonpb.EllipticCurveType
+		hash  commonpb.HashType
+		size  int
+	}{
+		{commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, 32},
+		{commonpb.EllipticCurveType_NIST_P384, commonpb.HashType_SHA384, 48},
+		{commonpb.EllipticCurveType_NIST_P521, commonpb.HashType_SHA512, 66},
+	} {
+		t.Run(tc.curve.String(), func(t *testing.T) {
+			params := testutil.NewECDSAParams(tc.hash, tc.curve, ecdsapb.EcdsaSignatureEncoding_DER)
+			keyFormat := mustMarshal(t, testutil.NewECDSAKeyFormat(params))
+			valid := random.GetRandomBytes(uint32(tc.size))
+			valid[0] = 0x01
+			want, err := keyManager.DeriveKey(keyFormat, bytes.NewReader(valid))
+			if err != nil {
+				t.Fatalf("keyManager.DeriveKey() err = %v, want nil", err)
+			}
+			if got := new(big.Int).SetBytes(want.(*ecdsapb.EcdsaPrivateKey).GetKeyValue()); got.Cmp(new(big.Int).SetBytes(valid)) != 0 {
+				t.Errorf("private key = %x, want %x", got, valid)
+			}
+			// Zero and values not lower than the order are skipped.
+			randomness := slices.Concat(make([]byte, tc.size), bytes.Repeat([]byte{0xff}, tc.size), valid)
+			got, err := keyManager.DeriveKey(keyFormat, bytes.NewReader(randomness))
+			if err != nil {
+				t.Fatalf("keyManager.DeriveKey() err = %v, want nil", err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("keyManager.DeriveKey() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSignerKeyManagerDeriveKeyFails(t *testing.T) {
+	keyManager := derivableSignerKeyManager(t)
+	validParams := testutil.NewECDSAParams(commonpb.HashType_SHA256, commonpb.EllipticCurveType_NIST_P256, ecdsapb.EcdsaSignatureEncoding_DER)
+	invalidVersion := testutil.NewECDSAKeyFormat(validParams)
+	invalidVersion.Version = 1
+	for _, tc := range []struct {
+		name       string
+		keyFormat  []byte
+		randomness []byte
+	}{
+		{
+			name:       "invalid version",
+			keyFormat:  mustMarshal(t, invalidVersion),
+			randomness: random.GetRandomBytes(32),
+		},
+		{
+			name:       "invalid params",
+			keyFormat:  mustMarshal(t, testutil.NewECDSAKeyFormat(testutil.NewECDSAParams(commonpb.HashType_SHA1, commonpb.EllipticCurveType_NIST_P256, ecdsapb.EcdsaSignatureEncoding_DER))),
+			randomness: random.GetRandomBytes(32),
+		},
+		{
+			name:       "missing params",
+			keyFormat:  nil,
+			randomness: random.GetRandomBytes(32),
+		},
+		{
+			name:       "invalid proto",
+			keyFormat:  []byte{0x80},
+			randomness: random.GetRandomBytes(32),
+		},
+		{
+			name:       "insufficient randomness",
+			keyFormat:  mustMarshal(t, testutil.NewECDSAKeyFormat(validParams)),
+			randomness: random.GetRandomBytes(31),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := keyManager.DeriveKey(tc.keyFormat, bytes.NewReader(tc.randomness)); err == nil {
+				t.Error("keyManager.DeriveKey() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// ECDSASignerFromComponents returns a keyset handle containing a single ECDSA
+// private key built from its components: the private scalar d and the public
+// point (x, y), all big-endian encoded.
+//
+// It returns an error if (x, y) is not on the curve or is not d*G, or if the
+// parameters are not a valid ECDSA configuration.
+func ECDSASignerFromComponents(curve commonpb.EllipticCurveType, hash commonpb.HashType, encoding ecdsapb.EcdsaSignatureEncoding, d, x, y []byte, prefix tinkpb.OutputPrefixType) (*keyset.Handle, error) {
+	if len(d) == 0 || len(x) == 0 || len(y) == 0 {
+		return nil, fmt.Errorf("signature: ECDSA key components must not be empty")
+	}
+	serializedKey, err := proto.Marshal(&ecdsapb.EcdsaPrivateKey{
+		Version: 0,
+		PublicKey: &ecdsapb.EcdsaPublicKey{
+			Version: 0,
+			Params: &ecdsapb.EcdsaParams{
+				HashType: hash,
+				Curve:    curve,
+				Encoding: encoding,
+			},
+			X: x,
+			Y: y,
+		},
+		KeyValue: d,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signature: failed to serialize ECDSA private key: %v", err)
+	}
+	var idRequirement uint32
+	if prefix != tinkpb.OutputPrefixType_RAW {
+		idRequirement = random.GetRandomUint32()
+	}
+	keySerialization, err := protoserialization.NewKeySerialization(&tinkpb.KeyData{
+		TypeUrl:         ecdsaSignerTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, prefix, idRequirement)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %v", err)
+	}
+	// The ECDSA key parser checks that the point is on the curve and that it
+	// matches the private scalar.
+	privateKey, err := protoserialization.ParseKey(keySerialization)
+	if err != nil {
+		return nil, fmt.Errorf("signature: invalid ECDSA key components: %v", err)
+	}
+	km := keyset.NewManager()
+	keyID, err := km.AddKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %v", err)
+	}
+	if err := km.SetPrimary(keyID); err != nil {
+		return nil, fmt.Errorf("signature: %v", err)
+	}
+	return km.Handle()
+}
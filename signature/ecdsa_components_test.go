@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/signature"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func TestECDSASignerFromComponents(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	for _, prefix := range []tinkpb.OutputPrefixType{tinkpb.OutputPrefixType_RAW, tinkpb.OutputPrefixType_TINK} {
+		t.Run(prefix.String(), func(t *testing.T) {
+			kh, err := signature.ECDSASignerFromComponents(commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, ecdsapb.EcdsaSignatureEncoding_DER, privKey.D.Bytes(), privKey.X.Bytes(), privKey.Y.Bytes(), prefix)
+			if err != nil {
+				t.Fatalf("signature.ECDSASignerFromComponents() err = %v, want nil", err)
+			}
+			if got, want := kh.KeysetInfo().GetKeyInfo()[0].GetOutputPrefixType(), prefix; got != want {
+				t.Errorf("OutputPrefixType = %v, want %v", got, want)
+			}
+			signer, err := signature.NewSigner(kh)
+			if err != nil {
+				t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+			}
+			data := []byte("data")
+			sig, err := signer.Sign(data)
+			if err != nil {
+				t.Fatalf("signer.Sign() err = %v, want nil", err)
+			}
+			publicHandle, err := kh.Public()
+			if err != nil {
+				t.Fatalf("kh.Public() err = %v, want nil", err)
+			}
+			verifier, err := signature.NewVerifier(publicHandle)
+			if err != nil {
+				t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+			}
+			if err := verifier.Verify(sig, data); err != nil {
+				t.Errorf("verifier.Verify() err = %v, want nil", err)
+			}
+			if prefix == tinkpb.OutputPrefixType_RAW {
+				digest := sha256.Sum256(data)
+				if !ecdsa.VerifyASN1(&privKey.PublicKey, digest[:], sig) {
+					t.Errorf("ecdsa.VerifyASN1() = false, want true")
+				}
+			}
+		})
+	}
+}
+
+func TestECDSASignerFromComponentsRejectsInvalidComponents(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	offCurveY := privKey.Y.Bytes()
+	offCurveY[len(offCurveY)-1] ^= 1
+	for _, tc := range []struct {
+		name  string
+		curve commonpb.EllipticCurveType
+		hash  commonpb.HashType
+		d     []byte
+		x     []byte
+		y     []byte
+	}{
+		{"mismatched private key", commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, otherKey.D.Bytes(), privKey.X.Bytes(), privKey.Y.Bytes()},
+		{"point not on curve", commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, privKey.D.Bytes(), privKey.X.Bytes(), offCurveY},
+		{"wrong curve", commonpb.EllipticCurveType_NIST_P384, commonpb.HashType_SHA384, privKey.D.Bytes(), privKey.X.Bytes(), privKey.Y.Bytes()},
+		{"invalid hash for curve", commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA1, privKey.D.Bytes(), privKey.X.Bytes(), privKey.Y.Bytes()},
+		{"empty private key", commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, nil, privKey.X.Bytes(), privKey.Y.Bytes()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.ECDSASignerFromComponents(tc.curve, tc.hash, ecdsapb.EcdsaSignatureEncoding_DER, tc.d, tc.x, tc.y, tinkpb.OutputPrefixType_TINK); err == nil {
+				t.Errorf("signature.ECDSASignerFromComponents() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// KeysetHandleFromECDSAPublicKeyPEM returns a keyset handle containing a
+// single ECDSA public key with output prefix type RAW, imported from a PEM
+// encoded SubjectPublicKeyInfo ("PUBLIC KEY" block).
+//
+// The curve, which must be NIST P-256, P-384 or P-521, is taken from the key.
+// The hash function and the signature encoding cannot be inferred from the key
+// and must be given. It returns an error if they are not a valid combination
+// with the curve, for example SHA256 with P-521.
+//
+// Use [VerifierKeysetFromPEMBundle] to import several keys at once with the
+// default hash function of each curve.
+func KeysetHandleFromECDSAPublicKeyPEM(pemBytes []byte, hash commonpb.HashType, encoding ecdsapb.EcdsaSignatureEncoding) (*keyset.Handle, error) {
+	block, rest := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("signature.KeysetHandleFromECDSAPublicKeyPEM: no PEM block found")
+	}
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, errors.New("signature.KeysetHandleFromECDSAPublicKeyPEM: trailing data after the PEM block")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: unsupported PEM block type %q", block.Type)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: %v", err)
+	}
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: got public key type %T, want ECDSA", publicKey)
+	}
+	curve, _, err := ecdsaCurveAndHash(ecdsaPublicKey.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: %v", err)
+	}
+	coordinateSize := (ecdsaPublicKey.Curve.Params().BitSize + 7) / 8
+	serializedKey, err := proto.Marshal(&ecdsapb.EcdsaPublicKey{
+		Version: 0,
+		Params: &ecdsapb.EcdsaParams{
+			HashType: hash,
+			Curve:    curve,
+			Encoding: encoding,
+		},
+		X: ecdsaPublicKey.X.FillBytes(make([]byte, coordinateSize)),
+		Y: ecdsaPublicKey.Y.FillBytes(make([]byte, coordinateSize)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: %v", err)
+	}
+	keySerialization, err := protoserialization.NewKeySerialization(&tinkpb.KeyData{
+		TypeUrl:         ecdsaVerifierTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, tinkpb.OutputPrefixType_RAW, 0)
+	if err != nil {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: %v", err)
+	}
+	// The ECDSA key parser checks the parameters.
+	key, err := protoserialization.ParseKey(keySerialization)
+	if err != nil {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: %v", err)
+	}
+	km := keyset.NewManager()
+	keyID, err := km.AddKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: %v", err)
+	}
+	if err := km.SetPrimary(keyID); err != nil {
+		return nil, fmt.Errorf("signature.KeysetHandleFromECDSAPublicKeyPEM: %v", err)
+	}
+	return km.Handle()
+}
+
+// ECDSAPublicKeyPEMFromKeysetHandle returns the primary key of the public
+// keyset handle as a PEM encoded SubjectPublicKeyInfo ("PUBLIC KEY" block).
+// It is the inverse of [KeysetHandleFromECDSAPublicKeyPEM].
+//
+// The hash function, signature encoding and output prefix type of the key
+// are not part of the SubjectPublicKeyInfo and are lost. It returns an error
+// if the primary key is not an ECDSA public key.
+func ECDSAPublicKeyPEMFromKeysetHandle(handle *keyset.Handle) ([]byte, error) {
+	primary, err := handle.Primary()
+	if err != nil {
+		return nil, fmt.Errorf("signature.ECDSAPublicKeyPEMFromKeysetHandle: %v", err)
+	}
+	keySerialization, err := protoserialization.SerializeKey(primary.Key())
+	if err != nil {
+		return nil, fmt.Errorf("signature.ECDSAPublicKeyPEMFromKeysetHandle: %v", err)
+	}
+	if keySerialization.KeyData().GetTypeUrl() != ecdsaVerifierTypeURL {
+		return nil, fmt.Errorf("signature.ECDSAPublicKeyPEMFromKeysetHandle: got primary key type %q, want %q", keySerialization.KeyData().GetTypeUrl(), ecdsaVerifierTypeURL)
+	}
+	protoKey := &ecdsapb.EcdsaPublicKey{}
+	if err := proto.Unmarshal(keySerialization.KeyData().GetValue(), protoKey); err != nil {
+		return nil, fmt.Errorf("signature.ECDSAPublicKeyPEMFromKeysetHandle: %v", err)
+	}
+	curve, err := ellipticCurveFromProto(protoKey.GetParams().GetCurve())
+	if err != nil {
+		return nil, fmt.Errorf("signature.ECDSAPublicKeyPEMFromKeysetHandle: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(protoKey.GetX()),
+		Y:     new(big.Int).SetBytes(protoKey.GetY()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signature.ECDSAPublicKeyPEMFromKeysetHandle: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+func ellipticCurveFromProto(curve commonpb.EllipticCurveType) (elliptic.Curve, error) {
+	switch curve {
+	case commonpb.EllipticCurveType_NIST_P256:
+		return elliptic.P256(), nil
+	case commonpb.EllipticCurveType_NIST_P384:
+		return elliptic.P384(), nil
+	case commonpb.EllipticCurveType_NIST_P521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %s", curve)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
+)
+
+func TestKeysetHandleFromECDSAPublicKeyPEM(t *testing.T) {
+	data := []byte("data")
+	for _, tc := range []struct {
+		name     string
+		curve    elliptic.Curve
+		hash     commonpb.HashType
+		cryptoH  crypto.Hash
+		encoding ecdsapb.EcdsaSignatureEncoding
+	}{
+		{"P256_SHA256_DER", elliptic.P256(), commonpb.HashType_SHA256, crypto.SHA256, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"P256_SHA256_IEEE_P1363", elliptic.P256(), commonpb.HashType_SHA256, crypto.SHA256, ecdsapb.EcdsaSignatureEncoding_IEEE_P1363},
+		{"P384_SHA512_DER", elliptic.P384(), commonpb.HashType_SHA512, crypto.SHA512, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"P521_SHA512_IEEE_P1363", elliptic.P521(), commonpb.HashType_SHA512, crypto.SHA512, ecdsapb.EcdsaSignatureEncoding_IEEE_P1363},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			privateKey, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+			}
+			pemBytes := publicKeyPEM(t, privateKey.Public())
+			handle, err := signature.KeysetHandleFromECDSAPublicKeyPEM(pemBytes, tc.hash, tc.encoding)
+			if err != nil {
+				t.Fatalf("signature.KeysetHandleFromECDSAPublicKeyPEM() err = %v, want nil", err)
+			}
+
+			h := tc.cryptoH.New()
+			h.Write(data)
+			digest := h.Sum(nil)
+			var sig []byte
+			if tc.encoding == ecdsapb.EcdsaSignatureEncoding_DER {
+				sig, err = ecdsa.SignASN1(rand.Reader, privateKey, digest)
+				if err != nil {
+					t.Fatalf("ecdsa.SignASN1() err = %v, want nil", err)
+				}
+			} else {
+				r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest)
+				if err != nil {
+					t.Fatalf("ecdsa.Sign() err = %v, want nil", err)
+				}
+				size := (tc.curve.Params().BitSize + 7) / 8
+				sig = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+			}
+			verifier, err := signature.NewVerifier(handle)
+			if err != nil {
+				t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+			}
+			if err := verifier.Verify(sig, data); err != nil {
+				t.Errorf("verifier.Verify() err = %v, want nil", err)
+			}
+
+			exported, err := signature.ECDSAPublicKeyPEMFromKeysetHandle(handle)
+			if err != nil {
+				t.Fatalf("signature.ECDSAPublicKeyPEMFromKeysetHandle() err = %v, want nil", err)
+			}
+			if !bytes.Equal(exported, pemBytes) {
+				t.Errorf("signature.ECDSAPublicKeyPEMFromKeysetHandle() = %s, want %s", exported, pemBytes)
+			}
+		})
+	}
+}
+
+func TestECDSAPublicKeyPEMFromKeysetHandleWithTinkKey(t *testing.T) {
+	_, publicHandle := newPublicHandle(t)
+	pemBytes, err := signature.ECDSAPublicKeyPEMFromKeysetHandle(publicHandle)
+	if err != nil {
+		t.Fatalf("signature.ECDSAPublicKeyPEMFromKeysetHandle() err = %v, want nil", err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		t.Fatal("pem.Decode() = nil, want block")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("x509.ParsePKIXPublicKey() err = %v, want nil", err)
+	}
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		t.Fatalf("x509.ParsePKIXPublicKey() = %T, want *ecdsa.PublicKey", publicKey)
+	}
+	if ecdsaPublicKey.Curve != elliptic.P256() {
+		t.Errorf("ecdsaPublicKey.Curve = %v, want P-256", ecdsaPublicKey.Curve.Params().Name)
+	}
+}
+
+func TestKeysetHandleFromECDSAPublicKeyPEMFails(t *testing.T) {
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	ed25519PublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() err = %v, want nil", err)
+	}
+	p256PEM := publicKeyPEM(t, p256Key.Public())
+	for _, tc := range []struct {
+		name     string
+		pem      []byte
+		hash     commonpb.HashType
+		encoding ecdsapb.EcdsaSignatureEncoding
+	}{
+		{"not PEM", []byte("not PEM"), commonpb.HashType_SHA256, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"two PEM blocks", append(p256PEM, p256PEM...), commonpb.HashType_SHA256, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"wrong block type", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{0}}), commonpb.HashType_SHA256, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"Ed25519 key", publicKeyPEM(t, ed25519PublicKey), commonpb.HashType_SHA256, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"SHA256 with P521", publicKeyPEM(t, p521Key.Public()), commonpb.HashType_SHA256, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"unknown hash", p256PEM, commonpb.HashType_UNKNOWN_HASH, ecdsapb.EcdsaSignatureEncoding_DER},
+		{"unknown encoding", p256PEM, commonpb.HashType_SHA256, ecdsapb.EcdsaSignatureEncoding_UNKNOWN_ENCODING},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.KeysetHandleFromECDSAPublicKeyPEM(tc.pem, tc.hash, tc.encoding); err == nil {
+				t.Error("signature.KeysetHandleFromECDSAPublicKeyPEM() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestECDSAPublicKeyPEMFromKeysetHandleFails(t *testing.T) {
+	privateHandle, _ := newPublicHandle(t)
+	ed25519Handle, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	ed25519PublicHandle, err := ed25519Handle.Public()
+	if err != nil {
+		t.Fatalf("ed25519Handle.Public() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		handle *keyset.Handle
+	}{
+		{"private ECDSA key", privateHandle},
+		{"Ed25519 public key", ed25519PublicHandle},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.ECDSAPublicKeyPEMFromKeysetHandle(tc.handle); err == nil {
+				t.Error("signature.ECDSAPublicKeyPEMFromKeysetHandle() err = nil, want error")
+			}
+		})
+	}
+}
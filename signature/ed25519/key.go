@@ -67,7 +67,8 @@ func (variant Variant) String() string {
 
 // Parameters represents the parameters of an ED25519 key.
 type Parameters struct {
-	variant Variant
+	variant           Variant
+	contextStringOnly bool
 }
 
 var _ key.Parameters = (*Parameters)(nil)
@@ -80,9 +81,29 @@ func NewParameters(variant Variant) (Parameters, error) {
 	return Parameters{variant: variant}, nil
 }
 
+// NewContextStringParameters creates a new Parameters for Ed25519ctx keys
+// (RFC 8032, Section 5.1). Keys with these parameters only sign and verify
+// with a context string, through tink.ContextStringSigner and
+// tink.ContextStringVerifier, and keys created with [NewParameters] never do,
+// so that the same key is not used in both modes.
+//
+// There is no Tink key format for Ed25519ctx keys yet, so these keys cannot
+// be serialized or added to a keyset; use them directly with [NewSigner] and
+// [NewVerifier].
+func NewContextStringParameters(variant Variant) (Parameters, error) {
+	if variant == VariantUnknown {
+		return Parameters{}, fmt.Errorf("ed25519.NewContextStringParameters: variant must not be %v", VariantUnknown)
+	}
+	return Parameters{variant: variant, contextStringOnly: true}, nil
+}
+
 // Variant returns the prefix variant of the parameters.
 func (p *Parameters) Variant() Variant { return p.variant }
 
+// ContextStringOnly returns true if keys with these parameters only produce
+// and verify Ed25519ctx signatures.
+func (p *Parameters) ContextStringOnly() bool { return p.contextStringOnly }
+
 // HasIDRequirement returns true if the key has an ID requirement.
 func (p *Parameters) HasIDRequirement() bool { return p.variant != VariantNoPrefix }
 
@@ -92,7 +113,7 @@ func (p *Parameters) Equal(other key.Parameters) bool {
 		return true
 	}
 	then, ok := other.(*Parameters)
-	return ok && p.variant == then.variant
+	return ok && p.variant == then.variant && p.contextStringOnly == then.contextStringOnly
 }
 
 // PublicKey represents an ED25519 public key.
@@ -121,7 +121,12 @@ func TestParametersEqual(t *testing.T) {
 		t.Fatalf("ed25519.NewParameters(%v) err = %v, want	 nil", ed25519.VariantNoPrefix, err)
 	}
 
-	for _, params := range []ed25519.Parameters{tinkVariant, legacyVariant, crunchyVariant, noPrefixVariant} {
+	contextStringTinkVariant, err := ed25519.NewContextStringParameters(ed25519.VariantTink)
+	if err != nil {
+		t.Fatalf("ed25519.NewContextStringParameters(%v) err = %v, want nil", ed25519.VariantTink, err)
+	}
+
+	for _, params := range []ed25519.Parameters{tinkVariant, legacyVariant, crunchyVariant, noPrefixVariant, contextStringTinkVariant} {
 		if !params.Equal(&params) {
 			t.Errorf("params.Equal(params) = false, want true")
 		}
@@ -163,6 +168,11 @@ func TestParametersEqual(t *testing.T) {
 			firstParams:  crunchyVariant,
 			secondParams: noPrefixVariant,
 		},
+		{
+			name:         "tink vs context string tink",
+			firstParams:  tinkVariant,
+			secondParams: contextStringTinkVariant,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.firstParams.Equal(&tc.secondParams) {
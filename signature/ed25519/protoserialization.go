@@ -15,6 +15,7 @@
 package ed25519
 
 import (
+	"errors"
 	"fmt"
 
 	"google.golang.org/protobuf/proto"
@@ -39,6 +40,12 @@ const (
 	privateKeyProtoVersion = 0
 )
 
+// errNoContextStringSerialization is returned when serializing Ed25519ctx keys
+// or parameters. There is no Tink proto format for them yet, so they can only
+// be used as key objects, for example with [NewSigner], and never in a
+// keyset.
+var errNoContextStringSerialization = errors.New("Ed25519ctx keys and parameters have no proto serialization")
+
 type publicKeySerializer struct{}
 
 var _ protoserialization.KeySerializer = (*publicKeySerializer)(nil)
@@ -63,6 +70,9 @@ func (s *publicKeySerializer) SerializeKey(key key.Key) (*protoserialization.Key
 	if !ok {
 		return nil, fmt.Errorf("invalid key type: %T, want *ed25519.PublicKey", key)
 	}
+	if ed25519PubKey.params.ContextStringOnly() {
+		return nil, errNoContextStringSerialization
+	}
 	outputPrefixType, err := protoOutputPrefixTypeFromVariant(ed25519PubKey.params.Variant())
 	if err != nil {
 		return nil, err
@@ -98,6 +108,9 @@ func (s *privateKeySerializer) SerializeKey(key key.Key) (*protoserialization.Ke
 		return nil, fmt.Errorf("invalid key: public key is nil")
 	}
 	params := ed25519PrivKey.publicKey.params
+	if params.ContextStringOnly() {
+		return nil, errNoContextStringSerialization
+	}
 	outputPrefixType, err := protoOutputPrefixTypeFromVariant(params.Variant())
 	if err != nil {
 		return nil, err
@@ -226,6 +239,9 @@ func (s *parametersSerializer) Serialize(parameters key.Parameters) (*tinkpb.Key
 	if !ok {
 		return nil, fmt.Errorf("invalid parameters type: got %T, want *ed25519.Parameters", parameters)
 	}
+	if ed25519Parameters.ContextStringOnly() {
+		return nil, errNoContextStringSerialization
+	}
 	outputPrefixType, err := protoOutputPrefixTypeFromVariant(ed25519Parameters.Variant())
 	if err != nil {
 		return nil, err
@@ -728,3 +728,27 @@ func TestSerializeParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestSerializeContextStringKeysFails(t *testing.T) {
+	params, err := NewContextStringParameters(VariantTink)
+	if err != nil {
+		t.Fatalf("NewContextStringParameters(%v) err = %v, want nil", VariantTink, err)
+	}
+	privateKeyBytes := secretdata.NewBytesFromData([]byte("12345678901234567890123456789012"), insecuresecretdataaccess.Token{})
+	privateKey, err := NewPrivateKey(privateKeyBytes, 123, params)
+	if err != nil {
+		t.Fatalf("NewPrivateKey() err = %v, want nil", err)
+	}
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatalf("privateKey.PublicKey() err = %v, want nil", err)
+	}
+	for _, k := range []key.Key{privateKey, publicKey} {
+		if _, err := protoserialization.SerializeKey(k); err == nil {
+			t.Errorf("protoserialization.SerializeKey(%T) err = nil, want error", k)
+		}
+	}
+	if _, err := (&parametersSerializer{}).Serialize(&params); err == nil {
+		t.Errorf("parametersSerializer.Serialize() err = nil, want error")
+	}
+}
@@ -15,48 +15,77 @@
 package ed25519
 
 import (
-	"crypto/ed25519"
 	"fmt"
 	"slices"
 
 	"github.com/tink-crypto/tink-go/v2/insecuresecretdataaccess"
 	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
 	"github.com/tink-crypto/tink-go/v2/key"
+	"github.com/tink-crypto/tink-go/v2/signature/subtle"
 	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 // signer is an implementation of [tink.Signer] for ED25519.
 type signer struct {
-	privateKey ed25519.PrivateKey
-	prefix     []byte
-	variant    Variant
+	signer            *subtle.ED25519Signer
+	prefix            []byte
+	variant           Variant
+	contextStringOnly bool
 }
 
-var _ tink.Signer = (*signer)(nil)
+var _ tink.ContextStringSigner = (*signer)(nil)
 
 // NewSigner creates a new [tink.Signer] for ED25519.
 //
 // This is an internal API.
 func NewSigner(privateKey *PrivateKey, _ internalapi.Token) (tink.Signer, error) {
+	s, err := subtle.NewED25519Signer(privateKey.PrivateKeyBytes().Data(insecuresecretdataaccess.Token{}))
+	if err != nil {
+		return nil, err
+	}
 	return &signer{
-		privateKey: ed25519.NewKeyFromSeed(privateKey.PrivateKeyBytes().Data(insecuresecretdataaccess.Token{})),
-		prefix:     privateKey.OutputPrefix(),
-		variant:    privateKey.publicKey.params.Variant(),
+		signer:            s,
+		prefix:            privateKey.OutputPrefix(),
+		variant:           privateKey.publicKey.params.Variant(),
+		contextStringOnly: privateKey.publicKey.params.ContextStringOnly(),
 	}, nil
 }
 
+func (e *signer) messageToSign(data []byte) []byte {
+	if e.variant == VariantLegacy {
+		return slices.Concat(data, []byte{0})
+	}
+	return data
+}
+
 // Sign computes a signature for the given data.
 //
 // If the key has prefix, the signature will be prefixed with the output
-// prefix.
+// prefix. It fails for Ed25519ctx keys.
 func (e *signer) Sign(data []byte) ([]byte, error) {
-	messageToSign := data
-	if e.variant == VariantLegacy {
-		messageToSign = slices.Concat(data, []byte{0})
+	if e.contextStringOnly {
+		return nil, fmt.Errorf("ed25519: the key only signs with a context string")
+	}
+	r, err := e.signer.Sign(e.messageToSign(data))
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(e.prefix, r), nil
+}
+
+// SignWithContextString computes an Ed25519ctx signature for the given data
+// under contextString, which must be between 1 and 255 bytes long. It fails
+// unless the key is an Ed25519ctx key.
+//
+// If the key has prefix, the signature will be prefixed with the output
+// prefix.
+func (e *signer) SignWithContextString(data, contextString []byte) ([]byte, error) {
+	if !e.contextStringOnly {
+		return nil, fmt.Errorf("ed25519: the key doesn't sign with a context string")
 	}
-	r := ed25519.Sign(e.privateKey, messageToSign)
-	if len(r) != ed25519.SignatureSize {
-		return nil, fmt.Errorf("ed25519: invalid signature")
+	r, err := e.signer.SignWithContextString(e.messageToSign(data), contextString)
+	if err != nil {
+		return nil, err
 	}
 	return slices.Concat(e.prefix, r), nil
 }
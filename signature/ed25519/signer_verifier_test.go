@@ -33,6 +33,7 @@ import (
 	"github.com/tink-crypto/tink-go/v2/signature"
 	"github.com/tink-crypto/tink-go/v2/subtle/random"
 	"github.com/tink-crypto/tink-go/v2/testutil"
+	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 func TestSignVerifyCorrectness(t *testing.T) {
@@ -266,6 +267,87 @@ func TestSignVerify(t *testing.T) {
 	}
 }
 
+func TestSignVerifyWithContextString(t *testing.T) {
+	public, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("key generation error: %s", err)
+	}
+	contextString := []byte("context")
+	for _, variant := range []tinked25519.Variant{tinked25519.VariantTink, tinked25519.VariantCrunchy, tinked25519.VariantLegacy, tinked25519.VariantNoPrefix} {
+		t.Run(variant.String(), func(t *testing.T) {
+			params, err := tinked25519.NewContextStringParameters(variant)
+			if err != nil {
+				t.Fatalf("tinked25519.NewContextStringParameters(%v) err = %v, want nil", variant, err)
+			}
+			publicKey, privateKey := keyPairWithParameters(t, public, priv, params)
+			signer, err := tinked25519.NewSigner(privateKey, internalapi.Token{})
+			if err != nil {
+				t.Fatalf("tinked25519.NewSigner(%v, internalapi.Token{}) err = %v, want nil", privateKey, err)
+			}
+			verifier, err := tinked25519.NewVerifier(publicKey, internalapi.Token{})
+			if err != nil {
+				t.Fatalf("tinked25519.NewVerifier(%v, internalapi.Token{}) err = %v, want nil", publicKey, err)
+			}
+			ctxSigner, ok := signer.(tink.ContextStringSigner)
+			if !ok {
+				t.Fatalf("signer is %T, want tink.ContextStringSigner", signer)
+			}
+			ctxVerifier, ok := verifier.(tink.ContextStringVerifier)
+			if !ok {
+				t.Fatalf("verifier is %T, want tink.ContextStringVerifier", verifier)
+			}
+			data := random.GetRandomBytes(20)
+			signatureBytes, err := ctxSigner.SignWithContextString(data, contextString)
+			if err != nil {
+				t.Fatalf("ctxSigner.SignWithContextString(%x, %q) err = %v, want nil", data, contextString, err)
+			}
+			if !bytes.HasPrefix(signatureBytes, privateKey.OutputPrefix()) {
+				t.Errorf("signature %x doesn't start with output prefix %x", signatureBytes, privateKey.OutputPrefix())
+			}
+			if err := ctxVerifier.VerifyWithContextString(signatureBytes, data, contextString); err != nil {
+				t.Errorf("ctxVerifier.VerifyWithContextString(%x, %x, %q) err = %v, want nil", signatureBytes, data, contextString, err)
+			}
+			if err := ctxVerifier.VerifyWithContextString(signatureBytes, data, []byte("other context")); err == nil {
+				t.Errorf("ctxVerifier.VerifyWithContextString() with other context err = nil, want error")
+			}
+			// Ed25519ctx keys don't sign or verify without a context string.
+			if _, err := signer.Sign(data); err == nil {
+				t.Errorf("signer.Sign(%x) err = nil, want error", data)
+			}
+			if err := verifier.Verify(signatureBytes, data); err == nil {
+				t.Errorf("verifier.Verify(%x, %x) err = nil, want error", signatureBytes, data)
+			}
+		})
+	}
+}
+
+func TestPlainKeyDoesNotSignWithContextString(t *testing.T) {
+	public, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("key generation error: %s", err)
+	}
+	publicKey, privateKey := keyPair(t, public, priv, tinked25519.VariantTink)
+	signer, err := tinked25519.NewSigner(privateKey, internalapi.Token{})
+	if err != nil {
+		t.Fatalf("tinked25519.NewSigner(%v, internalapi.Token{}) err = %v, want nil", privateKey, err)
+	}
+	verifier, err := tinked25519.NewVerifier(publicKey, internalapi.Token{})
+	if err != nil {
+		t.Fatalf("tinked25519.NewVerifier(%v, internalapi.Token{}) err = %v, want nil", publicKey, err)
+	}
+	data := random.GetRandomBytes(20)
+	if _, err := signer.(tink.ContextStringSigner).SignWithContextString(data, []byte("context")); err == nil {
+		t.Errorf("SignWithContextString() err = nil, want error")
+	}
+	signatureBytes, err := signer.Sign(data)
+	if err != nil {
+		t.Fatalf("signer.Sign(%x) err = %v, want nil", data, err)
+	}
+	if err := verifier.(tink.ContextStringVerifier).VerifyWithContextString(signatureBytes, data, []byte("context")); err == nil {
+		t.Errorf("VerifyWithContextString() err = nil, want error")
+	}
+}
+
 type ed25519Suite struct {
 	testutil.WycheproofSuite
 	TestGroups []*ed25519Group `json:"testGroups"`
@@ -359,8 +441,12 @@ func keyPair(t *testing.T, public, priv []byte, variant tinked25519.Variant) (*t
 	if err != nil {
 		t.Fatalf("tinked25519.NewParameters(%v) err = %v, want nil", variant, err)
 	}
+	return keyPairWithParameters(t, public, priv, params)
+}
+
+func keyPairWithParameters(t *testing.T, public, priv []byte, params tinked25519.Parameters) (*tinked25519.PublicKey, *tinked25519.PrivateKey) {
 	idRequirement := uint32(0x01020304)
-	if variant == tinked25519.VariantNoPrefix {
+	if !params.HasIDRequirement() {
 		idRequirement = 0
 	}
 	pubKey, err := tinked25519.NewPublicKey(public, idRequirement, params)
@@ -22,51 +22,88 @@ import (
 
 	"github.com/tink-crypto/tink-go/v2/internal/internalapi"
 	"github.com/tink-crypto/tink-go/v2/key"
+	"github.com/tink-crypto/tink-go/v2/signature/subtle"
 	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 // verifier is an implementation of [tink.Verifier] for ED25519.
 type verifier struct {
-	publicKey ed25519.PublicKey
-	prefix    []byte
-	variant   Variant
+	verifier          *subtle.ED25519Verifier
+	prefix            []byte
+	variant           Variant
+	contextStringOnly bool
 }
 
-var _ tink.Verifier = (*verifier)(nil)
+var _ tink.ContextStringVerifier = (*verifier)(nil)
 
 // NewVerifier creates a new [tink.Verifier] for ED25519.
 //
 // This is an internal API.
 func NewVerifier(publicKey *PublicKey, _ internalapi.Token) (tink.Verifier, error) {
+	v, err := subtle.NewED25519Verifier(publicKey.KeyBytes())
+	if err != nil {
+		return nil, err
+	}
 	return &verifier{
-		publicKey: publicKey.KeyBytes(),
-		variant:   publicKey.params.Variant(),
-		prefix:    publicKey.OutputPrefix(),
+		verifier:          v,
+		variant:           publicKey.params.Variant(),
+		prefix:            publicKey.OutputPrefix(),
+		contextStringOnly: publicKey.params.ContextStringOnly(),
 	}, nil
 }
 
-// Verify verifies whether the given signature is valid for the given data.
-//
-// It returns an error if the prefix is not valid or the signature is not
-// valid.
-func (e *verifier) Verify(signature, data []byte) error {
+// splitSignature returns the signature without the output prefix, and the
+// message that was signed for data.
+func (e *verifier) splitSignature(signature, data []byte) ([]byte, []byte, error) {
 	if !bytes.HasPrefix(signature, e.prefix) {
-		return fmt.Errorf("ed25519: the signature doesn't have the expected prefix")
+		return nil, nil, fmt.Errorf("ed25519: the signature doesn't have the expected prefix")
 	}
 	signatureNoPrefix := signature[len(e.prefix):]
 	if len(signatureNoPrefix) != ed25519.SignatureSize {
-		return fmt.Errorf("ed25519: the length of the signature is not %d", ed25519.SignatureSize)
+		return nil, nil, fmt.Errorf("ed25519: the length of the signature is not %d", ed25519.SignatureSize)
 	}
 	signedMessage := data
 	if e.variant == VariantLegacy {
 		signedMessage = slices.Concat(data, []byte{0})
 	}
-	if !ed25519.Verify(e.publicKey, signedMessage, signatureNoPrefix) {
+	return signatureNoPrefix, signedMessage, nil
+}
+
+// Verify verifies whether the given signature is valid for the given data.
+//
+// It returns an error if the prefix is not valid or the signature is not
+// valid. It fails for Ed25519ctx keys.
+func (e *verifier) Verify(signature, data []byte) error {
+	if e.contextStringOnly {
+		return fmt.Errorf("ed25519: the key only verifies with a context string")
+	}
+	signatureNoPrefix, signedMessage, err := e.splitSignature(signature, data)
+	if err != nil {
+		return err
+	}
+	if err := e.verifier.Verify(signatureNoPrefix, signedMessage); err != nil {
 		return fmt.Errorf("ed25519: invalid signature")
 	}
 	return nil
 }
 
+// VerifyWithContextString verifies whether the given Ed25519ctx signature is
+// valid for the given data under contextString. It fails unless the key is
+// an Ed25519ctx key.
+//
+// It returns an error if the prefix is not valid or the signature is not
+// valid.
+func (e *verifier) VerifyWithContextString(signature, data, contextString []byte) error {
+	if !e.contextStringOnly {
+		return fmt.Errorf("ed25519: the key doesn't verify with a context string")
+	}
+	signatureNoPrefix, signedMessage, err := e.splitSignature(signature, data)
+	if err != nil {
+		return err
+	}
+	return e.verifier.VerifyWithContextString(signatureNoPrefix, signedMessage, contextString)
+}
+
 func verifierConstructor(key key.Key) (any, error) {
 	that, ok := key.(*PublicKey)
 	if !ok {
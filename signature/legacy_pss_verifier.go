@@ -0,0 +1,227 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/core/cryptofmt"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	internal "github.com/tink-crypto/tink-go/v2/internal/signature"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	rsassapsspb "github.com/tink-crypto/tink-go/v2/proto/rsa_ssa_pss_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+const rsaSSAPSSVerifierTypeURL = "type.googleapis.com/google.crypto.tink.RsaSsaPssPublicKey"
+
+var errInvalidLegacyPSSSignature = errors.New("legacy_pss_verifier: invalid signature")
+
+// NewLegacyPSSVerifier returns a Verifier for RSA-SSA-PSS signatures that use
+// SHA-256 as the message hash and MGF1 with SHA-1 as the mask generation
+// function.
+//
+// SECURITY WARNING: This combination is not supported by the regular Tink
+// signature primitives, which require the MGF1 hash to match the signature
+// hash. It exists only to verify signatures produced by legacy systems. Tink
+// never generates such keys and cannot sign with them; this verifier is the
+// only place where they can be used. Do not use it for new protocols, and
+// migrate the signers to a standard key as soon as possible.
+//
+// Every enabled key in handle must be an RsaSsaPssPublicKey with SHA-256 as
+// signature hash and SHA-1 as MGF1 hash; other keys are rejected. Such keys
+// can be stored in a keyset, but [NewVerifier] fails on keysets containing
+// them.
+func NewLegacyPSSVerifier(handle *keyset.Handle) (tink.Verifier, error) {
+	if handle == nil {
+		return nil, fmt.Errorf("legacy_pss_verifier: nil handle")
+	}
+	v := &legacyPSSVerifier{}
+	for i := 0; i < handle.Len(); i++ {
+		entry, err := handle.Entry(i)
+		if err != nil {
+			return nil, fmt.Errorf("legacy_pss_verifier: %v", err)
+		}
+		if entry.KeyStatus() != keyset.Enabled {
+			continue
+		}
+		k, err := newLegacyPSSKey(entry)
+		if err != nil {
+			return nil, fmt.Errorf("legacy_pss_verifier: key %d: %v", entry.KeyID(), err)
+		}
+		v.keys = append(v.keys, k)
+	}
+	if len(v.keys) == 0 {
+		return nil, fmt.Errorf("legacy_pss_verifier: keyset has no enabled keys")
+	}
+	return v, nil
+}
+
+type legacyPSSKey struct {
+	n          *big.Int
+	e          *big.Int
+	saltLength int
+	prefix     []byte
+	legacy     bool
+}
+
+func newLegacyPSSKey(entry *keyset.Entry) (*legacyPSSKey, error) {
+	keySerialization, err := protoserialization.SerializeKey(entry.Key())
+	if err != nil {
+		return nil, err
+	}
+	keyData := keySerialization.KeyData()
+	if keyData.GetTypeUrl() != rsaSSAPSSVerifierTypeURL {
+		return nil, fmt.Errorf("unsupported key type %q", keyData.GetTypeUrl())
+	}
+	pubKey := new(rsassapsspb.RsaSsaPssPublicKey)
+	if err := proto.Unmarshal(keyData.GetValue(), pubKey); err != nil {
+		return nil, err
+	}
+	params := pubKey.GetParams()
+	if params.GetSigHash() != commonpb.HashType_SHA256 || params.GetMgf1Hash() != commonpb.HashType_SHA1 {
+		return nil, fmt.Errorf("unsupported parameters: signature hash %v, MGF1 hash %v, want SHA256 and SHA1", params.GetSigHash(), params.GetMgf1Hash())
+	}
+	if params.GetSaltLength() < 0 {
+		return nil, fmt.Errorf("salt length can't be negative")
+	}
+	n := new(big.Int).SetBytes(pubKey.GetN())
+	if err := internal.ValidateRSAPublicKeyParams(params.GetSigHash(), n.BitLen(), pubKey.GetE()); err != nil {
+		return nil, err
+	}
+	prefix, err := cryptofmt.OutputPrefix(&tinkpb.Keyset_Key{
+		OutputPrefixType: keySerialization.OutputPrefixType(),
+		KeyId:            entry.KeyID(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &legacyPSSKey{
+		n:          n,
+		e:          new(big.Int).SetBytes(pubKey.GetE()),
+		saltLength: int(params.GetSaltLength()),
+		prefix:     []byte(prefix),
+		legacy:     keySerialization.OutputPrefixType() == tinkpb.OutputPrefixType_LEGACY,
+	}, nil
+}
+
+type legacyPSSVerifier struct {
+	keys []*legacyPSSKey
+}
+
+var _ tink.Verifier = (*legacyPSSVerifier)(nil)
+
+// Verify verifies that signatureBytes is a valid signature of data under one of
+// the enabled keys.
+func (v *legacyPSSVerifier) Verify(signatureBytes, data []byte) error {
+	for _, k := range v.keys {
+		if !bytes.HasPrefix(signatureBytes, k.prefix) {
+			continue
+		}
+		message := data
+		if k.legacy {
+			message = slices.Concat(data, []byte{0})
+		}
+		if k.verify(signatureBytes[len(k.prefix):], message) == nil {
+			return nil
+		}
+	}
+	return errInvalidLegacyPSSSignature
+}
+
+// verify implements RSASSA-PSS-VERIFY of RFC 8017, Section 8.1.2, with
+// SHA-256 as message hash and MGF1-SHA1 as mask generation function.
+func (k *legacyPSSKey) verify(sig, message []byte) error {
+	modBits := k.n.BitLen()
+	if len(sig) != (modBits+7)/8 {
+		return errInvalidLegacyPSSSignature
+	}
+	s := new(big.Int).SetBytes(sig)
+	if s.Cmp(k.n) >= 0 {
+		return errInvalidLegacyPSSSignature
+	}
+	m := new(big.Int).Exp(s, k.e, k.n)
+	emBits := modBits - 1
+	emLen := (emBits + 7) / 8
+	if (m.BitLen()+7)/8 > emLen {
+		return errInvalidLegacyPSSSignature
+	}
+	em := m.FillBytes(make([]byte, emLen))
+	mHash := sha256.Sum256(message)
+	return emsaPSSVerifyMGF1SHA1(mHash[:], em, emBits, k.saltLength)
+}
+
+// emsaPSSVerifyMGF1SHA1 implements EMSA-PSS-VERIFY of RFC 8017, Section 9.1.2,
+// with SHA-256 as hash function and MGF1-SHA1 as mask generation function.
+func emsaPSSVerifyMGF1SHA1(mHash, em []byte, emBits, saltLength int) error {
+	hLen := sha256.Size
+	emLen := len(em)
+	if emLen < hLen+saltLength+2 {
+		return errInvalidLegacyPSSSignature
+	}
+	if em[emLen-1] != 0xbc {
+		return errInvalidLegacyPSSSignature
+	}
+	maskedDB := em[:emLen-hLen-1]
+	h := em[emLen-hLen-1 : emLen-1]
+	unusedBits := uint(8*emLen - emBits)
+	if maskedDB[0]&^(0xff>>unusedBits) != 0 {
+		return errInvalidLegacyPSSSignature
+	}
+	db := mgf1SHA1(h, len(maskedDB))
+	subtle.XORBytes(db, db, maskedDB)
+	db[0] &= 0xff >> unusedBits
+	psLen := emLen - hLen - saltLength - 2
+	for _, b := range db[:psLen] {
+		if b != 0 {
+			return errInvalidLegacyPSSSignature
+		}
+	}
+	if db[psLen] != 0x01 {
+		return errInvalidLegacyPSSSignature
+	}
+	salt := db[len(db)-saltLength:]
+	hPrime := sha256.New()
+	hPrime.Write(make([]byte, 8))
+	hPrime.Write(mHash)
+	hPrime.Write(salt)
+	if subtle.ConstantTimeCompare(h, hPrime.Sum(nil)) != 1 {
+		return errInvalidLegacyPSSSignature
+	}
+	return nil
+}
+
+// mgf1SHA1 implements MGF1 of RFC 8017, Appendix B.2.1, with SHA-1.
+func mgf1SHA1(seed []byte, length int) []byte {
+	out := make([]byte, 0, length+sha1.Size)
+	for counter := uint32(0); len(out) < length; counter++ {
+		h := sha1.New()
+		h.Write(seed)
+		h.Write(binary.BigEndian.AppendUint32(nil, counter))
+		out = h.Sum(out)
+	}
+	return out[:length]
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/testutil"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	rsassapsspb "github.com/tink-crypto/tink-go/v2/proto/rsa_ssa_pss_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// Generated with OpenSSL 3.0:
+//
+//	openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:3072 -out key.pem
+//	openssl dgst -sha256 -sign key.pem -sigopt rsa_padding_mode:pss \
+//	  -sigopt rsa_mgf1_md:sha1 -sigopt rsa_pss_saltlen:32 msg
+const (
+	legacyPSSModulusHex = "b2d09f263c3165f1ba9b509ad894d6db7c7508e50af231eaf9920f53e9e7228487450730396b9461e811b7086efc46bc" +
+		"5f3818931afff0260de89d2e04ffa1bea145bed63182e511c38091cc855e2e0f8b1e069b75bb342b4d4725c51516fa64" +
+		"981d230194221d51ac5c01d93c6afc6460671b8c393b429acc7096d4874165ebaeb3128fd4b7b96f121a487c5d6c9dd3" +
+		"f7d5fe49fef16357123a070a4dfff4073d625febca762e79e0e5078f0771f42caa1a8b70866e7d8cabff61300c89bc95" +
+		"3671f1569e3f00c63b1881f2f506dcc1efba9a8888507e77ce7fbbaa94a39fddbabf49a9e0d84afc8da1b9ac4fe162db" +
+		"80b90e722ddaf70ca6012b2c733cd1ab059d4280434e2b98608586ae053bae524e4ab81b0f3f8e63020751dcbfa49d97" +
+		"417601428099eb15a4dec2cb27447c4a4ab4fa9befdd3fabc6bdb7088eb548f3a38b49e2d7cfbf720de4bf60af67faa2" +
+		"25edf133e8bafb49b6317e0a31489b2fe1bf0507373f49dc093e703157ff671eedeaf994dcb800f473f43cd4188992c5"
+	legacyPSSMessage = "legacy system message"
+	// legacyPSSSignatureHex is a signature of legacyPSSMessage with SHA-256 and
+	// MGF1-SHA1.
+	legacyPSSSignatureHex = "67350b92dc18b848ac10477df1470d5518d5479a2b018fda0213d0ee0386828bc7c9952ac52b9db5ea566761beac3189" +
+		"c47a019877537efd4fa6ec5969379b359b37e2ff88fe68cbec9ae818c4180cdac69f92732571c62edb88fc3af07d224c" +
+		"8c773c8c1b65547825c32d75bbfb63b9762ac7d5034af7c461eeaf94e48e28df10b78e4ef19ab655ace865f71e516a2a" +
+		"0b918a12b655b8eb48e3b69a90f27180d2a7dd090d84a75688288def4ff989f8dd0a1ecf018725fbc3389d0261778480" +
+		"cf9cedd1be88b633ff1ee5cf5b368a95aec4f32eb70b65bd0ec29f1e383ec68e1fb1420654329ff4ec07c9da6250a4ab" +
+		"9b4872d360db3149d7b04cc5f79bd6f3b6fe5d90543e64f1730008a5d1633545670b63f5153008725a9ea036a24ab534" +
+		"fc5c11f7e4708afdc46e796d03b11af5ae48050517cf3a2f92752821713948fe1d8b32cfc96bc393bfef9c057815a4ce" +
+		"b24626195c79469c7b4d9eced13e93fa929276fe9516300c1a7e9cd8938c674a23af0f61b5a04a0dc3a5885c3fc0eeda"
+	// legacyPSSSignatureMGF1SHA256Hex is a signature of legacyPSSMessage with
+	// SHA-256 and MGF1-SHA256.
+	legacyPSSSignatureMGF1SHA256Hex = "62d2ed766323cf85cccfcdf93d0bd30a44fab46136a1b3429a82df51c520fd5e51ad015f231f9abafddba22144b8d2d3" +
+		"f5c79c61eecd9936971d2575bc63e860a97f5a9160729671fab09385e76c6176b6a4fc39f5709a8fac4671fd4430a4b9" +
+		"43c49f4f3d60c7fed6cee0834c9c597bde4fd8d54af566aa3405937fd1c6eaec163cf77617fee0eadddbf1cd15b68690" +
+		"9a77cfb4ae66f8e1a26e5a1243aa0eb8bf8f0abb8af858326c4ec3077ba7e1e84ffa7a5482fb9723200904b712c34574" +
+		"a5f3b3dfad681a40e6fe1db49e44d2b5dfc4fed6676b5755d24090dbf848fc9c8b3704553002f63e4a6b6e0abcf7409c" +
+		"84be7bf1b2fb04c846c8421f95751cddd64f6b93933401976a59d90cfcfbed5ca25cd1f77968d3411ddb85a68bffb59f" +
+		"6960c3a99b1b4a8ad885e958f56cbf2cf3d55cca0733ddfb95724028c04a5be38d090b23caf6a6d68cf9ea8e48826e29" +
+		"eb1cb1395f19ea9899e095cfeaec2a38eec9c7d8836ba32b8df69bcd9f0396ddb80279e150587796b9e4dc13e85b37ed"
+)
+
+func mustHexDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString() err = %v, want nil", err)
+	}
+	return b
+}
+
+func legacyPSSPublicKeyset(t *testing.T, mgf1Hash commonpb.HashType, keyID uint32, prefixType tinkpb.OutputPrefixType) *tinkpb.Keyset {
+	t.Helper()
+	pubKey := &rsassapsspb.RsaSsaPssPublicKey{
+		Version: 0,
+		Params: &rsassapsspb.RsaSsaPssParams{
+			SigHash:    commonpb.HashType_SHA256,
+			Mgf1Hash:   mgf1Hash,
+			SaltLength: 32,
+		},
+		N: mustHexDecode(t, legacyPSSModulusHex),
+		E: []byte{0x01, 0x00, 0x01},
+	}
+	value, err := proto.Marshal(pubKey)
+	if err != nil {
+		t.Fatalf("proto.Marshal() err = %v, want nil", err)
+	}
+	keyData := testutil.NewKeyData("type.googleapis.com/google.crypto.tink.RsaSsaPssPublicKey", value, tinkpb.KeyData_ASYMMETRIC_PUBLIC)
+	return testutil.NewKeyset(keyID, []*tinkpb.Keyset_Key{testutil.NewKey(keyData, tinkpb.KeyStatusType_ENABLED, keyID, prefixType)})
+}
+
+func TestLegacyPSSVerifierVerifies(t *testing.T) {
+	message := []byte(legacyPSSMessage)
+	sig := mustHexDecode(t, legacyPSSSignatureHex)
+	for _, tc := range []struct {
+		name       string
+		prefixType tinkpb.OutputPrefixType
+		prefix     []byte
+	}{
+		{"RAW", tinkpb.OutputPrefixType_RAW, nil},
+		{"TINK", tinkpb.OutputPrefixType_TINK, []byte{0x01, 0x00, 0x00, 0x00, 0x2a}},
+		{"CRUNCHY", tinkpb.OutputPrefixType_CRUNCHY, []byte{0x00, 0x00, 0x00, 0x00, 0x2a}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handle, err := keyset.NewHandleWithNoSecrets(legacyPSSPublicKeyset(t, commonpb.HashType_SHA1, 42, tc.prefixType))
+			if err != nil {
+				t.Fatalf("keyset.NewHandleWithNoSecrets() err = %v, want nil", err)
+			}
+			v, err := signature.NewLegacyPSSVerifier(handle)
+			if err != nil {
+				t.Fatalf("signature.NewLegacyPSSVerifier() err = %v, want nil", err)
+			}
+			if err := v.Verify(slices.Concat(tc.prefix, sig), message); err != nil {
+				t.Errorf("v.Verify() err = %v, want nil", err)
+			}
+			if err := v.Verify(slices.Concat(tc.prefix, sig), []byte("other message")); err == nil {
+				t.Error("v.Verify() with other message err = nil, want error")
+			}
+			modified := slices.Concat(tc.prefix, sig)
+			modified[len(modified)-1] ^= 1
+			if err := v.Verify(modified, message); err == nil {
+				t.Error("v.Verify() with modified signature err = nil, want error")
+			}
+			mgf1SHA256Sig := mustHexDecode(t, legacyPSSSignatureMGF1SHA256Hex)
+			if err := v.Verify(slices.Concat(tc.prefix, mgf1SHA256Sig), message); err == nil {
+				t.Error("v.Verify() with MGF1-SHA256 signature err = nil, want error")
+			}
+			if len(tc.prefix) > 0 {
+				if err := v.Verify(sig, message); err == nil {
+					t.Error("v.Verify() without prefix err = nil, want error")
+				}
+			}
+		})
+	}
+}
+
+func TestLegacyPSSKeysAreRejectedByRegularVerifier(t *testing.T) {
+	handle, err := keyset.NewHandleWithNoSecrets(legacyPSSPublicKeyset(t, commonpb.HashType_SHA1, 42, tinkpb.OutputPrefixType_RAW))
+	if err != nil {
+		t.Fatalf("keyset.NewHandleWithNoSecrets() err = %v, want nil", err)
+	}
+	if _, err := signature.NewVerifier(handle); err == nil {
+		t.Error("signature.NewVerifier() err = nil, want error")
+	}
+}
+
+func TestNewLegacyPSSVerifierRejectsOtherKeys(t *testing.T) {
+	handle, err := keyset.NewHandleWithNoSecrets(legacyPSSPublicKeyset(t, commonpb.HashType_SHA256, 42, tinkpb.OutputPrefixType_RAW))
+	if err != nil {
+		t.Fatalf("keyset.NewHandleWithNoSecrets() err = %v, want nil", err)
+	}
+	if _, err := signature.NewLegacyPSSVerifier(handle); err == nil {
+		t.Error("signature.NewLegacyPSSVerifier() with MGF1-SHA256 key err = nil, want error")
+	}
+
+	privHandle, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	pubHandle, err := privHandle.Public()
+	if err != nil {
+		t.Fatalf("privHandle.Public() err = %v, want nil", err)
+	}
+	if _, err := signature.NewLegacyPSSVerifier(pubHandle); err == nil {
+		t.Error("signature.NewLegacyPSSVerifier() with Ed25519 key err = nil, want error")
+	}
+}
+
+func TestLegacyPSSKeysCannotBeGenerated(t *testing.T) {
+	keyFormat := &rsassapsspb.RsaSsaPssKeyFormat{
+		Params: &rsassapsspb.RsaSsaPssParams{
+			SigHash:    commonpb.HashType_SHA256,
+			Mgf1Hash:   commonpb.HashType_SHA1,
+			SaltLength: 32,
+		},
+		ModulusSizeInBits: 3072,
+		PublicExponent:    []byte{0x01, 0x00, 0x01},
+	}
+	serializedFormat, err := proto.Marshal(keyFormat)
+	if err != nil {
+		t.Fatalf("proto.Marshal() err = %v, want nil", err)
+	}
+	template := &tinkpb.KeyTemplate{
+		TypeUrl:          "type.googleapis.com/google.crypto.tink.RsaSsaPssPrivateKey",
+		OutputPrefixType: tinkpb.OutputPrefixType_TINK,
+		Value:            serializedFormat,
+	}
+	if _, err := keyset.NewHandle(template); err == nil {
+		t.Error("keyset.NewHandle() with MGF1-SHA1 template err = nil, want error")
+	}
+}
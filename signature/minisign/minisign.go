@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package minisign verifies Minisign signatures, as produced by
+// "minisign -S".
+//
+// The format is specified in https://jedisct1.github.io/minisign/.
+package minisign
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"github.com/tink-crypto/tink-go/v2/signature/subtle"
+)
+
+const (
+	// algorithmPrehashed signs the BLAKE2b-512 hash of the message.
+	algorithmPrehashed = "ED"
+	// algorithmLegacy signs the message itself.
+	algorithmLegacy = "Ed"
+
+	untrustedCommentPrefix = "untrusted comment: "
+	trustedCommentPrefix   = "trusted comment: "
+
+	keyIDSize         = 8
+	ed25519KeySize    = 32
+	ed25519SigSize    = 64
+	signatureBlobSize = 2 + keyIDSize + ed25519SigSize
+)
+
+// signatureFile is a parsed Minisign signature file.
+type signatureFile struct {
+	algorithm       string
+	signature       []byte
+	trustedComment  string
+	globalSignature []byte
+}
+
+// Verify checks that signatureFile is a valid Minisign signature of message
+// made with the given 32-byte Ed25519 public key.
+//
+// Both the pre-hashed ("ED") and the legacy ("Ed") algorithms are supported.
+// In addition to the signature of message, Verify checks the global signature
+// of the trusted comment. The key ID in the signature file is not checked, since
+// it is not part of publicKey.
+func Verify(publicKey []byte, signatureFile, message []byte) error {
+	if len(publicKey) != ed25519KeySize {
+		return fmt.Errorf("minisign: invalid public key size %d, want %d", len(publicKey), ed25519KeySize)
+	}
+	sig, err := parseSignatureFile(signatureFile)
+	if err != nil {
+		return err
+	}
+	verifier, err := subtle.NewED25519Verifier(publicKey)
+	if err != nil {
+		return err
+	}
+	signedMessage := message
+	switch sig.algorithm {
+	case algorithmPrehashed:
+		h := blake2b.Sum512(message)
+		signedMessage = h[:]
+	case algorithmLegacy:
+	default:
+		return fmt.Errorf("minisign: unsupported signature algorithm %q", sig.algorithm)
+	}
+	if err := verifier.Verify(sig.signature, signedMessage); err != nil {
+		return fmt.Errorf("minisign: %v", err)
+	}
+	globalSignedData := append(append([]byte{}, sig.signature...), sig.trustedComment...)
+	if err := verifier.Verify(sig.globalSignature, globalSignedData); err != nil {
+		return fmt.Errorf("minisign: invalid trusted comment signature: %v", err)
+	}
+	return nil
+}
+
+// parseSignatureFile parses a Minisign signature file, which consists of the
+// following four lines:
+//
+//	untrusted comment: <arbitrary text>
+//	base64(<signature algorithm> || <key ID> || <signature>)
+//	trusted comment: <arbitrary text>
+//	base64(<global signature>)
+func parseSignatureFile(b []byte) (*signatureFile, error) {
+	lines := strings.Split(strings.TrimRight(string(b), "\r\n"), "\n")
+	if len(lines) != 4 {
+		return nil, fmt.Errorf("minisign: signature file has %d lines, want 4", len(lines))
+	}
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	if !strings.HasPrefix(lines[0], untrustedCommentPrefix) {
+		return nil, errors.New("minisign: missing untrusted comment")
+	}
+	blob, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil {
+		return nil, fmt.Errorf("minisign: invalid base64 signature: %v", err)
+	}
+	if len(blob) != signatureBlobSize {
+		return nil, fmt.Errorf("minisign: invalid signature size %d, want %d", len(blob), signatureBlobSize)
+	}
+	trustedComment, ok := strings.CutPrefix(lines[2], trustedCommentPrefix)
+	if !ok {
+		return nil, errors.New("minisign: missing trusted comment")
+	}
+	globalSignature, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil {
+		return nil, fmt.Errorf("minisign: invalid base64 global signature: %v", err)
+	}
+	if len(globalSignature) != ed25519SigSize {
+		return nil, fmt.Errorf("minisign: invalid global signature size %d, want %d", len(globalSignature), ed25519SigSize)
+	}
+	return &signatureFile{
+		algorithm:       string(blob[:2]),
+		signature:       blob[2+keyIDSize:],
+		trustedComment:  trustedComment,
+		globalSignature: globalSignature,
+	}, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minisign_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+	"github.com/tink-crypto/tink-go/v2/signature/minisign"
+)
+
+var keyID = []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+
+// sign returns a Minisign signature file of message with privateKey.
+func sign(privateKey ed25519.PrivateKey, algorithm string, message []byte, trustedComment string) []byte {
+	signedMessage := message
+	if algorithm == "ED" {
+		h := blake2b.Sum512(message)
+		signedMessage = h[:]
+	}
+	sig := ed25519.Sign(privateKey, signedMessage)
+	blob := append(append([]byte(algorithm), keyID...), sig...)
+	globalSig := ed25519.Sign(privateKey, append(append([]byte{}, sig...), trustedComment...))
+	var b strings.Builder
+	b.WriteString("untrusted comment: signature from minisign secret key\n")
+	b.WriteString(base64.StdEncoding.EncodeToString(blob) + "\n")
+	b.WriteString("trusted comment: " + trustedComment + "\n")
+	b.WriteString(base64.StdEncoding.EncodeToString(globalSig) + "\n")
+	return []byte(b.String())
+}
+
+func newKey(seedByte byte) (ed25519.PublicKey, ed25519.PrivateKey) {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seedByte}, ed25519.SeedSize))
+	return privateKey.Public().(ed25519.PublicKey), privateKey
+}
+
+func TestVerify(t *testing.T) {
+	publicKey, privateKey := newKey(0x01)
+	message := []byte("release artifact")
+	trustedComment := "timestamp:1700000000\tfile:artifact.tar.gz"
+	for _, tc := range []struct {
+		name          string
+		signatureFile []byte
+	}{
+		{
+			name:          "prehashed",
+			signatureFile: sign(privateKey, "ED", message, trustedComment),
+		},
+		{
+			name:          "legacy",
+			signatureFile: sign(privateKey, "Ed", message, trustedComment),
+		},
+		{
+			name:          "CRLF line endings",
+			signatureFile: bytes.ReplaceAll(sign(privateKey, "ED", message, trustedComment), []byte("\n"), []byte("\r\n")),
+		},
+		{
+			name:          "no trailing newline",
+			signatureFile: bytes.TrimSuffix(sign(privateKey, "ED", message, trustedComment), []byte("\n")),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := minisign.Verify(publicKey, tc.signatureFile, message); err != nil {
+				t.Errorf("minisign.Verify() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestVerifyFails(t *testing.T) {
+	publicKey, privateKey := newKey(0x01)
+	otherPublicKey, _ := newKey(0x02)
+	message := []byte("release artifact")
+	trustedComment := "timestamp:1700000000"
+	prehashed := sign(privateKey, "ED", message, trustedComment)
+	lines := strings.Split(string(prehashed), "\n")
+
+	// A legacy signature relabeled as pre-hashed, and vice versa.
+	legacyLines := strings.Split(string(sign(privateKey, "Ed", message, trustedComment)), "\n")
+	blob, err := base64.StdEncoding.DecodeString(legacyLines[1])
+	if err != nil {
+		t.Fatalf("base64.StdEncoding.DecodeString() err = %v, want nil", err)
+	}
+	blob[1] = 'D'
+	legacyAsPrehashed := strings.Join([]string{legacyLines[0], base64.StdEncoding.EncodeToString(blob), legacyLines[2], legacyLines[3]}, "\n")
+	blob[0], blob[1] = 'X', 'X'
+	unknownAlgorithm := strings.Join([]string{legacyLines[0], base64.StdEncoding.EncodeToString(blob), legacyLines[2], legacyLines[3]}, "\n")
+
+	for _, tc := range []struct {
+		name          string
+		publicKey     []byte
+		signatureFile string
+		message       []byte
+	}{
+		{
+			name:          "wrong message",
+			publicKey:     publicKey,
+			signatureFile: string(prehashed),
+			message:       []byte("other artifact"),
+		},
+		{
+			name:          "wrong key",
+			publicKey:     otherPublicKey,
+			signatureFile: string(prehashed),
+			message:       message,
+		},
+		{
+			name:          "invalid key size",
+			publicKey:     publicKey[:31],
+			signatureFile: string(prehashed),
+			message:       message,
+		},
+		{
+			name:          "modified trusted comment",
+			publicKey:     publicKey,
+			signatureFile: strings.Join([]string{lines[0], lines[1], "trusted comment: timestamp:1800000000", lines[3]}, "\n"),
+			message:       message,
+		},
+		{
+			name:          "legacy signature labeled as prehashed",
+			publicKey:     publicKey,
+			signatureFile: legacyAsPrehashed,
+			message:       message,
+		},
+		{
+			name:          "unknown algorithm",
+			publicKey:     publicKey,
+			signatureFile: unknownAlgorithm,
+			message:       message,
+		},
+		{
+			name:          "missing untrusted comment",
+			publicKey:     publicKey,
+			signatureFile: strings.Join([]string{"comment", lines[1], lines[2], lines[3]}, "\n"),
+			message:       message,
+		},
+		{
+			name:          "missing trusted comment",
+			publicKey:     publicKey,
+			signatureFile: strings.Join([]string{lines[0], lines[1], "comment", lines[3]}, "\n"),
+			message:       message,
+		},
+		{
+			name:          "missing global signature",
+			publicKey:     publicKey,
+			signatureFile: strings.Join(lines[:3], "\n"),
+			message:       message,
+		},
+		{
+			name:          "invalid base64",
+			publicKey:     publicKey,
+			signatureFile: strings.Join([]string{lines[0], "!" + lines[1][1:], lines[2], lines[3]}, "\n"),
+			message:       message,
+		},
+		{
+			name:          "truncated signature",
+			publicKey:     publicKey,
+			signatureFile: strings.Join([]string{lines[0], base64.StdEncoding.EncodeToString(blob[:len(blob)-1]), lines[2], lines[3]}, "\n"),
+			message:       message,
+		},
+		{
+			name:          "empty",
+			publicKey:     publicKey,
+			signatureFile: "",
+			message:       message,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := minisign.Verify(tc.publicKey, []byte(tc.signatureFile), tc.message); err == nil {
+				t.Errorf("minisign.Verify() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
+	ed25519pb "github.com/tink-crypto/tink-go/v2/proto/ed25519_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+const (
+	ecdsaVerifierTypeURL   = "type.googleapis.com/google.crypto.tink.EcdsaPublicKey"
+	ed25519VerifierTypeURL = "type.googleapis.com/google.crypto.tink.Ed25519PublicKey"
+)
+
+// VerifierKeysetFromPEMBundle returns a keyset handle with one public key for
+// each "PUBLIC KEY" block of the given concatenated PEM blocks. The keys get
+// the given output prefix type and the IDs 1, 2, 3, ... in the order of the
+// blocks, and the first key is the primary key.
+//
+// Each block must contain a DER encoded SubjectPublicKeyInfo of one of the
+// following keys:
+//   - An ECDSA key on NIST P-256, P-384 or P-521. It verifies DER encoded
+//     signatures with SHA256, SHA384 or SHA512 respectively.
+//   - An Ed25519 key.
+//
+// RSA keys are not supported, since the signature scheme and hash function
+// cannot be inferred from the key.
+//
+// If any block is malformed or unsupported, VerifierKeysetFromPEMBundle
+// returns an error that describes all such blocks.
+func VerifierKeysetFromPEMBundle(pemBundle []byte, prefix tinkpb.OutputPrefixType) (*keyset.Handle, error) {
+	if err := validateOutputPrefixType(prefix); err != nil {
+		return nil, fmt.Errorf("signature.VerifierKeysetFromPEMBundle: %v", err)
+	}
+	ks := &tinkpb.Keyset{}
+	var errs []error
+	rest := pemBundle
+	for i := 0; ; i++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		keyID := uint32(i + 1)
+		keyData, err := verifierKeyDataFromPEMBlock(block, prefix, keyID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("PEM block %d: %v", i, err))
+			continue
+		}
+		ks.Key = append(ks.Key, &tinkpb.Keyset_Key{
+			KeyData:          keyData,
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			KeyId:            keyID,
+			OutputPrefixType: prefix,
+		})
+	}
+	if len(bytes.TrimSpace(rest)) != 0 {
+		errs = append(errs, errors.New("trailing data after the last PEM block"))
+	}
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("signature.VerifierKeysetFromPEMBundle: %w", errors.Join(errs...))
+	}
+	if len(ks.GetKey()) == 0 {
+		return nil, errors.New("signature.VerifierKeysetFromPEMBundle: no PEM blocks found")
+	}
+	ks.PrimaryKeyId = ks.GetKey()[0].GetKeyId()
+	handle, err := keyset.NewHandleWithNoSecrets(ks)
+	if err != nil {
+		return nil, fmt.Errorf("signature.VerifierKeysetFromPEMBundle: %v", err)
+	}
+	return handle, nil
+}
+
+// verifierKeyDataFromPEMBlock returns the Tink public key data for the public
+// key in block.
+func verifierKeyDataFromPEMBlock(block *pem.Block, prefix tinkpb.OutputPrefixType, keyID uint32) (*tinkpb.KeyData, error) {
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	var typeURL string
+	var protoKey proto.Message
+	switch k := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		curve, hash, err := ecdsaCurveAndHash(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		coordinateSize := (k.Curve.Params().BitSize + 7) / 8
+		typeURL = ecdsaVerifierTypeURL
+		protoKey = &ecdsapb.EcdsaPublicKey{
+			Version: 0,
+			Params: &ecdsapb.EcdsaParams{
+				HashType: hash,
+				Curve:    curve,
+				Encoding: ecdsapb.EcdsaSignatureEncoding_DER,
+			},
+			X: k.X.FillBytes(make([]byte, coordinateSize)),
+			Y: k.Y.FillBytes(make([]byte, coordinateSize)),
+		}
+	case ed25519.PublicKey:
+		typeURL = ed25519VerifierTypeURL
+		protoKey = &ed25519pb.Ed25519PublicKey{
+			Version:  0,
+			KeyValue: k,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	serializedKey, err := proto.Marshal(protoKey)
+	if err != nil {
+		return nil, err
+	}
+	keyData := &tinkpb.KeyData{
+		TypeUrl:         typeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}
+	var idRequirement uint32
+	if prefix != tinkpb.OutputPrefixType_RAW {
+		idRequirement = keyID
+	}
+	keySerialization, err := protoserialization.NewKeySerialization(keyData, prefix, idRequirement)
+	if err != nil {
+		return nil, err
+	}
+	// Parsing validates the key, e.g. that the point is on the curve.
+	if _, err := protoserialization.ParseKey(keySerialization); err != nil {
+		return nil, err
+	}
+	return keyData, nil
+}
+
+func ecdsaCurveAndHash(curve elliptic.Curve) (commonpb.EllipticCurveType, commonpb.HashType, error) {
+	switch curve {
+	case elliptic.P256():
+		return commonpb.EllipticCurveType_NIST_P256, commonpb.HashType_SHA256, nil
+	case elliptic.P384():
+		return commonpb.EllipticCurveType_NIST_P384, commonpb.HashType_SHA384, nil
+	case elliptic.P521():
+		return commonpb.EllipticCurveType_NIST_P521, commonpb.HashType_SHA512, nil
+	default:
+		return commonpb.EllipticCurveType_UNKNOWN_CURVE, commonpb.HashType_UNKNOWN_HASH, fmt.Errorf("unsupported curve %s", curve.Params().Name)
+	}
+}
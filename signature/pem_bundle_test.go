@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/signature"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func publicKeyPEM(t *testing.T, publicKey crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKIXPublicKey() err = %v, want nil", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestVerifierKeysetFromPEMBundle(t *testing.T) {
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	ed25519PublicKey, ed25519PrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() err = %v, want nil", err)
+	}
+	var bundle []byte
+	bundle = append(bundle, publicKeyPEM(t, &p256Key.PublicKey)...)
+	bundle = append(bundle, publicKeyPEM(t, &p384Key.PublicKey)...)
+	bundle = append(bundle, publicKeyPEM(t, ed25519PublicKey)...)
+
+	data := []byte("data")
+	p256Digest := sha256.Sum256(data)
+	p256Sig, err := ecdsa.SignASN1(rand.Reader, p256Key, p256Digest[:])
+	if err != nil {
+		t.Fatalf("ecdsa.SignASN1() err = %v, want nil", err)
+	}
+	p384Digest := sha512.Sum384(data)
+	p384Sig, err := ecdsa.SignASN1(rand.Reader, p384Key, p384Digest[:])
+	if err != nil {
+		t.Fatalf("ecdsa.SignASN1() err = %v, want nil", err)
+	}
+	rawSigs := [][]byte{p256Sig, p384Sig, ed25519.Sign(ed25519PrivateKey, data)}
+
+	for _, tc := range []struct {
+		prefix    tinkpb.OutputPrefixType
+		sigPrefix func(keyID uint32) []byte
+	}{
+		{
+			prefix:    tinkpb.OutputPrefixType_RAW,
+			sigPrefix: func(uint32) []byte { return nil },
+		},
+		{
+			prefix:    tinkpb.OutputPrefixType_TINK,
+			sigPrefix: func(keyID uint32) []byte { return binary.BigEndian.AppendUint32([]byte{0x01}, keyID) },
+		},
+	} {
+		t.Run(tc.prefix.String(), func(t *testing.T) {
+			handle, err := signature.VerifierKeysetFromPEMBundle(bundle, tc.prefix)
+			if err != nil {
+				t.Fatalf("signature.VerifierKeysetFromPEMBundle() err = %v, want nil", err)
+			}
+			if got, want := handle.Len(), 3; got != want {
+				t.Fatalf("handle.Len() = %d, want %d", got, want)
+			}
+			primary, err := handle.Primary()
+			if err != nil {
+				t.Fatalf("handle.Primary() err = %v, want nil", err)
+			}
+			if got, want := primary.KeyID(), uint32(1); got != want {
+				t.Errorf("primary.KeyID() = %d, want %d", got, want)
+			}
+			verifier, err := signature.NewVerifier(handle)
+			if err != nil {
+				t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+			}
+			for i, rawSig := range rawSigs {
+				entry, err := handle.Entry(i)
+				if err != nil {
+					t.Fatalf("handle.Entry(%d) err = %v, want nil", i, err)
+				}
+				keyID := uint32(i + 1)
+				if entry.KeyID() != keyID {
+					t.Errorf("handle.Entry(%d).KeyID() = %d, want %d", i, entry.KeyID(), keyID)
+				}
+				sig := append(tc.sigPrefix(keyID), rawSig...)
+				if err := verifier.Verify(sig, data); err != nil {
+					t.Errorf("verifier.Verify() with key %d err = %v, want nil", keyID, err)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifierKeysetFromPEMBundleFails(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v, want nil", err)
+	}
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v, want nil", err)
+	}
+	validBlock := publicKeyPEM(t, &ecdsaKey.PublicKey)
+	malformedBlock := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("not a key")})
+	certificateBlock := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("certificate")})
+	rsaBlock := publicKeyPEM(t, &rsaKey.PublicKey)
+	p224Block := publicKeyPEM(t, &p224Key.PublicKey)
+
+	var bundle []byte
+	for _, block := range [][]byte{validBlock, malformedBlock, certificateBlock, rsaBlock, p224Block} {
+		bundle = append(bundle, block...)
+	}
+	_, err = signature.VerifierKeysetFromPEMBundle(bundle, tinkpb.OutputPrefixType_TINK)
+	if err == nil {
+		t.Fatalf("signature.VerifierKeysetFromPEMBundle() err = nil, want error")
+	}
+	for _, want := range []string{"PEM block 1", "PEM block 2", "PEM block 3", "PEM block 4"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("signature.VerifierKeysetFromPEMBundle() err = %v, want it to mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "PEM block 0") {
+		t.Errorf("signature.VerifierKeysetFromPEMBundle() err = %v, want it to not mention the valid block", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		bundle []byte
+		prefix tinkpb.OutputPrefixType
+	}{
+		{
+			name:   "empty bundle",
+			bundle: nil,
+			prefix: tinkpb.OutputPrefixType_TINK,
+		},
+		{
+			name:   "trailing data",
+			bundle: append(append([]byte{}, validBlock...), "trailing data"...),
+			prefix: tinkpb.OutputPrefixType_TINK,
+		},
+		{
+			name:   "unknown prefix type",
+			bundle: validBlock,
+			prefix: tinkpb.OutputPrefixType_UNKNOWN_PREFIX,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.VerifierKeysetFromPEMBundle(tc.bundle, tc.prefix); err == nil {
+				t.Errorf("signature.VerifierKeysetFromPEMBundle() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+const rsaSSAPKCS1VerifierTypeURL = "type.googleapis.com/google.crypto.tink.RsaSsaPkcs1PublicKey"
+
+// verifierTypeURLByAlgorithm maps the algorithm names accepted by
+// [PublicKeysByAlgorithm] to the type URL of their public keys.
+var verifierTypeURLByAlgorithm = map[string]string{
+	"ECDSA":         ecdsaVerifierTypeURL,
+	"ED25519":       ed25519VerifierTypeURL,
+	"RSA_SSA_PKCS1": rsaSSAPKCS1VerifierTypeURL,
+	"RSA_SSA_PSS":   rsaSSAPSSVerifierTypeURL,
+}
+
+// PublicKeysByAlgorithm returns a keyset handle that contains only the enabled
+// keys of the public keyset handle that belong to the given algorithm family,
+// which is one of "ECDSA", "ED25519", "RSA_SSA_PKCS1" or "RSA_SSA_PSS".
+//
+// The keys keep their IDs and output prefix types. If the primary key of
+// handle belongs to the algorithm family, it stays the primary key; otherwise
+// the first matching key becomes the primary key.
+//
+// It returns an error if handle contains secret key material, or if no
+// enabled key matches.
+func PublicKeysByAlgorithm(handle *keyset.Handle, algorithm string) (*keyset.Handle, error) {
+	typeURL, ok := verifierTypeURLByAlgorithm[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("signature.PublicKeysByAlgorithm: unsupported algorithm %q", algorithm)
+	}
+	mem := &keyset.MemReaderWriter{}
+	if err := handle.WriteWithNoSecrets(mem); err != nil {
+		return nil, fmt.Errorf("signature.PublicKeysByAlgorithm: %v", err)
+	}
+	ks := &tinkpb.Keyset{}
+	for _, key := range mem.Keyset.GetKey() {
+		if key.GetStatus() != tinkpb.KeyStatusType_ENABLED || key.GetKeyData().GetTypeUrl() != typeURL {
+			continue
+		}
+		if len(ks.GetKey()) == 0 || key.GetKeyId() == mem.Keyset.GetPrimaryKeyId() {
+			ks.PrimaryKeyId = key.GetKeyId()
+		}
+		ks.Key = append(ks.Key, key)
+	}
+	if len(ks.GetKey()) == 0 {
+		return nil, fmt.Errorf("signature.PublicKeysByAlgorithm: no enabled %s keys found", algorithm)
+	}
+	filtered, err := keyset.NewHandleWithNoSecrets(ks)
+	if err != nil {
+		return nil, fmt.Errorf("signature.PublicKeysByAlgorithm: %v", err)
+	}
+	return filtered, nil
+}
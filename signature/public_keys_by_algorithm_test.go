@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// newMixedPublicHandle returns a private and a public keyset handle with an
+// ECDSA primary key, an Ed25519 key, a disabled Ed25519 key and an
+// RSA-SSA-PKCS1 key, and the IDs of the keys in this order.
+func newMixedPublicHandle(t *testing.T) (*keyset.Handle, *keyset.Handle, []uint32) {
+	t.Helper()
+	manager := keyset.NewManager()
+	var ids []uint32
+	for _, template := range []*tinkpb.KeyTemplate{
+		signature.ECDSAP256KeyTemplate(),
+		signature.ED25519KeyTemplate(),
+		signature.ED25519KeyWithoutPrefixTemplate(),
+		signature.RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template(),
+	} {
+		id, err := manager.Add(template)
+		if err != nil {
+			t.Fatalf("manager.Add() err = %v, want nil", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := manager.SetPrimary(ids[0]); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	if err := manager.Disable(ids[2]); err != nil {
+		t.Fatalf("manager.Disable() err = %v, want nil", err)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	return handle, publicHandle, ids
+}
+
+func TestPublicKeysByAlgorithm(t *testing.T) {
+	_, publicHandle, ids := newMixedPublicHandle(t)
+	for _, tc := range []struct {
+		algorithm   string
+		wantIDs     []uint32
+		wantPrimary uint32
+	}{
+		{"ECDSA", []uint32{ids[0]}, ids[0]},
+		{"ED25519", []uint32{ids[1]}, ids[1]},
+		{"RSA_SSA_PKCS1", []uint32{ids[3]}, ids[3]},
+	} {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			got, err := signature.PublicKeysByAlgorithm(publicHandle, tc.algorithm)
+			if err != nil {
+				t.Fatalf("signature.PublicKeysByAlgorithm() err = %v, want nil", err)
+			}
+			var gotIDs []uint32
+			for i := 0; i < got.Len(); i++ {
+				entry, err := got.Entry(i)
+				if err != nil {
+					t.Fatalf("got.Entry(%d) err = %v, want nil", i, err)
+				}
+				gotIDs = append(gotIDs, entry.KeyID())
+			}
+			if diff := cmp.Diff(tc.wantIDs, gotIDs); diff != "" {
+				t.Errorf("signature.PublicKeysByAlgorithm() key IDs diff (-want +got):\n%s", diff)
+			}
+			primary, err := got.Primary()
+			if err != nil {
+				t.Fatalf("got.Primary() err = %v, want nil", err)
+			}
+			if primary.KeyID() != tc.wantPrimary {
+				t.Errorf("primary.KeyID() = %d, want %d", primary.KeyID(), tc.wantPrimary)
+			}
+		})
+	}
+}
+
+func TestPublicKeysByAlgorithmVerifiesSignatures(t *testing.T) {
+	manager := keyset.NewManager()
+	ecdsaID, err := manager.Add(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	ed25519ID, err := manager.Add(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(ecdsaID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	// Sign with the Ed25519 key, which is not the primary key of handle.
+	if err := manager.SetPrimary(ed25519ID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	ed25519Handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	signer, err := signature.NewSigner(ed25519Handle)
+	if err != nil {
+		t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	sig, err := signer.Sign(data)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+
+	ed25519Keys, err := signature.PublicKeysByAlgorithm(publicHandle, "ED25519")
+	if err != nil {
+		t.Fatalf("signature.PublicKeysByAlgorithm() err = %v, want nil", err)
+	}
+	verifier, err := signature.NewVerifier(ed25519Keys)
+	if err != nil {
+		t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+	}
+	if err := verifier.Verify(sig, data); err != nil {
+		t.Errorf("verifier.Verify() err = %v, want nil", err)
+	}
+
+	ecdsaKeys, err := signature.PublicKeysByAlgorithm(publicHandle, "ECDSA")
+	if err != nil {
+		t.Fatalf("signature.PublicKeysByAlgorithm() err = %v, want nil", err)
+	}
+	ecdsaVerifier, err := signature.NewVerifier(ecdsaKeys)
+	if err != nil {
+		t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+	}
+	if err := ecdsaVerifier.Verify(sig, data); err == nil {
+		t.Error("ecdsaVerifier.Verify() err = nil, want error")
+	}
+}
+
+func TestPublicKeysByAlgorithmFails(t *testing.T) {
+	handle, publicHandle, _ := newMixedPublicHandle(t)
+	for _, tc := range []struct {
+		name      string
+		handle    *keyset.Handle
+		algorithm string
+	}{
+		{"no matching keys", publicHandle, "RSA_SSA_PSS"},
+		{"unknown algorithm", publicHandle, "RSA"},
+		{"private keyset", handle, "ECDSA"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.PublicKeysByAlgorithm(tc.handle, tc.algorithm); err == nil {
+				t.Error("signature.PublicKeysByAlgorithm() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+// publishedKeysetSignatureContext is prepended to the payload before signing,
+// so that a signature made by the root signer for another purpose cannot be
+// mistaken for a published keyset signature.
+var publishedKeysetSignatureContext = []byte("tink-go published verifier keyset v1\x00")
+
+// publishedKeyset is the JSON encoding of a published keyset.
+type publishedKeyset struct {
+	// Payload is the JSON encoding of a publishedKeysetPayload. It is kept as
+	// bytes so that the signature is verified over exactly what was signed.
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+type publishedKeysetPayload struct {
+	// Keyset is the binary serialization of the public keyset.
+	Keyset   []byte            `json:"keyset"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// PublishVerifierKeyset returns a blob that contains the public keyset of
+// pubHandle and metadata, such as the issuer, validity period or purpose of
+// the keyset, signed together with rootSigner.
+//
+// The blob can be distributed over untrusted channels; consumers use
+// [LoadPublishedKeyset] with the verifier of rootSigner to check its
+// provenance. pubHandle must not contain secret key material.
+func PublishVerifierKeyset(pubHandle *keyset.Handle, rootSigner tink.Signer, metadata map[string]string) ([]byte, error) {
+	serializedKeyset := &bytes.Buffer{}
+	if err := pubHandle.WriteWithNoSecrets(keyset.NewBinaryWriter(serializedKeyset)); err != nil {
+		return nil, fmt.Errorf("signature.PublishVerifierKeyset: %v", err)
+	}
+	payload, err := json.Marshal(&publishedKeysetPayload{
+		Keyset:   serializedKeyset.Bytes(),
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signature.PublishVerifierKeyset: %v", err)
+	}
+	sig, err := rootSigner.Sign(slices.Concat(publishedKeysetSignatureContext, payload))
+	if err != nil {
+		return nil, fmt.Errorf("signature.PublishVerifierKeyset: %v", err)
+	}
+	blob, err := json.Marshal(&publishedKeyset{Payload: payload, Signature: sig})
+	if err != nil {
+		return nil, fmt.Errorf("signature.PublishVerifierKeyset: %v", err)
+	}
+	return blob, nil
+}
+
+// LoadPublishedKeyset verifies the signature of a blob created by
+// [PublishVerifierKeyset] with rootVerifier, and returns the published keyset
+// handle and metadata.
+//
+// Nothing in the blob is returned unless the signature is valid. Checking the
+// metadata, for example that the keyset is not expired, is up to the caller.
+func LoadPublishedKeyset(blob []byte, rootVerifier tink.Verifier) (*keyset.Handle, map[string]string, error) {
+	published := &publishedKeyset{}
+	if err := json.Unmarshal(blob, published); err != nil {
+		return nil, nil, fmt.Errorf("signature.LoadPublishedKeyset: %v", err)
+	}
+	if len(published.Payload) == 0 || len(published.Signature) == 0 {
+		return nil, nil, errors.New("signature.LoadPublishedKeyset: missing payload or signature")
+	}
+	if err := rootVerifier.Verify(published.Signature, slices.Concat(publishedKeysetSignatureContext, published.Payload)); err != nil {
+		return nil, nil, fmt.Errorf("signature.LoadPublishedKeyset: invalid signature: %v", err)
+	}
+	payload := &publishedKeysetPayload{}
+	if err := json.Unmarshal(published.Payload, payload); err != nil {
+		return nil, nil, fmt.Errorf("signature.LoadPublishedKeyset: %v", err)
+	}
+	handle, err := keyset.ReadWithNoSecrets(keyset.NewBinaryReader(bytes.NewReader(payload.Keyset)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("signature.LoadPublishedKeyset: %v", err)
+	}
+	return handle, payload.Metadata, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/tink"
+)
+
+func newRootSignerAndVerifier(t *testing.T) (tink.Signer, tink.Verifier) {
+	t.Helper()
+	handle, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	signer, err := signature.NewSigner(handle)
+	if err != nil {
+		t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+	}
+	verifier, err := signature.NewVerifier(publicHandle)
+	if err != nil {
+		t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+	}
+	return signer, verifier
+}
+
+func newPublicHandle(t *testing.T) (*keyset.Handle, *keyset.Handle) {
+	t.Helper()
+	handle, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	return handle, publicHandle
+}
+
+func TestPublishAndLoadVerifierKeyset(t *testing.T) {
+	rootSigner, rootVerifier := newRootSignerAndVerifier(t)
+	handle, publicHandle := newPublicHandle(t)
+	metadata := map[string]string{
+		"issuer":     "example.com",
+		"not_after":  "2030-01-01T00:00:00Z",
+		"purpose":    "firmware signing",
+		"not_before": "2026-01-01T00:00:00Z",
+	}
+	blob, err := signature.PublishVerifierKeyset(publicHandle, rootSigner, metadata)
+	if err != nil {
+		t.Fatalf("signature.PublishVerifierKeyset() err = %v, want nil", err)
+	}
+	gotHandle, gotMetadata, err := signature.LoadPublishedKeyset(blob, rootVerifier)
+	if err != nil {
+		t.Fatalf("signature.LoadPublishedKeyset() err = %v, want nil", err)
+	}
+	if diff := cmp.Diff(metadata, gotMetadata); diff != "" {
+		t.Errorf("signature.LoadPublishedKeyset() metadata diff (-want +got):\n%s", diff)
+	}
+
+	// The loaded keyset verifies signatures of the original private keyset.
+	signer, err := signature.NewSigner(handle)
+	if err != nil {
+		t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+	}
+	verifier, err := signature.NewVerifier(gotHandle)
+	if err != nil {
+		t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	sig, err := signer.Sign(data)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	if err := verifier.Verify(sig, data); err != nil {
+		t.Errorf("verifier.Verify() err = %v, want nil", err)
+	}
+}
+
+func TestPublishVerifierKeysetFailsWithPrivateKeyset(t *testing.T) {
+	rootSigner, _ := newRootSignerAndVerifier(t)
+	handle, _ := newPublicHandle(t)
+	if _, err := signature.PublishVerifierKeyset(handle, rootSigner, nil); err == nil {
+		t.Error("signature.PublishVerifierKeyset() err = nil, want error")
+	}
+}
+
+func TestLoadPublishedKeysetFails(t *testing.T) {
+	rootSigner, rootVerifier := newRootSignerAndVerifier(t)
+	_, otherRootVerifier := newRootSignerAndVerifier(t)
+	_, publicHandle := newPublicHandle(t)
+	blob, err := signature.PublishVerifierKeyset(publicHandle, rootSigner, map[string]string{"issuer": "example.com"})
+	if err != nil {
+		t.Fatalf("signature.PublishVerifierKeyset() err = %v, want nil", err)
+	}
+
+	var published struct {
+		Payload   []byte `json:"payload"`
+		Signature []byte `json:"signature"`
+	}
+	if err := json.Unmarshal(blob, &published); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v, want nil", err)
+	}
+	changedMetadata := published
+	changedMetadata.Payload = bytes.Replace(published.Payload, []byte("example.com"), []byte("example.org"), 1)
+	changedMetadataBlob, err := json.Marshal(changedMetadata)
+	if err != nil {
+		t.Fatalf("json.Marshal() err = %v, want nil", err)
+	}
+	// A signature by the root signer over the payload alone, without the
+	// signature context.
+	rawSignature := published
+	rawSignature.Signature, err = rootSigner.Sign(published.Payload)
+	if err != nil {
+		t.Fatalf("rootSigner.Sign() err = %v, want nil", err)
+	}
+	rawSignatureBlob, err := json.Marshal(rawSignature)
+	if err != nil {
+		t.Fatalf("json.Marshal() err = %v, want nil", err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		blob     []byte
+		verifier tink.Verifier
+	}{
+		{"wrong root verifier", blob, otherRootVerifier},
+		{"changed metadata", changedMetadataBlob, rootVerifier},
+		{"signature without context", rawSignatureBlob, rootVerifier},
+		{"not JSON", []byte("blob"), rootVerifier},
+		{"missing signature", []byte(`{"payload":"e30="}`), rootVerifier},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := signature.LoadPublishedKeyset(tc.blob, tc.verifier); err == nil {
+				t.Error("signature.LoadPublishedKeyset() err = nil, want error")
+			}
+		})
+	}
+}
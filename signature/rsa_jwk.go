@@ -0,0 +1,485 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/internal/protoserialization"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/subtle/random"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	rsassapkcs1pb "github.com/tink-crypto/tink-go/v2/proto/rsa_ssa_pkcs1_go_proto"
+	rsassapsspb "github.com/tink-crypto/tink-go/v2/proto/rsa_ssa_pss_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// rsaPrivateJWK holds the members of an RSA private JWK, see
+// https://www.rfc-editor.org/rfc/rfc7518#section-6.3.
+type rsaPrivateJWK struct {
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	D   string `json:"d"`
+	P   string `json:"p"`
+	Q   string `json:"q"`
+	DP  string `json:"dp"`
+	DQ  string `json:"dq"`
+	QI  string `json:"qi"`
+}
+
+// RSASignerFromJWK returns a keyset handle containing a single RSA private
+// key imported from an RSA private JWK (RFC 7517 and RFC 7518, Section 6.3),
+// including its CRT parameters p, q, dp, dq and qi.
+//
+// The signature scheme is selected by the "alg" member:
+//   - RS256, RS384 and RS512 give an RSA-SSA-PKCS1 key with SHA256, SHA384
+//     and SHA512 respectively.
+//   - PS256, PS384 and PS512 give an RSA-SSA-PSS key with SHA256, SHA384 and
+//     SHA512 respectively, used both for the signature and for MGF1, and a
+//     salt as long as the hash, as required by RFC 7518.
+//
+// It returns an error if any member is missing, or if p and q are not the
+// factors of n, or if d, dp, dq and qi are not consistent with them. JWKs
+// with more than two primes ("oth") are not supported.
+func RSASignerFromJWK(jwk []byte, prefix tinkpb.OutputPrefixType) (*keyset.Handle, error) {
+	if err := validateOutputPrefixType(prefix); err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: %v", err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(jwk, &raw); err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: invalid JWK: %v", err)
+	}
+	if _, ok := raw["oth"]; ok {
+		return nil, errors.New("signature.RSASignerFromJWK: multi-prime RSA keys are not supported")
+	}
+	var k rsaPrivateJWK
+	if err := json.Unmarshal(jwk, &k); err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: invalid JWK: %v", err)
+	}
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: unsupported key type %q", k.Kty)
+	}
+	members := []struct {
+		name  string
+		value string
+	}{
+		{"n", k.N}, {"e", k.E}, {"d", k.D}, {"p", k.P}, {"q", k.Q}, {"dp", k.DP}, {"dq", k.DQ}, {"qi", k.QI},
+	}
+	values := make(map[string][]byte, len(members))
+	for _, m := range members {
+		if m.value == "" {
+			return nil, fmt.Errorf("signature.RSASignerFromJWK: missing member %q", m.name)
+		}
+		b, err := base64.RawURLEncoding.DecodeString(m.value)
+		if err != nil {
+			return nil, fmt.Errorf("signature.RSASignerFromJWK: invalid member %q: %v", m.name, err)
+		}
+		values[m.name] = b
+	}
+	if err := validateRSACRTParameters(values); err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: %v", err)
+	}
+
+	var typeURL string
+	var protoKey proto.Message
+	switch k.Alg {
+	case "RS256", "RS384", "RS512":
+		typeURL = rsaSSAPKCS1SignerTypeURL
+		protoKey = &rsassapkcs1pb.RsaSsaPkcs1PrivateKey{
+			Version: 0,
+			PublicKey: &rsassapkcs1pb.RsaSsaPkcs1PublicKey{
+				Version: 0,
+				Params:  &rsassapkcs1pb.RsaSsaPkcs1Params{HashType: jwkAlgHashType(k.Alg)},
+				N:       values["n"],
+				E:       values["e"],
+			},
+			D:   values["d"],
+			P:   values["p"],
+			Q:   values["q"],
+			Dp:  values["dp"],
+			Dq:  values["dq"],
+			Crt: values["qi"],
+		}
+	case "PS256", "PS384", "PS512":
+		hash := jwkAlgHashType(k.Alg)
+		typeURL = rsaSSAPSSSignerTypeURL
+		protoKey = &rsassapsspb.RsaSsaPssPrivateKey{
+			Version: 0,
+			PublicKey: &rsassapsspb.RsaSsaPssPublicKey{
+				Version: 0,
+				Params: &rsassapsspb.RsaSsaPssParams{
+					SigHash:    hash,
+					Mgf1Hash:   hash,
+					SaltLength: jwkAlgHashSize(k.Alg),
+				},
+				N: values["n"],
+				E: values["e"],
+			},
+			D:   values["d"],
+			P:   values["p"],
+			Q:   values["q"],
+			Dp:  values["dp"],
+			Dq:  values["dq"],
+			Crt: values["qi"],
+		}
+	default:
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: unsupported algorithm %q", k.Alg)
+	}
+	serializedKey, err := proto.Marshal(protoKey)
+	if err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: failed to serialize RSA private key: %v", err)
+	}
+	var idRequirement uint32
+	if prefix != tinkpb.OutputPrefixType_RAW {
+		idRequirement = random.GetRandomUint32()
+	}
+	keySerialization, err := protoserialization.NewKeySerialization(&tinkpb.KeyData{
+		TypeUrl:         typeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, prefix, idRequirement)
+	if err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: %v", err)
+	}
+	// The RSA key parsers check the modulus size and public exponent, and that
+	// the key signs correctly.
+	privateKey, err := protoserialization.ParseKey(keySerialization)
+	if err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: invalid RSA key: %v", err)
+	}
+	km := keyset.NewManager()
+	keyID, err := km.AddKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: %v", err)
+	}
+	if err := km.SetPrimary(keyID); err != nil {
+		return nil, fmt.Errorf("signature.RSASignerFromJWK: %v", err)
+	}
+	return km.Handle()
+}
+
+// validateRSACRTParameters checks that p and q are the factors of n, and that
+// d, dp, dq and qi are consistent with them and with e.
+func validateRSACRTParameters(values map[string][]byte) error {
+	n := new(big.Int).SetBytes(values["n"])
+	e := new(big.Int).SetBytes(values["e"])
+	d := new(big.Int).SetBytes(values["d"])
+	p := new(big.Int).SetBytes(values["p"])
+	q := new(big.Int).SetBytes(values["q"])
+	dp := new(big.Int).SetBytes(values["dp"])
+	dq := new(big.Int).SetBytes(values["dq"])
+	qi := new(big.Int).SetBytes(values["qi"])
+
+	one := big.NewInt(1)
+	if p.Cmp(one) <= 0 || q.Cmp(one) <= 0 || new(big.Int).Mul(p, q).Cmp(n) != 0 {
+		return errors.New("p and q are not the prime factors of n")
+	}
+	pMinus1 := new(big.Int).Sub(p, one)
+	qMinus1 := new(big.Int).Sub(q, one)
+	if new(big.Int).Mod(d, pMinus1).Cmp(dp) != 0 {
+		return errors.New("dp is not d mod (p-1)")
+	}
+	if new(big.Int).Mod(d, qMinus1).Cmp(dq) != 0 {
+		return errors.New("dq is not d mod (q-1)")
+	}
+	if new(big.Int).Mod(new(big.Int).Mul(qi, q), p).Cmp(one) != 0 {
+		return errors.New("qi is not the inverse of q mod p")
+	}
+	// As dp and dq match d, this checks that d is the inverse of e modulo
+	// lcm(p-1, q-1).
+	if new(big.Int).Mod(new(big.Int).Mul(e, dp), pMinus1).Cmp(one) != 0 ||
+		new(big.Int).Mod(new(big.Int).Mul(e, dq), qMinus1).Cmp(one) != 0 {
+		return errors.New("d is not the inverse of e")
+	}
+	return nil
+}
+
+// jwkAlgHashType returns the hash function of a supported RSA JWK algorithm.
+func jwkAlgHashType(alg string) commonpb.HashType {
+	switch alg[2:] {
+	case "384":
+		return commonpb.HashType_SHA384
+	case "512":
+		return commonpb.HashType_SHA512
+	default:
+		return commonpb.HashType_SHA256
+	}
+}
+
+// jwkAlgHashSize returns the output size in bytes of the hash function of a
+// supported RSA JWK algorithm.
+func jwkAlgHashSize(alg string) int32 {
+	switch jwkAlgHashType(alg) {
+	case commonpb.HashType_SHA384:
+		return 48
+	case commonpb.HashType_SHA512:
+		return 64
+	default:
+		return 32
+	}
+}
+
+// rsaPublicJWK holds the members of an RSA public JWK, see
+// https://www.rfc-editor.org/rfc/rfc7517#section-4 and
+// https://www.rfc-editor.org/rfc/rfc7518#section-6.3.1.
+type rsaPublicJWK struct {
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// rsaPublicJWKSet is a JWK Set, see
+// https://www.rfc-editor.org/rfc/rfc7517#section-5.
+type rsaPublicJWKSet struct {
+	Keys []rsaPublicJWK `json:"keys"`
+}
+
+// RSAJWKSetFromPublicKeysetHandle returns a JWK Set (RFC 7517) with an RSA
+// public JWK for each enabled key of the public keyset handle.
+//
+// All enabled keys must be RSA-SSA-PKCS1 keys with output prefix type RAW,
+// since other parties cannot verify signatures that carry a Tink prefix. The
+// "alg" member is RS256, RS384 or RS512 depending on the hash function of the
+// key, and the "kid" member is the URL-safe base64 encoding of the big-endian
+// Tink key ID, as for JWT keys with output prefix type TINK.
+//
+// Use [PublicKeysByAlgorithm] to select the RSA-SSA-PKCS1 keys of a keyset
+// with mixed algorithms first.
+func RSAJWKSetFromPublicKeysetHandle(handle *keyset.Handle) ([]byte, error) {
+	mem := &keyset.MemReaderWriter{}
+	if err := handle.WriteWithNoSecrets(mem); err != nil {
+		return nil, fmt.Errorf("signature.RSAJWKSetFromPublicKeysetHandle: %v", err)
+	}
+	jwks := rsaPublicJWKSet{Keys: []rsaPublicJWK{}}
+	for _, key := range mem.Keyset.GetKey() {
+		if key.GetStatus() != tinkpb.KeyStatusType_ENABLED {
+			continue
+		}
+		if key.GetKeyData().GetTypeUrl() != rsaSSAPKCS1VerifierTypeURL {
+			return nil, fmt.Errorf("signature.RSAJWKSetFromPublicKeysetHandle: key %d has unsupported type %q", key.GetKeyId(), key.GetKeyData().GetTypeUrl())
+		}
+		if key.GetOutputPrefixType() != tinkpb.OutputPrefixType_RAW {
+			return nil, fmt.Errorf("signature.RSAJWKSetFromPublicKeysetHandle: key %d has output prefix type %s, want RAW", key.GetKeyId(), key.GetOutputPrefixType())
+		}
+		publicKey := &rsassapkcs1pb.RsaSsaPkcs1PublicKey{}
+		if err := proto.Unmarshal(key.GetKeyData().GetValue(), publicKey); err != nil {
+			return nil, fmt.Errorf("signature.RSAJWKSetFromPublicKeysetHandle: key %d: %v", key.GetKeyId(), err)
+		}
+		var alg string
+		switch publicKey.GetParams().GetHashType() {
+		case commonpb.HashType_SHA256:
+			alg = "RS256"
+		case commonpb.HashType_SHA384:
+			alg = "RS384"
+		case commonpb.HashType_SHA512:
+			alg = "RS512"
+		default:
+			return nil, fmt.Errorf("signature.RSAJWKSetFromPublicKeysetHandle: key %d has unsupported hash function %s", key.GetKeyId(), publicKey.GetParams().GetHashType())
+		}
+		jwks.Keys = append(jwks.Keys, rsaPublicJWK{
+			Kty: "RSA",
+			Alg: alg,
+			Use: "sig",
+			Kid: jwkKeyID(key.GetKeyId()),
+			// RFC 7518 does not allow leading zero bytes.
+			N: base64.RawURLEncoding.EncodeToString(new(big.Int).SetBytes(publicKey.GetN()).Bytes()),
+			E: base64.RawURLEncoding.EncodeToString(new(big.Int).SetBytes(publicKey.GetE()).Bytes()),
+		})
+	}
+	if len(jwks.Keys) == 0 {
+		return nil, errors.New("signature.RSAJWKSetFromPublicKeysetHandle: no enabled keys")
+	}
+	return json.Marshal(&jwks)
+}
+
+// jwkKeyID returns the "kid" member of the JWK of the key with the given
+// Tink key ID.
+func jwkKeyID(keyID uint32) string {
+	return base64.RawURLEncoding.EncodeToString(binary.BigEndian.AppendUint32(nil, keyID))
+}
+
+// rsaPublicJWKSetMember holds the members of a JWK in a JWK Set that are
+// needed to import it as an RSA verifier key.
+type rsaPublicJWKSetMember struct {
+	Kty    string   `json:"kty"`
+	Alg    string   `json:"alg"`
+	Use    string   `json:"use"`
+	Kid    string   `json:"kid"`
+	KeyOps []string `json:"key_ops"`
+	N      string   `json:"n"`
+	E      string   `json:"e"`
+}
+
+// skipReason returns why k is not imported as an RSA verifier key, or the
+// empty string if k is an RSA key for RS256, RS384 or RS512 that may be used
+// to verify signatures.
+func (k *rsaPublicJWKSetMember) skipReason() string {
+	if k.Kty != "RSA" {
+		return fmt.Sprintf("unsupported key type %q", k.Kty)
+	}
+	switch k.Alg {
+	case "RS256", "RS384", "RS512":
+	case "":
+		return "missing member \"alg\""
+	default:
+		return fmt.Sprintf("unsupported algorithm %q", k.Alg)
+	}
+	if k.Use != "" && k.Use != "sig" {
+		return fmt.Sprintf("unsupported use %q", k.Use)
+	}
+	if k.KeyOps != nil && !slices.Contains(k.KeyOps, "verify") {
+		return "key operations do not include \"verify\""
+	}
+	return ""
+}
+
+// tinkKeyID returns the Tink key ID encoded in the "kid" member of k, as
+// written by [RSAJWKSetFromPublicKeysetHandle], and whether there is one.
+// Only kids that are exactly that encoding are accepted, since other 6
+// character kids such as "key-01" also decode to 4 bytes.
+func (k *rsaPublicJWKSetMember) tinkKeyID() (uint32, bool) {
+	kid, err := base64.RawURLEncoding.Strict().DecodeString(k.Kid)
+	if err != nil || len(kid) != 4 {
+		return 0, false
+	}
+	keyID := binary.BigEndian.Uint32(kid)
+	if jwkKeyID(keyID) != k.Kid {
+		return 0, false
+	}
+	return keyID, true
+}
+
+// SkippedJWK describes a JWK of a JWK Set that [KeysetHandleFromRSAJWKSet]
+// did not import.
+type SkippedJWK struct {
+	// Index is the position of the JWK in the set.
+	Index int
+	// Kid is the "kid" member of the JWK, if any.
+	Kid string
+	// Reason says why the JWK was skipped.
+	Reason string
+}
+
+// KeysetHandleFromRSAJWKSet returns a verifier keyset handle with an
+// RSA-SSA-PKCS1 public key for each RSA signing key in the JWK Set jwks
+// (RFC 7517), for example one fetched from a JWKS endpoint, and the JWKs that
+// it skipped.
+//
+// The hash function of each key is given by its "alg" member, which must be
+// RS256, RS384 or RS512. Keys of other types or algorithms, keys without an
+// "alg" member, and keys whose "use" or "key_ops" members do not allow
+// verifying signatures are skipped and returned with the reason.
+//
+// The keys get output prefix type RAW, and the first key is the primary key.
+// A key whose "kid" member is exactly the encoding of a Tink key ID used by
+// [RSAJWKSetFromPublicKeysetHandle] keeps that ID, unless an earlier key
+// already has it; the other keys get the smallest unused IDs 1, 2, 3, ... in
+// the order of the set.
+//
+// It returns an error if an RSA signing key is invalid, for example if its
+// modulus is shorter than 2048 bits, or if jwks contains no RSA signing key.
+func KeysetHandleFromRSAJWKSet(jwks []byte) (*keyset.Handle, []SkippedJWK, error) {
+	var set struct {
+		Keys []rsaPublicJWKSetMember `json:"keys"`
+	}
+	if err := json.Unmarshal(jwks, &set); err != nil {
+		return nil, nil, fmt.Errorf("signature.KeysetHandleFromRSAJWKSet: invalid JWK Set: %v", err)
+	}
+	var skipped []SkippedJWK
+	var keys []*tinkpb.Keyset_Key
+	// usedKeyIDs holds the key IDs taken from "kid" members, and withoutKeyID
+	// the keys without a Tink key ID or whose ID is already taken, which are
+	// assigned IDs once all of them are known.
+	usedKeyIDs := make(map[uint32]bool)
+	var withoutKeyID []*tinkpb.Keyset_Key
+	for i, k := range set.Keys {
+		if reason := k.skipReason(); reason != "" {
+			skipped = append(skipped, SkippedJWK{Index: i, Kid: k.Kid, Reason: reason})
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil || len(n) == 0 {
+			return nil, nil, fmt.Errorf("signature.KeysetHandleFromRSAJWKSet: key %d: invalid member \"n\"", i)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 {
+			return nil, nil, fmt.Errorf("signature.KeysetHandleFromRSAJWKSet: key %d: invalid member \"e\"", i)
+		}
+		serializedKey, err := proto.Marshal(&rsassapkcs1pb.RsaSsaPkcs1PublicKey{
+			Version: 0,
+			Params:  &rsassapkcs1pb.RsaSsaPkcs1Params{HashType: jwkAlgHashType(k.Alg)},
+			N:       n,
+			E:       e,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("signature.KeysetHandleFromRSAJWKSet: key %d: %v", i, err)
+		}
+		keyData := &tinkpb.KeyData{
+			TypeUrl:         rsaSSAPKCS1VerifierTypeURL,
+			Value:           serializedKey,
+			KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+		}
+		keySerialization, err := protoserialization.NewKeySerialization(keyData, tinkpb.OutputPrefixType_RAW, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("signature.KeysetHandleFromRSAJWKSet: key %d: %v", i, err)
+		}
+		// The RSA-SSA-PKCS1 key parser checks the modulus size and the public
+		// exponent.
+		if _, err := protoserialization.ParseKey(keySerialization); err != nil {
+			return nil, nil, fmt.Errorf("signature.KeysetHandleFromRSAJWKSet: key %d: invalid RSA key: %v", i, err)
+		}
+		key := &tinkpb.Keyset_Key{
+			KeyData:          keyData,
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+		}
+		if keyID, ok := k.tinkKeyID(); ok && !usedKeyIDs[keyID] {
+			usedKeyIDs[keyID] = true
+			key.KeyId = keyID
+		} else {
+			withoutKeyID = append(withoutKeyID, key)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, nil, errors.New("signature.KeysetHandleFromRSAJWKSet: no RSA signing keys found")
+	}
+	nextKeyID := uint32(1)
+	for _, key := range withoutKeyID {
+		for usedKeyIDs[nextKeyID] {
+			nextKeyID++
+		}
+		key.KeyId = nextKeyID
+		usedKeyIDs[nextKeyID] = true
+	}
+	ks := &tinkpb.Keyset{Key: keys, PrimaryKeyId: keys[0].GetKeyId()}
+	handle, err := keyset.NewHandleWithNoSecrets(ks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("signature.KeysetHandleFromRSAJWKSet: %v", err)
+	}
+	return handle, skipped, nil
+}
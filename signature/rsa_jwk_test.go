@@ -0,0 +1,547 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+// rsaJWKMembers returns the members of the RSA private JWK of privKey.
+func rsaJWKMembers(privKey *rsa.PrivateKey, alg string) map[string]any {
+	enc := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	return map[string]any{
+		"kty": "RSA",
+		"alg": alg,
+		"n":   enc(privKey.N),
+		"e":   enc(big.NewInt(int64(privKey.E))),
+		"d":   enc(privKey.D),
+		"p":   enc(privKey.Primes[0]),
+		"q":   enc(privKey.Primes[1]),
+		"dp":  enc(privKey.Precomputed.Dp),
+		"dq":  enc(privKey.Precomputed.Dq),
+		"qi":  enc(privKey.Precomputed.Qinv),
+	}
+}
+
+func mustMarshalJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() err = %v, want nil", err)
+	}
+	return b
+}
+
+func TestRSASignerFromJWK(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	for _, tc := range []struct {
+		alg    string
+		hash   crypto.Hash
+		verify func(hashed, sig []byte, hash crypto.Hash) error
+	}{
+		{"RS256", crypto.SHA256, func(hashed, sig []byte, hash crypto.Hash) error {
+			return rsa.VerifyPKCS1v15(&privKey.PublicKey, hash, hashed, sig)
+		}},
+		{"RS512", crypto.SHA512, func(hashed, sig []byte, hash crypto.Hash) error {
+			return rsa.VerifyPKCS1v15(&privKey.PublicKey, hash, hashed, sig)
+		}},
+		{"PS256", crypto.SHA256, func(hashed, sig []byte, hash crypto.Hash) error {
+			return rsa.VerifyPSS(&privKey.PublicKey, hash, hashed, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}},
+		{"PS384", crypto.SHA384, func(hashed, sig []byte, hash crypto.Hash) error {
+			return rsa.VerifyPSS(&privKey.PublicKey, hash, hashed, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}},
+	} {
+		t.Run(tc.alg, func(t *testing.T) {
+			jwk := mustMarshalJSON(t, rsaJWKMembers(privKey, tc.alg))
+			handle, err := signature.RSASignerFromJWK(jwk, tinkpb.OutputPrefixType_RAW)
+			if err != nil {
+				t.Fatalf("signature.RSASignerFromJWK() err = %v, want nil", err)
+			}
+			signer, err := signature.NewSigner(handle)
+			if err != nil {
+				t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+			}
+			sig, err := signer.Sign(data)
+			if err != nil {
+				t.Fatalf("signer.Sign() err = %v, want nil", err)
+			}
+			h := tc.hash.New()
+			h.Write(data)
+			if err := tc.verify(h.Sum(nil), sig, tc.hash); err != nil {
+				t.Errorf("verifying the signature with crypto/rsa err = %v, want nil", err)
+			}
+		})
+	}
+
+	t.Run("TINK prefix", func(t *testing.T) {
+		jwk := mustMarshalJSON(t, rsaJWKMembers(privKey, "RS256"))
+		handle, err := signature.RSASignerFromJWK(jwk, tinkpb.OutputPrefixType_TINK)
+		if err != nil {
+			t.Fatalf("signature.RSASignerFromJWK() err = %v, want nil", err)
+		}
+		signer, err := signature.NewSigner(handle)
+		if err != nil {
+			t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+		}
+		sig, err := signer.Sign(data)
+		if err != nil {
+			t.Fatalf("signer.Sign() err = %v, want nil", err)
+		}
+		publicHandle, err := handle.Public()
+		if err != nil {
+			t.Fatalf("handle.Public() err = %v, want nil", err)
+		}
+		verifier, err := signature.NewVerifier(publicHandle)
+		if err != nil {
+			t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+		}
+		if err := verifier.Verify(sig, data); err != nil {
+			t.Errorf("verifier.Verify() err = %v, want nil", err)
+		}
+	})
+}
+
+func TestRSASignerFromJWKFails(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v, want nil", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v, want nil", err)
+	}
+	otherMembers := rsaJWKMembers(otherKey, "RS256")
+	withMember := func(name string, value any) []byte {
+		members := rsaJWKMembers(privKey, "RS256")
+		if value == nil {
+			delete(members, name)
+		} else {
+			members[name] = value
+		}
+		return mustMarshalJSON(t, members)
+	}
+	one := base64.RawURLEncoding.EncodeToString([]byte{1})
+	for _, tc := range []struct {
+		name   string
+		jwk    []byte
+		prefix tinkpb.OutputPrefixType
+	}{
+		{"invalid JSON", []byte("{"), tinkpb.OutputPrefixType_RAW},
+		{"unknown prefix", withMember("alg", "RS256"), tinkpb.OutputPrefixType_UNKNOWN_PREFIX},
+		{"EC key type", withMember("kty", "EC"), tinkpb.OutputPrefixType_RAW},
+		{"unsupported alg", withMember("alg", "ES256"), tinkpb.OutputPrefixType_RAW},
+		{"missing alg", withMember("alg", nil), tinkpb.OutputPrefixType_RAW},
+		{"missing qi", withMember("qi", nil), tinkpb.OutputPrefixType_RAW},
+		{"invalid base64", withMember("d", "not base64!"), tinkpb.OutputPrefixType_RAW},
+		{"multi-prime key", withMember("oth", []any{}), tinkpb.OutputPrefixType_RAW},
+		{"p of another key", withMember("p", otherMembers["p"]), tinkpb.OutputPrefixType_RAW},
+		{"d of another key", withMember("d", otherMembers["d"]), tinkpb.OutputPrefixType_RAW},
+		{"wrong dp", withMember("dp", one), tinkpb.OutputPrefixType_RAW},
+		{"wrong dq", withMember("dq", one), tinkpb.OutputPrefixType_RAW},
+		{"wrong qi", withMember("qi", one), tinkpb.OutputPrefixType_RAW},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.RSASignerFromJWK(tc.jwk, tc.prefix); err == nil {
+				t.Errorf("signature.RSASignerFromJWK() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestRSAJWKSetFromPublicKeysetHandle(t *testing.T) {
+	sha512Template, err := signature.RSASSAPKCS1KeyTemplate(commonpb.HashType_SHA512, 2048, tinkpb.OutputPrefixType_RAW)
+	if err != nil {
+		t.Fatalf("signature.RSASSAPKCS1KeyTemplate() err = %v, want nil", err)
+	}
+	manager := keyset.NewManager()
+	sha256ID, err := manager.Add(signature.RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	sha512ID, err := manager.Add(sha512Template)
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	disabledID, err := manager.Add(signature.RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template())
+	if err != nil {
+		t.Fatalf("manager.Add() err = %v, want nil", err)
+	}
+	if err := manager.Disable(disabledID); err != nil {
+		t.Fatalf("manager.Disable() err = %v, want nil", err)
+	}
+
+	data := []byte("data")
+	var want []map[string]string
+	sigs := make(map[string][]byte)
+	for _, tc := range []struct {
+		keyID uint32
+		alg   string
+	}{
+		{sha256ID, "RS256"},
+		{sha512ID, "RS512"},
+	} {
+		if err := manager.SetPrimary(tc.keyID); err != nil {
+			t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+		}
+		handle, err := manager.Handle()
+		if err != nil {
+			t.Fatalf("manager.Handle() err = %v, want nil", err)
+		}
+		signer, err := signature.NewSigner(handle)
+		if err != nil {
+			t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+		}
+		kid := base64.RawURLEncoding.EncodeToString(binary.BigEndian.AppendUint32(nil, tc.keyID))
+		if sigs[kid], err = signer.Sign(data); err != nil {
+			t.Fatalf("signer.Sign() err = %v, want nil", err)
+		}
+		want = append(want, map[string]string{"kty": "RSA", "alg": tc.alg, "use": "sig", "kid": kid})
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+
+	jwks, err := signature.RSAJWKSetFromPublicKeysetHandle(publicHandle)
+	if err != nil {
+		t.Fatalf("signature.RSAJWKSetFromPublicKeysetHandle() err = %v, want nil", err)
+	}
+	var got struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(jwks, &got); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v, want nil", err)
+	}
+	if len(got.Keys) != len(want) {
+		t.Fatalf("len(got.Keys) = %d, want %d", len(got.Keys), len(want))
+	}
+	for i, jwk := range got.Keys {
+		n, err := base64.RawURLEncoding.DecodeString(jwk["n"])
+		if err != nil {
+			t.Fatalf("base64.RawURLEncoding.DecodeString(n) err = %v, want nil", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk["e"])
+		if err != nil {
+			t.Fatalf("base64.RawURLEncoding.DecodeString(e) err = %v, want nil", err)
+		}
+		if n[0] == 0 || e[0] == 0 {
+			t.Errorf("jwk %d has leading zero bytes in n or e", i)
+		}
+		delete(jwk, "n")
+		delete(jwk, "e")
+		if diff := cmp.Diff(want[i], jwk); diff != "" {
+			t.Errorf("jwk %d diff (-want +got):\n%s", i, diff)
+		}
+
+		// The signature of the key verifies with the exported public key.
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		hash := crypto.SHA256
+		if jwk["alg"] == "RS512" {
+			hash = crypto.SHA512
+		}
+		h := hash.New()
+		h.Write(data)
+		if err := rsa.VerifyPKCS1v15(publicKey, hash, h.Sum(nil), sigs[jwk["kid"]]); err != nil {
+			t.Errorf("rsa.VerifyPKCS1v15() err = %v, want nil", err)
+		}
+	}
+}
+
+func TestRSAJWKSetFromPublicKeysetHandleFails(t *testing.T) {
+	newPublic := func(t *testing.T, template *tinkpb.KeyTemplate) *keyset.Handle {
+		t.Helper()
+		handle, err := keyset.NewHandle(template)
+		if err != nil {
+			t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+		}
+		publicHandle, err := handle.Public()
+		if err != nil {
+			t.Fatalf("handle.Public() err = %v, want nil", err)
+		}
+		return publicHandle
+	}
+	privateHandle, err := keyset.NewHandle(signature.RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		handle *keyset.Handle
+	}{
+		{"private keyset", privateHandle},
+		{"TINK prefix", newPublic(t, signature.RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template())},
+		{"RSA-SSA-PSS key", newPublic(t, signature.RSA_SSA_PSS_3072_SHA256_32_F4_Raw_Key_Template())},
+		{"ECDSA key", newPublic(t, signature.ECDSAP256RawKeyTemplate())},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.RSAJWKSetFromPublicKeysetHandle(tc.handle); err == nil {
+				t.Error("signature.RSAJWKSetFromPublicKeysetHandle() err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestKeysetHandleFromRSAJWKSet(t *testing.T) {
+	handle, err := keyset.NewHandle(signature.RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	exported, err := signature.RSAJWKSetFromPublicKeysetHandle(publicHandle)
+	if err != nil {
+		t.Fatalf("signature.RSAJWKSetFromPublicKeysetHandle() err = %v, want nil", err)
+	}
+	var set struct {
+		Keys []map[string]any `json:"keys"`
+	}
+	if err := json.Unmarshal(exported, &set); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v, want nil", err)
+	}
+	rsaKey := set.Keys[0]
+	withMembers := func(members map[string]any) map[string]any {
+		k := make(map[string]any)
+		for name, value := range rsaKey {
+			k[name] = value
+		}
+		for name, value := range members {
+			k[name] = value
+		}
+		return k
+	}
+	// Only the exported key is an RSA signing key.
+	jwks := mustMarshalJSON(t, map[string]any{"keys": []any{
+		map[string]any{"kty": "EC", "alg": "ES256", "crv": "P-256", "x": "AA", "y": "AA"},
+		withMembers(map[string]any{"use": "enc"}),
+		withMembers(map[string]any{"key_ops": []string{"encrypt"}}),
+		withMembers(map[string]any{"alg": "PS256"}),
+		withMembers(map[string]any{"alg": nil}),
+		rsaKey,
+	}})
+
+	got, skipped, err := signature.KeysetHandleFromRSAJWKSet(jwks)
+	if err != nil {
+		t.Fatalf("signature.KeysetHandleFromRSAJWKSet() err = %v, want nil", err)
+	}
+	if got.Len() != 1 {
+		t.Errorf("got.Len() = %d, want 1", got.Len())
+	}
+	var skippedIndexes []int
+	for _, s := range skipped {
+		if s.Reason == "" {
+			t.Errorf("skipped JWK %d has no reason", s.Index)
+		}
+		skippedIndexes = append(skippedIndexes, s.Index)
+	}
+	if want := []int{0, 1, 2, 3, 4}; !slices.Equal(skippedIndexes, want) {
+		t.Errorf("skipped JWK indexes = %v, want %v", skippedIndexes, want)
+	}
+	// The key keeps the Tink key ID encoded in its "kid" member.
+	wantKeyID, err := publicHandle.PrimaryKeyID()
+	if err != nil {
+		t.Fatalf("publicHandle.PrimaryKeyID() err = %v, want nil", err)
+	}
+	if gotKeyID, err := got.PrimaryKeyID(); err != nil || gotKeyID != wantKeyID {
+		t.Errorf("got.PrimaryKeyID() = %d, %v, want %d, nil", gotKeyID, err, wantKeyID)
+	}
+	signer, err := signature.NewSigner(handle)
+	if err != nil {
+		t.Fatalf("signature.NewSigner() err = %v, want nil", err)
+	}
+	verifier, err := signature.NewVerifier(got)
+	if err != nil {
+		t.Fatalf("signature.NewVerifier() err = %v, want nil", err)
+	}
+	data := []byte("data")
+	sig, err := signer.Sign(data)
+	if err != nil {
+		t.Fatalf("signer.Sign() err = %v, want nil", err)
+	}
+	if err := verifier.Verify(sig, data); err != nil {
+		t.Errorf("verifier.Verify() err = %v, want nil", err)
+	}
+
+	// Exporting the imported keyset gives the same key material.
+	reexported, err := signature.RSAJWKSetFromPublicKeysetHandle(got)
+	if err != nil {
+		t.Fatalf("signature.RSAJWKSetFromPublicKeysetHandle() err = %v, want nil", err)
+	}
+	var reexportedSet struct {
+		Keys []map[string]any `json:"keys"`
+	}
+	if err := json.Unmarshal(reexported, &reexportedSet); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v, want nil", err)
+	}
+	for _, name := range []string{"kty", "alg", "n", "e"} {
+		if got, want := reexportedSet.Keys[0][name], rsaKey[name]; got != want {
+			t.Errorf("reexported %q = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestKeysetHandleFromRSAJWKSetKeyIDs(t *testing.T) {
+	handle, err := keyset.NewHandle(signature.RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	publicHandle, err := handle.Public()
+	if err != nil {
+		t.Fatalf("handle.Public() err = %v, want nil", err)
+	}
+	exported, err := signature.RSAJWKSetFromPublicKeysetHandle(publicHandle)
+	if err != nil {
+		t.Fatalf("signature.RSAJWKSetFromPublicKeysetHandle() err = %v, want nil", err)
+	}
+	var set struct {
+		Keys []map[string]any `json:"keys"`
+	}
+	if err := json.Unmarshal(exported, &set); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v, want nil", err)
+	}
+	withKid := func(kid any) map[string]any {
+		k := make(map[string]any)
+		for name, value := range set.Keys[0] {
+			k[name] = value
+		}
+		k["kid"] = kid
+		return k
+	}
+	jwks := mustMarshalJSON(t, map[string]any{"keys": []any{
+		withKid(nil),
+		withKid("AAAAAQ"), // Tink key ID 1.
+		withKid("not a Tink key ID"),
+	}})
+	got, skipped, err := signature.KeysetHandleFromRSAJWKSet(jwks)
+	if err != nil {
+		t.Fatalf("signature.KeysetHandleFromRSAJWKSet() err = %v, want nil", err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %v, want none", skipped)
+	}
+	var keyIDs []uint32
+	for i := 0; i < got.Len(); i++ {
+		entry, err := got.Entry(i)
+		if err != nil {
+			t.Fatalf("got.Entry(%d) err = %v, want nil", i, err)
+		}
+		keyIDs = append(keyIDs, entry.KeyID())
+	}
+	if want := []uint32{2, 1, 3}; !slices.Equal(keyIDs, want) {
+		t.Errorf("key IDs = %v, want %v", keyIDs, want)
+	}
+	if primaryKeyID, err := got.PrimaryKeyID(); err != nil || primaryKeyID != 2 {
+		t.Errorf("got.PrimaryKeyID() = %d, %v, want 2, nil", primaryKeyID, err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		kids []any
+		want []uint32
+	}{
+		{
+			name: "duplicate Tink key IDs",
+			kids: []any{"AAAAAQ", "AAAAAQ"},
+			want: []uint32{1, 2},
+		},
+		{
+			// These kids decode to 4 bytes each, the same for all of them, but
+			// are not encodings of Tink key IDs.
+			name: "third-party kids",
+			kids: []any{"key-01", "key-02", "key-03"},
+			want: []uint32{1, 2, 3},
+		},
+		{
+			// "AAAAAR" decodes to key ID 1 with non-strict decoding.
+			name: "non-canonical encoding",
+			kids: []any{"AAAAAR", "AAAAAQ"},
+			want: []uint32{2, 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var keys []any
+			for _, kid := range tc.kids {
+				keys = append(keys, withKid(kid))
+			}
+			got, _, err := signature.KeysetHandleFromRSAJWKSet(mustMarshalJSON(t, map[string]any{"keys": keys}))
+			if err != nil {
+				t.Fatalf("signature.KeysetHandleFromRSAJWKSet() err = %v, want nil", err)
+			}
+			var keyIDs []uint32
+			for i := 0; i < got.Len(); i++ {
+				entry, err := got.Entry(i)
+				if err != nil {
+					t.Fatalf("got.Entry(%d) err = %v, want nil", i, err)
+				}
+				keyIDs = append(keyIDs, entry.KeyID())
+			}
+			if !slices.Equal(keyIDs, tc.want) {
+				t.Errorf("key IDs = %v, want %v", keyIDs, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeysetHandleFromRSAJWKSetFails(t *testing.T) {
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v, want nil", err)
+	}
+	enc := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	for _, tc := range []struct {
+		name string
+		jwks []byte
+	}{
+		{"not JSON", []byte("keys")},
+		{"no keys", []byte(`{"keys":[]}`)},
+		{"no RSA signing keys", []byte(`{"keys":[{"kty":"EC","alg":"ES256"}]}`)},
+		{"invalid n", mustMarshalJSON(t, map[string]any{"keys": []any{
+			map[string]any{"kty": "RSA", "alg": "RS256", "n": "!", "e": "AQAB"},
+		}})},
+		{"modulus too short", mustMarshalJSON(t, map[string]any{"keys": []any{
+			map[string]any{"kty": "RSA", "alg": "RS256", "n": enc(smallKey.N), "e": "AQAB"},
+		}})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := signature.KeysetHandleFromRSAJWKSet(tc.jwks); err == nil {
+				t.Error("signature.KeysetHandleFromRSAJWKSet() err = nil, want error")
+			}
+		})
+	}
+}
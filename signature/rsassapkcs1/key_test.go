@@ -81,6 +81,13 @@ func TestNewParametersInvalidValues(t *testing.T) {
 			publicExponent:  f4,
 			variant:         rsassapkcs1.VariantTink,
 		},
+		{
+			name:            "invalid modulus size (one bit below minimum)",
+			modulusSizeBits: 2047,
+			hashType:        rsassapkcs1.SHA256,
+			publicExponent:  f4,
+			variant:         rsassapkcs1.VariantTink,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
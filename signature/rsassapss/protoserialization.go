@@ -149,6 +149,13 @@ func hashTypeFromProto(hashType commonpb.HashType) (HashType, error) {
 	}
 }
 
+// isLegacyMGF1SHA1 reports whether params describe the legacy combination of a
+// SHA-256 signature hash with MGF1-SHA1, which is only supported for
+// verification.
+func isLegacyMGF1SHA1(params *rsassapsspb.RsaSsaPssParams) bool {
+	return params.GetSigHash() == commonpb.HashType_SHA256 && params.GetMgf1Hash() == commonpb.HashType_SHA1
+}
+
 func (s *publicKeyParser) ParseKey(keySerialization *protoserialization.KeySerialization) (key.Key, error) {
 	keyData := keySerialization.KeyData()
 	if keyData.GetTypeUrl() != verifierTypeURL {
@@ -164,6 +171,12 @@ func (s *publicKeyParser) ParseKey(keySerialization *protoserialization.KeySeria
 	if protoKey.GetVersion() != publicKeyProtoVersion {
 		return nil, fmt.Errorf("public key has unsupported version: %v", protoKey.GetVersion())
 	}
+	if isLegacyMGF1SHA1(protoKey.GetParams()) {
+		// Such keys cannot be represented as a PublicKey. They are kept as
+		// opaque proto keys, so that they can be stored in a keyset and used by
+		// signature.NewLegacyPSSVerifier, but not by the regular verifier.
+		return protoserialization.NewFallbackProtoKey(keySerialization), nil
+	}
 	variant, err := variantFromProto(keySerialization.OutputPrefixType())
 	if err != nil {
 		return nil, err
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"google.golang.org/protobuf/proto"
+	internal "github.com/tink-crypto/tink-go/v2/internal/signature"
 	"github.com/tink-crypto/tink-go/v2/internal/tinkerror"
 	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
 	ecdsapb "github.com/tink-crypto/tink-go/v2/proto/ecdsa_go_proto"
@@ -76,6 +77,31 @@ func ECDSAP256RawKeyTemplate() *tinkpb.KeyTemplate {
 		tinkpb.OutputPrefixType_RAW)
 }
 
+// ECDSAP256SHA512KeyTemplate is a KeyTemplate that generates a new ECDSA private key with the following parameters:
+//   - Hash function: SHA512
+//   - Curve: NIST P-256
+//   - Signature encoding: DER
+//   - Output prefix type: TINK
+func ECDSAP256SHA512KeyTemplate() *tinkpb.KeyTemplate {
+	return createECDSAKeyTemplate(commonpb.HashType_SHA512,
+		commonpb.EllipticCurveType_NIST_P256,
+		ecdsapb.EcdsaSignatureEncoding_DER,
+		tinkpb.OutputPrefixType_TINK)
+}
+
+// ECDSAP256SHA512KeyWithoutPrefixTemplate is a KeyTemplate that generates a new ECDSA private key with the following
+// parameters:
+//   - Hash function: SHA512
+//   - Curve: NIST P-256
+//   - Signature encoding: DER
+//   - Output prefix type: RAW
+func ECDSAP256SHA512KeyWithoutPrefixTemplate() *tinkpb.KeyTemplate {
+	return createECDSAKeyTemplate(commonpb.HashType_SHA512,
+		commonpb.EllipticCurveType_NIST_P256,
+		ecdsapb.EcdsaSignatureEncoding_DER,
+		tinkpb.OutputPrefixType_RAW)
+}
+
 // ECDSAP384SHA384KeyTemplate is a KeyTemplate that generates a new ECDSA private key with the following parameters:
 //   - Hash function: SHA384
 //   - Curve: NIST P-384
@@ -125,6 +151,32 @@ func ECDSAP384KeyWithoutPrefixTemplate() *tinkpb.KeyTemplate {
 		tinkpb.OutputPrefixType_RAW)
 }
 
+// ECDSAP384IEEEP1363KeyTemplate is a KeyTemplate that generates a new ECDSA private key with the following
+// parameters:
+//   - Hash function: SHA384
+//   - Curve: NIST P-384
+//   - Signature encoding: IEEE_P1363
+//   - Output prefix type: TINK
+func ECDSAP384IEEEP1363KeyTemplate() *tinkpb.KeyTemplate {
+	return createECDSAKeyTemplate(commonpb.HashType_SHA384,
+		commonpb.EllipticCurveType_NIST_P384,
+		ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+		tinkpb.OutputPrefixType_TINK)
+}
+
+// ECDSAP384IEEEP1363RawKeyTemplate is a KeyTemplate that generates a new ECDSA private key with the following
+// parameters:
+//   - Hash function: SHA384
+//   - Curve: NIST P-384
+//   - Signature encoding: IEEE_P1363
+//   - Output prefix type: RAW
+func ECDSAP384IEEEP1363RawKeyTemplate() *tinkpb.KeyTemplate {
+	return createECDSAKeyTemplate(commonpb.HashType_SHA384,
+		commonpb.EllipticCurveType_NIST_P384,
+		ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+		tinkpb.OutputPrefixType_RAW)
+}
+
 // ECDSAP521KeyTemplate is a KeyTemplate that generates a new ECDSA private key with the following parameters:
 //   - Hash function: SHA512
 //   - Curve: NIST P-521
@@ -150,6 +202,32 @@ func ECDSAP521KeyWithoutPrefixTemplate() *tinkpb.KeyTemplate {
 		tinkpb.OutputPrefixType_RAW)
 }
 
+// ECDSAP521IEEEP1363KeyTemplate is a KeyTemplate that generates a new ECDSA private key with the following
+// parameters:
+//   - Hash function: SHA512
+//   - Curve: NIST P-521
+//   - Signature encoding: IEEE_P1363
+//   - Output prefix type: TINK
+func ECDSAP521IEEEP1363KeyTemplate() *tinkpb.KeyTemplate {
+	return createECDSAKeyTemplate(commonpb.HashType_SHA512,
+		commonpb.EllipticCurveType_NIST_P521,
+		ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+		tinkpb.OutputPrefixType_TINK)
+}
+
+// ECDSAP521IEEEP1363RawKeyTemplate is a KeyTemplate that generates a new ECDSA private key with the following
+// parameters:
+//   - Hash function: SHA512
+//   - Curve: NIST P-521
+//   - Signature encoding: IEEE_P1363
+//   - Output prefix type: RAW
+func ECDSAP521IEEEP1363RawKeyTemplate() *tinkpb.KeyTemplate {
+	return createECDSAKeyTemplate(commonpb.HashType_SHA512,
+		commonpb.EllipticCurveType_NIST_P521,
+		ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+		tinkpb.OutputPrefixType_RAW)
+}
+
 // createECDSAKeyTemplate creates a KeyTemplate containing a EcdasKeyFormat
 // with the given parameters.
 func createECDSAKeyTemplate(hashType commonpb.HashType, curve commonpb.EllipticCurveType, encoding ecdsapb.EcdsaSignatureEncoding, prefixType tinkpb.OutputPrefixType) *tinkpb.KeyTemplate {
@@ -226,6 +304,77 @@ func create_RSA_SSA_PSS_Template(prefixType tinkpb.OutputPrefixType, hashType co
 	}
 }
 
+func validateOutputPrefixType(prefixType tinkpb.OutputPrefixType) error {
+	switch prefixType {
+	case tinkpb.OutputPrefixType_TINK, tinkpb.OutputPrefixType_LEGACY, tinkpb.OutputPrefixType_CRUNCHY, tinkpb.OutputPrefixType_RAW:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output prefix type: %v", prefixType)
+	}
+}
+
+// RSASSAPKCS1KeyTemplate returns a KeyTemplate that generates a new RSA SSA
+// PKCS1 private key with the given hash function, modulus size and output
+// prefix type, and public exponent 65537 (aka F4).
+//
+// It returns an error if the parameters are not supported.
+func RSASSAPKCS1KeyTemplate(hashType commonpb.HashType, modulusSizeInBits uint32, prefixType tinkpb.OutputPrefixType) (*tinkpb.KeyTemplate, error) {
+	if err := internal.ValidateRSAPublicKeyParams(hashType, int(modulusSizeInBits), []byte{0x01, 0x00, 0x01}); err != nil {
+		return nil, fmt.Errorf("signature.RSASSAPKCS1KeyTemplate: %v", err)
+	}
+	if err := validateOutputPrefixType(prefixType); err != nil {
+		return nil, fmt.Errorf("signature.RSASSAPKCS1KeyTemplate: %v", err)
+	}
+	return create_RSA_SSA_PKCS1_Template(prefixType, hashType, modulusSizeInBits), nil
+}
+
+// RSASSAPSSKeyTemplate returns a KeyTemplate that generates a new RSA SSA PSS
+// private key with the given hash function, salt length in bytes, modulus
+// size and output prefix type, and public exponent 65537 (aka F4). The hash
+// function is used both for the signature and for MGF1.
+//
+// It returns an error if the parameters are not supported.
+func RSASSAPSSKeyTemplate(hashType commonpb.HashType, saltLength int32, modulusSizeInBits uint32, prefixType tinkpb.OutputPrefixType) (*tinkpb.KeyTemplate, error) {
+	if saltLength < 0 {
+		return nil, fmt.Errorf("signature.RSASSAPSSKeyTemplate: salt length can't be negative")
+	}
+	if err := internal.ValidateRSAPublicKeyParams(hashType, int(modulusSizeInBits), []byte{0x01, 0x00, 0x01}); err != nil {
+		return nil, fmt.Errorf("signature.RSASSAPSSKeyTemplate: %v", err)
+	}
+	if err := validateOutputPrefixType(prefixType); err != nil {
+		return nil, fmt.Errorf("signature.RSASSAPSSKeyTemplate: %v", err)
+	}
+	return create_RSA_SSA_PSS_Template(prefixType, hashType, saltLength, modulusSizeInBits), nil
+}
+
+// RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template is a KeyTemplate that generates a new RSA SSA PKCS1 private key with the following
+// parameters:
+//   - Modulus size in bits: 2048.
+//   - Hash function: SHA256.
+//   - Public Exponent: 65537 (aka F4).
+//   - OutputPrefixType: TINK
+//
+// 2048 bits is the smallest modulus size Tink accepts. Prefer
+// RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template unless 2048-bit keys are required
+// for compatibility.
+func RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template() *tinkpb.KeyTemplate {
+	return create_RSA_SSA_PKCS1_Template(tinkpb.OutputPrefixType_TINK, commonpb.HashType_SHA256, 2048)
+}
+
+// RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template is a KeyTemplate that generates a new RSA SSA PKCS1 private key with the following
+// parameters:
+//   - Modulus size in bits: 2048.
+//   - Hash function: SHA256.
+//   - Public Exponent: 65537 (aka F4).
+//   - OutputPrefixType: RAW
+//
+// 2048 bits is the smallest modulus size Tink accepts. Prefer
+// RSA_SSA_PKCS1_3072_SHA256_F4_RAW_Key_Template unless 2048-bit keys are
+// required for compatibility.
+func RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template() *tinkpb.KeyTemplate {
+	return create_RSA_SSA_PKCS1_Template(tinkpb.OutputPrefixType_RAW, commonpb.HashType_SHA256, 2048)
+}
+
 // RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template is a KeyTemplate that generates a new RSA SSA PKCS1 private key with the following
 // parameters:
 //   - Modulus size in bits: 3072.
@@ -18,8 +18,10 @@ import (
 	"fmt"
 	"testing"
 
+	"google.golang.org/protobuf/proto"
 	"github.com/tink-crypto/tink-go/v2/keyset"
 	"github.com/tink-crypto/tink-go/v2/signature"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
 	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
 )
 
@@ -30,6 +32,8 @@ func TestKeyTemplates(t *testing.T) {
 	}{
 		{name: "ECDSA_P256",
 			template: signature.ECDSAP256KeyTemplate()},
+		{name: "ECDSA_P256_SHA512",
+			template: signature.ECDSAP256SHA512KeyTemplate()},
 		{name: "ECDSA_P384_SHA384",
 			template: signature.ECDSAP384SHA384KeyTemplate()},
 		{name: "ECDSA_P384_SHA512",
@@ -40,12 +44,26 @@ func TestKeyTemplates(t *testing.T) {
 			template: signature.ECDSAP256RawKeyTemplate()},
 		{name: "ECDSA_P256_NO_PREFIX",
 			template: signature.ECDSAP256KeyWithoutPrefixTemplate()},
+		{name: "ECDSA_P256_SHA512_NO_PREFIX",
+			template: signature.ECDSAP256SHA512KeyWithoutPrefixTemplate()},
 		{name: "ECDSA_P384_NO_PREFIX",
 			template: signature.ECDSAP384KeyWithoutPrefixTemplate()},
 		{name: "ECDSA_P384_SHA384_NO_PREFIX",
 			template: signature.ECDSAP384SHA384KeyWithoutPrefixTemplate()},
+		{name: "ECDSA_P384_IEEE_P1363",
+			template: signature.ECDSAP384IEEEP1363KeyTemplate()},
+		{name: "ECDSA_P384_IEEE_P1363_RAW",
+			template: signature.ECDSAP384IEEEP1363RawKeyTemplate()},
 		{name: "ECDSA_P521_NO_PREFIX",
 			template: signature.ECDSAP521KeyWithoutPrefixTemplate()},
+		{name: "ECDSA_P521_IEEE_P1363",
+			template: signature.ECDSAP521IEEEP1363KeyTemplate()},
+		{name: "ECDSA_P521_IEEE_P1363_RAW",
+			template: signature.ECDSAP521IEEEP1363RawKeyTemplate()},
+		{name: "RSA_SSA_PKCS1_2048_SHA256_F4",
+			template: signature.RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template()},
+		{name: "RSA_SSA_PKCS1_2048_SHA256_F4_RAW",
+			template: signature.RSA_SSA_PKCS1_2048_SHA256_F4_RAW_Key_Template()},
 		{name: "RSA_SSA_PKCS1_3072_SHA256_F4",
 			template: signature.RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template()},
 		{name: "RSA_SSA_PKCS1_3072_SHA256_F4_RAW",
@@ -72,6 +90,144 @@ func TestKeyTemplates(t *testing.T) {
 	}
 }
 
+func TestRSAKeyTemplateBuilders(t *testing.T) {
+	pkcs1Template, err := signature.RSASSAPKCS1KeyTemplate(commonpb.HashType_SHA384, 2048, tinkpb.OutputPrefixType_TINK)
+	if err != nil {
+		t.Fatalf("signature.RSASSAPKCS1KeyTemplate() err = %v, want nil", err)
+	}
+	pssTemplate, err := signature.RSASSAPSSKeyTemplate(commonpb.HashType_SHA384, 48, 3072, tinkpb.OutputPrefixType_RAW)
+	if err != nil {
+		t.Fatalf("signature.RSASSAPSSKeyTemplate() err = %v, want nil", err)
+	}
+	for _, template := range []*tinkpb.KeyTemplate{pkcs1Template, pssTemplate} {
+		if err := testSignVerify(template); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRSAKeyTemplateBuildersMatchPredefinedTemplates(t *testing.T) {
+	pkcs1Template, err := signature.RSASSAPKCS1KeyTemplate(commonpb.HashType_SHA256, 3072, tinkpb.OutputPrefixType_TINK)
+	if err != nil {
+		t.Fatalf("signature.RSASSAPKCS1KeyTemplate() err = %v, want nil", err)
+	}
+	if want := signature.RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template(); !proto.Equal(pkcs1Template, want) {
+		t.Errorf("signature.RSASSAPKCS1KeyTemplate() = %v, want %v", pkcs1Template, want)
+	}
+	pssTemplate, err := signature.RSASSAPSSKeyTemplate(commonpb.HashType_SHA512, 64, 4096, tinkpb.OutputPrefixType_RAW)
+	if err != nil {
+		t.Fatalf("signature.RSASSAPSSKeyTemplate() err = %v, want nil", err)
+	}
+	if want := signature.RSA_SSA_PSS_4096_SHA512_64_F4_Raw_Key_Template(); !proto.Equal(pssTemplate, want) {
+		t.Errorf("signature.RSASSAPSSKeyTemplate() = %v, want %v", pssTemplate, want)
+	}
+}
+
+func TestRSAKeyTemplateBuildersRejectInvalidParameters(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		hashType          commonpb.HashType
+		saltLength        int32
+		modulusSizeInBits uint32
+		prefixType        tinkpb.OutputPrefixType
+	}{
+		{
+			name:              "modulus too small",
+			hashType:          commonpb.HashType_SHA256,
+			modulusSizeInBits: 2047,
+			prefixType:        tinkpb.OutputPrefixType_TINK,
+		},
+		{
+			name:              "unsafe hash",
+			hashType:          commonpb.HashType_SHA1,
+			modulusSizeInBits: 3072,
+			prefixType:        tinkpb.OutputPrefixType_TINK,
+		},
+		{
+			name:              "unknown hash",
+			hashType:          commonpb.HashType_UNKNOWN_HASH,
+			modulusSizeInBits: 3072,
+			prefixType:        tinkpb.OutputPrefixType_TINK,
+		},
+		{
+			name:              "unknown prefix type",
+			hashType:          commonpb.HashType_SHA256,
+			modulusSizeInBits: 3072,
+			prefixType:        tinkpb.OutputPrefixType_UNKNOWN_PREFIX,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := signature.RSASSAPKCS1KeyTemplate(tc.hashType, tc.modulusSizeInBits, tc.prefixType); err == nil {
+				t.Errorf("signature.RSASSAPKCS1KeyTemplate() err = nil, want error")
+			}
+			if _, err := signature.RSASSAPSSKeyTemplate(tc.hashType, 32, tc.modulusSizeInBits, tc.prefixType); err == nil {
+				t.Errorf("signature.RSASSAPSSKeyTemplate() err = nil, want error")
+			}
+		})
+	}
+	if _, err := signature.RSASSAPSSKeyTemplate(commonpb.HashType_SHA256, -1, 3072, tinkpb.OutputPrefixType_TINK); err == nil {
+		t.Errorf("signature.RSASSAPSSKeyTemplate() with negative salt length err = nil, want error")
+	}
+}
+
+func TestIEEEP1363KeyTemplatesProduceFixedWidthSignatures(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+		sigLen   int
+	}{
+		{name: "ECDSA_P256_RAW",
+			template: signature.ECDSAP256RawKeyTemplate(),
+			sigLen:   64},
+		{name: "ECDSA_P384_IEEE_P1363_RAW",
+			template: signature.ECDSAP384IEEEP1363RawKeyTemplate(),
+			sigLen:   96},
+		{name: "ECDSA_P384_IEEE_P1363",
+			template: signature.ECDSAP384IEEEP1363KeyTemplate(),
+			sigLen:   5 + 96},
+		{name: "ECDSA_P521_IEEE_P1363_RAW",
+			template: signature.ECDSAP521IEEEP1363RawKeyTemplate(),
+			sigLen:   132},
+		{name: "ECDSA_P521_IEEE_P1363",
+			template: signature.ECDSAP521IEEEP1363KeyTemplate(),
+			sigLen:   5 + 132},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			privateHandle, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				t.Fatalf("keyset.NewHandle(tc.template) err = %v, want nil", err)
+			}
+			signer, err := signature.NewSigner(privateHandle)
+			if err != nil {
+				t.Fatalf("signature.NewSigner(privateHandle) err = %v, want nil", err)
+			}
+			publicHandle, err := privateHandle.Public()
+			if err != nil {
+				t.Fatalf("privateHandle.Public() err = %v, want nil", err)
+			}
+			verifier, err := signature.NewVerifier(publicHandle)
+			if err != nil {
+				t.Fatalf("signature.NewVerifier(publicHandle) err = %v, want nil", err)
+			}
+			// Sign several times, since DER-encoded signatures would vary in length.
+			for i := 0; i < 10; i++ {
+				data := []byte(fmt.Sprintf("data %d", i))
+				sig, err := signer.Sign(data)
+				if err != nil {
+					t.Fatalf("signer.Sign() err = %v, want nil", err)
+				}
+				if len(sig) != tc.sigLen {
+					t.Errorf("len(sig) = %d, want %d", len(sig), tc.sigLen)
+				}
+				if err := verifier.Verify(sig, data); err != nil {
+					t.Errorf("verifier.Verify() err = %v, want nil", err)
+				}
+			}
+		})
+	}
+}
+
 func testSignVerify(template *tinkpb.KeyTemplate) error {
 	privateHandle, err := keyset.NewHandle(template)
 	if err != nil {
@@ -97,7 +97,7 @@ func newWrappedSigner(ps *primitiveset.PrimitiveSet[tink.Signer]) (*wrappedSigne
 
 func createSignerLogger(ps *primitiveset.PrimitiveSet[tink.Signer]) (monitoring.Logger, error) {
 	// Only keysets which contain annotations are monitored.
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, nil
 	}
 	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
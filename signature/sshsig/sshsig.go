@@ -0,0 +1,249 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sshsig verifies SSH signatures (SSHSIG), as produced by
+// "ssh-keygen -Y sign", with the Ed25519 public keys of a keyset.
+//
+// The format is specified in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+package sshsig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature/ed25519"
+	"github.com/tink-crypto/tink-go/v2/signature/subtle"
+)
+
+const (
+	magicPreamble  = "SSHSIG"
+	sigVersion     = 1
+	armorBegin     = "-----BEGIN SSH SIGNATURE-----"
+	armorEnd       = "-----END SSH SIGNATURE-----"
+	keyTypeEd25519 = "ssh-ed25519"
+)
+
+// sshSignature is a parsed SSHSIG signature blob.
+type sshSignature struct {
+	publicKey     []byte
+	namespace     string
+	reserved      []byte
+	hashAlgorithm string
+	signature     []byte
+}
+
+// Verify checks that sshsig is a valid armored SSH signature of message in
+// namespace, made by one of the enabled Ed25519 keys in handle.
+//
+// handle may contain public or private keys; keys of other types are ignored.
+// Only Ed25519 SSH signatures are supported.
+func Verify(handle *keyset.Handle, namespace string, sshsig, message []byte) error {
+	if handle == nil {
+		return errors.New("sshsig: nil handle")
+	}
+	if namespace == "" {
+		return errors.New("sshsig: empty namespace")
+	}
+	blob, err := unarmor(sshsig)
+	if err != nil {
+		return err
+	}
+	sig, err := parseSignature(blob)
+	if err != nil {
+		return err
+	}
+	if sig.namespace != namespace {
+		return fmt.Errorf("sshsig: signature namespace is %q, want %q", sig.namespace, namespace)
+	}
+	keyBytes, err := parseEd25519PublicKey(sig.publicKey)
+	if err != nil {
+		return err
+	}
+	sigBytes, err := parseEd25519Signature(sig.signature)
+	if err != nil {
+		return err
+	}
+	var messageHash []byte
+	switch sig.hashAlgorithm {
+	case "sha256":
+		h := sha256.Sum256(message)
+		messageHash = h[:]
+	case "sha512":
+		h := sha512.Sum512(message)
+		messageHash = h[:]
+	default:
+		return fmt.Errorf("sshsig: unsupported hash algorithm %q", sig.hashAlgorithm)
+	}
+	if !hasEnabledEd25519Key(handle, keyBytes) {
+		return errors.New("sshsig: signing key not found in keyset")
+	}
+	verifier, err := subtle.NewED25519Verifier(keyBytes)
+	if err != nil {
+		return err
+	}
+	signedData := []byte(magicPreamble)
+	signedData = appendString(signedData, []byte(sig.namespace))
+	signedData = appendString(signedData, sig.reserved)
+	signedData = appendString(signedData, []byte(sig.hashAlgorithm))
+	signedData = appendString(signedData, messageHash)
+	if err := verifier.Verify(sigBytes, signedData); err != nil {
+		return fmt.Errorf("sshsig: %v", err)
+	}
+	return nil
+}
+
+// hasEnabledEd25519Key reports whether handle has an enabled Ed25519 key whose
+// public key is keyBytes.
+func hasEnabledEd25519Key(handle *keyset.Handle, keyBytes []byte) bool {
+	for i := 0; i < handle.Len(); i++ {
+		entry, err := handle.Entry(i)
+		if err != nil || entry.KeyStatus() != keyset.Enabled {
+			continue
+		}
+		var publicKey *ed25519.PublicKey
+		switch k := entry.Key().(type) {
+		case *ed25519.PublicKey:
+			publicKey = k
+		case *ed25519.PrivateKey:
+			pk, err := k.PublicKey()
+			if err != nil {
+				continue
+			}
+			publicKey, _ = pk.(*ed25519.PublicKey)
+		}
+		if publicKey != nil && bytes.Equal(publicKey.KeyBytes(), keyBytes) {
+			return true
+		}
+	}
+	return false
+}
+
+// unarmor decodes the base64 body of an armored SSH signature.
+func unarmor(armored []byte) ([]byte, error) {
+	armored = bytes.TrimSpace(armored)
+	body, ok := bytes.CutPrefix(armored, []byte(armorBegin))
+	if !ok {
+		return nil, errors.New("sshsig: missing armor header")
+	}
+	body, ok = bytes.CutSuffix(body, []byte(armorEnd))
+	if !ok {
+		return nil, errors.New("sshsig: missing armor footer")
+	}
+	encoded := bytes.Join(bytes.Fields(body), nil)
+	blob := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(blob, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("sshsig: invalid base64: %v", err)
+	}
+	return blob[:n], nil
+}
+
+// parseSignature parses an SSHSIG signature blob.
+func parseSignature(blob []byte) (*sshSignature, error) {
+	rest, ok := bytes.CutPrefix(blob, []byte(magicPreamble))
+	if !ok {
+		return nil, errors.New("sshsig: invalid magic preamble")
+	}
+	if len(rest) < 4 {
+		return nil, errors.New("sshsig: truncated signature")
+	}
+	if version := binary.BigEndian.Uint32(rest); version != sigVersion {
+		return nil, fmt.Errorf("sshsig: unsupported version %d", version)
+	}
+	rest = rest[4:]
+	fields := make([][]byte, 5)
+	for i := range fields {
+		var err error
+		fields[i], rest, err = readString(rest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("sshsig: trailing data after signature")
+	}
+	return &sshSignature{
+		publicKey:     fields[0],
+		namespace:     string(fields[1]),
+		reserved:      fields[2],
+		hashAlgorithm: string(fields[3]),
+		signature:     fields[4],
+	}, nil
+}
+
+// parseEd25519PublicKey returns the key bytes of an SSH wire-format Ed25519
+// public key.
+func parseEd25519PublicKey(b []byte) ([]byte, error) {
+	keyType, rest, err := readString(b)
+	if err != nil {
+		return nil, err
+	}
+	if string(keyType) != keyTypeEd25519 {
+		return nil, fmt.Errorf("sshsig: unsupported key type %q", keyType)
+	}
+	keyBytes, rest, err := readString(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 || len(keyBytes) != 32 {
+		return nil, errors.New("sshsig: invalid Ed25519 public key")
+	}
+	return keyBytes, nil
+}
+
+// parseEd25519Signature returns the signature bytes of an SSH wire-format
+// Ed25519 signature.
+func parseEd25519Signature(b []byte) ([]byte, error) {
+	sigType, rest, err := readString(b)
+	if err != nil {
+		return nil, err
+	}
+	if string(sigType) != keyTypeEd25519 {
+		return nil, fmt.Errorf("sshsig: unsupported signature type %q", sigType)
+	}
+	sigBytes, rest, err := readString(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("sshsig: invalid Ed25519 signature")
+	}
+	return sigBytes, nil
+}
+
+// readString reads an SSH wire-format string, a 4-byte big-endian length
+// followed by that many bytes, from the start of b.
+func readString(b []byte) (s, rest []byte, err error) {
+	if len(b) < 4 {
+		return nil, nil, errors.New("sshsig: truncated signature")
+	}
+	n := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	if uint64(n) > uint64(len(b)) {
+		return nil, nil, errors.New("sshsig: truncated signature")
+	}
+	return b[:n], b[n:], nil
+}
+
+func appendString(b, s []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshsig_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	"github.com/tink-crypto/tink-go/v2/signature/ed25519"
+	"github.com/tink-crypto/tink-go/v2/signature/sshsig"
+)
+
+// The test vectors below were produced with OpenSSH 9.2:
+//
+//	ssh-keygen -t ed25519 -f key
+//	ssh-keygen -Y sign -f key -n file message
+//	ssh-keygen -Y sign -f key -n git -O hashalg=sha256 message
+const (
+	publicKeyHex = "72b444a2259eade25d0c9887f3cd60f159cbf3a7087a1ad402b3d633f63cb538"
+	message      = "hello, sshsig\n"
+
+	// Namespace "file", hash algorithm "sha512".
+	fileSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgcrREoiWereJdDJiH881g8VnL86
+cIehrUArPWM/Y8tTgAAAAEZmlsZQAAAAAAAAAGc2hhNTEyAAAAUwAAAAtzc2gtZWQyNTUx
+OQAAAEAzF8LfF7BRnbynPjwbBTZXqXRH8VflC4iWtlOg+JH2hyqJMQzf6/K7NrFEisfl7h
+hTTQBnz2YpC8HQVANBuYoN
+-----END SSH SIGNATURE-----
+`
+
+	// Namespace "git", hash algorithm "sha256".
+	gitSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgcrREoiWereJdDJiH881g8VnL86
+cIehrUArPWM/Y8tTgAAAADZ2l0AAAAAAAAAAZzaGEyNTYAAABTAAAAC3NzaC1lZDI1NTE5
+AAAAQDL5XPl75/7VGpJ8z8VXPeLKt3f+80RtgyutFXuh1bKp9/VsYpP4LmsyrRhKtAxkO4
+dcUuTOYImvwZYQZqeFxw4=
+-----END SSH SIGNATURE-----
+`
+)
+
+// newHandle returns a keyset with the test public key and a freshly generated
+// Ed25519 key, with the test key in the given status.
+func newHandle(t *testing.T, disableTestKey bool) *keyset.Handle {
+	t.Helper()
+	keyBytes, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		t.Fatalf("hex.DecodeString() err = %v, want nil", err)
+	}
+	params, err := ed25519.NewParameters(ed25519.VariantNoPrefix)
+	if err != nil {
+		t.Fatalf("ed25519.NewParameters() err = %v, want nil", err)
+	}
+	publicKey, err := ed25519.NewPublicKey(keyBytes, 0, params)
+	if err != nil {
+		t.Fatalf("ed25519.NewPublicKey() err = %v, want nil", err)
+	}
+	otherHandle, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	otherEntry, err := otherHandle.Primary()
+	if err != nil {
+		t.Fatalf("otherHandle.Primary() err = %v, want nil", err)
+	}
+	otherPublicKey, err := otherEntry.Key().(*ed25519.PrivateKey).PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey() err = %v, want nil", err)
+	}
+
+	manager := keyset.NewManager()
+	otherKeyID, err := manager.AddKey(otherPublicKey)
+	if err != nil {
+		t.Fatalf("manager.AddKey() err = %v, want nil", err)
+	}
+	if err := manager.SetPrimary(otherKeyID); err != nil {
+		t.Fatalf("manager.SetPrimary() err = %v, want nil", err)
+	}
+	keyID, err := manager.AddKey(publicKey)
+	if err != nil {
+		t.Fatalf("manager.AddKey() err = %v, want nil", err)
+	}
+	if disableTestKey {
+		if err := manager.Disable(keyID); err != nil {
+			t.Fatalf("manager.Disable() err = %v, want nil", err)
+		}
+	}
+	handle, err := manager.Handle()
+	if err != nil {
+		t.Fatalf("manager.Handle() err = %v, want nil", err)
+	}
+	return handle
+}
+
+func TestVerify(t *testing.T) {
+	handle := newHandle(t, false)
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		sshsig    string
+	}{
+		{
+			name:      "sha512",
+			namespace: "file",
+			sshsig:    fileSignature,
+		},
+		{
+			name:      "sha256",
+			namespace: "git",
+			sshsig:    gitSignature,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := sshsig.Verify(handle, tc.namespace, []byte(tc.sshsig), []byte(message)); err != nil {
+				t.Errorf("sshsig.Verify() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestVerifyFails(t *testing.T) {
+	handle := newHandle(t, false)
+	otherHandle, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		name      string
+		handle    *keyset.Handle
+		namespace string
+		sshsig    string
+		message   string
+	}{
+		{
+			name:      "nil handle",
+			handle:    nil,
+			namespace: "file",
+			sshsig:    fileSignature,
+			message:   message,
+		},
+		{
+			name:      "wrong namespace",
+			handle:    handle,
+			namespace: "git",
+			sshsig:    fileSignature,
+			message:   message,
+		},
+		{
+			name:      "empty namespace",
+			handle:    handle,
+			namespace: "",
+			sshsig:    fileSignature,
+			message:   message,
+		},
+		{
+			name:      "wrong message",
+			handle:    handle,
+			namespace: "file",
+			sshsig:    fileSignature,
+			message:   "hello, sshsig",
+		},
+		{
+			name:      "key not in keyset",
+			handle:    otherHandle,
+			namespace: "file",
+			sshsig:    fileSignature,
+			message:   message,
+		},
+		{
+			name:      "key disabled",
+			handle:    newHandle(t, true),
+			namespace: "file",
+			sshsig:    fileSignature,
+			message:   message,
+		},
+		{
+			name:      "missing armor",
+			handle:    handle,
+			namespace: "file",
+			sshsig:    strings.TrimPrefix(fileSignature, "-----BEGIN SSH SIGNATURE-----\n"),
+			message:   message,
+		},
+		{
+			name:      "invalid base64",
+			handle:    handle,
+			namespace: "file",
+			sshsig:    strings.Replace(fileSignature, "U1NIU0lH", "U1NIU0l!", 1),
+			message:   message,
+		},
+		{
+			name:      "invalid magic preamble",
+			handle:    handle,
+			namespace: "file",
+			sshsig:    strings.Replace(fileSignature, "U1NIU0lH", "U1NIU0lI", 1),
+			message:   message,
+		},
+		{
+			name:      "truncated",
+			handle:    handle,
+			namespace: "file",
+			sshsig:    strings.Replace(fileSignature, "hTTQBnz2YpC8HQVANBuYoN\n", "", 1),
+			message:   message,
+		},
+		{
+			name:      "modified signature",
+			handle:    handle,
+			namespace: "file",
+			sshsig:    strings.Replace(fileSignature, "hTTQBnz2YpC8HQVANBuYoN", "hTTQBnz2YpC8HQVANBuYoM", 1),
+			message:   message,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := sshsig.Verify(tc.handle, tc.namespace, []byte(tc.sshsig), []byte(tc.message)); err == nil {
+				t.Errorf("sshsig.Verify() err = nil, want error")
+			}
+		})
+	}
+}
@@ -85,8 +85,8 @@ func ValidateECDSAParams(hashAlg string, curve string, encoding string) error {
 	}
 	switch curve {
 	case "NIST_P256":
-		if hashAlg != "SHA256" {
-			return errors.New("invalid hash type, expect SHA-256")
+		if hashAlg != "SHA256" && hashAlg != "SHA512" {
+			return errors.New("invalid hash type, expect SHA-256 or SHA-512")
 		}
 	case "NIST_P384":
 		if hashAlg != "SHA384" && hashAlg != "SHA512" {
@@ -224,8 +224,8 @@ func genECDSAInvalidParams() []paramsTestECDSA {
 		testCases = append(testCases,
 			// invalid curve
 			paramsTestECDSA{hash: "SHA256", curve: "UNKNOWN_CURVE", encoding: encoding},
-			// invalid hash: P256 and SHA-512
-			paramsTestECDSA{hash: "SHA512", curve: "NIST_P256", encoding: encoding},
+			// invalid hash: P256 and SHA-384
+			paramsTestECDSA{hash: "SHA384", curve: "NIST_P256", encoding: encoding},
 			// invalid hash: P521 and SHA-256
 			paramsTestECDSA{hash: "SHA256", curve: "NIST_P521", encoding: encoding},
 			// invalid hash: P384 and SHA-256
@@ -15,8 +15,11 @@
 package subtle
 
 import (
+	"crypto"
 	"crypto/ed25519"
 	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 // ED25519Signer is an implementation of Signer for ED25519.
@@ -24,6 +27,12 @@ type ED25519Signer struct {
 	privateKey *ed25519.PrivateKey
 }
 
+// ed25519ctxMaxContextSize is the maximum size of an Ed25519ctx context
+// string, as defined in RFC 8032.
+const ed25519ctxMaxContextSize = 255
+
+var _ tink.ContextStringSigner = (*ED25519Signer)(nil)
+
 // NewED25519Signer creates a new instance of ED25519Signer.
 func NewED25519Signer(keyValue []byte) (*ED25519Signer, error) {
 	if len(keyValue) != ed25519.SeedSize {
@@ -48,3 +57,33 @@ func (e *ED25519Signer) Sign(data []byte) ([]byte, error) {
 	}
 	return r, nil
 }
+
+// SignWithContextString computes an Ed25519ctx signature for the given data
+// under contextString, which must be between 1 and 255 bytes long.
+func (e *ED25519Signer) SignWithContextString(data, contextString []byte) ([]byte, error) {
+	opts, err := ed25519ctxOptions(contextString)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.privateKey.Sign(nil, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(r) != ed25519.SignatureSize {
+		return nil, errInvalidED25519Signature
+	}
+	return r, nil
+}
+
+// ed25519ctxOptions returns the options selecting Ed25519ctx with
+// contextString. An empty context string would select plain Ed25519 instead,
+// so it is rejected.
+func ed25519ctxOptions(contextString []byte) (*ed25519.Options, error) {
+	if len(contextString) == 0 {
+		return nil, fmt.Errorf("ed25519: empty context string")
+	}
+	if len(contextString) > ed25519ctxMaxContextSize {
+		return nil, fmt.Errorf("ed25519: context string too long: %d bytes, want at most %d", len(contextString), ed25519ctxMaxContextSize)
+	}
+	return &ed25519.Options{Hash: crypto.Hash(0), Context: string(contextString)}, nil
+}
@@ -114,6 +114,87 @@ func TestED25519VerifyFails(t *testing.T) {
 	}
 }
 
+func TestED25519ctxSignVerifyCorrectness(t *testing.T) {
+	// Taken from https://datatracker.ietf.org/doc/html/rfc8032#section-7.2 - "foo" context.
+	message := []byte{0xf7, 0x26, 0x93, 0x6d, 0x19, 0xc8, 0x00, 0x49, 0x4e, 0x3f, 0xda, 0xff, 0x20, 0xb2, 0x76, 0xa8}
+	contextString := []byte("foo")
+	privKeyHex := "0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6"
+	privKeySeed, err := hex.DecodeString(privKeyHex)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) err = %v, want nil", privKeyHex, err)
+	}
+	privateKey := ed25519.NewKeyFromSeed(privKeySeed)
+	pubKeyHex := "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292"
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) err = %v, want nil", pubKeyHex, err)
+	}
+	publicKey := ed25519.PublicKey(pubKeyBytes)
+	signatureHex := "55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d"
+	wantSignature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) err = %v, want nil", signatureHex, err)
+	}
+
+	signer, err := subtleSignature.NewED25519SignerFromPrivateKey(&privateKey)
+	if err != nil {
+		t.Fatalf("unexpected error when creating ED25519 Signer: %s", err)
+	}
+	verifier, err := subtleSignature.NewED25519VerifierFromPublicKey(&publicKey)
+	if err != nil {
+		t.Fatalf("unexpected error when creating ED25519 Verifier: %s", err)
+	}
+
+	gotSignature, err := signer.SignWithContextString(message, contextString)
+	if err != nil {
+		t.Fatalf("signer.SignWithContextString(%x, %q) err = %v, want nil", message, contextString, err)
+	}
+	if diff := cmp.Diff(gotSignature, wantSignature); diff != "" {
+		t.Errorf("signer.SignWithContextString() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if err := verifier.VerifyWithContextString(wantSignature, message, contextString); err != nil {
+		t.Errorf("verifier.VerifyWithContextString(%x, %x, %q) err = %v, want nil", wantSignature, message, contextString, err)
+	}
+	if err := verifier.VerifyWithContextString(wantSignature, message, []byte("bar")); err == nil {
+		t.Errorf("verifier.VerifyWithContextString(%x, %x, %q) err = nil, want error", wantSignature, message, "bar")
+	}
+	if err := verifier.Verify(wantSignature, message); err == nil {
+		t.Errorf("verifier.Verify(%x, %x) err = nil, want error", wantSignature, message)
+	}
+}
+
+func TestED25519ctxRejectsInvalidContextStrings(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("key generation error: %s", err)
+	}
+	signer, err := subtleSignature.NewED25519SignerFromPrivateKey(&privateKey)
+	if err != nil {
+		t.Fatalf("unexpected error when creating ED25519 Signer: %s", err)
+	}
+	verifier, err := subtleSignature.NewED25519VerifierFromPublicKey(&publicKey)
+	if err != nil {
+		t.Fatalf("unexpected error when creating ED25519 Verifier: %s", err)
+	}
+	data := random.GetRandomBytes(20)
+	signatureBytes, err := signer.Sign(data)
+	if err != nil {
+		t.Fatalf("signer.Sign(%x) err = %v, want nil", data, err)
+	}
+	for _, contextString := range [][]byte{nil, {}, make([]byte, 256)} {
+		if _, err := signer.SignWithContextString(data, contextString); err == nil {
+			t.Errorf("signer.SignWithContextString(data, %d-byte context) err = nil, want error", len(contextString))
+		}
+		// In particular, an empty context string doesn't verify plain Ed25519 signatures.
+		if err := verifier.VerifyWithContextString(signatureBytes, data, contextString); err == nil {
+			t.Errorf("verifier.VerifyWithContextString(signature, data, %d-byte context) err = nil, want error", len(contextString))
+		}
+	}
+	if _, err := signer.SignWithContextString(data, make([]byte, 255)); err != nil {
+		t.Errorf("signer.SignWithContextString(data, 255-byte context) err = %v, want nil", err)
+	}
+}
+
 func TestED25519SignVerify(t *testing.T) {
 	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
 	if err != nil {
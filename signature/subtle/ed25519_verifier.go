@@ -18,6 +18,8 @@ import (
 	"crypto/ed25519"
 	"errors"
 	"fmt"
+
+	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 var errInvalidED25519Signature = errors.New("ed25519: invalid signature")
@@ -28,6 +30,8 @@ type ED25519Verifier struct {
 	publicKey *ed25519.PublicKey
 }
 
+var _ tink.ContextStringVerifier = (*ED25519Verifier)(nil)
+
 // NewED25519Verifier creates a new instance of ED25519Verifier.
 func NewED25519Verifier(pub []byte) (*ED25519Verifier, error) {
 	publicKey := ed25519.PublicKey(pub)
@@ -52,3 +56,20 @@ func (e *ED25519Verifier) Verify(signature, data []byte) error {
 	}
 	return nil
 }
+
+// VerifyWithContextString verifies whether the given Ed25519ctx signature is
+// valid for the given data under contextString.
+// It returns an error if the signature is not valid; nil otherwise.
+func (e *ED25519Verifier) VerifyWithContextString(signature, data, contextString []byte) error {
+	if len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("the length of the signature is not %d", ed25519.SignatureSize)
+	}
+	opts, err := ed25519ctxOptions(contextString)
+	if err != nil {
+		return err
+	}
+	if err := ed25519.VerifyWithOptions(*e.publicKey, data, signature, opts); err != nil {
+		return errInvalidED25519Signature
+	}
+	return nil
+}
@@ -115,7 +115,7 @@ func newWrappedVerifier(ps *primitiveset.PrimitiveSet[tink.Verifier]) (*wrappedV
 
 func createVerifierLogger(ps *primitiveset.PrimitiveSet[tink.Verifier]) (monitoring.Logger, error) {
 	// only keysets which contain annotations are monitored.
-	if len(ps.Annotations) == 0 {
+	if len(ps.Annotations) == 0 && len(ps.KeyAnnotations) == 0 {
 		return &monitoringutil.DoNothingLogger{}, nil
 	}
 	keysetInfo, err := monitoringutil.KeysetInfoFromPrimitiveSet(ps)
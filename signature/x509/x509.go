@@ -0,0 +1,386 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package x509 creates X.509 certificate signing requests (RFC 2986) signed
+// with the primary key of a signature keyset, so that certificates can be
+// enrolled for keys managed by Tink without exporting the private key.
+package x509
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	tinkecdsa "github.com/tink-crypto/tink-go/v2/signature/ecdsa"
+	tinked25519 "github.com/tink-crypto/tink-go/v2/signature/ed25519"
+	"github.com/tink-crypto/tink-go/v2/signature/rsassapkcs1"
+	"github.com/tink-crypto/tink-go/v2/signature/rsassapss"
+)
+
+var (
+	oidSignatureSHA256WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+	oidSignatureSHA384WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 12}
+	oidSignatureSHA512WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 13}
+	oidSignatureRSAPSS          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+	oidSignatureECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidSignatureECDSAWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}
+	oidSignatureECDSAWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}
+	oidSignatureEd25519         = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+	oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+	oidMGF1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 8}
+)
+
+// certificateRequest is the CertificationRequest of RFC 2986, section 4.2.
+type certificateRequest struct {
+	TBSCSR             asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+// tbsCertificateRequest is the CertificationRequestInfo of RFC 2986,
+// section 4.1, with the public key left encoded.
+type tbsCertificateRequest struct {
+	Raw           asn1.RawContent
+	Version       int
+	Subject       asn1.RawValue
+	PublicKey     asn1.RawValue
+	RawAttributes []asn1.RawValue `asn1:"tag:0"`
+}
+
+// pssParameters is the RSASSA-PSS-params of RFC 4055, section 3.1.
+type pssParameters struct {
+	Hash       pkix.AlgorithmIdentifier `asn1:"explicit,tag:0"`
+	MGF        pkix.AlgorithmIdentifier `asn1:"explicit,tag:1"`
+	SaltLength int                      `asn1:"explicit,tag:2"`
+}
+
+// ecdsaSignature is the DER encoding of an ECDSA signature.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// signingKey describes how the primary key of a keyset signs a certificate
+// signing request.
+type signingKey struct {
+	publicKey          crypto.PublicKey
+	signatureAlgorithm x509.SignatureAlgorithm
+	algorithmID        pkix.AlgorithmIdentifier
+	outputPrefix       []byte
+	// ieeeP1363 is true if the signatures are IEEE P1363 encoded ECDSA
+	// signatures, which must be converted to DER.
+	ieeeP1363 bool
+}
+
+// CreateCertificateRequest creates a certificate signing request based on
+// template, signed with the primary key of handle, and returns it in DER
+// encoding.
+//
+// The primary key must be a private ECDSA, RSA SSA PKCS1, RSA SSA PSS or
+// Ed25519 key, whose public key becomes the public key of the request. The
+// signature algorithm is set from the key type, and template.SignatureAlgorithm
+// must either be unset or match it. RSA SSA PSS keys must use the same hash
+// function for signing and MGF1, and a salt as long as the hash, as required
+// by crypto/x509. Keys with the LEGACY output prefix type are not supported,
+// because they do not sign the request itself. template.PublicKey is ignored.
+//
+// The request is signed with the signer returned by [signature.NewSigner], so
+// the private key never leaves Tink.
+func CreateCertificateRequest(handle *keyset.Handle, template *x509.CertificateRequest) ([]byte, error) {
+	if handle == nil {
+		return nil, errors.New("x509.CreateCertificateRequest: nil handle")
+	}
+	if template == nil {
+		return nil, errors.New("x509.CreateCertificateRequest: nil template")
+	}
+	primary, err := handle.Primary()
+	if err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	key, err := signingKeyFromPrimary(primary)
+	if err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	if template.SignatureAlgorithm != x509.UnknownSignatureAlgorithm && template.SignatureAlgorithm != key.signatureAlgorithm {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: template signature algorithm is %v, but the primary key signs with %v", template.SignatureAlgorithm, key.signatureAlgorithm)
+	}
+	tbs, err := createTBSCertificateRequest(template, key.publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	signer, err := signature.NewSigner(handle)
+	if err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	sig, err := signer.Sign(tbs)
+	if err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	sig = sig[len(key.outputPrefix):]
+	if key.ieeeP1363 {
+		if sig, err = ieeeP1363ToDER(sig); err != nil {
+			return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+		}
+	}
+	csr, err := asn1.Marshal(certificateRequest{
+		TBSCSR:             asn1.RawValue{FullBytes: tbs},
+		SignatureAlgorithm: key.algorithmID,
+		SignatureValue:     asn1.BitString{Bytes: sig, BitLength: 8 * len(sig)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	// Check the result with crypto/x509, which also catches signature
+	// algorithm identifiers that it would not accept.
+	parsed, err := x509.ParseCertificateRequest(csr)
+	if err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	if err := parsed.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("x509.CreateCertificateRequest: %v", err)
+	}
+	return csr, nil
+}
+
+// createTBSCertificateRequest returns the DER encoded CertificationRequestInfo
+// of the request for template and publicKey.
+//
+// crypto/x509 only creates signed requests, and its signers must sign a
+// digest rather than the message, which Tink signers cannot do. The request
+// is therefore created with a throwaway Ed25519 key, whose public key is then
+// replaced by publicKey.
+func createTBSCertificateRequest(template *x509.CertificateRequest, publicKey crypto.PublicKey) ([]byte, error) {
+	spki, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	_, throwawayKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	t := *template
+	t.SignatureAlgorithm = x509.PureEd25519
+	der, err := x509.CreateCertificateRequest(rand.Reader, &t, throwawayKey)
+	if err != nil {
+		return nil, err
+	}
+	var csr certificateRequest
+	if rest, err := asn1.Unmarshal(der, &csr); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after certificate request")
+	}
+	var tbs tbsCertificateRequest
+	if rest, err := asn1.Unmarshal(csr.TBSCSR.FullBytes, &tbs); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after certificate request info")
+	}
+	tbs.Raw = nil
+	tbs.PublicKey = asn1.RawValue{FullBytes: spki}
+	return asn1.Marshal(tbs)
+}
+
+// signingKeyFromPrimary returns the signing key of primary.
+func signingKeyFromPrimary(primary *keyset.Entry) (*signingKey, error) {
+	switch k := primary.Key().(type) {
+	case *tinkecdsa.PrivateKey:
+		return ecdsaSigningKey(k)
+	case *rsassapkcs1.PrivateKey:
+		return rsaSSAPKCS1SigningKey(k)
+	case *rsassapss.PrivateKey:
+		return rsaSSAPSSSigningKey(k)
+	case *tinked25519.PrivateKey:
+		return ed25519SigningKey(k)
+	default:
+		return nil, fmt.Errorf("primary key of type %T is not a supported private signature key", k)
+	}
+}
+
+func ecdsaSigningKey(k *tinkecdsa.PrivateKey) (*signingKey, error) {
+	params := k.Parameters().(*tinkecdsa.Parameters)
+	if params.Variant() == tinkecdsa.VariantLegacy {
+		return nil, errors.New("keys with the LEGACY output prefix type are not supported")
+	}
+	var curve elliptic.Curve
+	switch params.CurveType() {
+	case tinkecdsa.NistP256:
+		curve = elliptic.P256()
+	case tinkecdsa.NistP384:
+		curve = elliptic.P384()
+	case tinkecdsa.NistP521:
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %v", params.CurveType())
+	}
+	var signatureAlgorithm x509.SignatureAlgorithm
+	var oid asn1.ObjectIdentifier
+	switch params.HashType() {
+	case tinkecdsa.SHA256:
+		signatureAlgorithm, oid = x509.ECDSAWithSHA256, oidSignatureECDSAWithSHA256
+	case tinkecdsa.SHA384:
+		signatureAlgorithm, oid = x509.ECDSAWithSHA384, oidSignatureECDSAWithSHA384
+	case tinkecdsa.SHA512:
+		signatureAlgorithm, oid = x509.ECDSAWithSHA512, oidSignatureECDSAWithSHA512
+	default:
+		return nil, fmt.Errorf("unsupported hash %v", params.HashType())
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	// The public point is encoded as 0x04 || x || y.
+	point := pub.(*tinkecdsa.PublicKey).PublicPoint()
+	coordinateSize := (curve.Params().BitSize + 7) / 8
+	if len(point) != 1+2*coordinateSize {
+		return nil, fmt.Errorf("invalid public point length %d", len(point))
+	}
+	return &signingKey{
+		publicKey: &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(point[1 : 1+coordinateSize]),
+			Y:     new(big.Int).SetBytes(point[1+coordinateSize:]),
+		},
+		signatureAlgorithm: signatureAlgorithm,
+		algorithmID:        pkix.AlgorithmIdentifier{Algorithm: oid},
+		outputPrefix:       k.OutputPrefix(),
+		ieeeP1363:          params.SignatureEncoding() == tinkecdsa.IEEEP1363,
+	}, nil
+}
+
+func rsaSSAPKCS1SigningKey(k *rsassapkcs1.PrivateKey) (*signingKey, error) {
+	params := k.Parameters().(*rsassapkcs1.Parameters)
+	if params.Variant() == rsassapkcs1.VariantLegacy {
+		return nil, errors.New("keys with the LEGACY output prefix type are not supported")
+	}
+	var signatureAlgorithm x509.SignatureAlgorithm
+	var oid asn1.ObjectIdentifier
+	switch params.HashType() {
+	case rsassapkcs1.SHA256:
+		signatureAlgorithm, oid = x509.SHA256WithRSA, oidSignatureSHA256WithRSA
+	case rsassapkcs1.SHA384:
+		signatureAlgorithm, oid = x509.SHA384WithRSA, oidSignatureSHA384WithRSA
+	case rsassapkcs1.SHA512:
+		signatureAlgorithm, oid = x509.SHA512WithRSA, oidSignatureSHA512WithRSA
+	default:
+		return nil, fmt.Errorf("unsupported hash %v", params.HashType())
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{
+		publicKey: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(pub.(*rsassapkcs1.PublicKey).Modulus()),
+			E: params.PublicExponent(),
+		},
+		signatureAlgorithm: signatureAlgorithm,
+		algorithmID:        pkix.AlgorithmIdentifier{Algorithm: oid, Parameters: asn1.NullRawValue},
+		outputPrefix:       k.OutputPrefix(),
+	}, nil
+}
+
+func rsaSSAPSSSigningKey(k *rsassapss.PrivateKey) (*signingKey, error) {
+	params := k.Parameters().(*rsassapss.Parameters)
+	if params.Variant() == rsassapss.VariantLegacy {
+		return nil, errors.New("keys with the LEGACY output prefix type are not supported")
+	}
+	var signatureAlgorithm x509.SignatureAlgorithm
+	var hashOID asn1.ObjectIdentifier
+	var hashSize int
+	switch params.SigHashType() {
+	case rsassapss.SHA256:
+		signatureAlgorithm, hashOID, hashSize = x509.SHA256WithRSAPSS, oidSHA256, 32
+	case rsassapss.SHA384:
+		signatureAlgorithm, hashOID, hashSize = x509.SHA384WithRSAPSS, oidSHA384, 48
+	case rsassapss.SHA512:
+		signatureAlgorithm, hashOID, hashSize = x509.SHA512WithRSAPSS, oidSHA512, 64
+	default:
+		return nil, fmt.Errorf("unsupported hash %v", params.SigHashType())
+	}
+	if params.MGF1HashType() != params.SigHashType() {
+		return nil, fmt.Errorf("MGF1 hash %v differs from signature hash %v", params.MGF1HashType(), params.SigHashType())
+	}
+	if params.SaltLengthBytes() != hashSize {
+		return nil, fmt.Errorf("salt length is %d bytes, want %d", params.SaltLengthBytes(), hashSize)
+	}
+	hashAlgorithm := pkix.AlgorithmIdentifier{Algorithm: hashOID, Parameters: asn1.NullRawValue}
+	mgf1Parameters, err := asn1.Marshal(hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	pssParams, err := asn1.Marshal(pssParameters{
+		Hash:       hashAlgorithm,
+		MGF:        pkix.AlgorithmIdentifier{Algorithm: oidMGF1, Parameters: asn1.RawValue{FullBytes: mgf1Parameters}},
+		SaltLength: hashSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{
+		publicKey: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(pub.(*rsassapss.PublicKey).Modulus()),
+			E: params.PublicExponent(),
+		},
+		signatureAlgorithm: signatureAlgorithm,
+		algorithmID:        pkix.AlgorithmIdentifier{Algorithm: oidSignatureRSAPSS, Parameters: asn1.RawValue{FullBytes: pssParams}},
+		outputPrefix:       k.OutputPrefix(),
+	}, nil
+}
+
+func ed25519SigningKey(k *tinked25519.PrivateKey) (*signingKey, error) {
+	params := k.Parameters().(*tinked25519.Parameters)
+	if params.Variant() == tinked25519.VariantLegacy {
+		return nil, errors.New("keys with the LEGACY output prefix type are not supported")
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{
+		publicKey:          ed25519.PublicKey(pub.(*tinked25519.PublicKey).KeyBytes()),
+		signatureAlgorithm: x509.PureEd25519,
+		algorithmID:        pkix.AlgorithmIdentifier{Algorithm: oidSignatureEd25519},
+		outputPrefix:       k.OutputPrefix(),
+	}, nil
+}
+
+// ieeeP1363ToDER converts an IEEE P1363 encoded ECDSA signature, r || s, to
+// DER.
+func ieeeP1363ToDER(sig []byte) ([]byte, error) {
+	if len(sig) == 0 || len(sig)%2 != 0 {
+		return nil, fmt.Errorf("invalid IEEE P1363 signature length %d", len(sig))
+	}
+	return asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(sig[:len(sig)/2]),
+		S: new(big.Int).SetBytes(sig[len(sig)/2:]),
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+	tinkx509 "github.com/tink-crypto/tink-go/v2/signature/x509"
+	commonpb "github.com/tink-crypto/tink-go/v2/proto/common_go_proto"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func mustTemplate(t *testing.T) func(*tinkpb.KeyTemplate, error) *tinkpb.KeyTemplate {
+	return func(kt *tinkpb.KeyTemplate, err error) *tinkpb.KeyTemplate {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("creating key template err = %v, want nil", err)
+		}
+		return kt
+	}
+}
+
+func TestCreateCertificateRequest(t *testing.T) {
+	must := mustTemplate(t)
+	for _, tc := range []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+		want     x509.SignatureAlgorithm
+	}{
+		{"ECDSA_P256", signature.ECDSAP256KeyTemplate(), x509.ECDSAWithSHA256},
+		{"ECDSA_P256_RAW", signature.ECDSAP256RawKeyTemplate(), x509.ECDSAWithSHA256},
+		{"ECDSA_P384_SHA384", signature.ECDSAP384SHA384KeyTemplate(), x509.ECDSAWithSHA384},
+		{"ECDSA_P384_IEEE_P1363", signature.ECDSAP384IEEEP1363KeyTemplate(), x509.ECDSAWithSHA384},
+		{"ECDSA_P521_IEEE_P1363_RAW", signature.ECDSAP521IEEEP1363RawKeyTemplate(), x509.ECDSAWithSHA512},
+		{"RSA_SSA_PKCS1_2048_SHA256", signature.RSA_SSA_PKCS1_2048_SHA256_F4_Key_Template(), x509.SHA256WithRSA},
+		{"RSA_SSA_PKCS1_3072_SHA384_RAW", must(signature.RSASSAPKCS1KeyTemplate(commonpb.HashType_SHA384, 3072, tinkpb.OutputPrefixType_RAW)), x509.SHA384WithRSA},
+		{"RSA_SSA_PSS_3072_SHA256", signature.RSA_SSA_PSS_3072_SHA256_32_F4_Key_Template(), x509.SHA256WithRSAPSS},
+		{"RSA_SSA_PSS_4096_SHA512_RAW", signature.RSA_SSA_PSS_4096_SHA512_64_F4_Raw_Key_Template(), x509.SHA512WithRSAPSS},
+		{"ED25519", signature.ED25519KeyTemplate(), x509.PureEd25519},
+		{"ED25519_RAW", signature.ED25519KeyWithoutPrefixTemplate(), x509.PureEd25519},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handle, err := keyset.NewHandle(tc.template)
+			if err != nil {
+				t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+			}
+			template := &x509.CertificateRequest{
+				Subject:        pkix.Name{CommonName: "example.com", Organization: []string{"Example"}},
+				DNSNames:       []string{"example.com", "www.example.com"},
+				EmailAddresses: []string{"admin@example.com"},
+			}
+			der, err := tinkx509.CreateCertificateRequest(handle, template)
+			if err != nil {
+				t.Fatalf("tinkx509.CreateCertificateRequest() err = %v, want nil", err)
+			}
+			csr, err := x509.ParseCertificateRequest(der)
+			if err != nil {
+				t.Fatalf("x509.ParseCertificateRequest() err = %v, want nil", err)
+			}
+			if err := csr.CheckSignature(); err != nil {
+				t.Errorf("csr.CheckSignature() err = %v, want nil", err)
+			}
+			if csr.SignatureAlgorithm != tc.want {
+				t.Errorf("csr.SignatureAlgorithm = %v, want %v", csr.SignatureAlgorithm, tc.want)
+			}
+			if got, want := csr.Subject.String(), template.Subject.String(); got != want {
+				t.Errorf("csr.Subject = %q, want %q", got, want)
+			}
+			if diff := cmp.Diff(template.DNSNames, csr.DNSNames); diff != "" {
+				t.Errorf("csr.DNSNames diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(template.EmailAddresses, csr.EmailAddresses); diff != "" {
+				t.Errorf("csr.EmailAddresses diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateCertificateRequestSetsMatchingSignatureAlgorithm(t *testing.T) {
+	handle, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate())
+	if err != nil {
+		t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+	}
+	template := &x509.CertificateRequest{
+		Subject:            pkix.Name{CommonName: "example.com"},
+		SignatureAlgorithm: x509.ECDSAWithSHA256,
+	}
+	der, err := tinkx509.CreateCertificateRequest(handle, template)
+	if err != nil {
+		t.Fatalf("tinkx509.CreateCertificateRequest() err = %v, want nil", err)
+	}
+	if _, err := x509.ParseCertificateRequest(der); err != nil {
+		t.Errorf("x509.ParseCertificateRequest() err = %v, want nil", err)
+	}
+}
+
+func TestCreateCertificateRequestFails(t *testing.T) {
+	must := mustTemplate(t)
+	newHandle := func(kt *tinkpb.KeyTemplate) *keyset.Handle {
+		t.Helper()
+		handle, err := keyset.NewHandle(kt)
+		if err != nil {
+			t.Fatalf("keyset.NewHandle() err = %v, want nil", err)
+		}
+		return handle
+	}
+	ecdsaHandle := newHandle(signature.ECDSAP256KeyTemplate())
+	publicHandle, err := ecdsaHandle.Public()
+	if err != nil {
+		t.Fatalf("ecdsaHandle.Public() err = %v, want nil", err)
+	}
+	legacyTemplate := proto.Clone(signature.ECDSAP256KeyTemplate()).(*tinkpb.KeyTemplate)
+	legacyTemplate.OutputPrefixType = tinkpb.OutputPrefixType_LEGACY
+	template := &x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.com"}}
+	for _, tc := range []struct {
+		name     string
+		handle   *keyset.Handle
+		template *x509.CertificateRequest
+	}{
+		{"nil handle", nil, template},
+		{"nil template", ecdsaHandle, nil},
+		{"public keyset", publicHandle, template},
+		{"LEGACY key", newHandle(legacyTemplate), template},
+		{"PSS salt not hash size", newHandle(must(signature.RSASSAPSSKeyTemplate(commonpb.HashType_SHA256, 0, 2048, tinkpb.OutputPrefixType_TINK))), template},
+		{"signature algorithm mismatch", ecdsaHandle, &x509.CertificateRequest{SignatureAlgorithm: x509.ECDSAWithSHA384}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tinkx509.CreateCertificateRequest(tc.handle, tc.template); err == nil {
+				t.Error("tinkx509.CreateCertificateRequest() err = nil, want error")
+			}
+		})
+	}
+}
@@ -21,6 +21,10 @@ AEAD is the interface for authenticated encryption with associated data.
 Implementations of this interface are secure against adaptive chosen ciphertext attacks.
 Encryption with associated data ensures authenticity and integrity of that data, but not
 its secrecy. (see RFC 5116, https://tools.ietf.org/html/rfc5116)
+
+Passing nil as associated data is the same as passing an empty slice: a
+ciphertext encrypted with either can be decrypted with either. Callers that
+have no associated data should pass nil.
 */
 type AEAD interface {
 	// Encrypt encrypts plaintext with associatedData as associated data.
@@ -27,7 +27,8 @@ with up to 2^32 keys. That means if an adversary obtains 2^32 ciphertexts of the
 encrypted under 2^32 keys, they need to do 2^128 computations to obtain a single key.
 
 Encryption with associated data ensures authenticity (who the sender is) and integrity (the
-data has not been tampered with) of that data, but not its secrecy.
+data has not been tampered with) of that data, but not its secrecy. Passing nil as associated
+data is the same as passing an empty slice.
 
 References:
  * https://tools.ietf.org/html/rfc5116
@@ -28,3 +28,22 @@ type MAC interface {
 	// otherwise it returns an error.
 	VerifyMAC(mac, data []byte) error
 }
+
+// StreamingMAC computes a MAC over data that is written to it incrementally,
+// for inputs that are too large to be held in memory.
+type StreamingMAC interface {
+	// Write adds p to the data the MAC is computed over.
+	Write(p []byte) (n int, err error)
+
+	// Finish returns the MAC of all data written so far. The StreamingMAC must
+	// not be used after Finish has been called.
+	Finish() ([]byte, error)
+}
+
+// StreamingMACComputer is implemented by MAC primitives that can compute MACs
+// incrementally.
+type StreamingMACComputer interface {
+	// NewStreamingMAC returns a StreamingMAC whose output is the same as that
+	// of ComputeMAC over the concatenation of all written data.
+	NewStreamingMAC() (StreamingMAC, error)
+}
@@ -23,3 +23,19 @@ type Signer interface {
 	// Computes the digital signature for data.
 	Sign(data []byte) ([]byte, error)
 }
+
+// ContextStringSigner is a [Signer] that can also sign with a context string,
+// which separates signatures made for different protocols or purposes with the
+// same key. For Ed25519 keys, this produces Ed25519ctx signatures (RFC 8032).
+// It is implemented by the signers of ed25519 key objects, not by primitives
+// created from a keyset handle.
+//
+// Signing with an empty context string is not equivalent to signing with Sign;
+// implementations reject empty context strings instead.
+type ContextStringSigner interface {
+	Signer
+
+	// SignWithContextString computes the digital signature for data under
+	// contextString.
+	SignWithContextString(data, contextString []byte) ([]byte, error)
+}
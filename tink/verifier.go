@@ -23,3 +23,16 @@ type Verifier interface {
 	// Verifies returns nil if signature is a valid signature for data; otherwise returns an error.
 	Verify(signature, data []byte) error
 }
+
+// ContextStringVerifier is a [Verifier] that can also verify signatures made
+// with a context string by a [ContextStringSigner].
+//
+// A signature made with a context string doesn't verify with Verify, and a
+// signature made without one doesn't verify with VerifyWithContextString.
+type ContextStringVerifier interface {
+	Verifier
+
+	// VerifyWithContextString returns nil if signature is a valid signature
+	// for data under contextString; otherwise returns an error.
+	VerifyWithContextString(signature, data, contextString []byte) error
+}